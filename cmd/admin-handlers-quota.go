@@ -68,18 +68,20 @@ func (a adminAPIHandlers) PutBucketQuotaConfigHandler(w http.ResponseWriter, r *
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
-	configFile := path.Join(bucketConfigPrefix, bucket, bucketQuotaConfigFile)
+	// gateways with a bucket namespace per account keep the quota by the namespaced bucket
+	nsBucket := namespacedBucket(ctx, objectAPI, bucket)
+	configFile := path.Join(bucketConfigPrefix, nsBucket, bucketQuotaConfigFile)
 	if err = saveConfig(ctx, objectAPI, configFile, data); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 	if quotaCfg.Quota > 0 {
-		globalBucketQuotaSys.Set(bucket, quotaCfg)
-		globalNotificationSys.PutBucketQuotaConfig(ctx, bucket, quotaCfg)
+		globalBucketQuotaSys.Set(nsBucket, quotaCfg)
+		globalNotificationSys.PutBucketQuotaConfig(ctx, nsBucket, quotaCfg)
 
 	} else {
-		globalBucketQuotaSys.Remove(bucket)
-		globalNotificationSys.RemoveBucketQuotaConfig(ctx, bucket)
+		globalBucketQuotaSys.Remove(nsBucket)
+		globalNotificationSys.RemoveBucketQuotaConfig(ctx, nsBucket)
 
 	}
 
@@ -103,7 +105,7 @@ func (a adminAPIHandlers) GetBucketQuotaConfigHandler(w http.ResponseWriter, r *
 		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
-	configFile := path.Join(bucketConfigPrefix, bucket, bucketQuotaConfigFile)
+	configFile := path.Join(bucketConfigPrefix, namespacedBucket(ctx, objectAPI, bucket), bucketQuotaConfigFile)
 	configData, err := readConfig(ctx, objectAPI, configFile)
 	if err != nil {
 		if err != errConfigNotFound {
@@ -134,7 +136,8 @@ func (a adminAPIHandlers) RemoveBucketQuotaConfigHandler(w http.ResponseWriter,
 		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
-	configFile := path.Join(bucketConfigPrefix, bucket, bucketQuotaConfigFile)
+	nsBucket := namespacedBucket(ctx, objectAPI, bucket)
+	configFile := path.Join(bucketConfigPrefix, nsBucket, bucketQuotaConfigFile)
 	if err := deleteConfig(ctx, objectAPI, configFile); err != nil {
 		if err != errConfigNotFound {
 			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -143,8 +146,8 @@ func (a adminAPIHandlers) RemoveBucketQuotaConfigHandler(w http.ResponseWriter,
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, BucketQuotaConfigNotFound{Bucket: bucket}), r.URL)
 		return
 	}
-	globalBucketQuotaSys.Remove(bucket)
-	globalNotificationSys.RemoveBucketQuotaConfig(ctx, bucket)
+	globalBucketQuotaSys.Remove(nsBucket)
+	globalNotificationSys.RemoveBucketQuotaConfig(ctx, nsBucket)
 	// Write success response.
 	writeSuccessNoContent(w)
 }
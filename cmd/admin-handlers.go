@@ -21,6 +21,7 @@ import (
 	"crypto/subtle"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -374,6 +375,55 @@ func (a adminAPIHandlers) AccountingUsageInfoHandler(w http.ResponseWriter, r *h
 	writeSuccessResponseJSON(w, usageInfoJSON)
 }
 
+// MeteringInfoHandler - GET /minio/admin/v3/meteringinfo?start=...&end=...&bucket=...&user=...
+// ----------
+// Get the hourly usage of the buckets and parent users, the last 24 hours if start and end are not set.
+func (a adminAPIHandlers) MeteringInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "MeteringInfo")
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AccountingUsageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := r.URL.Query()
+	end := UTCNow()
+	if v := vars.Get("end"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+			return
+		}
+		end = t
+	}
+	start := end.Add(-24 * time.Hour)
+	if v := vars.Get("start"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+			return
+		}
+		start = t
+	}
+
+	meteringInfo, err := getMeteringInfo(ctx, objectAPI, start, end, vars.Get("bucket"), vars.Get("user"))
+	if err == errMeteringInvalidRange {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	meteringInfoJSON, err := json.Marshal(meteringInfo)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, meteringInfoJSON)
+}
+
 func newLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEntry {
 	entry := &madmin.LockEntry{
 		Timestamp:  l.Timestamp,
@@ -668,8 +718,9 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if this setup has an erasure coded backend.
-	if !globalIsXL {
+	// Check if this setup has an erasure coded backend, or is
+	// a gateway which may implement healing of its backend.
+	if !globalIsXL && !globalIsGateway {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrHealNotImplemented), r.URL)
 		return
 	}
@@ -904,7 +955,16 @@ func toAdminAPIErrCode(ctx context.Context, err error) APIErrorCode {
 	switch err {
 	case errXLWriteQuorum:
 		return ErrAdminConfigNoQuorum
+	case errTierExists:
+		return ErrAdminTierAlreadyExists
+	case errTierNotFound:
+		return ErrAdminTierNotFound
+	case errTierInUse:
+		return ErrAdminTierInUse
 	default:
+		if errors.Is(err, errTierInvalidConfig) {
+			return ErrAdminTierInvalidConfig
+		}
 		return toAPIErrorCode(ctx, err)
 	}
 }
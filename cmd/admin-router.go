@@ -76,6 +76,12 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps, enab
 
 			/// Health operations
 
+		} else if globalIsGateway {
+			// Heal processing endpoint, gateways that do not
+			// implement healing reply with NotImplemented.
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}/{prefix:.*}").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
 		}
 
 		// Profiling operations
@@ -169,7 +175,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps, enab
 		}
 
 		// Quota operations
-		if enableConfigOps && enableBucketQuotaOps {
+		if enableBucketQuotaOps {
 			// GetBucketQuotaConfig
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-quota").HandlerFunc(
 				httpTraceHdrs(adminAPI.GetBucketQuotaConfigHandler)).Queries("bucket", "{bucket:.*}")
@@ -181,6 +187,22 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps, enab
 				httpTraceHdrs(adminAPI.RemoveBucketQuotaConfigHandler)).Queries("bucket", "{bucket:.*}")
 		}
 
+		// Metering operations, the rollups are saved in the backend like the quota configuration
+		if enableBucketQuotaOps {
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/meteringinfo").HandlerFunc(httpTraceAll(adminAPI.MeteringInfoHandler))
+		}
+
+		// Remote tier operations, not supported in gateway mode
+		if !globalIsGateway {
+			// AddTier
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/add-tier").HandlerFunc(httpTraceHdrs(adminAPI.AddTierHandler))
+			// ListTier
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-tiers").HandlerFunc(httpTraceHdrs(adminAPI.ListTierHandler))
+			// RemoveTier
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-tier").HandlerFunc(
+				httpTraceHdrs(adminAPI.RemoveTierHandler)).Queries("name", "{name:.*}")
+		}
+
 		// -- Top APIs --
 		// Top locks
 		if globalIsDistXL {
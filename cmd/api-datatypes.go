@@ -23,6 +23,7 @@ import (
 // ObjectIdentifier carries key name for the object to delete.
 type ObjectIdentifier struct {
 	ObjectName string `xml:"Key"`
+	VersionID  string `xml:"VersionId,omitempty"`
 }
 
 // createBucketConfiguration container for bucket configuration request from client.
@@ -103,6 +103,14 @@ const (
 	ErrNoSuchKey
 	ErrNoSuchUpload
 	ErrNoSuchVersion
+	ErrInvalidVersionID
+	ErrSuspendedVersioningNotAllowed
+	ErrReplicationConfigurationNotFoundError
+	ErrReplicationTargetNotConfigured
+	ErrReplicationDestinationNotFound
+	ErrInvalidTargetBucketForLogging
+	ErrNoSuchCORSConfiguration
+	ErrCORSForbidden
 	ErrNotImplemented
 	ErrPreconditionFailed
 	ErrRequestTimeTooSkewed
@@ -239,6 +247,11 @@ const (
 	ErrAdminBucketQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
 	ErrAdminBucketQuotaDisabled
+	// Remote tier error codes
+	ErrAdminTierAlreadyExists
+	ErrAdminTierNotFound
+	ErrAdminTierInUse
+	ErrAdminTierInvalidConfig
 
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
@@ -538,6 +551,46 @@ var errorCodes = errorCodeMap{
 		Description:    "Indicates that the version ID specified in the request does not match an existing version.",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidVersionID: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid version id specified",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSuspendedVersioningNotAllowed: {
+		Code:           "InvalidBucketState",
+		Description:    "An Object Lock configuration is present on this bucket, so the versioning state cannot be changed.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrReplicationConfigurationNotFoundError: {
+		Code:           "ReplicationConfigurationNotFoundError",
+		Description:    "The replication configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrReplicationTargetNotConfigured: {
+		Code:           "InvalidRequest",
+		Description:    "Replication target is not configured on the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrReplicationDestinationNotFound: {
+		Code:           "InvalidRequest",
+		Description:    "Destination bucket must exist on the replication target",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchCORSConfiguration: {
+		Code:           "NoSuchCORSConfiguration",
+		Description:    "The CORS configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evaluation of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrNotImplemented: {
 		Code:           "NotImplemented",
 		Description:    "A header you provided implies functionality that is not implemented",
@@ -1108,6 +1161,26 @@ var errorCodes = errorCodeMap{
 		Description:    "Quota specified but disk usage crawl is disabled on MinIO server",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminTierAlreadyExists: {
+		Code:           "XMinioAdminTierAlreadyExists",
+		Description:    "The specified remote tier already exists",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminTierNotFound: {
+		Code:           "XMinioAdminTierNotFound",
+		Description:    "The specified remote tier does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminTierInUse: {
+		Code:           "XMinioAdminTierInUse",
+		Description:    "The specified remote tier is used by a bucket lifecycle configuration",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminTierInvalidConfig: {
+		Code:           "XMinioAdminTierInvalidConfig",
+		Description:    "The remote tier configuration is invalid",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1758,6 +1831,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrBucketAlreadyOwnedByYou
 	case ObjectNotFound:
 		apiErr = ErrNoSuchKey
+	case VersionNotFound:
+		apiErr = ErrNoSuchVersion
+	case MethodNotAllowed:
+		apiErr = ErrMethodNotAllowed
 	case ObjectAlreadyExists:
 		apiErr = ErrMethodNotAllowed
 	case ObjectNameInvalid:
@@ -1802,6 +1879,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchLifecycleConfiguration
 	case BucketSSEConfigNotFound:
 		apiErr = ErrNoSuchBucketSSEConfig
+	case BucketReplicationConfigNotFound:
+		apiErr = ErrReplicationConfigurationNotFoundError
+	case BucketCorsConfigNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
 	case BucketQuotaConfigNotFound:
 		apiErr = ErrAdminNoSuchQuotaConfiguration
 	case BucketQuotaExceeded:
@@ -82,6 +82,8 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		w.Header()[xhttp.ETag] = []string{"\"" + objInfo.ETag + "\""}
 	}
 
+	setVersionHeaders(w, objInfo)
+
 	if strings.Contains(objInfo.ETag, "-") && len(objInfo.Parts) > 0 {
 		w.Header().Set(xhttp.AmzMpPartsCount, strconv.Itoa(len(objInfo.Parts)))
 	}
@@ -151,3 +153,14 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 
 	return nil
 }
+
+// setVersionHeaders sets the version id and the delete marker headers
+// of the object version in the response, if any.
+func setVersionHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
+	if objInfo.VersionID != "" {
+		w.Header().Set(xhttp.AmzVersionID, objInfo.VersionID)
+	}
+	if objInfo.DeleteMarker {
+		w.Header().Set(xhttp.AmzDeleteMarker, "true")
+	}
+}
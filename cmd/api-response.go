@@ -29,6 +29,7 @@ import (
 
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
 	"github.com/minio/minio/pkg/handlers"
 )
 
@@ -79,6 +80,7 @@ type ListVersionsResponse struct {
 
 	CommonPrefixes []CommonPrefix
 	Versions       []ObjectVersion
+	DeleteMarkers  []DeleteMarkerVersion
 
 	// Encoding type used to encode object keys in the response.
 	EncodingType string `xml:"EncodingType,omitempty"`
@@ -239,6 +241,16 @@ type ObjectVersion struct {
 	IsLatest  bool
 }
 
+// DeleteMarkerVersion container for a delete marker.
+type DeleteMarkerVersion struct {
+	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteMarker" json:"-"`
+	Key          string
+	LastModified string // time string of format "2006-01-02T15:04:05.000Z"
+	Owner        Owner
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+}
+
 // StringMap is a map[string]string.
 type StringMap map[string]string
 
@@ -336,12 +348,21 @@ type DeleteError struct {
 	Key     string
 }
 
+// DeletedObject object deleted by a multiple object delete, with the
+// delete marker added for it on versioned buckets.
+type DeletedObject struct {
+	ObjectName            string `xml:"Key"`
+	VersionID             string `xml:"VersionId,omitempty"`
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionID string `xml:"DeleteMarkerVersionId,omitempty"`
+}
+
 // DeleteObjectsResponse container for multiple object deletes.
 type DeleteObjectsResponse struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult" json:"-"`
 
 	// Collection of all deleted objects
-	DeletedObjects []ObjectIdentifier `xml:"Deleted,omitempty"`
+	DeletedObjects []DeletedObject `xml:"Deleted,omitempty"`
 
 	// Collection of errors deleting certain objects.
 	Errors []DeleteError `xml:"Error,omitempty"`
@@ -411,18 +432,33 @@ func generateListBucketsResponse(buckets []BucketInfo) ListBucketsResponse {
 }
 
 // generates an ListBucketVersions response for the said bucket with other enumerated options.
-func generateListVersionsResponse(bucket, prefix, marker, delimiter, encodingType string, maxKeys int, resp ListObjectsInfo) ListVersionsResponse {
+func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delimiter, encodingType string, maxKeys int, resp ListObjectVersionsInfo) ListVersionsResponse {
 	var versions []ObjectVersion
+	var deleteMarkers []DeleteMarkerVersion
 	var prefixes []CommonPrefix
 	var owner = Owner{}
 	var data = ListVersionsResponse{}
 
 	owner.ID = globalMinioDefaultOwnerID
 	for _, object := range resp.Objects {
-		var content = ObjectVersion{}
 		if object.Name == "" {
 			continue
 		}
+		versionID := object.VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+		if object.DeleteMarker {
+			deleteMarkers = append(deleteMarkers, DeleteMarkerVersion{
+				Key:          s3EncodeName(object.Name, encodingType),
+				LastModified: object.ModTime.UTC().Format(timeFormatAMZLong),
+				Owner:        owner,
+				VersionID:    versionID,
+				IsLatest:     object.IsLatest,
+			})
+			continue
+		}
+		var content = ObjectVersion{}
 		content.Key = s3EncodeName(object.Name, encodingType)
 		content.LastModified = object.ModTime.UTC().Format(timeFormatAMZLong)
 		if object.ETag != "" {
@@ -436,20 +472,23 @@ func generateListVersionsResponse(bucket, prefix, marker, delimiter, encodingTyp
 		}
 
 		content.Owner = owner
-		content.VersionID = "null"
-		content.IsLatest = true
+		content.VersionID = versionID
+		content.IsLatest = object.IsLatest
 		versions = append(versions, content)
 	}
 	data.Name = bucket
 	data.Versions = versions
+	data.DeleteMarkers = deleteMarkers
 
 	data.EncodingType = encodingType
 	data.Prefix = s3EncodeName(prefix, encodingType)
 	data.KeyMarker = s3EncodeName(marker, encodingType)
+	data.VersionIDMarker = versionIDMarker
 	data.Delimiter = s3EncodeName(delimiter, encodingType)
 	data.MaxKeys = maxKeys
 
 	data.NextKeyMarker = s3EncodeName(resp.NextMarker, encodingType)
+	data.NextVersionIDMarker = resp.NextVersionIDMarker
 	data.IsTruncated = resp.IsTruncated
 
 	for _, prefix := range resp.Prefixes {
@@ -663,7 +702,7 @@ func generateListMultipartUploadsResponse(bucket string, multipartsInfo ListMult
 }
 
 // generate multi objects delete response.
-func generateMultiDeleteResponse(quiet bool, deletedObjects []ObjectIdentifier, errs []DeleteError) DeleteObjectsResponse {
+func generateMultiDeleteResponse(quiet bool, deletedObjects []DeletedObject, errs []DeleteError) DeleteObjectsResponse {
 	deleteResp := DeleteObjectsResponse{}
 	if !quiet {
 		deleteResp.DeletedObjects = deletedObjects
@@ -741,6 +780,9 @@ func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError
 		}
 	}
 
+	// The error code is part of the audit entry of the request.
+	logger.GetReqInfo(ctx).SetTags(audit.TagErrorCode, err.Code)
+
 	// Generate error response.
 	errorResponse := getAPIErrorResponse(ctx, err, reqURL.Path,
 		w.Header().Get(xhttp.AmzRequestID), globalDeploymentID)
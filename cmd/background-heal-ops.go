@@ -74,8 +74,13 @@ func (h *healRoutine) run(ctx context.Context, objAPI ObjectLayer) {
 				break
 			}
 
-			// Wait and proceed if there are active requests
-			waitForLowHTTPReq(int32(globalEndpoints.NEndpoints()))
+			// Wait and proceed if there are active requests,
+			// gateways have no endpoints so tolerate one request.
+			tolerance := int32(globalEndpoints.NEndpoints())
+			if globalIsGateway {
+				tolerance = 1
+			}
+			waitForLowHTTPReq(tolerance)
 
 			var res madmin.HealResultItem
 			var err error
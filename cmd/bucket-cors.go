@@ -0,0 +1,173 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+const bucketCorsConfigFile = "cors.xml"
+
+// BucketCorsSys - map of bucket and CORS configuration.
+type BucketCorsSys struct {
+	sync.RWMutex
+	bucketCorsConfigMap map[string]*cors.Config
+}
+
+// Set - sets CORS config to given bucket name.
+func (sys *BucketCorsSys) Set(bucketName string, config *cors.Config) {
+	sys.Lock()
+	sys.bucketCorsConfigMap[bucketName] = config
+	sys.Unlock()
+}
+
+// Get - gets CORS config associated to a given bucket name.
+func (sys *BucketCorsSys) Get(bucketName string) (config *cors.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketCorsConfigMap[bucketName]
+	return
+}
+
+// Remove - removes CORS config for given bucket name.
+func (sys *BucketCorsSys) Remove(bucketName string) {
+	sys.Lock()
+	delete(sys.bucketCorsConfigMap, bucketName)
+	sys.Unlock()
+}
+
+// Init - initializes bucket CORS sys configuration with all buckets.
+func (sys *BucketCorsSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Bucket CORS is not supported in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		ctx := logger.SetReqInfo(GlobalContext, &logger.ReqInfo{BucketName: bucket.Name})
+		configFile := path.Join(bucketConfigPrefix, bucket.Name, bucketCorsConfigFile)
+		configData, err := readConfig(ctx, objAPI, configFile)
+		if err != nil {
+			if errors.Is(err, errConfigNotFound) {
+				continue
+			}
+			return err
+		}
+		config, err := cors.ParseConfig(bytes.NewReader(configData))
+		if err != nil {
+			return err
+		}
+		sys.Set(bucket.Name, config)
+	}
+	return nil
+}
+
+// NewBucketCorsSys returns initialized BucketCorsSys
+func NewBucketCorsSys() *BucketCorsSys {
+	return &BucketCorsSys{
+		bucketCorsConfigMap: make(map[string]*cors.Config),
+	}
+}
+
+// bucketCorsHandler - evaluates the CORS configuration of the bucket of
+// a request, the requests to buckets without a CORS configuration are
+// served by the server wide CORS handler.
+type bucketCorsHandler struct {
+	handler        http.Handler
+	defaultHandler http.Handler
+}
+
+func (h bucketCorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get(xhttp.Origin)
+	if origin == "" {
+		h.defaultHandler.ServeHTTP(w, r)
+		return
+	}
+
+	bucket, _ := request2BucketObjectName(r)
+	config, ok := globalBucketCorsSys.Get(bucket)
+	if bucket == "" || !ok {
+		h.defaultHandler.ServeHTTP(w, r)
+		return
+	}
+
+	// Preflight requests are answered without reaching the API handlers.
+	if r.Method == http.MethodOptions && r.Header.Get(xhttp.AccessControlRequestMethod) != "" {
+		method := r.Header.Get(xhttp.AccessControlRequestMethod)
+		var headers []string
+		if value := r.Header.Get(xhttp.AccessControlRequestHeaders); value != "" {
+			headers = strings.Split(value, ",")
+		}
+
+		w.Header().Add(xhttp.Vary, xhttp.Origin)
+		w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		rule, ok := config.Match(origin, method, headers)
+		if !ok {
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrCORSForbidden), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		setBucketCorsHeaders(w, origin, rule)
+		if len(headers) > 0 {
+			w.Header().Set(xhttp.AccessControlAllowHeaders, r.Header.Get(xhttp.AccessControlRequestHeaders))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			w.Header().Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Actual requests are served in any case, the CORS headers
+	// are only added when a rule allows the request.
+	w.Header().Add(xhttp.Vary, xhttp.Origin)
+	if rule, ok := config.Match(origin, r.Method, nil); ok {
+		setBucketCorsHeaders(w, origin, rule)
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// setBucketCorsHeaders - sets the CORS response headers of a request
+// from origin allowed by rule.
+func setBucketCorsHeaders(w http.ResponseWriter, origin string, rule cors.Rule) {
+	if rule.AllowsAnyOrigin() {
+		w.Header().Set(xhttp.AccessControlAllowOrigin, "*")
+	} else {
+		w.Header().Set(xhttp.AccessControlAllowOrigin, origin)
+		w.Header().Set(xhttp.AccessControlAllowCredentials, "true")
+	}
+	w.Header().Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+	if len(rule.ExposeHeaders) > 0 {
+		w.Header().Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+// Test S3 Bucket CORS APIs
+func TestBucketCorsHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketCorsHandlers, []string{"GetBucketCors", "PutBucketCors", "DeleteBucketCors"})
+}
+
+func testBucketCorsHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	corsConfig := []byte(`<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><MaxAgeSeconds>300</MaxAgeSeconds></CORSRule></CORSConfiguration>`)
+
+	testCases := []struct {
+		method             string
+		body               []byte
+		expectedRespStatus int
+	}{
+		// GET without a CORS configuration
+		{method: http.MethodGet, expectedRespStatus: http.StatusNotFound},
+		// PUT an invalid CORS configuration
+		{method: http.MethodPut, body: []byte(`<CORSConfiguration></CORSConfiguration>`), expectedRespStatus: http.StatusBadRequest},
+		// PUT a valid CORS configuration
+		{method: http.MethodPut, body: corsConfig, expectedRespStatus: http.StatusOK},
+		// GET the stored CORS configuration
+		{method: http.MethodGet, expectedRespStatus: http.StatusOK},
+		// DELETE the CORS configuration
+		{method: http.MethodDelete, expectedRespStatus: http.StatusNoContent},
+		// GET the removed CORS configuration
+		{method: http.MethodGet, expectedRespStatus: http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, getBucketCorsURL("", bucketName),
+			int64(len(testCase.body)), bytes.NewReader(testCase.body), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+
+		switch {
+		case testCase.method == http.MethodPut && rec.Code == http.StatusOK:
+			if _, ok := globalBucketCorsSys.Get(bucketName); !ok {
+				t.Fatalf("Test %d: %s: Expected the CORS configuration to be cached", i+1, instanceType)
+			}
+		case testCase.method == http.MethodGet && rec.Code == http.StatusOK:
+			config, err := cors.ParseConfig(rec.Body)
+			if err != nil {
+				t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+			}
+			if len(config.CORSRules) != 1 || config.CORSRules[0].MaxAgeSeconds != 300 {
+				t.Fatalf("Test %d: %s: Unexpected CORS configuration %v", i+1, instanceType, config)
+			}
+		case testCase.method == http.MethodDelete:
+			if _, ok := globalBucketCorsSys.Get(bucketName); ok {
+				t.Fatalf("Test %d: %s: Expected the CORS configuration to be removed", i+1, instanceType)
+			}
+		}
+	}
+}
+
+// Tests the evaluation of the CORS configuration of a bucket.
+func TestBucketCorsHandler(t *testing.T) {
+	config, err := cors.ParseConfig(bytes.NewReader([]byte(`<CORSConfiguration>
+	<CORSRule><AllowedOrigin>https://app.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>Content-*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>600</MaxAgeSeconds></CORSRule>
+	<CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>
+</CORSConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalBucketCorsSys = NewBucketCorsSys()
+	defer func() {
+		globalBucketCorsSys = NewBucketCorsSys()
+	}()
+	globalBucketCorsSys.Set("bucket", config)
+
+	served := false
+	handler := setCorsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+	}))
+
+	testCases := []struct {
+		method         string
+		url            string
+		headers        map[string]string
+		expectedStatus int
+		expectedServed bool
+		expectedHeader map[string]string
+	}{
+		// Allowed preflight request
+		{
+			method: http.MethodOptions, url: "/bucket/object",
+			headers: map[string]string{
+				xhttp.Origin:                      "https://app.example.com",
+				xhttp.AccessControlRequestMethod:  http.MethodPut,
+				xhttp.AccessControlRequestHeaders: "content-type",
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{
+				xhttp.AccessControlAllowOrigin:      "https://app.example.com",
+				xhttp.AccessControlAllowCredentials: "true",
+				xhttp.AccessControlAllowMethods:     "PUT",
+				xhttp.AccessControlAllowHeaders:     "content-type",
+				xhttp.AccessControlExposeHeaders:    "ETag",
+				xhttp.AccessControlMaxAge:           "600",
+			},
+		},
+		// Preflight request with a header not allowed
+		{
+			method: http.MethodOptions, url: "/bucket/object",
+			headers: map[string]string{
+				xhttp.Origin:                      "https://app.example.com",
+				xhttp.AccessControlRequestMethod:  http.MethodPut,
+				xhttp.AccessControlRequestHeaders: "x-amz-acl",
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedHeader: map[string]string{xhttp.AccessControlAllowOrigin: ""},
+		},
+		// Preflight request from an origin not allowed
+		{
+			method: http.MethodOptions, url: "/bucket/object",
+			headers: map[string]string{
+				xhttp.Origin:                     "https://other.com",
+				xhttp.AccessControlRequestMethod: http.MethodPut,
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedHeader: map[string]string{xhttp.AccessControlAllowOrigin: ""},
+		},
+		// Actual request allowed from any origin
+		{
+			method: http.MethodGet, url: "/bucket/object",
+			headers:        map[string]string{xhttp.Origin: "https://other.com"},
+			expectedStatus: http.StatusOK,
+			expectedServed: true,
+			expectedHeader: map[string]string{
+				xhttp.AccessControlAllowOrigin:      "*",
+				xhttp.AccessControlAllowCredentials: "",
+				xhttp.AccessControlAllowMethods:     "GET",
+			},
+		},
+		// Actual request not allowed is served without CORS headers
+		{
+			method: http.MethodPut, url: "/bucket/object",
+			headers:        map[string]string{xhttp.Origin: "https://other.com"},
+			expectedStatus: http.StatusOK,
+			expectedServed: true,
+			expectedHeader: map[string]string{xhttp.AccessControlAllowOrigin: ""},
+		},
+		// Bucket without a CORS configuration uses the server defaults
+		{
+			method: http.MethodPut, url: "/other/object",
+			headers:        map[string]string{xhttp.Origin: "https://other.com"},
+			expectedStatus: http.StatusOK,
+			expectedServed: true,
+			expectedHeader: map[string]string{xhttp.AccessControlAllowOrigin: "*"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		served = false
+		req := httptest.NewRequest(testCase.method, testCase.url, nil)
+		for k, v := range testCase.headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, rec.Code)
+		}
+		if served != testCase.expectedServed {
+			t.Errorf("Test %d: expected served %v, got %v", i+1, testCase.expectedServed, served)
+		}
+		for k, v := range testCase.expectedHeader {
+			if got := rec.Header().Get(k); got != v {
+				t.Errorf("Test %d: expected header %s to be %q, got %q", i+1, k, v, got)
+			}
+		}
+	}
+}
@@ -89,6 +89,9 @@ func (sys *BucketSSEConfigSys) Get(bucket string) (config *bucketsse.BucketSSECo
 		return cfg, true
 	}
 
+	if sys == nil {
+		return
+	}
 	sys.Lock()
 	defer sys.Unlock()
 	config, ok = sys.bucketSSEConfigMap[bucket]
@@ -681,7 +681,7 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 
 	getObjectIdentifierList := func(objectNames []string) (objectIdentifierList []ObjectIdentifier) {
 		for _, objectName := range objectNames {
-			objectIdentifierList = append(objectIdentifierList, ObjectIdentifier{objectName})
+			objectIdentifierList = append(objectIdentifierList, ObjectIdentifier{ObjectName: objectName})
 		}
 
 		return objectIdentifierList
@@ -698,6 +698,14 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 		return deleteErrorList
 	}
 
+	getDeletedObjects := func(objects []ObjectIdentifier) (deletedObjects []DeletedObject) {
+		for _, obj := range objects {
+			deletedObjects = append(deletedObjects, DeletedObject{ObjectName: obj.ObjectName})
+		}
+
+		return deletedObjects
+	}
+
 	requestList := []DeleteObjectsRequest{
 		{Quiet: false, Objects: getObjectIdentifierList(objectNames[:5])},
 		{Quiet: true, Objects: getObjectIdentifierList(objectNames[5:])},
@@ -705,16 +713,16 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 
 	// generate multi objects delete response.
 	successRequest0 := encodeResponse(requestList[0])
-	successResponse0 := generateMultiDeleteResponse(requestList[0].Quiet, requestList[0].Objects, nil)
+	successResponse0 := generateMultiDeleteResponse(requestList[0].Quiet, getDeletedObjects(requestList[0].Objects), nil)
 	encodedSuccessResponse0 := encodeResponse(successResponse0)
 
 	successRequest1 := encodeResponse(requestList[1])
-	successResponse1 := generateMultiDeleteResponse(requestList[1].Quiet, requestList[1].Objects, nil)
+	successResponse1 := generateMultiDeleteResponse(requestList[1].Quiet, getDeletedObjects(requestList[1].Objects), nil)
 	encodedSuccessResponse1 := encodeResponse(successResponse1)
 
 	// generate multi objects delete response for errors.
 	// errorRequest := encodeResponse(requestList[1])
-	errorResponse := generateMultiDeleteResponse(requestList[1].Quiet, requestList[1].Objects, nil)
+	errorResponse := generateMultiDeleteResponse(requestList[1].Quiet, getDeletedObjects(requestList[1].Objects), nil)
 	encodedErrorResponse := encodeResponse(errorResponse)
 
 	anonRequest := encodeResponse(requestList[0])
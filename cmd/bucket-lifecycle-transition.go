@@ -0,0 +1,220 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// The stub left in place of a transitioned object records where its
+// data is, the size and the modification time of the object.
+const (
+	transitionTierKey    = ReservedMetadataPrefix + "transition-tier"
+	transitionObjectKey  = ReservedMetadataPrefix + "transitioned-object"
+	transitionSizeKey    = ReservedMetadataPrefix + "transitioned-size"
+	transitionModTimeKey = ReservedMetadataPrefix + "transitioned-mtime"
+)
+
+// isTransitioned returns true if the data of the object is on a tier.
+func isTransitioned(metadata map[string]string) bool {
+	return metadata[transitionTierKey] != "" && metadata[transitionObjectKey] != ""
+}
+
+// setTransitionedObjectInfo sets the size and the modification time of
+// a transitioned object to the ones of the object before its transition.
+func setTransitionedObjectInfo(objInfo *ObjectInfo, metadata map[string]string) {
+	if !isTransitioned(metadata) {
+		return
+	}
+	if size, err := strconv.ParseInt(metadata[transitionSizeKey], 10, 64); err == nil {
+		objInfo.Size = size
+	}
+	if modTime, err := time.Parse(time.RFC3339Nano, metadata[transitionModTimeKey]); err == nil {
+		objInfo.ModTime = modTime
+	}
+}
+
+// getTransitionedObject returns a reader of length bytes, starting at
+// offset, of the data of a transitioned object on its tier.
+func getTransitionedObject(ctx context.Context, objInfo ObjectInfo, offset, length int64) (io.ReadCloser, error) {
+	tier, ok := globalTierConfigSys.Get(objInfo.UserDefined[transitionTierKey])
+	if !ok {
+		return nil, errTierNotFound
+	}
+	if length == 0 {
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+	return tier.Get(ctx, objInfo.UserDefined[transitionObjectKey], offset, length)
+}
+
+// copyTransitionedObject writes length bytes, starting at offset, of the
+// data of a transitioned object on its tier to writer, all the data after
+// offset for a negative length.
+func copyTransitionedObject(ctx context.Context, objInfo ObjectInfo, offset, length int64, writer io.Writer) error {
+	if length < 0 {
+		length = objInfo.Size - offset
+	}
+	if offset < 0 || offset > objInfo.Size || offset+length > objInfo.Size {
+		return InvalidRange{offset, length, objInfo.Size}
+	}
+	rc, err := getTransitionedObject(ctx, objInfo, offset, length)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(writer, rc)
+	return err
+}
+
+// removeTransitionedObject removes the data of a transitioned object
+// from its tier.
+func removeTransitionedObject(ctx context.Context, objInfo ObjectInfo) error {
+	tier, ok := globalTierConfigSys.Get(objInfo.UserDefined[transitionTierKey])
+	if !ok {
+		return errTierNotFound
+	}
+	return tier.Remove(ctx, objInfo.UserDefined[transitionObjectKey])
+}
+
+// removeReplacedTransitionedObject removes the data of a transitioned object
+// from its tier once the object was deleted or overwritten.
+func removeReplacedTransitionedObject(ctx context.Context, objInfo ObjectInfo) {
+	if isTransitioned(objInfo.UserDefined) {
+		logger.LogIf(ctx, removeTransitionedObject(ctx, objInfo))
+	}
+}
+
+// transitionObject copies the data of the object to the tier and replaces
+// the object with a stub keeping its metadata and ETag. Directories, empty
+// objects, encrypted multipart objects and the objects of versioned buckets
+// are not transitioned. It returns true if the object was transitioned.
+func transitionObject(ctx context.Context, objAPI ObjectLayer, objInfo ObjectInfo, tierName string) (bool, error) {
+	tier, ok := globalTierConfigSys.Get(tierName)
+	if !ok {
+		return false, errTierNotFound
+	}
+	if objInfo.IsDir || objInfo.Size == 0 || isTransitioned(objInfo.UserDefined) ||
+		crypto.IsMultiPart(objInfo.UserDefined) {
+		return false, nil
+	}
+	var versionOpts ObjectOptions
+	setVersioningOpts(objInfo.Bucket, &versionOpts)
+	if versionOpts.Versioned || versionOpts.VersionSuspended {
+		return false, nil
+	}
+
+	// The data is copied as stored, compressed or encrypted, reads of the
+	// transitioned object decode it with the metadata kept on the stub.
+	remoteObject := mustGetUUID()
+	pr, pw := io.Pipe()
+	getErrCh := make(chan error, 1)
+	go func() {
+		getErr := objAPI.GetObject(ctx, objInfo.Bucket, objInfo.Name, 0, objInfo.Size, pw, objInfo.ETag, ObjectOptions{})
+		pw.CloseWithError(getErr)
+		getErrCh <- getErr
+	}()
+	err := tier.Put(ctx, remoteObject, pr, objInfo.Size)
+	pr.CloseWithError(err)
+	// Leave the object alone if it was removed or overwritten since it was listed.
+	switch getErr := <-getErrCh; getErr.(type) {
+	case ObjectNotFound, InvalidETag, InvalidRange:
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	meta := make(map[string]string, len(objInfo.UserDefined)+7)
+	for k, v := range objInfo.UserDefined {
+		meta[k] = v
+	}
+	// The stub keeps the ETag of the object.
+	meta["etag"] = objInfo.ETag
+	// The tags and the expiry are not part of the user defined metadata
+	// returned for the object, add them back to keep them.
+	if objInfo.UserTags != "" {
+		meta[xhttp.AmzObjectTagging] = objInfo.UserTags
+	}
+	if !objInfo.Expires.IsZero() {
+		meta["expires"] = objInfo.Expires.Format(http.TimeFormat)
+	}
+	meta[xhttp.AmzStorageClass] = tierName
+	meta[transitionTierKey] = tierName
+	meta[transitionObjectKey] = remoteObject
+	meta[transitionSizeKey] = strconv.FormatInt(objInfo.Size, 10)
+	meta[transitionModTimeKey] = objInfo.ModTime.UTC().Format(time.RFC3339Nano)
+
+	hashReader, err := hash.NewReader(bytes.NewReader(nil), 0, "", "", 0, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		logger.LogIf(ctx, tier.Remove(ctx, remoteObject))
+		return false, err
+	}
+	// The stub replaces the object under its lock, unless the object was
+	// removed or overwritten during the copy.
+	_, err = objAPI.PutObject(ctx, objInfo.Bucket, objInfo.Name, NewPutObjReader(hashReader, nil, nil), ObjectOptions{
+		UserDefined: meta,
+		CheckPutPrecondFn: func(latest ObjectInfo) bool {
+			return latest.ETag != objInfo.ETag || !latest.ModTime.Equal(objInfo.ModTime)
+		},
+	})
+	if err != nil {
+		logger.LogIf(ctx, tier.Remove(ctx, remoteObject))
+		if _, ok := err.(PreConditionFailed); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// transitionedMetadata returns the metadata recording the tier of a
+// transitioned object.
+func transitionedMetadata(metadata map[string]string) map[string]string {
+	meta := make(map[string]string)
+	if !isTransitioned(metadata) {
+		return meta
+	}
+	for _, k := range []string{transitionTierKey, transitionObjectKey, transitionSizeKey, transitionModTimeKey, xhttp.AmzStorageClass} {
+		if v, ok := metadata[k]; ok {
+			meta[k] = v
+		}
+	}
+	return meta
+}
+
+// removeTransitionedMetadata removes the metadata recording the tier of a
+// transitioned object from the metadata of a local copy of its data.
+func removeTransitionedMetadata(metadata map[string]string) {
+	if tier, ok := metadata[transitionTierKey]; ok && metadata[xhttp.AmzStorageClass] == tier {
+		delete(metadata, xhttp.AmzStorageClass)
+	}
+	delete(metadata, transitionTierKey)
+	delete(metadata, transitionObjectKey)
+	delete(metadata, transitionSizeKey)
+	delete(metadata, transitionModTimeKey)
+}
@@ -0,0 +1,179 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// warmBackendMemory keeps the transitioned objects in memory.
+type warmBackendMemory struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *warmBackendMemory) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	data, err := ioutil.ReadAll(io.LimitReader(r, length))
+	if err != nil {
+		return err
+	}
+	m.Lock()
+	m.objects[object] = data
+	m.Unlock()
+	return nil
+}
+
+func (m *warmBackendMemory) Get(ctx context.Context, object string, offset, length int64) (io.ReadCloser, error) {
+	m.Lock()
+	defer m.Unlock()
+	data, ok := m.objects[object]
+	if !ok {
+		return nil, errFileNotFound
+	}
+	return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+}
+
+func (m *warmBackendMemory) Remove(ctx context.Context, object string) error {
+	m.Lock()
+	delete(m.objects, object)
+	m.Unlock()
+	return nil
+}
+
+func (m *warmBackendMemory) Check(ctx context.Context) error {
+	return nil
+}
+
+// Tests that a transitioned object is read back from its tier.
+func TestTransitionObject(t *testing.T) {
+	ExecObjectLayerTest(t, testTransitionObject)
+}
+
+func testTransitionObject(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	tier := &warmBackendMemory{objects: make(map[string][]byte)}
+	globalTierConfigSys = NewTierConfigSys()
+	globalTierConfigSys.tiers["WARM"] = madmin.TierConfig{Name: "WARM", Type: madmin.TierS3}
+	globalTierConfigSys.backends["WARM"] = tier
+	defer func() {
+		globalTierConfigSys = NewTierConfigSys()
+	}()
+
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+	meta := map[string]string{"content-type": "text/plain", "x-amz-meta-key": "value"}
+	objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{UserDefined: meta})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	transitioned, err := transitionObject(ctx, obj, objInfo, "WARM")
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !transitioned || len(tier.objects) != 1 {
+		t.Fatalf("%s: expected 1 object on the tier, got %d", instanceType, len(tier.objects))
+	}
+
+	stubInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !isTransitioned(stubInfo.UserDefined) {
+		t.Fatalf("%s: expected the object to be transitioned", instanceType)
+	}
+	if stubInfo.Size != objInfo.Size || stubInfo.ETag != objInfo.ETag || !stubInfo.ModTime.Equal(objInfo.ModTime) {
+		t.Fatalf("%s: expected size %d, etag %s and modtime %s, got %d, %s and %s", instanceType,
+			objInfo.Size, objInfo.ETag, objInfo.ModTime, stubInfo.Size, stubInfo.ETag, stubInfo.ModTime)
+	}
+	if stubInfo.StorageClass != "WARM" || stubInfo.UserDefined["x-amz-meta-key"] != "value" {
+		t.Fatalf("%s: unexpected metadata %v", instanceType, stubInfo.UserDefined)
+	}
+
+	// A transitioned object is not transitioned again.
+	if transitioned, err = transitionObject(ctx, obj, stubInfo, "WARM"); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if transitioned || len(tier.objects) != 1 {
+		t.Fatalf("%s: expected 1 object on the tier, got %d", instanceType, len(tier.objects))
+	}
+
+	gr, err := obj.GetObjectNInfo(ctx, bucket, object, &HTTPRangeSpec{Start: 10, End: 19}, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !bytes.Equal(got, data[10:20]) {
+		t.Fatalf("%s: expected %q, got %q", instanceType, data[10:20], got)
+	}
+
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("%s: expected the data of the object from its tier, got %d bytes", instanceType, buf.Len())
+	}
+
+	// Overwriting a transitioned object removes its data from the tier.
+	newInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data[:10]), 10, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(tier.objects) != 0 {
+		t.Fatalf("%s: expected no object on the tier, got %d", instanceType, len(tier.objects))
+	}
+
+	// An object overwritten since it was listed is not transitioned.
+	if transitioned, err = transitionObject(ctx, obj, objInfo, "WARM"); err != nil || transitioned {
+		t.Fatalf("%s: expected no transition, got %v", instanceType, err)
+	}
+	if latest, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil || isTransitioned(latest.UserDefined) {
+		t.Fatalf("%s: expected the overwritten object to be left alone, got %v", instanceType, err)
+	}
+	if len(tier.objects) != 0 {
+		t.Fatalf("%s: expected no object on the tier, got %d", instanceType, len(tier.objects))
+	}
+
+	// Deleting a transitioned object removes its data from the tier.
+	if _, err = transitionObject(ctx, obj, newInfo, "WARM"); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(tier.objects) != 1 {
+		t.Fatalf("%s: expected 1 object on the tier, got %d", instanceType, len(tier.objects))
+	}
+	if err = obj.DeleteObject(ctx, bucket, object); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(tier.objects) != 0 {
+		t.Fatalf("%s: expected no object on the tier, got %d", instanceType, len(tier.objects))
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/xml"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 )
@@ -30,6 +31,13 @@ const (
 
 	// Disabled means the lifecycle rule is inactive
 	Disabled = "Disabled"
+
+	// The bucket, the object and the initiation time of a multipart
+	// upload are saved in its metadata, to evaluate the lifecycle rules
+	// of the bucket when cleaning up the incomplete uploads.
+	multipartUploadBucketKey    = ReservedMetadataPrefix + "upload-bucket"
+	multipartUploadObjectKey    = ReservedMetadataPrefix + "upload-object"
+	multipartUploadInitiatedKey = ReservedMetadataPrefix + "upload-initiated"
 )
 
 // LifecycleSys - Bucket lifecycle subsystem.
@@ -161,3 +169,67 @@ func (sys *LifecycleSys) Remove(bucketName string) {
 
 	delete(sys.bucketLifecycleMap, bucketName)
 }
+
+// IsTierUsed - returns true if a rule of a bucket lifecycle transitions
+// objects to the tier.
+func (sys *LifecycleSys) IsTierUsed(tier string) bool {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	for _, lc := range sys.bucketLifecycleMap {
+		for _, rule := range lc.Rules {
+			if rule.Transition.StorageClass == tier {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasAbortIncompleteMultipartUpload - returns true if a bucket lifecycle
+// aborts the incomplete multipart uploads.
+func (sys *LifecycleSys) HasAbortIncompleteMultipartUpload() bool {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	for _, lc := range sys.bucketLifecycleMap {
+		if lc.HasAbortIncompleteMultipartUpload() {
+			return true
+		}
+	}
+	return false
+}
+
+// AbortMultipartUpload - returns true if the lifecycle of the bucket of the
+// multipart upload, found in the upload metadata, aborts the upload.
+func (sys *LifecycleSys) AbortMultipartUpload(meta map[string]string) bool {
+	bucket, object := meta[multipartUploadBucketKey], meta[multipartUploadObjectKey]
+	if bucket == "" || object == "" {
+		return false
+	}
+	initiated, err := time.Parse(time.RFC3339Nano, meta[multipartUploadInitiatedKey])
+	if err != nil {
+		return false
+	}
+	lc, ok := sys.Get(bucket)
+	if !ok {
+		return false
+	}
+	return lc.ComputeAbortMultipartUpload(object, initiated)
+}
+
+// setMultipartUploadMetadata - saves the bucket, the object and the
+// initiation time of a new multipart upload in its metadata.
+func setMultipartUploadMetadata(meta map[string]string, bucket, object string, initiated time.Time) {
+	meta[multipartUploadBucketKey] = bucket
+	meta[multipartUploadObjectKey] = object
+	meta[multipartUploadInitiatedKey] = initiated.UTC().Format(time.RFC3339Nano)
+}
+
+// removeMultipartUploadMetadata - removes the multipart upload metadata
+// from the metadata of the completed object.
+func removeMultipartUploadMetadata(meta map[string]string) {
+	delete(meta, multipartUploadBucketKey)
+	delete(meta, multipartUploadObjectKey)
+	delete(meta, multipartUploadInitiatedKey)
+}
@@ -0,0 +1,102 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+)
+
+// Tests that the multipart uploads are aborted by the lifecycle of their
+// bucket, and that the completed objects don't keep the upload metadata.
+func TestLifecycleAbortMultipartUpload(t *testing.T) {
+	ExecObjectLayerTest(t, testLifecycleAbortMultipartUpload)
+}
+
+func testLifecycleAbortMultipartUpload(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration><Rule><Filter><Prefix>uploads/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	globalLifecycleSys = NewLifecycleSys()
+	defer func() {
+		globalLifecycleSys = NewLifecycleSys()
+	}()
+
+	bucket, object := "bucket", "uploads/object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if globalLifecycleSys.HasAbortIncompleteMultipartUpload() {
+		t.Fatalf("%s: expected no lifecycle to abort multipart uploads", instanceType)
+	}
+	globalLifecycleSys.Set(bucket, lc)
+	if !globalLifecycleSys.HasAbortIncompleteMultipartUpload() {
+		t.Fatalf("%s: expected a lifecycle to abort multipart uploads", instanceType)
+	}
+
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	parts, err := obj.ListObjectParts(ctx, bucket, object, uploadID, 0, 1000, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	meta := parts.UserDefined
+	if globalLifecycleSys.AbortMultipartUpload(meta) {
+		t.Fatalf("%s: expected the new upload not to be aborted", instanceType)
+	}
+
+	meta[multipartUploadInitiatedKey] = UTCNow().Add(-2 * 24 * time.Hour).Format(time.RFC3339Nano)
+	if !globalLifecycleSys.AbortMultipartUpload(meta) {
+		t.Fatalf("%s: expected the upload initiated 2 days ago to be aborted", instanceType)
+	}
+	meta[multipartUploadObjectKey] = "object"
+	if globalLifecycleSys.AbortMultipartUpload(meta) {
+		t.Fatalf("%s: expected the upload of an object out of the rule prefix not to be aborted", instanceType)
+	}
+	meta[multipartUploadObjectKey] = object
+	meta[multipartUploadBucketKey] = "otherbucket"
+	if globalLifecycleSys.AbortMultipartUpload(meta) {
+		t.Fatalf("%s: expected the upload of a bucket without lifecycle not to be aborted", instanceType)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	part, err := obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	objInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	for _, key := range []string{multipartUploadBucketKey, multipartUploadObjectKey, multipartUploadInitiatedKey} {
+		if _, ok := objInfo.UserDefined[key]; ok {
+			t.Fatalf("%s: expected the object metadata without %s", instanceType, key)
+		}
+	}
+}
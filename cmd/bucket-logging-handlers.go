@@ -0,0 +1,191 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// PutBucketLoggingHandler - Stores given bucket logging status, an empty
+// status disables the logging of the bucket.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLogging.html
+func (api objectAPIHandlers) PutBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketLogging")
+
+	defer logger.AuditLog(ctx, w, r, "PutBucketLogging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	accessKey, owner, s3Error := checkRequestAuthTypeToAccessKey(ctx, r, policy.PutBucketLoggingAction, bucket, "")
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Bucket logging is not supported in gateway mode.
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	loggingConfig, err := logging.ParseConfig(r.Body)
+	if err != nil {
+		apiErr := errorCodes.ToAPIErr(ErrMalformedXML)
+		apiErr.Description = fmt.Sprintf("%s (%s)", apiErr.Description, err)
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketLoggingConfigFile)
+	if !loggingConfig.Enabled() {
+		if err = deleteConfig(ctx, objAPI, configFile); err != nil && err != errConfigNotFound {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		// Remove entry from the in-memory bucket logging cache
+		globalBucketLoggingSys.Remove(bucket)
+
+		// Update peer MinIO servers of the removed bucket logging config
+		globalNotificationSys.RemoveBucketLoggingConfig(ctx, bucket)
+
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// The target bucket must exist.
+	if _, err = objAPI.GetBucketInfo(ctx, loggingConfig.LoggingEnabled.TargetBucket); err != nil {
+		apiErr := errorCodes.ToAPIErr(ErrInvalidTargetBucketForLogging)
+		apiErr.Description = fmt.Sprintf("%s (%s)", apiErr.Description, err)
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// The logs are written on behalf of the caller, who must be allowed
+	// to put them under the target prefix.
+	if s3Error = isLoggingTargetAllowed(r, accessKey, owner, loggingConfig.LoggingEnabled); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	loggingConfig.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	configData, err := xml.Marshal(loggingConfig)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = saveConfig(ctx, objAPI, configFile, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Update the in-memory bucket logging cache
+	globalBucketLoggingSys.Set(bucket, loggingConfig)
+
+	// Update peer MinIO servers of the updated bucket logging config
+	globalNotificationSys.SetBucketLoggingConfig(ctx, bucket, loggingConfig)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketLoggingHandler - Returns bucket logging status, an empty status
+// if the logging of the bucket is disabled.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLogging.html
+func (api objectAPIHandlers) GetBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketLogging")
+
+	defer logger.AuditLog(ctx, w, r, "GetBucketLogging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketLoggingAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configData := []byte(`<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`)
+	if !globalIsGateway {
+		data, err := readConfig(ctx, objAPI, path.Join(bucketConfigPrefix, bucket, bucketLoggingConfigFile))
+		if err != nil && err != errConfigNotFound {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if err == nil {
+			configData = data
+		}
+	}
+
+	// Write bucket logging status to client
+	writeSuccessResponseXML(w, configData)
+}
+
+// isLoggingTargetAllowed checks that the request is allowed to put objects
+// under the prefix of the target bucket of the logs, by the IAM policy of
+// the caller or by the bucket policy of the target bucket.
+func isLoggingTargetAllowed(r *http.Request, accessKey string, owner bool, target *logging.LoggingEnabled) APIErrorCode {
+	s3Error := isPutActionAllowed(getRequestAuthType(r), target.TargetBucket, target.TargetPrefix, r, iampolicy.PutObjectAction)
+	if s3Error != ErrAccessDenied || accessKey == "" {
+		return s3Error
+	}
+	if globalPolicySys.IsAllowed(policy.Args{
+		AccountName:     accessKey,
+		Action:          policy.PutObjectAction,
+		BucketName:      target.TargetBucket,
+		ConditionValues: getConditionValues(r, "", accessKey, nil),
+		IsOwner:         owner,
+		ObjectName:      target.TargetPrefix,
+	}) {
+		return ErrNone
+	}
+	return ErrAccessDenied
+}
@@ -0,0 +1,415 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	bucketLoggingConfigFile = "logging.xml"
+
+	// Interval at which the access log records are written to the
+	// target buckets.
+	bucketLoggingFlushInterval = 5 * time.Minute
+
+	// Size of the records of a bucket at which they are written
+	// without waiting for the interval.
+	bucketLoggingMaxBufferSize = 4 << 20
+
+	// Size of the records of a bucket kept while they can not be
+	// written, the oldest records are dropped beyond it.
+	bucketLoggingMaxPendingSize = 64 << 20
+
+	// Time format of the access log records.
+	bucketLoggingTimeFormat = "02/Jan/2006:15:04:05 -0700"
+)
+
+// Sub-resources naming the resource of the operation of an access log
+// record, in the order they are looked up in the request query.
+var bucketLoggingSubResources = []string{
+	"acl",
+	"cors",
+	"delete",
+	"encryption",
+	"legal-hold",
+	"lifecycle",
+	"location",
+	"logging",
+	"notification",
+	"object-lock",
+	"policy",
+	"replication",
+	"retention",
+	"tagging",
+	"versioning",
+	"versions",
+	"website",
+}
+
+// BucketLoggingSys - map of bucket and logging configuration, the access log
+// records of the requests to the buckets are received as an audit target.
+type BucketLoggingSys struct {
+	sync.RWMutex
+	bucketLoggingConfigMap map[string]*logging.Config
+
+	// Access log records of the buckets not written yet.
+	recordsMu sync.Mutex
+	records   map[string]*bytes.Buffer
+	flushCh   chan struct{}
+
+	// Serializes the writes of the records, so that the records put back
+	// by a failed write are written by the next one.
+	flushMu sync.Mutex
+}
+
+// Set - sets logging config to given bucket name.
+func (sys *BucketLoggingSys) Set(bucketName string, config *logging.Config) {
+	sys.Lock()
+	sys.bucketLoggingConfigMap[bucketName] = config
+	sys.Unlock()
+}
+
+// Get - gets logging config associated to a given bucket name.
+func (sys *BucketLoggingSys) Get(bucketName string) (config *logging.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketLoggingConfigMap[bucketName]
+	return
+}
+
+// Remove - removes logging config for given bucket name.
+func (sys *BucketLoggingSys) Remove(bucketName string) {
+	sys.Lock()
+	delete(sys.bucketLoggingConfigMap, bucketName)
+	sys.Unlock()
+}
+
+// Init - initializes bucket logging sys configuration with all buckets.
+func (sys *BucketLoggingSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Bucket logging is not supported in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		ctx := logger.SetReqInfo(GlobalContext, &logger.ReqInfo{BucketName: bucket.Name})
+		configFile := path.Join(bucketConfigPrefix, bucket.Name, bucketLoggingConfigFile)
+		configData, err := readConfig(ctx, objAPI, configFile)
+		if err != nil {
+			if errors.Is(err, errConfigNotFound) {
+				continue
+			}
+			return err
+		}
+		config, err := logging.ParseConfig(bytes.NewReader(configData))
+		if err != nil {
+			return err
+		}
+		sys.Set(bucket.Name, config)
+	}
+	return nil
+}
+
+// NewBucketLoggingSys returns initialized BucketLoggingSys
+func NewBucketLoggingSys() *BucketLoggingSys {
+	return &BucketLoggingSys{
+		bucketLoggingConfigMap: make(map[string]*logging.Config),
+		records:                make(map[string]*bytes.Buffer),
+		flushCh:                make(chan struct{}, 1),
+	}
+}
+
+// Send - implements logger.Target, adds the access log record of the audit
+// entry of a request to a bucket with logging enabled.
+func (sys *BucketLoggingSys) Send(entry interface{}, errKind string) error {
+	e, ok := entry.(audit.Entry)
+	if !ok || e.API.Bucket == "" {
+		return nil
+	}
+	if _, ok = sys.Get(e.API.Bucket); !ok {
+		return nil
+	}
+
+	record := bucketLoggingRecord(e)
+
+	sys.recordsMu.Lock()
+	buf, ok := sys.records[e.API.Bucket]
+	if !ok {
+		buf = &bytes.Buffer{}
+		sys.records[e.API.Bucket] = buf
+	}
+	buf.WriteString(record)
+	full := buf.Len() >= bucketLoggingMaxBufferSize
+	sys.recordsMu.Unlock()
+
+	if full {
+		select {
+		case sys.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// flush writes the access log records of every bucket to an object of the
+// target bucket of the bucket, named after the target prefix and the time.
+// The records that can not be written are kept to be written by the next
+// flush, it returns false if any records were kept.
+func (sys *BucketLoggingSys) flush(ctx context.Context, objAPI ObjectLayer) bool {
+	sys.flushMu.Lock()
+	defer sys.flushMu.Unlock()
+
+	written := true
+	sys.recordsMu.Lock()
+	records := sys.records
+	sys.records = make(map[string]*bytes.Buffer)
+	sys.recordsMu.Unlock()
+
+	for bucket, buf := range records {
+		config, ok := sys.Get(bucket)
+		if !ok || !config.Enabled() {
+			continue
+		}
+		target := config.LoggingEnabled
+		// Records of different servers are written to different
+		// objects, made unique by a random string.
+		object := target.TargetPrefix + UTCNow().Format("2006-01-02-15-04-05") + "-" +
+			strings.ToUpper(strings.Replace(mustGetUUID(), "-", "", -1)[:16])
+		hashReader, err := hash.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()), "", "", int64(buf.Len()), globalCLIContext.StrictS3Compat)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		opts := ObjectOptions{UserDefined: map[string]string{"content-type": "text/plain"}}
+		if _, err = objAPI.PutObject(ctx, target.TargetBucket, object, NewPutObjReader(hashReader, nil, nil), opts); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to write the access logs of bucket %s to %s/%s: %w",
+				bucket, target.TargetBucket, object, err))
+			sys.requeue(ctx, bucket, buf)
+			written = false
+		}
+	}
+	return written
+}
+
+// requeue puts back the records of a bucket that could not be written, in
+// front of the records received since, dropping the oldest records beyond
+// bucketLoggingMaxPendingSize.
+func (sys *BucketLoggingSys) requeue(ctx context.Context, bucket string, buf *bytes.Buffer) {
+	sys.recordsMu.Lock()
+	defer sys.recordsMu.Unlock()
+	if newer, ok := sys.records[bucket]; ok {
+		buf.Write(newer.Bytes())
+	}
+	if excess := buf.Len() - bucketLoggingMaxPendingSize; excess > 0 {
+		// Drop whole records, which end with a newline.
+		if i := bytes.IndexByte(buf.Bytes()[excess:], '\n'); i >= 0 {
+			excess += i + 1
+		} else {
+			excess = buf.Len()
+		}
+		buf.Next(excess)
+		logger.LogIf(ctx, fmt.Errorf("Dropped %d bytes of the access logs of bucket %s which could not be written", excess, bucket))
+	}
+	sys.records[bucket] = buf
+}
+
+// startBucketLogging registers the bucket logging system as an audit target
+// and writes the access log records to the target buckets periodically.
+// The records left when the server stops are written by stopBucketLogging.
+func startBucketLogging(ctx context.Context, objAPI ObjectLayer) {
+	logger.AddAuditTarget(globalBucketLoggingSys)
+
+	go func() {
+		ticker := time.NewTicker(bucketLoggingFlushInterval)
+		defer ticker.Stop()
+		flushCh := globalBucketLoggingSys.flushCh
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-flushCh:
+			}
+			// After a failed write the records are only written again on
+			// the next tick, instead of on every request once they are full.
+			if globalBucketLoggingSys.flush(ctx, objAPI) {
+				flushCh = globalBucketLoggingSys.flushCh
+			} else {
+				flushCh = nil
+			}
+		}
+	}()
+}
+
+// stopBucketLogging writes the access log records received until the server
+// stopped serving requests, while the object layer is still available.
+func stopBucketLogging(objAPI ObjectLayer) {
+	globalBucketLoggingSys.flush(context.Background(), objAPI)
+}
+
+// bucketLoggingRecord returns the access log record of the audit entry of a
+// request, in the format of
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/LogFormat.html
+func bucketLoggingRecord(e audit.Entry) string {
+	requester, sigVersion, authType := bucketLoggingAuth(e)
+
+	t, err := time.Parse(time.RFC3339Nano, e.Time)
+	if err != nil {
+		t = UTCNow()
+	}
+
+	resource := "BUCKET"
+	if e.API.Object != "" {
+		resource = "OBJECT"
+	}
+	if _, ok := e.ReqQuery["uploadId"]; ok {
+		resource = "UPLOAD"
+	} else if _, ok = e.ReqQuery["uploads"]; ok {
+		resource = "UPLOADS"
+	} else {
+		for _, name := range bucketLoggingSubResources {
+			if _, ok = e.ReqQuery[name]; ok {
+				resource = strings.ToUpper(name)
+				break
+			}
+		}
+	}
+
+	// No body is sent in the response to a HEAD request.
+	bytesSent := e.RespHeader[xhttp.ContentLength]
+	if e.ReqMethod == http.MethodHead || bytesSent == "0" {
+		bytesSent = ""
+	}
+
+	key := "-"
+	if e.API.Object != "" {
+		key = s3URLEncode(e.API.Object)
+	}
+
+	// Size of the object read or written by a successful request.
+	objectSize := ""
+	if e.API.Object != "" && e.API.StatusCode >= 200 && e.API.StatusCode < 300 {
+		switch e.ReqMethod {
+		case http.MethodGet, http.MethodHead:
+			objectSize = e.RespHeader[xhttp.ContentLength]
+			if contentRange := e.RespHeader[xhttp.ContentRange]; contentRange != "" {
+				objectSize = contentRange[strings.LastIndex(contentRange, "/")+1:]
+			}
+		case http.MethodPut:
+			objectSize = e.ReqHeader[xhttp.AmzDecodedContentLength]
+			if objectSize == "" {
+				objectSize = e.ReqHeader[xhttp.ContentLength]
+			}
+		}
+	}
+
+	fields := []string{
+		"-", // Bucket owner
+		e.API.Bucket,
+		"[" + t.Format(bucketLoggingTimeFormat) + "]",
+		bucketLoggingField(e.RemoteHost),
+		requester,
+		bucketLoggingField(e.RequestID),
+		"REST." + e.ReqMethod + "." + resource,
+		key,
+		strconv.Quote(e.ReqMethod + " " + e.ReqURI + " " + e.ReqProto),
+		strconv.Itoa(e.API.StatusCode),
+		bucketLoggingField(e.Tags[audit.TagErrorCode]),
+		bucketLoggingField(bytesSent),
+		bucketLoggingField(objectSize),
+		bucketLoggingMillis(e.API.TimeToResponse),
+		bucketLoggingMillis(e.API.TimeToFirstByte),
+		bucketLoggingQuote(e.ReqHeader["Referer"]),
+		bucketLoggingQuote(e.UserAgent),
+		bucketLoggingField(e.RespHeader[xhttp.AmzVersionID]),
+		"-", // Host id
+		sigVersion,
+		"-", // Cipher suite
+		authType,
+		bucketLoggingField(e.ReqHost),
+		"-", // TLS version
+	}
+	return strings.Join(fields, " ") + "\n"
+}
+
+// bucketLoggingAuth returns the access key, the signature version and the
+// authentication type of a request, "-" for anonymous requests.
+func bucketLoggingAuth(e audit.Entry) (accessKey, sigVersion, authType string) {
+	authHeader := e.ReqHeader["Authorization"]
+	switch {
+	case strings.HasPrefix(authHeader, signV4Algorithm):
+		accessKey = "-"
+		if i := strings.Index(authHeader, "Credential="); i >= 0 {
+			accessKey = bucketLoggingField(strings.SplitN(authHeader[i+len("Credential="):], "/", 2)[0])
+		}
+		return accessKey, "SigV4", "AuthHeader"
+	case strings.HasPrefix(authHeader, signV2Algorithm+" "):
+		accessKey = strings.TrimPrefix(authHeader, signV2Algorithm+" ")
+		return bucketLoggingField(strings.SplitN(accessKey, ":", 2)[0]), "SigV2", "AuthHeader"
+	case e.ReqQuery[xhttp.AmzCredential] != "":
+		return bucketLoggingField(strings.SplitN(e.ReqQuery[xhttp.AmzCredential], "/", 2)[0]), "SigV4", "QueryString"
+	case e.ReqQuery[xhttp.AmzAccessKeyID] != "":
+		return bucketLoggingField(e.ReqQuery[xhttp.AmzAccessKeyID]), "SigV2", "QueryString"
+	}
+	return "-", "-", "-"
+}
+
+// bucketLoggingField returns "-" for an empty field of an access log record.
+func bucketLoggingField(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// bucketLoggingQuote returns a quoted field of an access log record.
+func bucketLoggingQuote(s string) string {
+	if s == "" {
+		return `"-"`
+	}
+	return strconv.Quote(s)
+}
+
+// bucketLoggingMillis returns the milliseconds of a duration of an audit
+// entry, "-" if it is not known.
+func bucketLoggingMillis(s string) string {
+	d, err := time.ParseDuration(s)
+	if err != nil || d == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(int64(d/time.Millisecond), 10)
+}
@@ -0,0 +1,233 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
+)
+
+func testBucketLoggingEntry() audit.Entry {
+	var e audit.Entry
+	e.Time = "2020-06-01T10:20:30.123456789Z"
+	e.API.Name = "GetObject"
+	e.API.Bucket = "bucket"
+	e.API.Object = "dir/my object"
+	e.API.StatusCode = 206
+	e.API.TimeToResponse = "12.5ms"
+	e.API.TimeToFirstByte = "3ms"
+	e.RemoteHost = "192.0.2.3"
+	e.RequestID = "161B2E5D6A9F0E1C"
+	e.UserAgent = "aws-cli/1.18"
+	e.ReqMethod = "GET"
+	e.ReqURI = "/bucket/dir/my%20object?versionId=1"
+	e.ReqProto = "HTTP/1.1"
+	e.ReqHost = "localhost:9000"
+	e.ReqQuery = map[string]string{"versionId": "1"}
+	e.ReqHeader = map[string]string{
+		"Authorization": "AWS4-HMAC-SHA256 Credential=minio/20200601/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc",
+	}
+	e.RespHeader = map[string]string{
+		"Content-Length":   "10",
+		"Content-Range":    "bytes 0-9/1024",
+		"X-Amz-Version-Id": "1",
+	}
+	return e
+}
+
+// Tests the access log records of the audit entries.
+func TestBucketLoggingRecord(t *testing.T) {
+	getObject := testBucketLoggingEntry()
+
+	denied := testBucketLoggingEntry()
+	denied.API.Name = "PutBucketVersioning"
+	denied.API.Object = ""
+	denied.API.StatusCode = 403
+	denied.API.TimeToFirstByte = ""
+	denied.ReqMethod = "PUT"
+	denied.ReqURI = "/bucket?versioning"
+	denied.ReqQuery = map[string]string{"versioning": ""}
+	denied.ReqHeader = map[string]string{"Referer": "http://example.com"}
+	denied.RespHeader = map[string]string{"Content-Length": "250"}
+	denied.Tags = map[string]string{audit.TagErrorCode: "AccessDenied"}
+
+	headObject := testBucketLoggingEntry()
+	headObject.API.StatusCode = 200
+	headObject.ReqMethod = "HEAD"
+	headObject.ReqURI = "/bucket/dir/my%20object?X-Amz-Credential=minio%2F20200601"
+	headObject.ReqQuery = map[string]string{"X-Amz-Credential": "minio/20200601/us-east-1/s3/aws4_request"}
+	headObject.ReqHeader = nil
+	headObject.RespHeader = map[string]string{"Content-Length": "1024"}
+
+	testCases := []struct {
+		entry    audit.Entry
+		expected string
+	}{
+		{
+			entry:    getObject,
+			expected: `- bucket [01/Jun/2020:10:20:30 +0000] 192.0.2.3 minio 161B2E5D6A9F0E1C REST.GET.OBJECT dir/my+object "GET /bucket/dir/my%20object?versionId=1 HTTP/1.1" 206 - 10 1024 12 3 "-" "aws-cli/1.18" 1 - SigV4 - AuthHeader localhost:9000 -` + "\n",
+		},
+		{
+			entry:    denied,
+			expected: `- bucket [01/Jun/2020:10:20:30 +0000] 192.0.2.3 - 161B2E5D6A9F0E1C REST.PUT.VERSIONING - "PUT /bucket?versioning HTTP/1.1" 403 AccessDenied 250 - 12 - "http://example.com" "aws-cli/1.18" - - - - - localhost:9000 -` + "\n",
+		},
+		{
+			entry:    headObject,
+			expected: `- bucket [01/Jun/2020:10:20:30 +0000] 192.0.2.3 minio 161B2E5D6A9F0E1C REST.HEAD.OBJECT dir/my+object "HEAD /bucket/dir/my%20object?X-Amz-Credential=minio%2F20200601 HTTP/1.1" 200 - - 1024 12 3 "-" "aws-cli/1.18" - - SigV4 - QueryString localhost:9000 -` + "\n",
+		},
+	}
+	for i, tc := range testCases {
+		if record := bucketLoggingRecord(tc.entry); record != tc.expected {
+			t.Errorf("Test %d: expected\n%s got\n%s", i+1, tc.expected, record)
+		}
+	}
+}
+
+// Tests that the access log records are written to the target bucket.
+func TestBucketLoggingFlush(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketLoggingFlush)
+}
+
+func testBucketLoggingFlush(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	sys := NewBucketLoggingSys()
+
+	for _, bucket := range []string{"bucket", "other", "logs"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	sys.Set("bucket", &logging.Config{LoggingEnabled: &logging.LoggingEnabled{TargetBucket: "logs", TargetPrefix: "bucket/"}})
+
+	entry := testBucketLoggingEntry()
+	other := testBucketLoggingEntry()
+	other.API.Bucket = "other"
+	for _, e := range []audit.Entry{entry, other, entry} {
+		if err := sys.Send(e, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	sys.flush(ctx, obj)
+
+	result, err := obj.ListObjects(ctx, "logs", "", "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 1 || !strings.HasPrefix(result.Objects[0].Name, "bucket/") {
+		t.Fatalf("%s: expected one access log object under bucket/, got %v", instanceType, result.Objects)
+	}
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, "logs", result.Objects[0].Name, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if expected := strings.Repeat(bucketLoggingRecord(entry), 2); buf.String() != expected {
+		t.Fatalf("%s: expected\n%s got\n%s", instanceType, expected, buf.String())
+	}
+
+	// Nothing is written when there are no new records.
+	if !sys.flush(ctx, obj) {
+		t.Fatalf("%s: expected the flush to succeed", instanceType)
+	}
+	if result, err = obj.ListObjects(ctx, "logs", "", "", "", 10); err != nil || len(result.Objects) != 1 {
+		t.Fatalf("%s: expected one access log object, got %v, %v", instanceType, result.Objects, err)
+	}
+
+	// The records are kept while the target bucket can not be written,
+	// and written with the records received since.
+	sys.Set("other", &logging.Config{LoggingEnabled: &logging.LoggingEnabled{TargetBucket: "missing", TargetPrefix: "other/"}})
+	if err = sys.Send(other, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if sys.flush(ctx, obj) {
+		t.Fatalf("%s: expected the flush to fail", instanceType)
+	}
+	if err = sys.Send(other, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err = obj.MakeBucketWithLocation(ctx, "missing", ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !sys.flush(ctx, obj) {
+		t.Fatalf("%s: expected the flush to succeed", instanceType)
+	}
+	if result, err = obj.ListObjects(ctx, "missing", "", "", "", 10); err != nil || len(result.Objects) != 1 {
+		t.Fatalf("%s: expected one access log object, got %v, %v", instanceType, result.Objects, err)
+	}
+	buf.Reset()
+	if err = obj.GetObject(ctx, "missing", result.Objects[0].Name, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if expected := strings.Repeat(bucketLoggingRecord(other), 2); buf.String() != expected {
+		t.Fatalf("%s: expected\n%s got\n%s", instanceType, expected, buf.String())
+	}
+}
+
+// Tests that the oldest records are dropped when too many can not be written.
+func TestBucketLoggingRequeue(t *testing.T) {
+	sys := NewBucketLoggingSys()
+	record := strings.Repeat("a", 1<<20-1) + "\n"
+	buf := bytes.NewBufferString("old\n" + strings.Repeat(record, bucketLoggingMaxPendingSize>>20))
+	sys.records["bucket"] = bytes.NewBufferString("new\n")
+	sys.requeue(context.Background(), "bucket", buf)
+	got := sys.records["bucket"].String()
+	if len(got) > bucketLoggingMaxPendingSize || !strings.HasPrefix(got, record) || !strings.HasSuffix(got, record+"new\n") {
+		t.Fatalf("unexpected records of %d bytes", len(got))
+	}
+}
+
+// Tests that the logs are written only under a prefix the caller is
+// allowed to put objects under.
+func TestIsLoggingTargetAllowed(t *testing.T) {
+	savedPolicySys := globalPolicySys
+	defer func() {
+		globalPolicySys = savedPolicySys
+	}()
+	globalPolicySys = NewPolicySys()
+
+	target := &logging.LoggingEnabled{TargetBucket: "target", TargetPrefix: "logs/"}
+	r := httptest.NewRequest(http.MethodPut, "/bucket?logging", nil)
+	if s3Err := isLoggingTargetAllowed(r, "", false, target); s3Err != ErrAccessDenied {
+		t.Fatalf("expected access to be denied, got %v", s3Err)
+	}
+
+	globalPolicySys.Set("target", &policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{policy.NewStatement(
+			policy.Allow,
+			policy.NewPrincipal("*"),
+			policy.NewActionSet(policy.PutObjectAction),
+			policy.NewResourceSet(policy.NewResource("target", "logs/*")),
+			condition.NewFunctions(),
+		)},
+	})
+	if s3Err := isLoggingTargetAllowed(r, "", false, target); s3Err != ErrNone {
+		t.Fatalf("expected access to be allowed, got %v", s3Err)
+	}
+	target.TargetPrefix = "other/"
+	if s3Err := isLoggingTargetAllowed(r, "", false, target); s3Err != ErrAccessDenied {
+		t.Fatalf("expected access to be denied, got %v", s3Err)
+	}
+}
@@ -47,6 +47,9 @@ func (sys *BucketQuotaSys) Set(bucketName string, q madmin.BucketQuota) {
 
 // Get - Get quota configuration.
 func (sys *BucketQuotaSys) Get(bucketName string) (q madmin.BucketQuota, ok bool) {
+	if sys == nil {
+		return
+	}
 	sys.RLock()
 	defer sys.RUnlock()
 	q, ok = sys.quotaMap[bucketName]
@@ -77,12 +80,21 @@ func (sys *BucketQuotaSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error
 		return errServerNotInitialized
 	}
 
-	// In gateway mode, we do not support bucket quota.
-	// So, this is a no-op for gateway servers.
-	if globalIsGateway {
+	// In gateway mode, bucket quota is only supported by gateways
+	// which report their data usage, this is a no-op for other gateways.
+	if globalIsGateway && !objAPI.IsDataUsageSupported() {
 		return nil
 	}
 
+	// Gateways with a bucket namespace per account keep the quotas
+	// of the buckets of all accounts by their namespaced names.
+	if n, ok := objAPI.(GatewayBucketNamespacer); ok {
+		var err error
+		if buckets, err = n.ListNamespacedBuckets(GlobalContext); err != nil {
+			return err
+		}
+	}
+
 	return sys.load(buckets, objAPI)
 }
 
@@ -123,6 +135,12 @@ func parseBucketQuota(data []byte) (quotaCfg madmin.BucketQuota, err error) {
 	return
 }
 
+// bucketUsageGetter is implemented by gateways which account the usage of every
+// bucket as objects change, so the usage of a single bucket is looked up cheaply.
+type bucketUsageGetter interface {
+	GetBucketUsage(ctx context.Context, bucket string) (uint64, error)
+}
+
 type bucketStorageCache struct {
 	bucketsSizes map[string]uint64
 	lastUpdate   time.Time
@@ -130,10 +148,23 @@ type bucketStorageCache struct {
 }
 
 func (b *bucketStorageCache) check(ctx context.Context, q madmin.BucketQuota, bucket string, size int64) error {
+	objAPI := newObjectLayerWithoutSafeModeFn()
+	// Gateways looking up the usage of a bucket keep it up to date as
+	// objects change, so hard quotas are enforced precisely.
+	if g, ok := objAPI.(bucketUsageGetter); ok {
+		currUsage, err := g.GetBucketUsage(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		if (currUsage + uint64(size)) > q.Quota {
+			return BucketQuotaExceeded{Bucket: bucket}
+		}
+		return nil
+	}
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	if time.Since(b.lastUpdate) > 10*time.Second {
-		dui, err := loadDataUsageFromBackend(ctx, newObjectLayerWithoutSafeModeFn())
+		dui, err := loadDataUsageFromBackend(ctx, objAPI)
 		if err != nil {
 			return err
 		}
@@ -150,7 +181,7 @@ func enforceBucketQuota(ctx context.Context, bucket string, size int64) error {
 	if size < 0 {
 		return nil
 	}
-	q, ok := globalBucketQuotaSys.Get(bucket)
+	q, ok := globalBucketQuotaSys.Get(namespacedBucket(ctx, newObjectLayerWithoutSafeModeFn(), bucket))
 	if !ok {
 		return nil
 	}
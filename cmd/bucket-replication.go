@@ -0,0 +1,486 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/queuestore"
+)
+
+const (
+	bucketReplicationConfigFile = "replication.xml"
+
+	replicationOpPut    = "put"
+	replicationOpDelete = "delete"
+
+	// Number of workers copying objects to the replication target.
+	replicationWorkers = 4
+
+	// Interval at which the failed tasks are tried again.
+	replicationRetryInterval = 5 * time.Minute
+
+	replicationQueueLimit = queuestore.DefaultLimit
+	replicationTaskExt    = ".task"
+)
+
+// BucketReplicationSys - map of bucket and replication configuration.
+type BucketReplicationSys struct {
+	sync.RWMutex
+	bucketReplicationConfigMap map[string]*replication.Config
+}
+
+// Set - sets replication config to given bucket name.
+func (sys *BucketReplicationSys) Set(bucketName string, config *replication.Config) {
+	sys.Lock()
+	sys.bucketReplicationConfigMap[bucketName] = config
+	sys.Unlock()
+}
+
+// Get - gets replication config associated to a given bucket name.
+func (sys *BucketReplicationSys) Get(bucketName string) (config *replication.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketReplicationConfigMap[bucketName]
+	return
+}
+
+// Remove - removes replication config for given bucket name.
+func (sys *BucketReplicationSys) Remove(bucketName string) {
+	sys.Lock()
+	delete(sys.bucketReplicationConfigMap, bucketName)
+	sys.Unlock()
+}
+
+// Init - initializes bucket replication sys configuration with all buckets.
+func (sys *BucketReplicationSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Replication is not supported in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		ctx := logger.SetReqInfo(GlobalContext, &logger.ReqInfo{BucketName: bucket.Name})
+		configFile := path.Join(bucketConfigPrefix, bucket.Name, bucketReplicationConfigFile)
+		configData, err := readConfig(ctx, objAPI, configFile)
+		if err != nil {
+			if errors.Is(err, errConfigNotFound) {
+				continue
+			}
+			return err
+		}
+		config, err := replication.ParseConfig(bytes.NewReader(configData))
+		if err != nil {
+			return err
+		}
+		sys.Set(bucket.Name, config)
+	}
+	return nil
+}
+
+// NewBucketReplicationSys returns initialized BucketReplicationSys
+func NewBucketReplicationSys() *BucketReplicationSys {
+	return &BucketReplicationSys{
+		bucketReplicationConfigMap: make(map[string]*replication.Config),
+	}
+}
+
+// mustReplicate returns true if the object written by the request matches a
+// replication rule of its bucket, SSE-C encrypted objects cannot be read by
+// the replication workers and are not replicated.
+func mustReplicate(r *http.Request, bucket, object string, metadata map[string]string) bool {
+	if globalReplicationPool == nil || crypto.SSEC.IsRequested(r.Header) {
+		return false
+	}
+	config, ok := globalBucketReplicationSys.Get(bucket)
+	if !ok {
+		return false
+	}
+	_, ok = config.Match(object, metadata[xhttp.AmzObjectTagging])
+	return ok
+}
+
+// scheduleReplication queues the copy of an object written with the
+// PENDING replication status to the replication target.
+func scheduleReplication(ctx context.Context, objInfo ObjectInfo) {
+	if globalReplicationPool == nil || objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] != replication.StatusPending {
+		return
+	}
+	config, ok := globalBucketReplicationSys.Get(objInfo.Bucket)
+	if !ok {
+		return
+	}
+	rule, ok := config.Match(objInfo.Name, objInfo.UserTags)
+	if !ok {
+		return
+	}
+	globalReplicationPool.queue(ctx, replicationTask{
+		Op:           replicationOpPut,
+		Bucket:       objInfo.Bucket,
+		Object:       objInfo.Name,
+		VersionID:    objInfo.VersionID,
+		ETag:         objInfo.ETag,
+		TargetBucket: rule.Destination.BucketName(),
+		StorageClass: rule.Destination.StorageClass,
+	})
+}
+
+// scheduleReplicationDelete queues the delete of an object on the
+// replication target if the rule matching it replicates deletes.
+func scheduleReplicationDelete(ctx context.Context, bucket, object string) {
+	if globalReplicationPool == nil {
+		return
+	}
+	config, ok := globalBucketReplicationSys.Get(bucket)
+	if !ok {
+		return
+	}
+	rule, ok := config.MatchDelete(object)
+	if !ok {
+		return
+	}
+	globalReplicationPool.queue(ctx, replicationTask{
+		Op:           replicationOpDelete,
+		Bucket:       bucket,
+		Object:       object,
+		TargetBucket: rule.Destination.BucketName(),
+	})
+}
+
+// replicationTask - a copy or a delete of an object to be done on the
+// replication target.
+type replicationTask struct {
+	Op           string `json:"op"`
+	Bucket       string `json:"bucket"`
+	Object       string `json:"object"`
+	VersionID    string `json:"versionId,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	TargetBucket string `json:"targetBucket"`
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// replicationPool copies the objects to the replication target, the tasks
+// are kept in a queue store until they succeed so that the objects which
+// failed to be replicated, or were pending when the server stopped, are
+// tried again. The tasks of an object are done one at a time, a worker
+// waits for the others to be done with the object.
+type replicationPool struct {
+	objAPI ObjectLayer
+	client *miniogo.Client
+	store  *queuestore.QueueStore
+	keyCh  chan string
+	locks  *nsLockMap
+
+	mu       sync.Mutex
+	inFlight map[string]struct{}
+}
+
+// newReplicationClient returns a client of the configured replication target.
+func newReplicationClient() (*miniogo.Client, error) {
+	cfg := globalReplicationConfig
+	endpoint, secure, err := ParseGatewayEndpoint(cfg.Endpoint.String())
+	if err != nil {
+		return nil, err
+	}
+	client, err := miniogo.NewWithOptions(endpoint, &miniogo.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       secure,
+		Region:       cfg.Region,
+		BucketLookup: miniogo.BucketLookupAuto,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.SetCustomTransport(NewGatewayHTTPTransport())
+	return client, nil
+}
+
+// initBucketReplication starts the replication workers if a replication
+// target is configured, every server replicates the objects it writes.
+func initBucketReplication(ctx context.Context, objAPI ObjectLayer) error {
+	if !globalReplicationConfig.Enabled {
+		return nil
+	}
+	client, err := newReplicationClient()
+	if err != nil {
+		return err
+	}
+	queueDir := globalReplicationConfig.QueueDir
+	if queueDir == "" {
+		queueDir = filepath.Join(globalConfigDir.Get(), "replication")
+	}
+	store := queuestore.New(queueDir, replicationTaskExt, globalReplicationConfig.QueueLimit)
+	if err = store.Open(); err != nil {
+		return err
+	}
+	pool := &replicationPool{
+		objAPI:   objAPI,
+		client:   client,
+		store:    store,
+		keyCh:    make(chan string, replicationQueueLimit),
+		locks:    newNSLock(false),
+		inFlight: make(map[string]struct{}),
+	}
+	for i := 0; i < replicationWorkers; i++ {
+		go pool.worker(ctx)
+	}
+	go pool.retry(ctx)
+	globalReplicationPool = pool
+	return nil
+}
+
+// queue saves the task in the queue store and hands it to the workers.
+func (p *replicationPool) queue(ctx context.Context, task replicationTask) {
+	key, err := p.store.Put(task)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to queue the replication of %s/%s: %w", task.Bucket, task.Object, err))
+		return
+	}
+	p.send(key)
+}
+
+// send hands the queued task to the workers unless it is already being
+// processed, a task which does not fit in the channel is sent on the next
+// retry.
+func (p *replicationPool) send(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.inFlight[key]; ok {
+		return true
+	}
+	select {
+	case p.keyCh <- key:
+		p.inFlight[key] = struct{}{}
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *replicationPool) done(key string) {
+	p.mu.Lock()
+	delete(p.inFlight, key)
+	p.mu.Unlock()
+}
+
+// retry sends the tasks left in the queue store, at startup and then
+// periodically.
+func (p *replicationPool) retry(ctx context.Context) {
+	ticker := time.NewTicker(replicationRetryInterval)
+	defer ticker.Stop()
+	for {
+		keys, err := p.store.Keys()
+		if err != nil {
+			logger.LogIf(ctx, err)
+		}
+		for _, key := range keys {
+			if !p.send(key) {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *replicationPool) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case key := <-p.keyCh:
+			var task replicationTask
+			err := p.store.Get(key, &task)
+			if err == nil {
+				if err = p.replicate(ctx, task); err == nil {
+					err = p.store.Del(key)
+				}
+				if err != nil {
+					rctx := logger.SetReqInfo(ctx, &logger.ReqInfo{BucketName: task.Bucket, ObjectName: task.Object})
+					logger.LogIf(rctx, err)
+				}
+			}
+			p.done(key)
+		}
+	}
+}
+
+func (p *replicationPool) replicate(ctx context.Context, task replicationTask) error {
+	lock := p.locks.NewNSLock(ctx, nil, task.Bucket, task.Object)
+	if err := lock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	switch task.Op {
+	case replicationOpPut:
+		return p.replicateObject(ctx, task)
+	case replicationOpDelete:
+		return p.replicateDelete(ctx, task)
+	}
+	return fmt.Errorf("unknown replication operation %q", task.Op)
+}
+
+// replicateDelete removes the object from the target, the task is done
+// without removing it if the object was written again in the meantime,
+// the task of the new object replicates it.
+func (p *replicationPool) replicateDelete(ctx context.Context, task replicationTask) error {
+	_, err := p.objAPI.GetObjectInfo(ctx, task.Bucket, task.Object, ObjectOptions{})
+	if err == nil {
+		return nil
+	}
+	if !isErrObjectNotFound(err) && !isErrBucketNotFound(err) {
+		return err
+	}
+	return p.client.RemoveObject(task.TargetBucket, task.Object)
+}
+
+// replicateObject copies the version of the object to the target, the task
+// is done if the object was removed or replaced in the meantime.
+func (p *replicationPool) replicateObject(ctx context.Context, task replicationTask) error {
+	opts := ObjectOptions{VersionID: task.VersionID}
+	objInfo, err := p.objAPI.GetObjectInfo(ctx, task.Bucket, task.Object, opts)
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) || isErrBucketNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if objInfo.ETag != task.ETag {
+		return nil
+	}
+
+	err = p.putObject(ctx, task, objInfo)
+	status := replication.StatusCompleted
+	if err != nil {
+		status = replication.StatusFailed
+	}
+	logger.LogIf(ctx, p.setStatus(ctx, task, status))
+	return err
+}
+
+// putObject copies the data and the metadata of the object to the target.
+func (p *replicationPool) putObject(ctx context.Context, task replicationTask, objInfo ObjectInfo) error {
+	opts := ObjectOptions{VersionID: task.VersionID}
+	gr, err := p.objAPI.GetObjectNInfo(ctx, task.Bucket, task.Object, nil, http.Header{}, readLock, opts)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	size := gr.ObjInfo.Size
+	if crypto.IsEncrypted(gr.ObjInfo.UserDefined) {
+		if size, err = gr.ObjInfo.DecryptedSize(); err != nil {
+			return err
+		}
+	}
+	tags, err := url.ParseQuery(objInfo.UserTags)
+	if err != nil {
+		return err
+	}
+	userTags := make(map[string]string, len(tags))
+	for k := range tags {
+		userTags[k] = tags.Get(k)
+	}
+
+	meta := replicatedMetadata(objInfo.UserDefined)
+	if !objInfo.Expires.IsZero() {
+		meta[xhttp.Expires] = objInfo.Expires.Format(http.TimeFormat)
+	}
+
+	_, err = p.client.PutObjectWithContext(ctx, task.TargetBucket, task.Object, gr, size, miniogo.PutObjectOptions{
+		UserMetadata:    meta,
+		UserTags:        userTags,
+		ContentType:     objInfo.ContentType,
+		ContentEncoding: objInfo.ContentEncoding,
+		StorageClass:    task.StorageClass,
+	})
+	return err
+}
+
+// setStatus updates the replication status of the object, unless the
+// replicated version is no longer the latest version of the object. The
+// object is write locked until its metadata is updated.
+func (p *replicationPool) setStatus(ctx context.Context, task replicationTask, status string) error {
+	objectLock := p.objAPI.NewNSLock(ctx, task.Bucket, task.Object)
+	if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer objectLock.Unlock()
+
+	objInfo, err := p.objAPI.GetObjectInfo(ctx, task.Bucket, task.Object, ObjectOptions{NoLock: true})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrBucketNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if objInfo.VersionID != task.VersionID || objInfo.ETag != task.ETag ||
+		objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] == status {
+		return nil
+	}
+	objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = status
+	// The tags and the expiry are not part of the user defined metadata
+	// returned for the object, add them back to keep them.
+	if objInfo.UserTags != "" {
+		objInfo.UserDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
+	}
+	if !objInfo.Expires.IsZero() {
+		objInfo.UserDefined["expires"] = objInfo.Expires.Format(http.TimeFormat)
+	}
+	objInfo.metadataOnly = true
+	_, err = p.objAPI.CopyObject(ctx, task.Bucket, task.Object, task.Bucket, task.Object, objInfo, ObjectOptions{}, ObjectOptions{})
+	return err
+}
+
+// replicatedMetadata returns the user metadata and the standard headers,
+// other than the content type and encoding, sent to the target.
+func replicatedMetadata(userDefined map[string]string) map[string]string {
+	meta := make(map[string]string)
+	for k, v := range userDefined {
+		switch key := strings.ToLower(k); {
+		case strings.HasPrefix(key, "x-amz-meta-"):
+			meta[k] = v
+		case key == "cache-control", key == "content-language", key == "content-disposition":
+			meta[k] = v
+		}
+	}
+	return meta
+}
@@ -0,0 +1,126 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	miniogo "github.com/minio/minio-go/v6"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/replication"
+)
+
+// Tests the metadata sent to the replication target.
+func TestReplicatedMetadata(t *testing.T) {
+	userDefined := map[string]string{
+		"X-Amz-Meta-Foo":           "bar",
+		"x-amz-meta-baz":           "qux",
+		"cache-control":            "no-cache",
+		"content-type":             "text/plain",
+		"X-Amz-Replication-Status": "PENDING",
+		"X-Amz-Storage-Class":      "REDUCED_REDUNDANCY",
+	}
+	expected := map[string]string{
+		"X-Amz-Meta-Foo": "bar",
+		"x-amz-meta-baz": "qux",
+		"cache-control":  "no-cache",
+	}
+	if meta := replicatedMetadata(userDefined); !reflect.DeepEqual(meta, expected) {
+		t.Fatalf("expected %v, got %v", expected, meta)
+	}
+}
+
+// Tests that the delete of an object written again is not replicated, and
+// that the replication status is saved.
+func TestReplicationPoolDeleteAndStatus(t *testing.T) {
+	objAPI, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	var deletes int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			// The location of the target bucket.
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+			return
+		}
+		atomic.AddInt32(&deletes, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	client, err := miniogo.New(strings.TrimPrefix(ts.URL, "http://"), "access", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := &replicationPool{
+		objAPI: objAPI,
+		client: client,
+		locks:  newNSLock(false),
+	}
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = objAPI.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	objInfo, err := objAPI.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), ObjectOptions{
+		UserDefined: map[string]string{xhttp.AmzBucketReplicationStatus: replication.StatusPending},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := replicationTask{Op: replicationOpDelete, Bucket: bucket, Object: object, TargetBucket: "target"}
+	if err = pool.replicate(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&deletes); n != 0 {
+		t.Fatalf("expected the delete of an existing object to be skipped, got %d deletes", n)
+	}
+
+	putTask := replicationTask{Op: replicationOpPut, Bucket: bucket, Object: object, ETag: objInfo.ETag}
+	if err = pool.setStatus(ctx, putTask, replication.StatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	objInfo, err = objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := objInfo.UserDefined[xhttp.AmzBucketReplicationStatus]; status != replication.StatusCompleted {
+		t.Fatalf("expected status %s, got %s", replication.StatusCompleted, status)
+	}
+
+	if err = objAPI.DeleteObject(ctx, bucket, object); err != nil {
+		t.Fatal(err)
+	}
+	if err = pool.replicate(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&deletes); n != 1 {
+		t.Fatalf("expected the delete to be replicated, got %d deletes", n)
+	}
+}
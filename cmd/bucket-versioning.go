@@ -0,0 +1,134 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"path"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/versioning"
+)
+
+const (
+	bucketVersioningConfigFile = "versioning.xml"
+
+	// nullVersionID is the version ID of the objects written while
+	// versioning was not enabled on their bucket.
+	nullVersionID = "null"
+)
+
+// BucketVersioningSys - map of bucket and versioning configuration.
+type BucketVersioningSys struct {
+	sync.RWMutex
+	versioningMap map[string]versioning.Versioning
+}
+
+// Set - set versioning configuration.
+func (sys *BucketVersioningSys) Set(bucketName string, v versioning.Versioning) {
+	sys.Lock()
+	sys.versioningMap[bucketName] = v
+	sys.Unlock()
+}
+
+// Get - Get versioning configuration.
+func (sys *BucketVersioningSys) Get(bucketName string) (v versioning.Versioning, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+	v, ok = sys.versioningMap[bucketName]
+	return
+}
+
+// Remove - removes versioning configuration.
+func (sys *BucketVersioningSys) Remove(bucketName string) {
+	sys.Lock()
+	delete(sys.versioningMap, bucketName)
+	sys.Unlock()
+}
+
+// Enabled - returns true if writes to the bucket create new versions.
+func (sys *BucketVersioningSys) Enabled(bucketName string) bool {
+	v, _ := sys.Get(bucketName)
+	return v.Enabled()
+}
+
+// Suspended - returns true if writes to the bucket replace the null version.
+func (sys *BucketVersioningSys) Suspended(bucketName string) bool {
+	v, _ := sys.Get(bucketName)
+	return v.Suspended()
+}
+
+// Init initialize bucket versioning sys configuration with all buckets.
+func (sys *BucketVersioningSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Versioning is a no-op for the gateways which do not keep versions.
+	if !objAPI.IsVersioningSupported() {
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		ctx := logger.SetReqInfo(GlobalContext, &logger.ReqInfo{BucketName: bucket.Name})
+		configFile := path.Join(bucketConfigPrefix, bucket.Name, bucketVersioningConfigFile)
+		configData, err := readConfig(ctx, objAPI, configFile)
+		if err != nil {
+			if errors.Is(err, errConfigNotFound) {
+				continue
+			}
+			return err
+		}
+		v, err := versioning.ParseConfig(bytes.NewReader(configData))
+		if err != nil {
+			return err
+		}
+		sys.Set(bucket.Name, *v)
+	}
+	return nil
+}
+
+// NewBucketVersioningSys returns initialized BucketVersioningSys
+func NewBucketVersioningSys() *BucketVersioningSys {
+	return &BucketVersioningSys{versioningMap: map[string]versioning.Versioning{}}
+}
+
+// setVersioningOpts sets the versioning state of the bucket in the
+// options of a write or a delete of an object.
+func setVersioningOpts(bucket string, opts *ObjectOptions) {
+	opts.Versioned = globalBucketVersioningSys.Enabled(bucket)
+	opts.VersionSuspended = globalBucketVersioningSys.Suspended(bucket)
+}
+
+// checkVersionID returns ErrNoSuchVersion for a version other than the
+// null version if the object layer does not keep versions, the other
+// version IDs are UUIDs.
+func checkVersionID(objAPI ObjectLayer, versionID string) APIErrorCode {
+	if versionID == "" || versionID == nullVersionID {
+		return ErrNone
+	}
+	if !objAPI.IsVersioningSupported() {
+		return ErrNoSuchVersion
+	}
+	if _, err := uuid.Parse(versionID); err != nil {
+		return ErrInvalidVersionID
+	}
+	return ErrNone
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -31,11 +32,14 @@ import (
 	"github.com/minio/minio/cmd/config/identity/openid"
 	"github.com/minio/minio/cmd/config/notify"
 	"github.com/minio/minio/cmd/config/policy/opa"
+	"github.com/minio/minio/cmd/config/replication"
 	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/target/file"
 	"github.com/minio/minio/cmd/logger/target/http"
+	"github.com/minio/minio/cmd/logger/target/kafka"
 	"github.com/minio/minio/pkg/env"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -45,6 +49,7 @@ func initHelp() {
 		config.EtcdSubSys:           etcd.DefaultKVS,
 		config.CacheSubSys:          cache.DefaultKVS,
 		config.CompressionSubSys:    compress.DefaultKVS,
+		config.ReplicationSubSys:    replication.DefaultKVS,
 		config.IdentityLDAPSubSys:   xldap.DefaultKVS,
 		config.IdentityOpenIDSubSys: openid.DefaultKVS,
 		config.PolicyOPASubSys:      opa.DefaultKVS,
@@ -55,6 +60,8 @@ func initHelp() {
 		config.KmsKesSubSys:         crypto.DefaultKesKVS,
 		config.LoggerWebhookSubSys:  logger.DefaultKVS,
 		config.AuditWebhookSubSys:   logger.DefaultAuditKVS,
+		config.AuditKafkaSubSys:     logger.DefaultAuditKafkaKVS,
+		config.AuditFileSubSys:      logger.DefaultAuditFileKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -78,6 +85,10 @@ func initHelp() {
 			Key:         config.CompressionSubSys,
 			Description: "enable server side compression of objects",
 		},
+		config.HelpKV{
+			Key:         config.ReplicationSubSys,
+			Description: "set the remote S3 target for bucket replication",
+		},
 		config.HelpKV{
 			Key:         config.EtcdSubSys,
 			Description: "federate multiple clusters for IAM and Bucket DNS",
@@ -116,6 +127,16 @@ func initHelp() {
 			Description:     "send audit logs to webhook endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.AuditKafkaSubSys,
+			Description:     "send audit logs to Kafka topics",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.AuditFileSubSys,
+			Description:     "write audit logs to local files rotated by size and age",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyWebhookSubSys,
 			Description:     "publish bucket notifications to webhook endpoints",
@@ -185,6 +206,7 @@ func initHelp() {
 		config.EtcdSubSys:           etcd.Help,
 		config.CacheSubSys:          cache.Help,
 		config.CompressionSubSys:    compress.Help,
+		config.ReplicationSubSys:    replication.Help,
 		config.IdentityOpenIDSubSys: openid.Help,
 		config.IdentityLDAPSubSys:   xldap.Help,
 		config.PolicyOPASubSys:      opa.Help,
@@ -192,6 +214,8 @@ func initHelp() {
 		config.KmsKesSubSys:         crypto.HelpKes,
 		config.LoggerWebhookSubSys:  logger.Help,
 		config.AuditWebhookSubSys:   logger.HelpAudit,
+		config.AuditKafkaSubSys:     logger.HelpAuditKafka,
+		config.AuditFileSubSys:      logger.HelpAuditFile,
 		config.NotifyAMQPSubSys:     notify.HelpAMQP,
 		config.NotifyKafkaSubSys:    notify.HelpKafka,
 		config.NotifyMQTTSubSys:     notify.HelpMQTT,
@@ -247,6 +271,10 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
+	if _, err := replication.LookupConfig(s[config.ReplicationSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -311,6 +339,15 @@ func validateConfig(s config.Config) error {
 		globalNotificationSys.ConfiguredTargetIDs())
 }
 
+// loggerQueueDir returns the queue directory of a logger target, targets
+// configured with the same queue directory queue in separate directories.
+func loggerQueueDir(queueDir, target string) string {
+	if queueDir == "" {
+		return ""
+	}
+	return filepath.Join(queueDir, target)
+}
+
 func lookupConfigs(s config.Config) {
 	ctx := GlobalContext
 
@@ -412,6 +449,11 @@ func lookupConfigs(s config.Config) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to setup Compression: %w", err))
 	}
 
+	globalReplicationConfig, err = replication.LookupConfig(s[config.ReplicationSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to setup bucket replication: %w", err))
+	}
+
 	globalOpenIDConfig, err = openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
 	if err != nil {
@@ -441,32 +483,65 @@ func lookupConfigs(s config.Config) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize logger: %w", err))
 	}
 
-	for _, l := range loggerCfg.HTTP {
+	for name, l := range loggerCfg.HTTP {
 		if l.Enabled {
 			// Enable http logging
-			logger.AddTarget(
-				http.New(http.WithEndpoint(l.Endpoint),
-					http.WithAuthToken(l.AuthToken),
-					http.WithUserAgent(loggerUserAgent),
-					http.WithLogKind(string(logger.All)),
-					http.WithTransport(NewGatewayHTTPTransport()),
-				),
+			target, err := http.New(http.WithEndpoint(l.Endpoint),
+				http.WithAuthToken(l.AuthToken),
+				http.WithUserAgent(loggerUserAgent),
+				http.WithLogKind(l.LogKind),
+				http.WithQueueDir(loggerQueueDir(l.QueueDir, "logger-webhook-"+name), l.QueueLimit),
+				http.WithTransport(NewGatewayHTTPTransport()),
 			)
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize logger target %s: %w", name, err))
+				continue
+			}
+			logger.AddTarget(target)
 		}
 	}
 
-	for _, l := range loggerCfg.Audit {
+	for name, l := range loggerCfg.Audit {
 		if l.Enabled {
 			// Enable http audit logging
-			logger.AddAuditTarget(
-				http.New(http.WithEndpoint(l.Endpoint),
-					http.WithAuthToken(l.AuthToken),
-					http.WithUserAgent(loggerUserAgent),
-					http.WithLogKind(string(logger.All)),
-					http.WithTransport(NewGatewayHTTPTransport()),
-				),
+			target, err := http.New(http.WithEndpoint(l.Endpoint),
+				http.WithAuthToken(l.AuthToken),
+				http.WithUserAgent(loggerUserAgent),
+				http.WithLogKind(string(logger.All)),
+				http.WithAPIEvents(l.APIEvents),
+				http.WithQueueDir(loggerQueueDir(l.QueueDir, "audit-webhook-"+name), l.QueueLimit),
+				http.WithTransport(NewGatewayHTTPTransport()),
 			)
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit target %s: %w", name, err))
+				continue
+			}
+			logger.AddAuditTarget(target)
+		}
+	}
+
+	for name, k := range loggerCfg.AuditKafka {
+		target, err := kafka.New(k.KafkaArgs, k.APIEvents)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to initialize kafka audit target %s: %w", name, err))
+			continue
+		}
+		logger.AddAuditTarget(target)
+	}
+
+	for name, f := range loggerCfg.AuditFile {
+		target, err := file.New(f.Path,
+			file.WithMaxSize(int64(f.MaxSize)),
+			file.WithMaxAge(f.MaxAge),
+			file.WithMaxBackups(f.MaxBackups),
+			file.WithCompress(f.Compress),
+			file.WithAPIEvents(f.APIEvents),
+		)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to initialize file audit target %s: %w", name, err))
+			continue
 		}
+		logger.AddAuditTarget(target)
 	}
 
 	globalConfigTargetList, err = notify.GetNotificationTargets(s, GlobalContext.Done(), NewGatewayHTTPTransport())
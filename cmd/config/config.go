@@ -72,10 +72,13 @@ const (
 	StorageClassSubSys   = "storage_class"
 	APISubSys            = "api"
 	CompressionSubSys    = "compression"
+	ReplicationSubSys    = "replication"
 	KmsVaultSubSys       = "kms_vault"
 	KmsKesSubSys         = "kms_kes"
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
+	AuditKafkaSubSys     = "audit_kafka"
+	AuditFileSubSys      = "audit_file"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -105,10 +108,13 @@ var SubSystems = set.CreateStringSet([]string{
 	APISubSys,
 	StorageClassSubSys,
 	CompressionSubSys,
+	ReplicationSubSys,
 	KmsVaultSubSys,
 	KmsKesSubSys,
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
+	AuditKafkaSubSys,
+	AuditFileSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
@@ -133,6 +139,7 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	APISubSys,
 	StorageClassSubSys,
 	CompressionSubSys,
+	ReplicationSubSys,
 	KmsVaultSubSys,
 	KmsKesSubSys,
 	PolicyOPASubSys,
@@ -0,0 +1,141 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Replication remote target keys and environment variables
+const (
+	Endpoint   = "endpoint"
+	AccessKey  = "access_key"
+	SecretKey  = "secret_key"
+	Region     = "region"
+	QueueDir   = "queue_dir"
+	QueueLimit = "queue_limit"
+
+	EnvReplicationEnable     = "MINIO_REPLICATION_ENABLE"
+	EnvReplicationEndpoint   = "MINIO_REPLICATION_ENDPOINT"
+	EnvReplicationAccessKey  = "MINIO_REPLICATION_ACCESS_KEY"
+	EnvReplicationSecretKey  = "MINIO_REPLICATION_SECRET_KEY"
+	EnvReplicationRegion     = "MINIO_REPLICATION_REGION"
+	EnvReplicationQueueDir   = "MINIO_REPLICATION_QUEUE_DIR"
+	EnvReplicationQueueLimit = "MINIO_REPLICATION_QUEUE_LIMIT"
+)
+
+// Config - the remote S3 compatible target the buckets are replicated to.
+type Config struct {
+	Enabled    bool     `json:"enabled"`
+	Endpoint   *url.URL `json:"endpoint"`
+	AccessKey  string   `json:"accessKey"`
+	SecretKey  string   `json:"secretKey"`
+	Region     string   `json:"region"`
+	QueueDir   string   `json:"queueDir"`
+	QueueLimit uint64   `json:"queueLimit"`
+}
+
+// DefaultKVS - default KV config for the replication target
+var (
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   AccessKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   SecretKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   Region,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "",
+		},
+	}
+)
+
+// LookupConfig - lookup the replication target config.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.ReplicationSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvReplicationEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		// Parsing failures happen due to empty KVS, ignore it.
+		if kvs.Empty() {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+
+	endpoint := env.Get(EnvReplicationEndpoint, kvs.Get(Endpoint))
+	if endpoint == "" {
+		return cfg, errors.New("replication endpoint cannot be empty")
+	}
+	cfg.Endpoint, err = url.Parse(endpoint)
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.Endpoint.Scheme != "http" && cfg.Endpoint.Scheme != "https" {
+		return cfg, fmt.Errorf("replication endpoint %q should be an http or https URL", endpoint)
+	}
+
+	cfg.AccessKey = env.Get(EnvReplicationAccessKey, kvs.Get(AccessKey))
+	cfg.SecretKey = env.Get(EnvReplicationSecretKey, kvs.Get(SecretKey))
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return cfg, errors.New("replication access_key and secret_key cannot be empty")
+	}
+	cfg.Region = env.Get(EnvReplicationRegion, kvs.Get(Region))
+
+	cfg.QueueDir = env.Get(EnvReplicationQueueDir, kvs.Get(QueueDir))
+	if cfg.QueueDir != "" && !filepath.IsAbs(cfg.QueueDir) {
+		return cfg, errors.New("replication queue_dir path should be absolute")
+	}
+	if queueLimit := env.Get(EnvReplicationQueueLimit, kvs.Get(QueueLimit)); queueLimit != "" {
+		cfg.QueueLimit, err = strconv.ParseUint(queueLimit, 10, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid replication queue_limit %q: %w", queueLimit, err)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import "github.com/minio/minio/cmd/config"
+
+// Help template for the bucket replication target.
+var (
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `remote S3 compatible endpoint e.g. "https://replica.example.com:9000"`,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         AccessKey,
+			Description: `access key of the remote endpoint`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SecretKey,
+			Description: `secret key of the remote endpoint`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Region,
+			Description: `region of the remote endpoint e.g. "us-east-1"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for pending replication tasks e.g. '/home/replication'`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueLimit,
+			Description: `maximum limit for pending replication tasks, defaults to '100000'`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
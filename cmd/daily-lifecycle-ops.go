@@ -65,6 +65,20 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 
 		_, bucketHasLockConfig := globalBucketObjectLockConfig.Get(bucket.Name)
 
+		// Expired objects of versioned buckets get a delete marker each.
+		deleteObjects := objAPI.DeleteObjects
+		var versionOpts ObjectOptions
+		setVersioningOpts(bucket.Name, &versionOpts)
+		if objAPI.IsVersioningSupported() && (versionOpts.Versioned || versionOpts.VersionSuspended) {
+			deleteObjects = func(ctx context.Context, bucket string, objects []string) ([]error, error) {
+				errs := make([]error, len(objects))
+				for idx, object := range objects {
+					_, errs[idx] = objAPI.DeleteObjectVersion(ctx, bucket, object, versionOpts)
+				}
+				return errs, nil
+			}
+		}
+
 		// Calculate the common prefix of all lifecycle rules
 		var prefixes []string
 		for _, rule := range l.Rules {
@@ -82,17 +96,41 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 
 		for {
 			var objects []string
+			var objInfos []ObjectInfo
 			for obj := range objInfoCh {
 				if len(objects) == maxObjectList {
 					// Reached maximum delete requests, attempt a delete for now.
 					break
 				}
 				// Find the action that need to be executed
-				if l.ComputeAction(obj.Name, obj.UserTags, obj.ModTime) == lifecycle.DeleteAction {
+				switch l.ComputeAction(obj.Name, obj.UserTags, obj.ModTime) {
+				case lifecycle.DeleteAction:
 					if bucketHasLockConfig && enforceRetentionForDeletion(ctx, obj) {
 						continue
 					}
 					objects = append(objects, obj.Name)
+					objInfos = append(objInfos, obj)
+				case lifecycle.TransitionAction:
+					if isTransitioned(obj.UserDefined) {
+						continue
+					}
+					_, tr := l.FilterRuleActions(obj.Name, obj.UserTags)
+					waitForLowHTTPReq(int32(globalEndpoints.NEndpoints()))
+					transitioned, err := transitionObject(ctx, objAPI, obj, tr.StorageClass)
+					if err != nil {
+						logger.LogIf(ctx, err)
+						continue
+					}
+					if !transitioned {
+						continue
+					}
+					// Notify object transitioned event.
+					sendEvent(eventArgs{
+						EventName:  event.LifecycleTransition,
+						BucketName: bucket.Name,
+						Object:     obj,
+						Host:       "Internal: [ILM-TRANSITION]",
+					})
 				}
 			}
 
@@ -104,7 +142,7 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 			waitForLowHTTPReq(int32(globalEndpoints.NEndpoints()))
 
 			// Deletes a list of objects.
-			deleteErrs, err := objAPI.DeleteObjects(ctx, bucket.Name, objects)
+			deleteErrs, err := deleteObjects(ctx, bucket.Name, objects)
 			if err != nil {
 				logger.LogIf(ctx, err)
 			} else {
@@ -113,14 +151,17 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 						logger.LogIf(ctx, deleteErrs[i])
 						continue
 					}
-					// Notify object deleted event.
+					// Notify object deleted event, the expired objects
+					// of versioned buckets get a delete marker.
+					eventName := event.ObjectRemovedDelete
+					if objAPI.IsVersioningSupported() && (versionOpts.Versioned || versionOpts.VersionSuspended) {
+						eventName = event.ObjectRemovedDeleteMarkerCreated
+					}
 					sendEvent(eventArgs{
-						EventName:  event.ObjectRemovedDelete,
+						EventName:  eventName,
 						BucketName: bucket.Name,
-						Object: ObjectInfo{
-							Name: objects[i],
-						},
-						Host: "Internal: [ILM-EXPIRY]",
+						Object:     objInfos[i],
+						Host:       "Internal: [ILM-EXPIRY]",
 					})
 				}
 			}
@@ -101,6 +101,10 @@ type bloomFilter struct {
 	*bloom.BloomFilter
 }
 
+// BloomFilter is the bloom filter of updated paths passed to CrawlAndGetDataUsage,
+// it is exported so object layers outside this package can implement the crawler.
+type BloomFilter = bloomFilter
+
 // emptyBloomFilter returns an empty bloom filter.
 func emptyBloomFilter() bloomFilter {
 	return bloomFilter{BloomFilter: &bloom.BloomFilter{}}
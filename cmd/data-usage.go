@@ -141,6 +141,11 @@ func loadDataUsageFromBackend(ctx context.Context, objAPI ObjectLayer) (DataUsag
 
 	err := objAPI.GetObject(ctx, dataUsageBucket, dataUsageObjName, 0, -1, &dataUsageInfoJSON, "", ObjectOptions{})
 	if err != nil {
+		if isErrBucketNotFound(err) && globalIsGateway && objAPI.IsDataUsageSupported() {
+			// Gateways without a meta bucket can not store the crawler
+			// results, their data usage is crawled on demand instead.
+			return crawlDataUsage(ctx, objAPI)
+		}
 		if isErrObjectNotFound(err) || isErrBucketNotFound(err) {
 			return DataUsageInfo{}, nil
 		}
@@ -157,6 +162,26 @@ func loadDataUsageFromBackend(ctx context.Context, objAPI ObjectLayer) (DataUsag
 	return dataUsageInfo, nil
 }
 
+// crawlDataUsage runs a full crawl and returns the last update sent by the object layer.
+func crawlDataUsage(ctx context.Context, objAPI ObjectLayer) (DataUsageInfo, error) {
+	var dataUsageInfo DataUsageInfo
+	updates := make(chan DataUsageInfo)
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		for update := range updates {
+			dataUsageInfo = update
+		}
+	}()
+	err := objAPI.CrawlAndGetDataUsage(ctx, nil, updates)
+	close(updates)
+	<-doneCh
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+	return dataUsageInfo, nil
+}
+
 // Item represents each file while walking.
 type Item struct {
 	Path string
@@ -939,6 +939,9 @@ func getOpts(ctx context.Context, r *http.Request, bucket, object string) (Objec
 		opts       ObjectOptions
 	)
 
+	// The version of the object read, the current version if empty.
+	versionID := r.URL.Query().Get("versionId")
+
 	var partNumber int
 	var err error
 	if pn := r.URL.Query().Get("partNumber"); pn != "" {
@@ -959,7 +962,7 @@ func getOpts(ctx context.Context, r *http.Request, bucket, object string) (Objec
 		derivedKey := deriveClientKey(key, bucket, object)
 		encryption, err = encrypt.NewSSEC(derivedKey[:])
 		logger.CriticalIf(ctx, err)
-		return ObjectOptions{ServerSideEncryption: encryption, PartNumber: partNumber}, nil
+		return ObjectOptions{ServerSideEncryption: encryption, PartNumber: partNumber, VersionID: versionID}, nil
 	}
 
 	// default case of passing encryption headers to backend
@@ -968,11 +971,15 @@ func getOpts(ctx context.Context, r *http.Request, bucket, object string) (Objec
 		return opts, err
 	}
 	opts.PartNumber = partNumber
+	opts.VersionID = versionID
 	return opts, nil
 }
 
 // get ObjectOptions for PUT calls from encryption headers and metadata
 func putOpts(ctx context.Context, r *http.Request, bucket, object string, metadata map[string]string) (opts ObjectOptions, err error) {
+	// Writes create a new version of the object if versioning is enabled on the bucket.
+	defer setVersioningOpts(bucket, &opts)
+
 	// In the case of multipart custom format, the metadata needs to be checked in addition to header to see if it
 	// is SSE-S3 encrypted, primarily because S3 protocol does not require SSE-S3 headers in PutObjectPart calls
 	if GlobalGatewaySSE.SSES3() && (crypto.S3.IsRequested(r.Header) || crypto.S3.IsEncrypted(metadata)) {
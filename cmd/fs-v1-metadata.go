@@ -118,6 +118,10 @@ type fsMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// parts info for current object - used in encryption.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// Version ID of the object, empty for the null version.
+	VersionID string `json:"versionId,omitempty"`
+	// Set if this version of the object is a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
 }
 
 // IsValid - tells if the format is sane by validating the version
@@ -149,8 +153,10 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 	}
 
 	objInfo := ObjectInfo{
-		Bucket: bucket,
-		Name:   object,
+		Bucket:       bucket,
+		Name:         object,
+		VersionID:    m.VersionID,
+		DeleteMarker: m.DeleteMarker,
 	}
 
 	// We set file info only if its valid.
@@ -165,6 +171,9 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 		}
 	}
 
+	// The data of a transitioned object is on its tier.
+	setTransitionedObjectInfo(&objInfo, m.Meta)
+
 	objInfo.ETag = extractETag(m.Meta)
 	objInfo.ContentType = m.Meta["content-type"]
 	objInfo.ContentEncoding = m.Meta["content-encoding"]
@@ -231,6 +231,10 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 	// Initialize fs.json values.
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = opts.UserDefined
+	if len(fsMeta.Meta) == 0 {
+		fsMeta.Meta = make(map[string]string)
+	}
+	setMultipartUploadMetadata(fsMeta.Meta, bucket, object, UTCNow())
 
 	fsMetaBytes, err := json.Marshal(fsMeta)
 	if err != nil {
@@ -651,6 +655,13 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, err
 	}
 	defer destLock.Unlock()
+
+	// The versions kept by the object are not replaced by a new version.
+	var replaced ObjectInfo
+	if !opts.Versioned && !opts.VersionSuspended {
+		replaced, _ = fs.getObjectInfo(ctx, bucket, object)
+	}
+
 	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	metaFile, err := fs.rwPool.Create(fsMetaPath)
 	if err != nil {
@@ -675,8 +686,18 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		fsMeta.Meta = make(map[string]string)
 	}
 	fsMeta.Meta["etag"] = s3MD5
+	removeMultipartUploadMetadata(fsMeta.Meta)
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+	if opts.Versioned {
+		fsMeta.VersionID = mustGetUUID()
+	}
+	// The current version is kept with the non-current versions of the object.
+	if opts.Versioned || opts.VersionSuspended {
+		if err = fs.archiveCurrentVersion(ctx, bucket, object, opts.VersionSuspended); err != nil {
+			return oi, toObjectErr(err, bucket, object)
+		}
+	}
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
@@ -687,6 +708,7 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
 	}
+	removeReplacedTransitionedObject(ctx, replaced)
 	fsRemoveAll(ctx, uploadIDDir)
 	// It is safe to ignore any directory not empty error (in case there were multiple uploadIDs on the same object)
 	fsRemoveDir(ctx, fs.getMultipartSHADir(bucket, object))
@@ -761,6 +783,8 @@ func (fs *FSObjects) cleanupStaleMultipartUploads(ctx context.Context, cleanupIn
 			return
 		case <-ticker.C:
 			now := time.Now()
+			// The uploads aborted by a bucket lifecycle are removed as well.
+			checkLifecycle := globalLifecycleSys != nil && globalLifecycleSys.HasAbortIncompleteMultipartUpload()
 			entries, err := readDir(pathJoin(fs.fsPath, minioMetaMultipartBucket))
 			if err != nil {
 				continue
@@ -781,7 +805,15 @@ func (fs *FSObjects) cleanupStaleMultipartUploads(ctx context.Context, cleanupIn
 					if err != nil {
 						continue
 					}
-					if now.Sub(fi.ModTime()) > expiry {
+					abort := now.Sub(fi.ModTime()) > expiry
+					if !abort && checkLifecycle {
+						var fsMeta fsMetaV1
+						fsMetaBuf, err := ioutil.ReadFile(pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID, fs.metaJSONFile))
+						if err == nil && json.Unmarshal(fsMetaBuf, &fsMeta) == nil {
+							abort = globalLifecycleSys.AbortMultipartUpload(fsMeta.Meta)
+						}
+					}
+					if abort {
 						fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID))
 						// It is safe to ignore any directory not empty error (in case there were multiple uploadIDs on the same object)
 						fsRemoveDir(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry))
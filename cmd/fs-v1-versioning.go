@@ -0,0 +1,540 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// The current version of an object is kept at its usual location, the
+// non-current versions and the delete markers are kept in the metadata
+// directory of the object under
+//
+//   .minio.sys/buckets/<bucket>/<object>/.versions/<versionID>/
+//
+// with their `fs.json` and their data file. The null version is kept in
+// the `null` directory. An object whose current version is a delete marker
+// has no data file, its newest version is the delete marker.
+const (
+	// Directory of the non-current versions of an object.
+	fsVersionsDir = ".versions"
+
+	// Data file of a non-current version.
+	fsVersionDataFile = "part.1"
+)
+
+// fsObjectVersion - a non-current version of an object.
+type fsObjectVersion struct {
+	fsMeta  fsMetaV1
+	dir     string      // Directory of the version.
+	modTime time.Time   // Creation time of the version.
+	fi      os.FileInfo // Data file of the version, nil for a delete marker.
+}
+
+// ToObjectInfo - converts the version to ObjectInfo.
+func (v fsObjectVersion) ToObjectInfo(bucket, object string) ObjectInfo {
+	if v.fsMeta.DeleteMarker {
+		return ObjectInfo{
+			Bucket:       bucket,
+			Name:         object,
+			VersionID:    v.fsMeta.VersionID,
+			DeleteMarker: true,
+			ModTime:      v.modTime,
+		}
+	}
+	return v.fsMeta.ToObjectInfo(bucket, object, v.fi)
+}
+
+// getVersionsDir - returns the directory of the non-current versions of an object.
+func (fs *FSObjects) getVersionsDir(bucket, object string) string {
+	return pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fsVersionsDir)
+}
+
+// getVersionDir - returns the directory of a non-current version of an object.
+func (fs *FSObjects) getVersionDir(bucket, object, versionID string) (string, error) {
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	if versionID != nullVersionID {
+		if _, err := uuid.Parse(versionID); err != nil {
+			return "", errFileVersionNotFound
+		}
+	}
+	return pathJoin(fs.getVersionsDir(bucket, object), versionID), nil
+}
+
+// isFSVersion - returns true if versionID, where "null" is the null
+// version, is the version ID of an object written with objVersionID.
+func isFSVersion(objVersionID, versionID string) bool {
+	if versionID == nullVersionID {
+		versionID = ""
+	}
+	return objVersionID == versionID
+}
+
+// readFSMetaFile - reads `fs.json` at fsMetaPath, the caller holds the
+// namespace lock of the object.
+func readFSMetaFile(fsMetaPath string) (fsMeta fsMetaV1, err error) {
+	fsMetaBuf, err := ioutil.ReadFile(fsMetaPath)
+	if err != nil {
+		return fsMeta, osErrToFSFileErr(err)
+	}
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err = json.Unmarshal(fsMetaBuf, &fsMeta); err != nil {
+		return fsMeta, err
+	}
+	if !isFSMetaValid(fsMeta.Version) {
+		return fsMeta, errCorruptedFormat
+	}
+	return fsMeta, nil
+}
+
+// writeFSMetaFile - writes `fs.json` at fsMetaPath, the caller holds the
+// namespace lock of the object.
+func writeFSMetaFile(fsMetaPath string, fsMeta fsMetaV1) error {
+	fsMetaBuf, err := json.Marshal(fsMeta)
+	if err != nil {
+		return err
+	}
+	if err = mkdirAll(path.Dir(fsMetaPath), 0777); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fsMetaPath, fsMetaBuf, 0666)
+}
+
+// readVersions - reads the non-current versions of an object, newest first.
+func (fs *FSObjects) readVersions(ctx context.Context, bucket, object string) ([]fsObjectVersion, error) {
+	versionsDir := fs.getVersionsDir(bucket, object)
+	entries, err := readDir(versionsDir)
+	if err != nil {
+		if err == errFileNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var versions []fsObjectVersion
+	for _, entry := range entries {
+		if !HasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		versionDir := pathJoin(versionsDir, entry)
+		fsMetaPath := pathJoin(versionDir, fs.metaJSONFile)
+		fsMeta, err := readFSMetaFile(fsMetaPath)
+		if err != nil {
+			logger.GetReqInfo(ctx).AppendTags("versionDir", versionDir)
+			logger.LogIf(ctx, err)
+			continue
+		}
+		version := fsObjectVersion{fsMeta: fsMeta, dir: versionDir}
+		if fsMeta.DeleteMarker {
+			fi, err := fsStatFile(ctx, fsMetaPath)
+			if err != nil {
+				continue
+			}
+			version.modTime = fi.ModTime()
+		} else {
+			// The data file keeps the modification time of the version.
+			fi, err := fsStatFile(ctx, pathJoin(versionDir, fsVersionDataFile))
+			if err != nil {
+				continue
+			}
+			version.fi = fi
+			version.modTime = fi.ModTime()
+		}
+		versions = append(versions, version)
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].modTime.After(versions[j].modTime)
+	})
+	return versions, nil
+}
+
+// archiveCurrentVersion - moves the current version of an object to its
+// non-current versions, the null version is removed instead when versioning
+// is suspended.
+func (fs *FSObjects) archiveCurrentVersion(ctx context.Context, bucket, object string, suspended bool) error {
+	if suspended {
+		nullVersionDir, _ := fs.getVersionDir(bucket, object, nullVersionID)
+		if err := fsRemoveAll(ctx, nullVersionDir); err != nil {
+			return err
+		}
+	}
+
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if _, err := fsStatFile(ctx, fsObjPath); err != nil {
+		if err == errFileNotFound {
+			return nil
+		}
+		return err
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	fsMeta, err := readFSMetaFile(fsMetaPath)
+	if err != nil {
+		// Objects written before `fs.json` existed are null versions.
+		fsMeta = fs.defaultFsJSON(object)
+	}
+
+	if suspended && fsMeta.VersionID == "" {
+		return fsRemoveFile(ctx, fsObjPath)
+	}
+
+	versionDir, err := fs.getVersionDir(bucket, object, fsMeta.VersionID)
+	if err != nil {
+		return err
+	}
+	if err = fsRenameFile(ctx, fsObjPath, pathJoin(versionDir, fsVersionDataFile)); err != nil {
+		return err
+	}
+	return writeFSMetaFile(pathJoin(versionDir, fs.metaJSONFile), fsMeta)
+}
+
+// restoreLatestVersion - makes the newest non-current version of an object
+// without a current version its current version, unless it is a delete
+// marker. The metadata directory of the object is removed with its last
+// version.
+func (fs *FSObjects) restoreLatestVersion(ctx context.Context, bucket, object string) error {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if _, err := fsStatFile(ctx, fsObjPath); err != errFileNotFound {
+		return err
+	}
+
+	versions, err := fs.readVersions(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	if len(versions) == 0 {
+		// Remove the metadata of the object and its directories left empty.
+		fsRemoveDir(ctx, fs.getVersionsDir(bucket, object))
+		if err = fsRemoveFile(ctx, fsMetaPath); err != nil && err != errFileNotFound {
+			return err
+		}
+		deleteFile(pathJoin(fs.fsPath, minioMetaBucket), path.Dir(fsMetaPath), false)
+		return nil
+	}
+	latest := versions[0]
+	if latest.fsMeta.DeleteMarker {
+		return nil
+	}
+
+	if err = fsRenameFile(ctx, pathJoin(latest.dir, fsVersionDataFile), fsObjPath); err != nil {
+		return err
+	}
+	if err = writeFSMetaFile(fsMetaPath, latest.fsMeta); err != nil {
+		return err
+	}
+	if err = fsRemoveAll(ctx, latest.dir); err != nil {
+		return err
+	}
+	fsRemoveDir(ctx, fs.getVersionsDir(bucket, object))
+	return nil
+}
+
+// getObjectVersionInfo - returns the info of a version of an object and
+// the path of its data file, the current version is returned if versionID
+// is empty.
+func (fs *FSObjects) getObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (ObjectInfo, string, error) {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+	if versionID == "" || HasSuffix(object, SlashSeparator) {
+		return objInfo, fsObjPath, err
+	}
+	if err == nil && isFSVersion(objInfo.VersionID, versionID) {
+		return objInfo, fsObjPath, nil
+	}
+	if err != nil && err != errFileNotFound {
+		return objInfo, "", err
+	}
+
+	versionDir, err := fs.getVersionDir(bucket, object, versionID)
+	if err != nil {
+		return ObjectInfo{}, "", toObjectErr(err, bucket, object, versionID)
+	}
+	fsMeta, err := readFSMetaFile(pathJoin(versionDir, fs.metaJSONFile))
+	if err != nil {
+		if err == errFileNotFound {
+			err = errFileVersionNotFound
+		}
+		return ObjectInfo{}, "", toObjectErr(err, bucket, object, versionID)
+	}
+	if fsMeta.DeleteMarker {
+		return ObjectInfo{}, "", errFileIsDeleteMarker
+	}
+	fsObjPath = pathJoin(versionDir, fsVersionDataFile)
+	fi, err := fsStatFile(ctx, fsObjPath)
+	if err != nil {
+		return ObjectInfo{}, "", err
+	}
+	return fsMeta.ToObjectInfo(bucket, object, fi), fsObjPath, nil
+}
+
+// getObjectVersionInfoWithLock - reads the metadata of a version of an object.
+func (fs *FSObjects) getObjectVersionInfoWithLock(ctx context.Context, bucket, object, versionID string, lockType LockType) (ObjectInfo, error) {
+	if lockType != noLock {
+		// Lock the object before reading.
+		objectLock := fs.NewNSLock(ctx, bucket, object)
+		if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer objectLock.RUnlock()
+	}
+
+	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket)
+	}
+
+	objInfo, _, err := fs.getObjectVersionInfo(ctx, bucket, object, versionID)
+	return objInfo, toObjectErr(err, bucket, object)
+}
+
+// DeleteObjectVersion - deletes a version of an object. If no version is
+// specified a delete marker becomes the current version of the object, it
+// replaces the null version when versioning is suspended.
+func (fs *FSObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	objInfo = ObjectInfo{Bucket: bucket, Name: object}
+
+	// Directories and the objects of buckets without versioning do not keep versions.
+	if HasSuffix(object, SlashSeparator) || (opts.VersionID == "" && !opts.Versioned && !opts.VersionSuspended) {
+		return objInfo, fs.DeleteObject(ctx, bucket, object)
+	}
+
+	// Acquire a write lock before deleting the object.
+	objectLock := fs.NewNSLock(ctx, bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	defer ObjectPathUpdated(path.Join(bucket, object))
+
+	atomic.AddInt64(&fs.activeIOCount, 1)
+	defer func() {
+		atomic.AddInt64(&fs.activeIOCount, -1)
+	}()
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+
+	if opts.VersionID == "" {
+		versions, err := fs.readVersions(ctx, bucket, object)
+		if err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if len(versions) == 0 && !fsIsFile(ctx, fsObjPath) {
+			return objInfo, ObjectNotFound{Bucket: bucket, Object: object}
+		}
+		if err = fs.archiveCurrentVersion(ctx, bucket, object, opts.VersionSuspended); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+
+		marker := newFSMetaV1()
+		marker.DeleteMarker = true
+		if opts.Versioned {
+			marker.VersionID = mustGetUUID()
+		}
+		versionDir, err := fs.getVersionDir(bucket, object, marker.VersionID)
+		if err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if err = writeFSMetaFile(pathJoin(versionDir, fs.metaJSONFile), marker); err != nil {
+			logger.LogIf(ctx, err)
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+
+		// Remove the metadata of the previous current version, and the
+		// directories of the object left empty.
+		if err = fsRemoveFile(ctx, fsMetaPath); err != nil && err != errFileNotFound {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		deleteFile(pathJoin(fs.fsPath, bucket), path.Dir(fsObjPath), false)
+
+		objInfo.VersionID = marker.VersionID
+		objInfo.DeleteMarker = true
+		return objInfo, nil
+	}
+
+	curInfo, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil && err != errFileNotFound {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	if err == nil && isFSVersion(curInfo.VersionID, opts.VersionID) {
+		// Delete the current version.
+		if err = fsDeleteFile(ctx, pathJoin(fs.fsPath, bucket), fsObjPath); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if err = fsRemoveFile(ctx, fsMetaPath); err != nil && err != errFileNotFound {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		objInfo.VersionID = curInfo.VersionID
+	} else {
+		versionDir, err := fs.getVersionDir(bucket, object, opts.VersionID)
+		if err != nil {
+			return objInfo, toObjectErr(err, bucket, object, opts.VersionID)
+		}
+		fsMeta, err := readFSMetaFile(pathJoin(versionDir, fs.metaJSONFile))
+		if err != nil {
+			if err == errFileNotFound {
+				err = errFileVersionNotFound
+			}
+			return objInfo, toObjectErr(err, bucket, object, opts.VersionID)
+		}
+		if err = fsRemoveAll(ctx, versionDir); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		objInfo.VersionID = fsMeta.VersionID
+		objInfo.DeleteMarker = fsMeta.DeleteMarker
+	}
+
+	if err = fs.restoreLatestVersion(ctx, bucket, object); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	return objInfo, nil
+}
+
+// getObjectVersions - returns all the versions of an object, the current
+// version first and the non-current versions newest first.
+func (fs *FSObjects) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	// Lock the object before reading.
+	objectLock := fs.NewNSLock(ctx, bucket, object)
+	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+		return nil, err
+	}
+	defer objectLock.RUnlock()
+
+	var objInfos []ObjectInfo
+	objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil && err != errFileNotFound {
+		return nil, toObjectErr(err, bucket, object)
+	}
+	if err == nil {
+		objInfos = append(objInfos, objInfo)
+	}
+
+	versions, err := fs.readVersions(ctx, bucket, object)
+	if err != nil {
+		return nil, toObjectErr(err, bucket, object)
+	}
+	for _, version := range versions {
+		objInfos = append(objInfos, version.ToObjectInfo(bucket, object))
+	}
+
+	if len(objInfos) == 0 {
+		return nil, ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	objInfos[0].IsLatest = true
+	return objInfos, nil
+}
+
+// listVersionsDirFactory - returns the listDir function of the objects
+// with versions, the objects whose current version is a delete marker
+// are only found in the metadata directory of the bucket.
+func (fs *FSObjects) listVersionsDirFactory() ListDirFunc {
+	listDir := fs.listDirFactory()
+	return func(bucket, prefixDir, prefixEntry string) (emptyDir bool, entries []string) {
+		emptyDir, entries = listDir(bucket, prefixDir, prefixEntry)
+
+		metaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, prefixDir)
+		metaEntries, err := readDir(metaDir)
+		if err != nil {
+			if err != errFileNotFound {
+				logger.LogIf(GlobalContext, err)
+			}
+			return emptyDir, entries
+		}
+
+		found := make(map[string]struct{}, len(entries))
+		for _, entry := range entries {
+			found[entry] = struct{}{}
+		}
+		for _, metaEntry := range filterMatchingPrefix(metaEntries, prefixEntry) {
+			if !HasSuffix(metaEntry, SlashSeparator) || metaEntry == fsVersionsDir+SlashSeparator {
+				continue
+			}
+			subEntries, err := readDir(pathJoin(metaDir, metaEntry))
+			if err != nil {
+				continue
+			}
+			for _, subEntry := range subEntries {
+				entry := metaEntry
+				if subEntry == fsVersionsDir+SlashSeparator {
+					// The object keeps versions.
+					entry = strings.TrimSuffix(metaEntry, SlashSeparator)
+				} else if !HasSuffix(subEntry, SlashSeparator) {
+					continue
+				}
+				if _, ok := found[entry]; !ok {
+					found[entry] = struct{}{}
+					entries = append(entries, entry)
+				}
+			}
+		}
+		if len(entries) == 0 {
+			// Only the directories of the bucket are empty directory objects.
+			if _, err = fsStatDir(GlobalContext, pathJoin(fs.fsPath, bucket, prefixDir)); err != nil {
+				return false, nil
+			}
+			return emptyDir, nil
+		}
+		sort.Strings(entries)
+		return false, entries
+	}
+}
+
+// ListObjectVersions - lists the versions of the objects, including the
+// objects whose current version is a delete marker.
+func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	atomic.AddInt64(&fs.activeIOCount, 1)
+	defer func() {
+		atomic.AddInt64(&fs.activeIOCount, -1)
+	}()
+
+	getObjInfo := func(ctx context.Context, bucket, object string) (ObjectInfo, error) {
+		return ObjectInfo{Bucket: bucket, Name: object, IsDir: HasSuffix(object, SlashSeparator)}, nil
+	}
+	listFn := func(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsInfo, error) {
+		return listObjects(ctx, fs, bucket, prefix, marker, delimiter, maxKeys, fs.listVersPool,
+			fs.listVersionsDirFactory(), getObjInfo, getObjInfo)
+	}
+	return listObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys, listFn, fs.getObjectVersions)
+}
@@ -79,6 +79,9 @@ type FSObjects struct {
 	// ListObjects pool management.
 	listPool *TreeWalkPool
 
+	// ListObjectVersions pool management.
+	listVersPool *TreeWalkPool
+
 	diskMount bool
 
 	appendFileMap   map[string]*fsAppendFile
@@ -169,6 +172,7 @@ func NewFSObjectLayer(fsPath string) (ObjectLayer, error) {
 		},
 		nsMutex:       newNSLock(false),
 		listPool:      NewTreeWalkPool(globalLookupTimeout),
+		listVersPool:  NewTreeWalkPool(globalLookupTimeout),
 		appendFileMap: make(map[string]*fsAppendFile),
 		diskMount:     mountinfo.IsLikelyMountPoint(fsPath),
 
@@ -474,7 +478,8 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 	defer ObjectPathUpdated(path.Join(dstBucket, dstObject))
 
-	if !cpSrcDstSame {
+	// A copy onto the same object writes a new version of it on versioned buckets.
+	if !cpSrcDstSame || !srcInfo.metadataOnly {
 		objectDWLock := fs.NewNSLock(ctx, dstBucket, dstObject)
 		if err := objectDWLock.GetLock(globalObjectTimeout); err != nil {
 			return oi, err
@@ -528,7 +533,12 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return ObjectInfo{}, err
 	}
 
-	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined})
+	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+	})
 	if err != nil {
 		return oi, toObjectErr(err, dstBucket, dstObject)
 	}
@@ -575,7 +585,8 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 
 	// Otherwise we get the object info
 	var objInfo ObjectInfo
-	if objInfo, err = fs.getObjectInfo(ctx, bucket, object); err != nil {
+	var fsObjPath string
+	if objInfo, fsObjPath, err = fs.getObjectVersionInfo(ctx, bucket, object, opts.VersionID); err != nil {
 		nsUnlocker()
 		return nil, toObjectErr(err, bucket, object)
 	}
@@ -605,8 +616,18 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 		return nil, rErr
 	}
 
+	// The data of a transitioned object is read from its tier.
+	if isTransitioned(objInfo.UserDefined) {
+		rc, err := getTransitionedObject(ctx, objInfo, off, length)
+		if err != nil {
+			rwPoolUnlocker()
+			nsUnlocker()
+			return nil, toObjectErr(err, bucket, object)
+		}
+		return objReaderFn(rc, h, opts.CheckCopyPrecondFn, func() { rc.Close() })
+	}
+
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
 	readCloser, size, err := fsOpenFile(ctx, fsObjPath, off)
 	if err != nil {
 		rwPoolUnlocker()
@@ -706,6 +727,22 @@ func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offse
 		}
 	}
 
+	// The data of a transitioned object is read from its tier.
+	if bucket != minioMetaBucket {
+		objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			return toObjectErr(err, bucket, object)
+		}
+		if isTransitioned(objInfo.UserDefined) {
+			err = copyTransitionedObject(ctx, objInfo, offset, length, writer)
+			// The writer will be closed incase of range queries, which will emit ErrClosedPipe.
+			if err == io.ErrClosedPipe {
+				err = nil
+			}
+			return toObjectErr(err, bucket, object)
+		}
+	}
+
 	// Read the object, doesn't exist returns an s3 compatible error.
 	fsObjPath := pathJoin(fs.fsPath, bucket, object)
 	reader, size, err := fsOpenFile(ctx, fsObjPath, offset)
@@ -815,13 +852,15 @@ func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (
 }
 
 // getObjectInfoWithLock - reads object metadata and replies back ObjectInfo.
-func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object string) (oi ObjectInfo, e error) {
-	// Lock the object before reading.
-	objectLock := fs.NewNSLock(ctx, bucket, object)
-	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
-		return oi, err
+func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object string, lockType LockType) (oi ObjectInfo, e error) {
+	if lockType != noLock {
+		// Lock the object before reading.
+		objectLock := fs.NewNSLock(ctx, bucket, object)
+		if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+			return oi, err
+		}
+		defer objectLock.RUnlock()
 	}
-	defer objectLock.RUnlock()
 
 	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
 		return oi, err
@@ -846,21 +885,33 @@ func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, o
 		atomic.AddInt64(&fs.activeIOCount, -1)
 	}()
 
-	oi, err := fs.getObjectInfoWithLock(ctx, bucket, object)
+	lockType := readLock
+	if opts.NoLock {
+		lockType = noLock
+	}
+	if opts.VersionID != "" {
+		return fs.getObjectVersionInfoWithLock(ctx, bucket, object, opts.VersionID, lockType)
+	}
+
+	oi, err := fs.getObjectInfoWithLock(ctx, bucket, object, lockType)
 	if err == errCorruptedFormat || err == io.EOF {
 		objectLock := fs.NewNSLock(ctx, bucket, object)
-		if err = objectLock.GetLock(globalObjectTimeout); err != nil {
-			return oi, toObjectErr(err, bucket, object)
+		if lockType != noLock {
+			if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+				return oi, toObjectErr(err, bucket, object)
+			}
 		}
 
 		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 		err = fs.createFsJSON(object, fsMetaPath)
-		objectLock.Unlock()
+		if lockType != noLock {
+			objectLock.Unlock()
+		}
 		if err != nil {
 			return oi, toObjectErr(err, bucket, object)
 		}
 
-		oi, err = fs.getObjectInfoWithLock(ctx, bucket, object)
+		oi, err = fs.getObjectInfoWithLock(ctx, bucket, object, lockType)
 	}
 	return oi, toObjectErr(err, bucket, object)
 }
@@ -927,9 +978,26 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket)
 	}
 
+	if opts.CheckPutPrecondFn != nil {
+		oi, err := fs.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+				return ObjectInfo{}, err
+			}
+		}
+		if opts.CheckPutPrecondFn(oi) {
+			return ObjectInfo{}, PreConditionFailed{}
+		}
+	}
+
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = meta
 
+	versioned := bucket != minioMetaBucket && (opts.Versioned || opts.VersionSuspended)
+	if versioned && opts.Versioned {
+		fsMeta.VersionID = mustGetUUID()
+	}
+
 	// This is a special case with size as '0' and object ends
 	// with a slash separator, we treat it like a valid operation
 	// and return success.
@@ -960,6 +1028,12 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, errInvalidArgument
 	}
 
+	// The versions kept by the object are not replaced by a new version.
+	var replaced ObjectInfo
+	if bucket != minioMetaBucket && !versioned {
+		replaced, _ = fs.getObjectInfo(ctx, bucket, object)
+	}
+
 	var wlk *lock.LockedFile
 	if bucket != minioMetaBucket {
 		bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
@@ -999,7 +1073,10 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		fsRemoveFile(ctx, fsTmpObjPath)
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
-	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	// The stub of a transitioned object keeps the ETag of the object.
+	if !isTransitioned(fsMeta.Meta) || fsMeta.Meta["etag"] == "" {
+		fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	}
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
@@ -1013,6 +1090,13 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	// nothing to delete.
 	defer fsRemoveFile(ctx, fsTmpObjPath)
 
+	// The current version is kept with the non-current versions of the object.
+	if versioned {
+		if err = fs.archiveCurrentVersion(ctx, bucket, object, opts.VersionSuspended); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+
 	// Entire object was written to the temp location, now it's safe to rename it to the actual location.
 	fsNSObjPath := pathJoin(fs.fsPath, bucket, object)
 	if err = fsRenameFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
@@ -1025,6 +1109,7 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 			return ObjectInfo{}, toObjectErr(err, bucket, object)
 		}
 	}
+	removeReplacedTransitionedObject(ctx, replaced)
 
 	// Stat the file to fetch timestamp, size.
 	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
@@ -1071,6 +1156,11 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) er
 		return toObjectErr(err, bucket)
 	}
 
+	var deleted ObjectInfo
+	if bucket != minioMetaBucket {
+		deleted, _ = fs.getObjectInfo(ctx, bucket, object)
+	}
+
 	minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
 	fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	if bucket != minioMetaBucket {
@@ -1097,6 +1187,7 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) er
 			return toObjectErr(err, bucket, object)
 		}
 	}
+	removeReplacedTransitionedObject(ctx, deleted)
 	return nil
 }
 
@@ -1306,7 +1397,7 @@ func (fs *FSObjects) Walk(ctx context.Context, bucket, prefix string, results ch
 }
 
 // HealObjects - no-op for fs. Valid only for XL.
-func (fs *FSObjects) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn healObjectFn) (e error) {
+func (fs *FSObjects) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn HealObjectFn) (e error) {
 	logger.LogIf(ctx, NotImplemented{})
 	return NotImplemented{}
 }
@@ -1410,6 +1501,16 @@ func (fs *FSObjects) IsCompressionSupported() bool {
 	return true
 }
 
+// IsDataUsageSupported returns whether data usage crawling is implemented for this layer.
+func (fs *FSObjects) IsDataUsageSupported() bool {
+	return true
+}
+
+// IsVersioningSupported returns whether bucket versioning is implemented for this layer.
+func (fs *FSObjects) IsVersioningSupported() bool {
+	return true
+}
+
 // IsReady - Check if the backend disk is ready to accept traffic.
 func (fs *FSObjects) IsReady(_ context.Context) bool {
 	if _, err := os.Stat(fs.fsPath); err != nil {
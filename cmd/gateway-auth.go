@@ -0,0 +1,123 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+
+	jwtreq "github.com/dgrijalva/jwt-go/request"
+	xjwt "github.com/minio/minio/cmd/jwt"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/certs"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// GetTLSCertificateFunc returns the function serving the TLS certificate of the server,
+// or nil if TLS is not configured. Gateways use it to serve their own APIs with TLS.
+func GetTLSCertificateFunc() certs.GetCertificateFunc {
+	if globalTLSCerts == nil {
+		return nil
+	}
+	return globalTLSCerts.GetCertificate
+}
+
+// IsGatewayRequestAllowed authenticates the caller of an API served by a gateway next to
+// the S3 API, and checks that IAM policies allow it to perform the action on the bucket
+// and object. The Authorization header of the request must either hold a JWT, as issued
+// by the web and admin login, or the access key and secret key using basic authentication.
+// An error is returned if the caller can not be authenticated.
+func IsGatewayRequestAllowed(r *http.Request, action iampolicy.Action, bucket, object string) (bool, error) {
+	claims, owner, err := gatewayRequestAuthenticate(r)
+	if err != nil {
+		return false, err
+	}
+	if globalIAMSys == nil {
+		return owner, nil
+	}
+	if r.URL == nil {
+		// the conditions read the query of the request, which gateway APIs don't use
+		rc := *r
+		rc.URL = &url.URL{}
+		r = &rc
+	}
+	return globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.AccessKey,
+		Action:          action,
+		BucketName:      bucket,
+		ObjectName:      object,
+		ConditionValues: getConditionValues(r, "", claims.AccessKey, claims.Map()),
+		IsOwner:         owner,
+		Claims:          claims.Map(),
+	}), nil
+}
+
+// GatewayRequestCredentials authenticates the caller of an API served by a gateway like
+// IsGatewayRequestAllowed, and returns its credentials. Gateways use them to identify the
+// account of the caller, the same way the credentials of S3 requests are passed to them.
+func GatewayRequestCredentials(r *http.Request) (auth.Credentials, error) {
+	claims, owner, err := gatewayRequestAuthenticate(r)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	if owner {
+		return globalActiveCred, nil
+	}
+	if globalIAMSys == nil {
+		return auth.Credentials{}, errInvalidAccessKeyID
+	}
+	cred, ok := globalIAMSys.GetUser(claims.AccessKey)
+	if !ok {
+		return auth.Credentials{}, errInvalidAccessKeyID
+	}
+	return cred, nil
+}
+
+// gatewayRequestAuthenticate authenticates the request with basic
+// authentication, or with the JWT in the Authorization header.
+func gatewayRequestAuthenticate(r *http.Request) (*xjwt.MapClaims, bool, error) {
+	if accessKey, secretKey, ok := r.BasicAuth(); ok {
+		return basicAuthenticate(accessKey, secretKey)
+	}
+	token, err := jwtreq.AuthorizationHeaderExtractor.ExtractToken(r)
+	if err != nil {
+		return nil, false, errNoAuthToken
+	}
+	return webTokenAuthenticate(token)
+}
+
+// basicAuthenticate checks the access key and secret key of a user,
+// temporary credentials are not accepted since they require a session token.
+func basicAuthenticate(accessKey, secretKey string) (*xjwt.MapClaims, bool, error) {
+	serverCred := globalActiveCred
+	if serverCred.AccessKey != accessKey {
+		if globalIAMSys == nil {
+			return nil, false, errInvalidAccessKeyID
+		}
+		var ok bool
+		serverCred, ok = globalIAMSys.GetUser(accessKey)
+		if !ok {
+			return nil, false, errInvalidAccessKeyID
+		}
+	}
+	if serverCred.IsTemp() || !serverCred.Equal(auth.Credentials{AccessKey: accessKey, SecretKey: secretKey}) {
+		return nil, false, errAuthentication
+	}
+	claims := xjwt.NewMapClaims()
+	claims.SetAccessKey(accessKey)
+	return claims, accessKey == globalActiveCred.AccessKey, nil
+}
@@ -0,0 +1,117 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// Tests authentication and credentials of requests to gateway APIs.
+func TestIsGatewayRequestAllowed(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	creds := globalActiveCred
+	token, err := getTokenString(creds.AccessKey, creds.SecretKey)
+	if err != nil {
+		t.Fatalf("unable get token %s", err)
+	}
+	newBasicAuthRequest := func(accessKey, secretKey string) *http.Request {
+		req := &http.Request{URL: &url.URL{}, Header: http.Header{}}
+		req.SetBasicAuth(accessKey, secretKey)
+		return req
+	}
+	testCases := []struct {
+		req         *http.Request
+		allowed     bool
+		expectedErr error
+	}{
+		// Valid token of the owner.
+		{
+			req: &http.Request{
+				URL: &url.URL{},
+				Header: http.Header{
+					"Authorization": []string{"Bearer " + token},
+				},
+			},
+			allowed: true,
+		},
+		// Valid access keys of the owner.
+		{
+			req:     newBasicAuthRequest(creds.AccessKey, creds.SecretKey),
+			allowed: true,
+		},
+		// No authorization header.
+		{
+			req:         &http.Request{URL: &url.URL{}, Header: http.Header{}},
+			expectedErr: errNoAuthToken,
+		},
+		// Invalid token.
+		{
+			req: &http.Request{
+				URL: &url.URL{},
+				Header: http.Header{
+					"Authorization": []string{"Bearer invalid-token"},
+				},
+			},
+			expectedErr: errAuthentication,
+		},
+		// Invalid secret key.
+		{
+			req:         newBasicAuthRequest(creds.AccessKey, "invalid-secret"),
+			expectedErr: errAuthentication,
+		},
+		// Unknown access key.
+		{
+			req:         newBasicAuthRequest("unknown-access", creds.SecretKey),
+			expectedErr: errInvalidAccessKeyID,
+		},
+		// Valid access keys of the owner, in a request without a URL.
+		{
+			req:     &http.Request{Header: newBasicAuthRequest(creds.AccessKey, creds.SecretKey).Header},
+			allowed: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		allowed, gotErr := IsGatewayRequestAllowed(testCase.req, iampolicy.ListBucketAction, "bucket", "")
+		if testCase.expectedErr != gotErr {
+			t.Errorf("Test %d, expected err %s, got %s", i+1, testCase.expectedErr, gotErr)
+		}
+		if testCase.allowed != allowed {
+			t.Errorf("Test %d, expected allowed %t, got %t", i+1, testCase.allowed, allowed)
+		}
+		cred, gotErr := GatewayRequestCredentials(testCase.req)
+		if testCase.expectedErr != gotErr {
+			t.Errorf("Test %d, expected credentials err %s, got %s", i+1, testCase.expectedErr, gotErr)
+		}
+		if gotErr == nil && cred.AccessKey != creds.AccessKey {
+			t.Errorf("Test %d, expected access key %s, got %s", i+1, creds.AccessKey, cred.AccessKey)
+		}
+	}
+}
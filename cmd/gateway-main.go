@@ -176,7 +176,10 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	}
 
 	enableIAMOps := globalEtcdClient != nil
-	enableBucketQuotaOps := env.Get(envDataUsageCrawlConf, config.EnableOn) == config.EnableOn
+	// Bucket quota needs the configuration to be stored in the backend,
+	// the s3x gateway keeps bucket configuration in its ledger.
+	enableBucketQuotaOps := env.Get(envDataUsageCrawlConf, config.EnableOn) == config.EnableOn &&
+		(enableConfigOps || gatewayName == "s3x")
 
 	// Enable IAM admin APIs if etcd is enabled, if not just enable basic
 	// operations such as profiling, server info etc.
@@ -193,8 +196,9 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 		logger.FatalIf(registerWebRouter(router), "Unable to configure web browser")
 	}
 
-	// Currently only NAS and S3 gateway support encryption headers.
-	encryptionEnabled := gatewayName == "s3" || gatewayName == "nas"
+	// Currently only NAS, S3 and s3x gateway support encryption headers,
+	// s3x encrypts the data before it is uploaded to ipfs.
+	encryptionEnabled := gatewayName == "s3" || gatewayName == "nas" || gatewayName == "s3x"
 	allowSSEKMS := gatewayName == "s3" // Only S3 can support SSE-KMS (as pass-through)
 
 	// Add API router.
@@ -259,6 +263,15 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 		// Start watching disk for reloading config, this
 		// is only enabled for "NAS" gateway.
 		globalConfigSys.WatchConfigNASDisk(GlobalContext, newObject)
+	} else if newObject.IsNotificationSupported() {
+		// Gateways such as s3x keep the bucket notification
+		// configuration in their backend.
+		buckets, err := newObject.ListBuckets(GlobalContext)
+		if err != nil {
+			logger.Fatal(err, "Unable to list buckets")
+		}
+
+		logger.FatalIf(globalNotificationSys.Init(buckets, newObject), "Unable to initialize notification system")
 	}
 	// This is only to uniquely identify each gateway deployments.
 	globalDeploymentID = env.Get("MINIO_GATEWAY_DEPLOYMENT_ID", mustGetUUID())
@@ -297,6 +310,30 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 		initFederatorBackend(buckets, newObject)
 	}
 
+	if newObject.IsVersioningSupported() {
+		buckets, err := newObject.ListBuckets(GlobalContext)
+		if err != nil {
+			logger.Fatal(err, "Unable to list buckets")
+		}
+		logger.FatalIf(globalBucketVersioningSys.Init(buckets, newObject), "Unable to initialize bucket versioning system")
+	}
+
+	if enableBucketQuotaOps {
+		buckets, err := newObject.ListBuckets(GlobalContext)
+		if err != nil {
+			logger.Fatal(err, "Unable to list buckets")
+		}
+		logger.FatalIf(globalBucketQuotaSys.Init(buckets, newObject), "Unable to initialize bucket quota system")
+		initQuotaEnforcement(GlobalContext, newObject)
+		globalMeteringSys.Init(GlobalContext, newObject)
+	}
+
+	// Initialize heal state and start the heal routine, healing
+	// is only carried out by gateways implementing heal operations.
+	globalAllHealState = initHealState()
+	globalBackgroundHealRoutine = initHealRoutine()
+	go globalBackgroundHealRoutine.run(GlobalContext, newObject)
+
 	// Verify if object layer supports
 	// - encryption
 	// - compression
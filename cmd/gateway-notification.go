@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/event"
+)
+
+// GatewayEventSender is implemented by gateway object layers which send
+// the events of created and removed objects themselves with
+// SendGatewayEvent, so the S3 handlers do not send them again.
+type GatewayEventSender interface {
+	SendsObjectEvents() bool
+}
+
+// gatewaySendsObjectEvents returns whether the object layer sends the
+// events of created and removed objects.
+func gatewaySendsObjectEvents(objAPI ObjectLayer) bool {
+	s, ok := objAPI.(GatewayEventSender)
+	return ok && s.SendsObjectEvents()
+}
+
+// isObjectChangeEvent returns whether the event is sent by object layers
+// implementing GatewayEventSender.
+func isObjectChangeEvent(name event.Name) bool {
+	s := name.String()
+	return strings.HasPrefix(s, "s3:ObjectCreated:") || strings.HasPrefix(s, "s3:ObjectRemoved:")
+}
+
+// SendGatewayEvent sends a bucket event notification for an object changed
+// by a gateway. The request parameters are taken from the request info and
+// credentials of ctx, elements are added to the response elements of the
+// event, such as the backend identifiers of the object.
+func SendGatewayEvent(ctx context.Context, name event.Name, objInfo ObjectInfo, elements map[string]string) {
+	reqInfo := logger.GetReqInfo(ctx)
+	if reqInfo == nil {
+		reqInfo = &logger.ReqInfo{}
+	}
+	cred, _ := ctx.Value("Authorization").(auth.Credentials)
+	sendEvent(eventArgs{
+		EventName:  name,
+		BucketName: objInfo.Bucket,
+		Object:     objInfo,
+		ReqParams: map[string]string{
+			"region":          globalServerRegion,
+			"accessKey":       cred.AccessKey,
+			"sourceIPAddress": reqInfo.RemoteHost,
+		},
+		RespElements: map[string]string{
+			"requestId": reqInfo.RequestID,
+		},
+		ExtraElements: elements,
+		Host:          reqInfo.RemoteHost,
+		UserAgent:     reqInfo.UserAgent,
+		fromGateway:   true,
+	})
+}
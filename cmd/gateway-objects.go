@@ -0,0 +1,55 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/minio/minio/pkg/hash"
+)
+
+// NewGatewayPutObjReader returns the reader of an object that a gateway saves
+// outside of the S3 handlers, like the staged puts of a transaction. The bucket
+// quota is enforced, and the data is encrypted with SSE-S3 if auto encryption
+// or the encryption of the bucket is enabled, like by PutObjectHandler. The
+// sealed object key is added to metadata, which must be saved with the object.
+func NewGatewayPutObjReader(ctx context.Context, objAPI ObjectLayer, bucket, object string, data []byte, metadata map[string]string) (*PutObjReader, error) {
+	size := int64(len(data))
+	if err := enforceBucketQuota(ctx, bucket, size); err != nil {
+		return nil, err
+	}
+	hashReader, err := hash.NewReader(bytes.NewReader(data), size, "", "", size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return nil, err
+	}
+	_, encEnabled := globalBucketSSEConfigSys.Get(bucket)
+	if !(globalAutoEncryption || encEnabled) || !objAPI.IsEncryptionSupported() || HasSuffix(object, SlashSeparator) {
+		return NewPutObjReader(hashReader, nil, nil), nil
+	}
+	reader, objectEncryptionKey, err := newEncryptReader(hashReader, nil, bucket, object, metadata, true)
+	if err != nil {
+		return nil, err
+	}
+	info := ObjectInfo{Size: size}
+	// do not try to verify encrypted content
+	encReader, err := hash.NewReader(reader, info.EncryptedSize(), "", "", size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return nil, err
+	}
+	return NewPutObjReader(hashReader, encReader, &objectEncryptionKey), nil
+}
@@ -0,0 +1,78 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// usageObjectLayer is a gateway looking up the usage of its buckets
+type usageObjectLayer struct {
+	ObjectLayer
+	usage uint64
+}
+
+func (l *usageObjectLayer) GetBucketUsage(ctx context.Context, bucket string) (uint64, error) {
+	return l.usage, nil
+}
+
+func (l *usageObjectLayer) IsEncryptionSupported() bool {
+	return true
+}
+
+func TestNewGatewayPutObjReader(t *testing.T) {
+	defer func(objAPI ObjectLayer, quotaSys *BucketQuotaSys, kms crypto.KMS, autoEncryption bool) {
+		globalObjectAPI, globalBucketQuotaSys, GlobalKMS, globalAutoEncryption = objAPI, quotaSys, kms, autoEncryption
+	}(globalObjectAPI, globalBucketQuotaSys, GlobalKMS, globalAutoEncryption)
+
+	objAPI := &usageObjectLayer{usage: 5}
+	globalObjectAPI = objAPI
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketQuotaSys.Set("bucket", madmin.BucketQuota{Quota: 10, Type: madmin.HardQuota})
+
+	ctx := context.Background()
+	if _, err := NewGatewayPutObjReader(ctx, objAPI, "bucket", "object", []byte("too much data"), map[string]string{}); err == nil {
+		t.Fatal("expected the quota of the bucket to be exceeded")
+	}
+	data := []byte("data")
+	metadata := map[string]string{}
+	r, err := NewGatewayPutObjReader(ctx, objAPI, "bucket", "object", data, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Size() != int64(len(data)) || crypto.IsEncrypted(metadata) {
+		t.Fatalf("expected unencrypted data of size %d, but got %d and %v", len(data), r.Size(), metadata)
+	}
+
+	GlobalKMS = crypto.NewMasterKey("my-minio-key", [32]byte{})
+	globalAutoEncryption = true
+	r, err = NewGatewayPutObjReader(ctx, objAPI, "bucket", "object", data, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !crypto.S3.IsEncrypted(metadata) {
+		t.Fatalf("expected the object key to be sealed into the metadata, but got %v", metadata)
+	}
+	info := ObjectInfo{Size: int64(len(data))}
+	if r.Size() != info.EncryptedSize() {
+		t.Fatalf("expected encrypted data, but got size %d", r.Size())
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "context"
+
+// GatewayBucketNamespacer is implemented by gateway object layers which give
+// every account its own bucket namespace. The bucket configs saved by the
+// server, like the quota and the owner of a bucket, are kept by the name the
+// object layer uses for the bucket of the account, so they are not shared
+// by the buckets of the same name of other accounts.
+type GatewayBucketNamespacer interface {
+	// NamespacedBucket returns the name of the bucket of the account in ctx.
+	NamespacedBucket(ctx context.Context, bucket string) string
+	// ListNamespacedBuckets returns the buckets of all accounts by their namespaced names.
+	ListNamespacedBuckets(ctx context.Context) ([]BucketInfo, error)
+}
+
+// namespacedBucket returns the name of the bucket of the account in ctx,
+// which is the bucket itself for object layers without namespaces.
+func namespacedBucket(ctx context.Context, objAPI ObjectLayer, bucket string) string {
+	if n, ok := objAPI.(GatewayBucketNamespacer); ok {
+		return n.NamespacedBucket(ctx, bucket)
+	}
+	return bucket
+}
@@ -182,7 +182,7 @@ func (a GatewayUnsupported) Walk(ctx context.Context, bucket, prefix string, res
 }
 
 // HealObjects - Not implemented stub
-func (a GatewayUnsupported) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn healObjectFn) (e error) {
+func (a GatewayUnsupported) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn HealObjectFn) (e error) {
 	return NotImplemented{}
 }
 
@@ -236,6 +236,26 @@ func (a GatewayUnsupported) IsCompressionSupported() bool {
 	return false
 }
 
+// IsDataUsageSupported returns whether data usage crawling is implemented for this layer.
+func (a GatewayUnsupported) IsDataUsageSupported() bool {
+	return false
+}
+
+// IsVersioningSupported returns whether the versions of the objects are kept by this layer.
+func (a GatewayUnsupported) IsVersioningSupported() bool {
+	return false
+}
+
+// DeleteObjectVersion - Not implemented stub
+func (a GatewayUnsupported) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	return ObjectInfo{}, NotImplemented{}
+}
+
+// ListObjectVersions - Not implemented stub
+func (a GatewayUnsupported) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	return ListObjectVersionsInfo{}, NotImplemented{}
+}
+
 // IsReady - No Op.
 func (a GatewayUnsupported) IsReady(_ context.Context) bool {
 	return false
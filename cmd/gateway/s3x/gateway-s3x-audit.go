@@ -0,0 +1,27 @@
+package s3x
+
+import (
+	"context"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+)
+
+// objectChanged is called by the ledger after an object is saved or removed, it adds the ipfs
+// hashes of the change to the audit entry of the request in ctx, and sends the bucket event.
+func objectChanged(ctx context.Context, bucket, object string, obj *Object, objHash, bucketHash string) {
+	auditBucketHash(ctx, bucketHash)
+	if obj != nil {
+		reqInfo := logger.GetReqInfo(ctx)
+		reqInfo.SetTags(audit.TagIpfsHash, objHash)
+		if obj.DataHash != "" {
+			reqInfo.SetTags(audit.TagIpfsDataHash, obj.DataHash)
+		}
+	}
+	sendObjectEvent(ctx, bucket, object, obj, objHash, bucketHash)
+}
+
+// auditBucketHash adds the hash of the bucket root after a change to the audit entry of the request in ctx
+func auditBucketHash(ctx context.Context, bucketHash string) {
+	logger.GetReqInfo(ctx).SetTags(audit.TagIpfsBucketHash, bucketHash)
+}
@@ -0,0 +1,79 @@
+package s3x
+
+import (
+	"context"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+)
+
+func TestS3X_Audit_Badger(t *testing.T) {
+	testS3XAudit(t, DSTypeBadger)
+}
+func TestS3X_Audit_Crdt(t *testing.T) {
+	testS3XAudit(t, DSTypeCrdt)
+}
+func testS3XAudit(t *testing.T, dsType DSType) {
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	// newRequest returns the context of a request, with the ReqInfo the audit entry is created from
+	newRequest := func(api string) (context.Context, *logger.ReqInfo) {
+		reqInfo := &logger.ReqInfo{API: api}
+		return logger.SetReqInfo(context.Background(), reqInfo), reqInfo
+	}
+	bucketHash := func(t *testing.T) string {
+		t.Helper()
+		hash, err := gateway.ledgerStore.GetBucketHash(testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+
+	t.Run("make bucket", func(t *testing.T) {
+		ctx, reqInfo := newRequest("PutBucket")
+		if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+			t.Fatal(err)
+		}
+		tags := reqInfo.GetTagsMap()
+		if len(tags) != 1 || tags[audit.TagIpfsBucketHash] != bucketHash(t) {
+			t.Fatalf("unexpected tags %v", tags)
+		}
+	})
+	t.Run("put", func(t *testing.T) {
+		ctx, reqInfo := newRequest("PutObject")
+		if _, err := gateway.PutObject(ctx, testBucket1, testObject1,
+			getTestPutObjectReader(t, []byte("hello")), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		objHash, err := gateway.ledgerStore.GetObjectHash(ctx, testBucket1, testObject1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dataHash, _, err := gateway.ledgerStore.GetObjectDataHash(ctx, testBucket1, testObject1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tags := reqInfo.GetTagsMap()
+		if tags[audit.TagIpfsHash] != objHash || tags[audit.TagIpfsDataHash] != dataHash ||
+			tags[audit.TagIpfsBucketHash] != bucketHash(t) {
+			t.Fatalf("unexpected tags %v", tags)
+		}
+	})
+	t.Run("delete", func(t *testing.T) {
+		ctx, reqInfo := newRequest("DeleteObject")
+		if err := gateway.DeleteObject(ctx, testBucket1, testObject1); err != nil {
+			t.Fatal(err)
+		}
+		tags := reqInfo.GetTagsMap()
+		if len(tags) != 1 || tags[audit.TagIpfsBucketHash] != bucketHash(t) {
+			t.Fatalf("unexpected tags %v", tags)
+		}
+	})
+}
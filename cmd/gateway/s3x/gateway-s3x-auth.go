@@ -0,0 +1,208 @@
+package s3x
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	minio "github.com/minio/minio/cmd"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// authHeaders are the request headers passed to the IAM policy evaluation,
+// they are read from the grpc metadata or from the metadata forwarded by the grpc-gateway.
+var authHeaders = []string{"Authorization", "User-Agent", "X-Forwarded-For"}
+
+// authInfoAPIServer authenticates the callers of the InfoAPI before passing the requests
+// on to xObjects. The callers are authenticated with the access keys of the S3 api, using
+// basic authentication, or with a JWT issued by the web or admin login. The bucket of the
+// request must be allowed for the caller by IAM policies.
+//
+// xObjects is intentionally not embedded, so that new InfoAPI methods can not be served
+// without an authorization check.
+type authInfoAPIServer struct {
+	x   *xObjects
+	tls bool // whether the InfoAPI is served over TLS
+}
+
+// GetHash requires s3:ListBucket for the hash of a bucket and s3:GetObject for the hashes of an object
+func (s *authInfoAPIServer) GetHash(ctx context.Context, req *InfoRequest) (*InfoResponse, error) {
+	action := iampolicy.Action(iampolicy.GetObjectAction)
+	if req.GetObject() == "" {
+		action = iampolicy.ListBucketAction
+	}
+	ctx, err := s.authorize(ctx, action, req.GetBucket(), req.GetObject())
+	if err != nil {
+		return nil, err
+	}
+	return s.x.GetHash(ctx, req)
+}
+
+// Fsck requires admin:Heal, since it can repair and remove entries from the ledger
+func (s *authInfoAPIServer) Fsck(ctx context.Context, req *FsckRequest) (*FsckResponse, error) {
+	ctx, err := s.authorize(ctx, iampolicy.Action(iampolicy.HealAdminAction), req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.Fsck(ctx, req)
+}
+
+// SetBucketIPNS requires s3:PutBucketPolicy, since publishing makes the bucket content discoverable
+func (s *authInfoAPIServer) SetBucketIPNS(ctx context.Context, req *BucketIPNSRequest) (*BucketIPNS, error) {
+	ctx, err := s.authorize(ctx, iampolicy.Action(iampolicy.PutBucketPolicyAction), req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.SetBucketIPNS(ctx, req)
+}
+
+// SetBucketPinTargets requires s3:PutBucketPolicy, since pinning copies the bucket content to other nodes
+func (s *authInfoAPIServer) SetBucketPinTargets(ctx context.Context, req *BucketPinTargets) (*BucketPinTargets, error) {
+	ctx, err := s.authorize(ctx, iampolicy.Action(iampolicy.PutBucketPolicyAction), req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.SetBucketPinTargets(ctx, req)
+}
+
+// GetPinStatus requires s3:ListBucket for the status of a bucket and s3:GetObject for the status of an object
+func (s *authInfoAPIServer) GetPinStatus(ctx context.Context, req *PinStatusRequest) (*PinStatusResponse, error) {
+	action := iampolicy.Action(iampolicy.GetObjectAction)
+	if req.GetObject() == "" {
+		action = iampolicy.ListBucketAction
+	}
+	ctx, err := s.authorize(ctx, action, req.GetBucket(), req.GetObject())
+	if err != nil {
+		return nil, err
+	}
+	return s.x.GetPinStatus(ctx, req)
+}
+
+// BeginTransaction requires s3:PutObject, since the bucket can only be changed by staged operations
+func (s *authInfoAPIServer) BeginTransaction(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
+	ctx, err := s.authorize(ctx, iampolicy.Action(iampolicy.PutObjectAction), req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.BeginTransaction(ctx, req)
+}
+
+// StageTransaction requires s3:PutObject for the bucket and every put and copy, s3:GetObject
+// for the sources of copies and s3:DeleteObject for deletes
+func (s *authInfoAPIServer) StageTransaction(ctx context.Context, req *TransactionOps) (*Transaction, error) {
+	actx, err := s.authorize(ctx, iampolicy.Action(iampolicy.PutObjectAction), req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	type check struct {
+		action iampolicy.Action
+		object string
+	}
+	for _, op := range req.GetOps() {
+		checks := []check{{iampolicy.PutObjectAction, op.Object}}
+		switch op.Type {
+		case TransactionOpType_COPY:
+			checks = append(checks, check{iampolicy.GetObjectAction, op.Source})
+		case TransactionOpType_DELETE:
+			checks = []check{{iampolicy.DeleteObjectAction, op.Object}}
+		}
+		for _, c := range checks {
+			if _, err := s.authorize(ctx, c.action, req.GetBucket(), c.object); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s.x.StageTransaction(actx, req)
+}
+
+// CommitTransaction requires s3:PutObject, the operations were authorized when they were staged
+func (s *authInfoAPIServer) CommitTransaction(ctx context.Context, req *TransactionRequest) (*InfoResponse, error) {
+	ctx, err := s.authorize(ctx, iampolicy.Action(iampolicy.PutObjectAction), req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.CommitTransaction(ctx, req)
+}
+
+// AbortTransaction requires s3:PutObject
+func (s *authInfoAPIServer) AbortTransaction(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
+	ctx, err := s.authorize(ctx, iampolicy.Action(iampolicy.PutObjectAction), req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.AbortTransaction(ctx, req)
+}
+
+// GetDedupStats requires s3:ListBucket for the stats of a bucket, and admin:DataUsageInfo
+// for the stats of all buckets, which include the buckets of all tenants
+func (s *authInfoAPIServer) GetDedupStats(ctx context.Context, req *DedupStatsRequest) (*DedupStats, error) {
+	action := iampolicy.Action(iampolicy.ListBucketAction)
+	if req.GetBucket() == "" {
+		action = iampolicy.DataUsageInfoAdminAction
+	}
+	ctx, err := s.authorize(ctx, action, req.GetBucket(), "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.GetDedupStats(ctx, req)
+}
+
+// GetDataRefs requires admin:DataUsageInfo, since the references include the objects of all buckets
+func (s *authInfoAPIServer) GetDataRefs(ctx context.Context, req *DataRefsRequest) (*DataRefs, error) {
+	ctx, err := s.authorize(ctx, iampolicy.Action(iampolicy.DataUsageInfoAdminAction), "", "")
+	if err != nil {
+		return nil, err
+	}
+	return s.x.GetDataRefs(ctx, req)
+}
+
+// authorize checks that the caller of the request in ctx is allowed to perform the action.
+// With tenants enabled, the returned ctx holds the credentials of the caller like the
+// context of S3 requests, so xObjects resolves the bucket namespace of the caller.
+func (s *authInfoAPIServer) authorize(ctx context.Context, action iampolicy.Action, bucket, object string) (context.Context, error) {
+	r := s.newAuthRequest(ctx)
+	allowed, err := minio.IsGatewayRequestAllowed(r, action, bucket, object)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	if !s.x.tenants {
+		return ctx, nil
+	}
+	cred, err := minio.GatewayRequestCredentials(r)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, authHeader, cred), nil
+}
+
+// newAuthRequest builds the http request used to authenticate the caller of a grpc request
+func (s *authInfoAPIServer) newAuthRequest(ctx context.Context) *http.Request {
+	r := &http.Request{URL: &url.URL{}, Header: http.Header{}}
+	if s.tls {
+		r.TLS = &tls.ConnectionState{}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		r.RemoteAddr = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range authHeaders {
+		// the grpc-gateway prefixes the headers of the http request
+		values := md.Get(runtime.MetadataPrefix + key)
+		if len(values) == 0 {
+			values = md.Get(key)
+		}
+		for _, v := range values {
+			r.Header.Add(key, v)
+		}
+	}
+	return r
+}
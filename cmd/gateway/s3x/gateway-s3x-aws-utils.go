@@ -37,15 +37,15 @@ type lambdaHelper struct {
 type API struct {
 	Bucket     string `json:"bucket"`           // bucket slug
 	Name       string `json:"name"`             //operation
-	Object     string `json:"object",omitempty` // optional object key
+	Object     string `json:"object,omitempty"` // optional object key
 	ObjectSize int64  `json:"objectSize"`
 }
 
 // Entry is a property for handler input
 type Entry struct {
 	API            API               `json:"api"`
-	RequestHeader  map[string]string `json:"requestHeader",omitempty`
-	ResponseHeader map[string]string `json:"responseHeader",omitempty`
+	RequestHeader  map[string]string `json:"requestHeader,omitempty"`
+	ResponseHeader map[string]string `json:"responseHeader,omitempty"`
 }
 
 // HandlerInput is a custom input object for calling handler
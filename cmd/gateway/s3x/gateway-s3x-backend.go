@@ -0,0 +1,327 @@
+package s3x
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sync"
+	"time"
+
+	pb "github.com/RTradeLtd/TxPB/v3/go"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultXTimeout is the deadline of unary calls to TemporalX, if none is configured
+	defaultXTimeout = time.Minute
+	// defaultXRetries is the number of retries of idempotent calls, if none is configured
+	defaultXRetries = 3
+	// defaultXBreakerFailures is the number of consecutive failures that open the circuit breaker, if none is configured
+	defaultXBreakerFailures = 5
+	// defaultXBreakerCooldown is the time the circuit breaker stays open, if none is configured
+	defaultXBreakerCooldown = 10 * time.Second
+	// xRetryBackoff is the time before the first retry of a call, doubled on every retry
+	xRetryBackoff = 100 * time.Millisecond
+)
+
+// errBackendDown is returned without calling TemporalX while the circuit breaker is open,
+// toMinioErr converts it to minio.BackendDown like other unavailable errors.
+var errBackendDown = status.Error(codes.Unavailable, "temporalx backend is down")
+
+var xOnlineDesc = prometheus.NewDesc(
+	prometheus.BuildFQName("s3x", "temporalx", "online"),
+	"Whether TemporalX is reachable, 0 while the connection fails or the circuit breaker is open",
+	nil, nil,
+)
+
+// xBackend guards the calls to TemporalX. Unary calls get a deadline if the caller did not set one,
+// and idempotent dag reads are retried with exponential backoff when TemporalX is unavailable.
+//
+// After consecutive calls failed because TemporalX was unavailable, the circuit breaker opens and
+// calls fail with errBackendDown right away, instead of waiting for their deadline. Once the
+// cooldown passed, a single call is let through, which closes the breaker again if it succeeds.
+// Errors returned by TemporalX itself, such as a missing cid, show that it is reachable.
+type xBackend struct {
+	timeout  time.Duration
+	retries  int
+	failures int
+	cooldown time.Duration
+
+	mu          sync.Mutex
+	consecutive int       // number of consecutive failed calls
+	openUntil   time.Time // the breaker is open until this time, once consecutive reached failures
+	probing     bool      // whether a call is let through after the cooldown
+
+	conn *grpc.ClientConn // set after dialing, reports the connection state
+
+	calls   *prometheus.CounterVec
+	retried prometheus.Counter
+	trips   prometheus.Counter
+}
+
+func newXBackend(timeout time.Duration, retries, failures int, cooldown time.Duration) *xBackend {
+	if timeout <= 0 {
+		timeout = defaultXTimeout
+	}
+	if retries <= 0 {
+		retries = defaultXRetries
+	}
+	if failures <= 0 {
+		failures = defaultXBreakerFailures
+	}
+	if cooldown <= 0 {
+		cooldown = defaultXBreakerCooldown
+	}
+	return &xBackend{
+		timeout:  timeout,
+		retries:  retries,
+		failures: failures,
+		cooldown: cooldown,
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "s3x",
+			Subsystem: "temporalx",
+			Name:      "calls_total",
+			Help:      "Number of calls to TemporalX by result",
+		}, []string{"result"}),
+		retried: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "s3x",
+			Subsystem: "temporalx",
+			Name:      "retries_total",
+			Help:      "Number of retried calls to TemporalX",
+		}),
+		trips: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "s3x",
+			Subsystem: "temporalx",
+			Name:      "breaker_trips_total",
+			Help:      "Number of times the TemporalX circuit breaker opened",
+		}),
+	}
+}
+
+// dialOptions returns the options that apply the deadlines, retries and circuit breaker to a connection
+func (b *xBackend) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(b.unaryInterceptor),
+		grpc.WithStreamInterceptor(b.streamInterceptor),
+		// reconnect quickly after TemporalX restarted, and notice broken connections while idle
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.Config{BaseDelay: time.Second, Multiplier: 1.6, Jitter: 0.2, MaxDelay: 30 * time.Second},
+			MinConnectTimeout: 10 * time.Second,
+		}),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Minute,
+			Timeout:             20 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// start registers the TemporalX metrics
+func (b *xBackend) start() {
+	if err := prometheus.Register(b); err != nil {
+		log.Printf("failed to register temporalx metrics: %v", err)
+	}
+}
+
+// close unregisters the TemporalX metrics and closes the connection
+func (b *xBackend) close() error {
+	prometheus.Unregister(b)
+	if b.conn == nil {
+		return nil
+	}
+	return b.conn.Close()
+}
+
+// online returns whether TemporalX is reachable, as far as the recent calls and the connection tell
+func (b *xBackend) online() bool {
+	b.mu.Lock()
+	open := b.consecutive >= b.failures
+	b.mu.Unlock()
+	if open {
+		return false
+	}
+	if b.conn != nil {
+		switch b.conn.GetState() {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return false
+		}
+	}
+	return true
+}
+
+func (b *xBackend) unaryInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	attempts := 1
+	if isIdempotentXCall(req) {
+		attempts += b.retries
+	}
+	var err error
+	wait := xRetryBackoff
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return err
+			}
+			wait *= 2
+			b.retried.Inc()
+		}
+		if !b.allow() {
+			b.calls.WithLabelValues("rejected").Inc()
+			return errBackendDown
+		}
+		err = b.invoke(ctx, method, req, reply, cc, invoker, opts...)
+		if !b.record(ctx, err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// invoke calls TemporalX with the configured deadline, unless the caller set one
+func (b *xBackend) invoke(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// streamInterceptor applies the circuit breaker to streams, they can be long lived, so only
+// their creation is recorded, and they do not get a deadline.
+func (b *xBackend) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if !b.allow() {
+		b.calls.WithLabelValues("rejected").Inc()
+		return nil, errBackendDown
+	}
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	b.record(ctx, err)
+	return stream, err
+}
+
+// allow returns whether a call may be made, while the breaker is open only one call is let through after the cooldown
+func (b *xBackend) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.consecutive < b.failures {
+		return true
+	}
+	if b.probing || time.Now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record updates the circuit breaker with the result of a call, and returns whether TemporalX was unavailable
+func (b *xBackend) record(ctx context.Context, err error) bool {
+	code := status.Code(err)
+	unavailable := code == codes.Unavailable || code == codes.DeadlineExceeded
+	if unavailable && ctx.Err() != nil {
+		// the caller gave up, this does not tell anything about TemporalX
+		unavailable = false
+	}
+	switch {
+	case err == nil:
+		b.calls.WithLabelValues("ok").Inc()
+	case unavailable:
+		b.calls.WithLabelValues("unavailable").Inc()
+	default:
+		b.calls.WithLabelValues("error").Inc()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !unavailable {
+		b.consecutive = 0
+		return false
+	}
+	b.consecutive++
+	if b.consecutive >= b.failures {
+		if b.consecutive == b.failures {
+			b.trips.Inc()
+		}
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+	return true
+}
+
+// isIdempotentXCall returns whether a call only reads from TemporalX, so it can be retried
+func isIdempotentXCall(req interface{}) bool {
+	r, ok := req.(*pb.DagRequest)
+	if !ok {
+		return false
+	}
+	switch r.GetRequestType() {
+	case pb.DAGREQTYPE_DAG_GET, pb.DAGREQTYPE_DAG_GET_LINKS, pb.DAGREQTYPE_DAG_STAT:
+		return true
+	}
+	return false
+}
+
+// Describe implements prometheus.Collector
+func (b *xBackend) Describe(ch chan<- *prometheus.Desc) {
+	ch <- xOnlineDesc
+	b.calls.Describe(ch)
+	b.retried.Describe(ch)
+	b.trips.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (b *xBackend) Collect(ch chan<- prometheus.Metric) {
+	online := 0.0
+	if b.online() {
+		online = 1
+	}
+	ch <- prometheus.MustNewConstMetric(xOnlineDesc, prometheus.GaugeValue, online)
+	b.calls.Collect(ch)
+	b.retried.Collect(ch)
+	b.trips.Collect(ch)
+}
+
+// temporalXTLSConfig returns the TLS config of a connection to TemporalX, or nil for an insecure connection.
+// The certificate of TemporalX is verified with the CA certificates in caFile, or the system roots if it is empty.
+func temporalXTLSConfig(insecure bool, caFile string, skipVerify bool) (*tls.Config, error) {
+	if insecure {
+		return nil, nil
+	}
+	config := &tls.Config{
+		InsecureSkipVerify: skipVerify,
+	}
+	if caFile == "" {
+		return config, nil
+	}
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	config.RootCAs = x509.NewCertPool()
+	if !config.RootCAs.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	return config, nil
+}
+
+// dialTemporalX connects to the TemporalX api server at addr, without TLS if config is nil
+func dialTemporalX(addr string, config *tls.Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if config == nil {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	}
+	return grpc.Dial(addr, opts...)
+}
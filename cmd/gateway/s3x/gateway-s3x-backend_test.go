@@ -0,0 +1,158 @@
+package s3x
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/RTradeLtd/TxPB/v3/go"
+	minio "github.com/minio/minio/cmd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestS3X_Backend_Breaker(t *testing.T) {
+	const cooldown = 500 * time.Millisecond
+	ctx := context.Background()
+	b := newXBackend(time.Second, 2, 3, cooldown)
+	var (
+		calls int
+		err   error
+	)
+	call := func(t *testing.T, reqType pb.DAGREQTYPE) error {
+		t.Helper()
+		return b.unaryInterceptor(ctx, "/pb.NodeAPI/Dag", &pb.DagRequest{RequestType: reqType}, &pb.DagResponse{}, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				if _, ok := ctx.Deadline(); !ok {
+					t.Fatal("expected the call to have a deadline")
+				}
+				return err
+			})
+	}
+
+	t.Run("errors of temporalx", func(t *testing.T) {
+		calls, err = 0, status.Error(codes.NotFound, "not found")
+		for i := 0; i < 5; i++ {
+			if got := call(t, pb.DAGREQTYPE_DAG_GET); status.Code(got) != codes.NotFound {
+				t.Fatalf("expected NotFound, but got %v", got)
+			}
+		}
+		if calls != 5 || !b.online() {
+			t.Fatalf("expected 5 calls without retries or opening the breaker, but got %d calls", calls)
+		}
+	})
+	t.Run("retries", func(t *testing.T) {
+		calls, err = 0, status.Error(codes.Unavailable, "unavailable")
+		if got := call(t, pb.DAGREQTYPE_DAG_PUT); got != err {
+			t.Fatalf("expected %v, but got %v", err, got)
+		}
+		if calls != 1 {
+			t.Fatalf("expected a put not to be retried, but got %d calls", calls)
+		}
+		if !b.online() {
+			t.Fatal("expected the breaker to be closed")
+		}
+		// the get fails twice more, which opens the breaker before all retries are made
+		if got := call(t, pb.DAGREQTYPE_DAG_GET); got != errBackendDown {
+			t.Fatalf("expected %v, but got %v", errBackendDown, got)
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 calls, but got %d", calls)
+		}
+	})
+	t.Run("open", func(t *testing.T) {
+		if b.online() {
+			t.Fatal("expected the backend to be offline")
+		}
+		calls = 0
+		if got := call(t, pb.DAGREQTYPE_DAG_PUT); got != errBackendDown {
+			t.Fatalf("expected %v, but got %v", errBackendDown, got)
+		}
+		if calls != 0 {
+			t.Fatalf("expected no calls while the breaker is open, but got %d", calls)
+		}
+		if _, ok := (&xObjects{}).toMinioErr(errBackendDown, "", "", "").(minio.BackendDown); !ok {
+			t.Fatal("expected errBackendDown to be converted to minio.BackendDown")
+		}
+	})
+	t.Run("half open", func(t *testing.T) {
+		time.Sleep(cooldown)
+		// the call after the cooldown fails, so the breaker stays open
+		if got := call(t, pb.DAGREQTYPE_DAG_PUT); got != err {
+			t.Fatalf("expected %v, but got %v", err, got)
+		}
+		if got := call(t, pb.DAGREQTYPE_DAG_PUT); got != errBackendDown {
+			t.Fatalf("expected %v, but got %v", errBackendDown, got)
+		}
+		time.Sleep(cooldown)
+		calls, err = 0, nil
+		for i := 0; i < 2; i++ {
+			if got := call(t, pb.DAGREQTYPE_DAG_PUT); got != nil {
+				t.Fatal(got)
+			}
+		}
+		if calls != 2 || !b.online() {
+			t.Fatalf("expected the breaker to be closed, but got %d calls", calls)
+		}
+	})
+}
+
+func TestS3X_Backend_Offline(t *testing.T) {
+	ctx := context.Background()
+	gateway := newTestGateway(t, DSTypeBadger)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if si := gateway.StorageInfo(ctx, false); !si.Backend.GatewayOnline {
+		t.Fatal("expected the backend to be online")
+	}
+	// nothing listens on the discard port
+	gateway.temx.XAddr = "127.0.0.1:9"
+	gateway.temx.XBreakerFailures = 2
+	gateway.restart(t)
+	for i := 0; i < 3; i++ {
+		err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1")
+		if _, ok := err.(minio.BackendDown); !ok {
+			t.Fatalf("expected minio.BackendDown, but got %v", err)
+		}
+	}
+	if si := gateway.StorageInfo(ctx, false); si.Backend.GatewayOnline {
+		t.Fatal("expected the backend to be offline")
+	}
+}
+
+func TestS3X_Backend_TLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "s3x-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	empty := filepath.Join(dir, "empty.pem")
+	if err := ioutil.WriteFile(empty, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if config, err := temporalXTLSConfig(true, empty, false); err != nil || config != nil {
+		t.Fatalf("expected no TLS for an insecure connection, but got %v, %v", config, err)
+	}
+	config, err := temporalXTLSConfig(false, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.InsecureSkipVerify || config.RootCAs != nil {
+		t.Fatal("expected the certificate to be verified with the system roots")
+	}
+	if _, err := temporalXTLSConfig(false, filepath.Join(dir, "missing.pem"), false); err == nil {
+		t.Fatal("expected an error for a missing CA file")
+	}
+	if _, err := temporalXTLSConfig(false, empty, false); err == nil {
+		t.Fatal("expected an error for a CA file without certificates")
+	}
+}
@@ -21,11 +21,12 @@ func (x *xObjects) MakeBucketWithLocation(
 	if !isTest { // creates consistent hashes for testing
 		b.BucketInfo.Created = time.Now().UTC()
 	}
-	hash, err := x.ledgerStore.CreateBucket(ctx, name, b)
+	hash, err := x.ledgerStore.CreateBucket(ctx, x.ledgerBucket(ctx, name), b)
 	if err != nil {
 		return x.toMinioErr(err, name, "", "")
 	}
 
+	auditBucketHash(ctx, hash)
 	pingHash(hash)
 	log.Printf("bucket-name: %s\tbucket-hash: %s", name, hash)
 	return nil
@@ -36,7 +37,7 @@ func (x *xObjects) GetBucketInfo(
 	ctx context.Context,
 	bucket string,
 ) (bi minio.BucketInfo, err error) {
-	b, err := x.ledgerStore.GetBucketInfo(ctx, bucket)
+	b, err := x.ledgerStore.GetBucketInfo(ctx, x.ledgerBucket(ctx, bucket))
 	if err != nil {
 		return bi, x.toMinioErr(err, bucket, "", "")
 	}
@@ -50,21 +51,26 @@ func (x *xObjects) GetBucketInfo(
 	}, nil
 }
 
-// ListBuckets lists all S3 buckets
+// ListBuckets lists all S3 buckets of the tenant
 func (x *xObjects) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error) {
 	// TODO(bonedaddy): decide if we should handle a minio error here
 	names, err := x.ledgerStore.GetBucketNames()
 	if err != nil {
 		return nil, err
 	}
-	var infos = make([]minio.BucketInfo, len(names))
-	for i, name := range names {
+	tenant := x.tenant(ctx)
+	var infos = make([]minio.BucketInfo, 0, len(names))
+	for _, name := range names {
+		t, bucket := splitLedgerBucket(name)
+		if t != tenant {
+			continue
+		}
 		//TODO(George): detect context cancelation here (or in GetBucketInfo), as this could be a long running process
-		info, err := x.GetBucketInfo(ctx, name)
+		info, err := x.GetBucketInfo(ctx, bucket)
 		if err != nil {
 			return nil, err // no need to handle GetBucketInfo parses error accordingly
 		}
-		infos[i] = info
+		infos = append(infos, info)
 	}
 	return infos, nil
 }
@@ -72,5 +78,11 @@ func (x *xObjects) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error)
 // DeleteBucket deletes a bucket on S3
 func (x *xObjects) DeleteBucket(ctx context.Context, name string, forceDelete bool) error {
 	// TODO(bonedaddy): implement removal call from TemporalX
-	return x.toMinioErr(x.ledgerStore.DeleteBucket(name), name, "", "")
+	if err := x.ledgerStore.DeleteBucket(x.ledgerBucket(ctx, name)); err != nil {
+		return x.toMinioErr(err, name, "", "")
+	}
+	if err := x.ipns.remove(ctx, x.ledgerBucket(ctx, name)); err != nil {
+		return x.toMinioErr(err, name, "", "")
+	}
+	return nil
 }
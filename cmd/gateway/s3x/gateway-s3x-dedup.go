@@ -0,0 +1,95 @@
+package s3x
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetDedupStats returns the deduplication statistics of a bucket of the tenant, or of all buckets of all tenants
+func (x *xObjects) GetDedupStats(ctx context.Context, req *DedupStatsRequest) (*DedupStats, error) {
+	if req.GetBucket() != "" {
+		bucket := x.ledgerBucket(ctx, req.GetBucket())
+		u, err := x.ledgerStore.GetBucketUsage(ctx, bucket)
+		if err != nil {
+			return nil, dedupStatusError(err)
+		}
+		return newDedupStats(bucket, u), nil
+	}
+	names, err := x.ledgerStore.GetBucketNames()
+	if err != nil {
+		return nil, dedupStatusError(err)
+	}
+	sort.Strings(names)
+	stats := &DedupStats{}
+	for _, name := range names {
+		u, err := x.ledgerStore.GetBucketUsage(ctx, name)
+		if err == ErrLedgerBucketDoesNotExist {
+			// the bucket was removed after the names were read
+			continue
+		}
+		if err != nil {
+			return nil, dedupStatusError(err)
+		}
+		bs := newDedupStats(name, u)
+		stats.ObjectsCount += bs.ObjectsCount
+		stats.LogicalSize += bs.LogicalSize
+		stats.Buckets = append(stats.Buckets, bs)
+	}
+	// data shared by buckets is only counted once, so the unique size is not the sum of the buckets
+	stats.UniqueSize, stats.UniqueCount, err = x.ledgerStore.GetUniqueData(ctx)
+	if err != nil {
+		return nil, dedupStatusError(err)
+	}
+	return stats, nil
+}
+
+// GetDataRefs returns the objects of all buckets of all tenants that reference a data cid
+func (x *xObjects) GetDataRefs(ctx context.Context, req *DataRefsRequest) (*DataRefs, error) {
+	if _, err := cid.Decode(req.GetHash()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid hash %q: %s", req.GetHash(), err)
+	}
+	refs, err := x.ledgerStore.GetDataRefs(ctx, req.GetHash())
+	if err != nil {
+		return nil, dedupStatusError(err)
+	}
+	for i := range refs {
+		refs[i].Tenant, refs[i].Bucket = splitLedgerBucket(refs[i].Bucket)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Bucket != refs[j].Bucket {
+			return refs[i].Bucket < refs[j].Bucket
+		}
+		if refs[i].Tenant != refs[j].Tenant {
+			return refs[i].Tenant < refs[j].Tenant
+		}
+		return refs[i].Object < refs[j].Object
+	})
+	return &DataRefs{
+		Hash: req.GetHash(),
+		Refs: refs,
+	}, nil
+}
+
+// newDedupStats returns the deduplication statistics of a bucket from its usage
+func newDedupStats(name string, u *BucketUsage) *DedupStats {
+	tenant, bucket := splitLedgerBucket(name)
+	return &DedupStats{
+		Bucket:       bucket,
+		Tenant:       tenant,
+		ObjectsCount: u.GetObjectsCount(),
+		LogicalSize:  u.GetSize_(),
+		UniqueSize:   u.GetUniqueSize(),
+		UniqueCount:  u.GetUniqueCount(),
+	}
+}
+
+func dedupStatusError(err error) error {
+	if err == ErrLedgerBucketDoesNotExist {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,172 @@
+package s3x
+
+import (
+	"context"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestS3X_Dedup_Badger(t *testing.T) {
+	testS3XDedup(t, DSTypeBadger)
+}
+func TestS3X_Dedup_Crdt(t *testing.T) {
+	testS3XDedup(t, DSTypeCrdt)
+}
+func testS3XDedup(t *testing.T, dsType DSType) {
+	const (
+		hello = "hello"
+		world = "world"
+	)
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	for _, bucket := range []string{testBucket1, testBucket2} {
+		if err := gateway.MakeBucketWithLocation(ctx, bucket, "us-east-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	put := func(t *testing.T, bucket, object, data string) {
+		t.Helper()
+		if _, err := gateway.PutObject(ctx, bucket, object, getTestPutObjectReader(t, []byte(data)), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func(t *testing.T, bucket string, objects uint64, logical, unique int64, count uint64) {
+		t.Helper()
+		stats, err := gateway.GetDedupStats(ctx, &DedupStatsRequest{Bucket: bucket})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Bucket != bucket || stats.ObjectsCount != objects || stats.LogicalSize != logical ||
+			stats.UniqueSize != unique || stats.UniqueCount != count {
+			t.Fatalf("expected %d objects of %d bytes with %d unique bytes in %d cids, but got %+v",
+				objects, logical, unique, count, stats)
+		}
+	}
+	refs := func(t *testing.T, hash string) []DataRef {
+		t.Helper()
+		refs, err := gateway.GetDataRefs(ctx, &DataRefsRequest{Hash: hash})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return refs.Refs
+	}
+	put(t, testBucket1, "a", hello)
+	put(t, testBucket1, "b", hello)
+	put(t, testBucket1, "c", world)
+	put(t, testBucket2, "d", hello)
+	put(t, testBucket2, "empty", "")
+	resp, err := gateway.GetHash(ctx, &InfoRequest{Bucket: testBucket1, Object: "a", ObjectDataOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	helloHash := resp.GetHash()
+
+	t.Run("stats", func(t *testing.T) {
+		check(t, testBucket1, 3, 15, 10, 2)
+		check(t, testBucket2, 2, 5, 5, 1)
+		stats, err := gateway.GetDedupStats(ctx, &DedupStatsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.ObjectsCount != 5 || stats.LogicalSize != 20 || stats.UniqueSize != 10 || stats.UniqueCount != 2 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+		if len(stats.Buckets) != 2 || stats.Buckets[0].Bucket != testBucket1 || stats.Buckets[1].Bucket != testBucket2 {
+			t.Fatalf("unexpected bucket stats %+v", stats.Buckets)
+		}
+	})
+	t.Run("refs", func(t *testing.T) {
+		got := refs(t, helloHash)
+		if len(got) != 3 {
+			t.Fatalf("expected 3 references, but got %+v", got)
+		}
+		for i, want := range []DataRef{
+			{Bucket: testBucket1, Object: "a", Size_: 5},
+			{Bucket: testBucket1, Object: "b", Size_: 5},
+			{Bucket: testBucket2, Object: "d", Size_: 5},
+		} {
+			if got[i] != want {
+				t.Fatalf("expected reference %+v, but got %+v", want, got[i])
+			}
+		}
+	})
+	t.Run("overwrite and remove", func(t *testing.T) {
+		put(t, testBucket1, "b", world)
+		check(t, testBucket1, 3, 15, 10, 2)
+		if err := gateway.DeleteObject(ctx, testBucket1, "a"); err != nil {
+			t.Fatal(err)
+		}
+		check(t, testBucket1, 2, 10, 5, 1)
+		if got := refs(t, helloHash); len(got) != 1 || got[0].Bucket != testBucket2 {
+			t.Fatalf("unexpected references %+v", got)
+		}
+	})
+	t.Run("transaction", func(t *testing.T) {
+		txn, err := gateway.BeginTransaction(ctx, &TransactionRequest{Bucket: testBucket2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.StageTransaction(ctx, &TransactionOps{Bucket: testBucket2, Id: txn.Id, Ops: []TransactionOp{
+			{Type: TransactionOpType_PUT, Object: "e", Data: []byte(world)},
+			{Type: TransactionOpType_COPY, Object: "f", Source: "d"},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.CommitTransaction(ctx, &TransactionRequest{Bucket: testBucket2, Id: txn.Id}); err != nil {
+			t.Fatal(err)
+		}
+		check(t, testBucket2, 4, 15, 10, 2)
+		if got := refs(t, helloHash); len(got) != 2 {
+			t.Fatalf("unexpected references %+v", got)
+		}
+	})
+	t.Run("recompute", func(t *testing.T) {
+		if err := gateway.ledgerStore.resetUsage(testBucket2); err != nil {
+			t.Fatal(err)
+		}
+		// usage recorded before the index existed is computed again
+		if err := putUsage(gateway.ledgerStore.ds, testBucket1, &BucketUsage{Size_: 10, ObjectsCount: 2}); err != nil {
+			t.Fatal(err)
+		}
+		gateway.restart(t)
+		if got := refs(t, helloHash); len(got) != 2 {
+			t.Fatalf("unexpected references %+v", got)
+		}
+		check(t, testBucket1, 2, 10, 5, 1)
+		check(t, testBucket2, 4, 15, 10, 2)
+	})
+	t.Run("delete bucket", func(t *testing.T) {
+		if _, err := gateway.DeleteObjects(ctx, testBucket2, []string{"d", "e", "f", "empty"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.DeleteBucket(ctx, testBucket2, false); err != nil {
+			t.Fatal(err)
+		}
+		if got := refs(t, helloHash); len(got) != 0 {
+			t.Fatalf("unexpected references %+v", got)
+		}
+		stats, err := gateway.GetDedupStats(ctx, &DedupStatsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.ObjectsCount != 2 || stats.UniqueSize != 5 || stats.UniqueCount != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := gateway.GetDataRefs(ctx, &DataRefsRequest{Hash: "invalid"}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, but got %v", err)
+		}
+		if _, err := gateway.GetDedupStats(ctx, &DedupStatsRequest{Bucket: testBucket2}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound, but got %v", err)
+		}
+	})
+}
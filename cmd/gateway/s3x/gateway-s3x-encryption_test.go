@@ -0,0 +1,152 @@
+package s3x
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/hash"
+)
+
+func TestS3X_Encryption_Badger(t *testing.T) {
+	testS3XEncryption(t, DSTypeBadger)
+}
+func TestS3X_Encryption_Crdt(t *testing.T) {
+	testS3XEncryption(t, DSTypeCrdt)
+}
+func testS3XEncryption(t *testing.T, dsType DSType) {
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	var masterKey [32]byte
+	if _, err := rand.Read(masterKey[:]); err != nil {
+		t.Fatal(err)
+	}
+	defer func(kms crypto.KMS) { minio.GlobalKMS = kms }(minio.GlobalKMS)
+	minio.GlobalKMS = crypto.NewMasterKey("s3x-test", masterKey)
+
+	// spans several encrypted packages of 64KiB
+	data := make([]byte, 200*1024)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		header http.Header
+	}{
+		{"SSE-S3", newSSES3Header()},
+		{"SSE-C", newSSECHeader(t)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object := "encrypted-" + tt.name
+			putEncryptedObject(t, gateway, object, data, tt.header)
+			oi, err := gateway.GetObjectInfo(ctx, testBucket1, object, minio.ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !crypto.IsEncrypted(oi.UserDefined) {
+				t.Fatalf("expected sealed keys in %v", oi.UserDefined)
+			}
+			if size, err := oi.DecryptedSize(); err != nil || size != int64(len(data)) {
+				t.Fatalf("expected decrypted size %d, but got %d, %v", len(data), size, err)
+			}
+			t.Run("ipfs data is ciphertext", func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				if err := gateway.GetObject(ctx, testBucket1, object, 0, oi.Size, buf, "", minio.ObjectOptions{}); err != nil {
+					t.Fatal(err)
+				}
+				if int64(buf.Len()) != oi.Size || bytes.Contains(buf.Bytes(), data[:1024]) {
+					t.Fatal("expected ipfs to only store ciphertext")
+				}
+			})
+			rangeTests := []struct {
+				name       string
+				rs         *minio.HTTPRangeSpec
+				start, end int
+			}{
+				{"full", nil, 0, len(data)},
+				{"in package", &minio.HTTPRangeSpec{Start: 10, End: 1000}, 10, 1001},
+				{"across packages", &minio.HTTPRangeSpec{Start: 60 * 1024, End: 140 * 1024}, 60 * 1024, 140*1024 + 1},
+				{"suffix", &minio.HTTPRangeSpec{IsSuffixLength: true, Start: -100}, len(data) - 100, len(data)},
+			}
+			for _, rt := range rangeTests {
+				t.Run(rt.name, func(t *testing.T) {
+					gateway.restart(t)
+					gr, err := gateway.GetObjectNInfo(ctx, testBucket1, object, rt.rs, tt.header, 0, minio.ObjectOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer gr.Close()
+					buf := &bytes.Buffer{}
+					if _, err := buf.ReadFrom(gr); err != nil {
+						t.Fatal(err)
+					}
+					if !bytes.Equal(buf.Bytes(), data[rt.start:rt.end]) {
+						t.Fatalf("decrypted data does not match range [%d,%d)", rt.start, rt.end)
+					}
+				})
+			}
+		})
+	}
+}
+
+// putEncryptedObject encrypts the data the way minio's put object handler does, and uploads it
+func putEncryptedObject(t *testing.T, gateway *testGateway, object string, data []byte, header http.Header) {
+	req, err := http.NewRequest(http.MethodPut, "/"+testBucket1+"/"+object, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header = header
+	rawReader := getTestHashReader(t, bytes.NewReader(data), int64(len(data)))
+	metadata := map[string]string{}
+	reader, objectKey, err := minio.EncryptRequest(rawReader, req, testBucket1, object, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encSize := (&minio.ObjectInfo{Size: int64(len(data))}).EncryptedSize()
+	encReader, err := hash.NewReader(reader, encSize, "", "", int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crypto.RemoveSensitiveEntries(metadata)
+	_, err = gateway.PutObject(context.Background(), testBucket1, object,
+		minio.NewPutObjReader(rawReader, encReader, &objectKey),
+		minio.ObjectOptions{UserDefined: metadata},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newSSES3Header() http.Header {
+	h := http.Header{}
+	h.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+	return h
+}
+
+func newSSECHeader(t *testing.T) http.Header {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		t.Fatal(err)
+	}
+	sum := md5.Sum(key[:])
+	h := http.Header{}
+	h.Set(crypto.SSECAlgorithm, crypto.SSEAlgorithmAES256)
+	h.Set(crypto.SSECKey, base64.StdEncoding.EncodeToString(key[:]))
+	h.Set(crypto.SSECKeyMD5, base64.StdEncoding.EncodeToString(sum[:]))
+	return h
+}
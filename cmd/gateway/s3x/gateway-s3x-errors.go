@@ -2,7 +2,10 @@ package s3x
 
 import (
 	"errors"
+
 	minio "github.com/minio/minio/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -24,10 +27,9 @@ var (
 	// ErrInvalidPartNumber is an error message returned when the multipart part
 	// number is out of range (not mappable to a minio error type)
 	ErrInvalidPartNumber = errors.New("invalid multipart part number")
-
-	ErrObjectSizeZero = errors.New("object with size zero not allowed")
-
-	ErrCreatingEmptyFolder = errors.New("error creating empty folder. File keep not found")
+	// ErrLedgerTransactionDoesNotExist is an error message returned from the internal
+	// ledgerStore indicating that a transaction does not exist
+	ErrLedgerTransactionDoesNotExist = errors.New("transaction does not exist")
 
 	ErrLambdaHandler = errors.New("error when calling the lambda function")
 )
@@ -46,15 +48,20 @@ func (x *xObjects) toMinioErr(err error, bucket, object, id string) error {
 		err = minio.InvalidUploadID{Bucket: bucket, Object: object, UploadID: id}
 	case ErrLedgerNonEmptyBucket:
 		err = minio.BucketNotEmpty{Bucket: bucket}
-	case ErrObjectSizeZero:
-		err = minio.ObjectTooSmall{Bucket: bucket, Object: object}
-	case ErrCreatingEmptyFolder:
-		// Note: maybe there is a better error
-		err = minio.ObjectNotFound{Bucket: bucket, Object: object}
 	case ErrLambdaHandler:
 		err = minio.BackendDown{}
 	case nil:
 		return nil
 	}
+	if isUnavailable(err) {
+		// TemporalX can not be reached, or the circuit breaker is open
+		return minio.BackendDown{}
+	}
 	return err
 }
+
+// isUnavailable returns whether err is, or wraps, a grpc error with the Unavailable code
+func isUnavailable(err error) bool {
+	var se interface{ GRPCStatus() *status.Status }
+	return errors.As(err, &se) && se.GRPCStatus().Code() == codes.Unavailable
+}
@@ -0,0 +1,60 @@
+package s3x
+
+import (
+	"context"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+)
+
+// response elements added to bucket events, with the ipfs hashes of the change
+const (
+	eventDataHashElement   = "x-fleek-ipfs-hash"        // hash of the object data, not set for removed or empty objects
+	eventObjectHashElement = "x-fleek-ipfs-object-hash" // hash of the ledger object, not set for removed objects
+	eventBucketHashElement = "x-fleek-ipfs-bucket-hash" // hash of the bucket root after the change
+)
+
+// IsNotificationSupported returns whether bucket notification is applicable for this layer.
+// The ledger sees every change of an object, so the events are sent by sendObjectEvent.
+func (x *xObjects) IsNotificationSupported() bool {
+	return true
+}
+
+// SendsObjectEvents implements minio.GatewayEventSender
+func (x *xObjects) SendsObjectEvents() bool {
+	return true
+}
+
+// sendObjectEvent is called by objectChanged after an object is saved or removed,
+// the event name depends on the S3 API of the request in ctx. bucket is the ledger
+// name of the bucket, events have the bucket name without its tenant.
+func sendObjectEvent(ctx context.Context, bucket, object string, obj *Object, objHash, bucketHash string) {
+	elements := map[string]string{
+		eventBucketHashElement: bucketHash,
+	}
+	if obj == nil {
+		minio.SendGatewayEvent(ctx, event.ObjectRemovedDelete, minio.ObjectInfo{
+			Bucket: tenantBucket(bucket),
+			Name:   object,
+		}, elements)
+		return
+	}
+
+	name := event.ObjectCreatedPut
+	switch logger.GetReqInfo(ctx).API {
+	case "PostPolicyBucket":
+		name = event.ObjectCreatedPost
+	case "CopyObject":
+		name = event.ObjectCreatedCopy
+	case "CompleteMultipartUpload":
+		name = event.ObjectCreatedCompleteMultipartUpload
+	}
+	info := getMinioObjectInfo(&obj.ObjectInfo)
+	if obj.DataHash != "" {
+		info.UserDefined = withContentHash(info.UserDefined, obj.DataHash)
+		elements[eventDataHashElement] = obj.DataHash
+	}
+	elements[eventObjectHashElement] = objHash
+	minio.SendGatewayEvent(ctx, name, info, elements)
+}
@@ -0,0 +1,106 @@
+package s3x
+
+import (
+	"context"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+func TestS3X_Events_Badger(t *testing.T) {
+	testS3XEvents(t, DSTypeBadger)
+}
+func TestS3X_Events_Crdt(t *testing.T) {
+	testS3XEvents(t, DSTypeCrdt)
+}
+func testS3XEvents(t *testing.T, dsType DSType) {
+	const testObject2 = "testobject2"
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		object              string
+		removed             bool
+		objHash, bucketHash string
+	}
+	var changes []change
+	gateway.ledgerStore.objectChanged = func(ctx context.Context, bucket, object string, obj *Object, objHash, bucketHash string) {
+		if bucket != testBucket1 {
+			t.Errorf("unexpected bucket %s", bucket)
+		}
+		changes = append(changes, change{object: object, removed: obj == nil, objHash: objHash, bucketHash: bucketHash})
+		// events are dropped, since the notification system is not initialized
+		sendObjectEvent(ctx, bucket, object, obj, objHash, bucketHash)
+	}
+	// last returns the last change, which must be of the object and leave the current bucket root
+	last := func(t *testing.T, object string, removed bool) change {
+		t.Helper()
+		if len(changes) == 0 {
+			t.Fatal("expected a change")
+		}
+		c := changes[len(changes)-1]
+		changes = nil
+		if c.object != object || c.removed != removed {
+			t.Fatalf("unexpected change %+v", c)
+		}
+		bucketHash, err := gateway.ledgerStore.GetBucketHash(testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.bucketHash != bucketHash {
+			t.Fatalf("expected bucket hash %s, but got %s", bucketHash, c.bucketHash)
+		}
+		return c
+	}
+	// checkObject checks that the changed object hash is the object in the ledger
+	checkObject := func(t *testing.T, c change) {
+		t.Helper()
+		obj, err := ipfsObject(ctx, gateway.dagClient, c.objHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dataHash, _, err := gateway.ledgerStore.GetObjectDataHash(ctx, testBucket1, c.object)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if obj.GetDataHash() != dataHash || obj.GetObjectInfo().Name != c.object {
+			t.Fatalf("unexpected object %+v", obj)
+		}
+	}
+
+	t.Run("put", func(t *testing.T) {
+		testPutObject(t, gateway)
+		checkObject(t, last(t, testObject1, false))
+	})
+	t.Run("copy", func(t *testing.T) {
+		if _, err := gateway.CopyObject(ctx, testBucket1, testObject1, testBucket1, testObject2,
+			minio.ObjectInfo{}, minio.ObjectOptions{}, minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		checkObject(t, last(t, testObject2, false))
+	})
+	t.Run("delete", func(t *testing.T) {
+		if err := gateway.DeleteObject(ctx, testBucket1, testObject2); err != nil {
+			t.Fatal(err)
+		}
+		if c := last(t, testObject2, true); c.objHash != "" {
+			t.Fatalf("unexpected object hash %s", c.objHash)
+		}
+	})
+	t.Run("delete missing", func(t *testing.T) {
+		if _, err := gateway.DeleteObjects(ctx, testBucket1, []string{testObject2}); err != nil {
+			t.Fatal(err)
+		}
+		if len(changes) != 0 {
+			t.Fatalf("unexpected changes %+v", changes)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package s3x
+
+import (
+	"context"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/madmin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// healEndpoint is reported as the only drive of s3x heal results
+const healEndpoint = "ipfs"
+
+// Fsck checks the consistency of the ledger, see ledgerStore.Fsck
+func (x *xObjects) Fsck(ctx context.Context, req *FsckRequest) (*FsckResponse, error) {
+	if req.GetRemove() && !req.GetRepair() {
+		return nil, status.Error(codes.InvalidArgument, "remove requires repair to be set")
+	}
+	resp, err := x.ledgerStore.Fsck(ctx, req)
+	if err != nil {
+		if err == ErrLedgerBucketDoesNotExist {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+// HealFormat is a no-op, s3x does not have a disk format to heal.
+func (x *xObjects) HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error) {
+	return newHealResult(madmin.HealItemMetadata, "", "", nil), nil
+}
+
+// ListBucketsHeal lists all buckets tracked by the ledger
+func (x *xObjects) ListBucketsHeal(ctx context.Context) ([]minio.BucketInfo, error) {
+	return x.ListNamespacedBuckets(ctx)
+}
+
+// HealBucket checks that the bucket root can be resolved, re-pinning it unless dryRun is set.
+// Unresolvable buckets are removed from the ledger if remove is set.
+func (x *xObjects) HealBucket(ctx context.Context, bucket string, dryRun, remove bool) (madmin.HealResultItem, error) {
+	issue, err := x.ledgerStore.FsckBucket(ctx, bucket, !dryRun, remove)
+	if err != nil {
+		return madmin.HealResultItem{}, x.toMinioErr(err, bucket, "", "")
+	}
+	var issues []FsckIssue
+	if issue != nil {
+		issues = append(issues, *issue)
+	}
+	return newHealResult(madmin.HealItemBucket, bucket, "", issues), nil
+}
+
+// HealObject checks that the object and its data can be resolved and that the data size matches,
+// re-pinning and correcting the object unless opts.DryRun is set.
+// Unresolvable objects are removed from the ledger if opts.Remove is set.
+func (x *xObjects) HealObject(ctx context.Context, bucket, object string, opts madmin.HealOpts) (madmin.HealResultItem, error) {
+	issues, err := x.ledgerStore.FsckObject(ctx, bucket, object, !opts.DryRun, opts.Remove)
+	if err != nil {
+		return madmin.HealResultItem{}, x.toMinioErr(err, bucket, object, "")
+	}
+	res := newHealResult(madmin.HealItemObject, bucket, object, issues)
+	if oi, err := x.ledgerStore.ObjectInfo(ctx, bucket, object); err == nil {
+		res.ObjectSize = oi.GetSize_()
+	}
+	return res, nil
+}
+
+// HealObjects calls healObject for every object in the bucket with the given prefix
+func (x *xObjects) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObject minio.HealObjectFn) error {
+	names, err := x.ledgerStore.GetObjectNames(ctx, bucket, prefix)
+	if err == ErrLedgerBucketDoesNotExist {
+		// this includes the minio meta bucket, which s3x does not have
+		return nil
+	}
+	if err != nil {
+		return x.toMinioErr(err, bucket, "", "")
+	}
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := healObject(bucket, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newHealResult converts fsck issues to a heal result with a single drive representing ipfs
+func newHealResult(itemType madmin.HealItemType, bucket, object string, issues []FsckIssue) madmin.HealResultItem {
+	res := madmin.HealResultItem{
+		Type:      itemType,
+		Bucket:    bucket,
+		Object:    object,
+		DiskCount: 1,
+		SetCount:  1,
+	}
+	before, after := madmin.DriveStateOk, madmin.DriveStateOk
+	for _, issue := range issues {
+		state := madmin.DriveStateMissing
+		if issue.Type == FsckIssueType_SIZE_MISMATCH {
+			state = madmin.DriveStateCorrupt
+		}
+		before = state
+		if !issue.Repaired {
+			after = state
+		}
+		if res.Detail != "" {
+			res.Detail += "; "
+		}
+		res.Detail += issue.Type.String() + ": " + issue.Detail
+	}
+	res.Before.Drives = []madmin.HealDriveInfo{{Endpoint: healEndpoint, State: before}}
+	res.After.Drives = []madmin.HealDriveInfo{{Endpoint: healEndpoint, State: after}}
+	return res
+}
@@ -0,0 +1,123 @@
+package s3x
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/multiformats/go-multihash"
+)
+
+func TestS3X_Fsck_Badger(t *testing.T) {
+	testS3XFsck(t, DSTypeBadger)
+}
+func TestS3X_Fsck_Crdt(t *testing.T) {
+	testS3XFsck(t, DSTypeCrdt)
+}
+func testS3XFsck(t *testing.T, dsType DSType) {
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	testPutObject(t, gateway)
+	ledger := gateway.ledgerStore
+
+	t.Run("consistent", func(t *testing.T) {
+		resp, err := gateway.Fsck(ctx, &FsckRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Buckets != 1 || resp.Objects != 1 || len(resp.Issues) != 0 {
+			t.Fatalf("unexpected fsck response %+v", resp)
+		}
+		res, err := gateway.HealObject(ctx, testBucket1, testObject1, madmin.HealOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, a := res.GetOnlineCounts(); b != 1 || a != 1 {
+			t.Fatalf("unexpected heal result %+v", res)
+		}
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		if _, err := gateway.Fsck(ctx, &FsckRequest{Remove: true}); err == nil {
+			t.Fatal("expected error when remove is set without repair")
+		}
+		if _, err := gateway.Fsck(ctx, &FsckRequest{Bucket: testBucket2}); err == nil {
+			t.Fatal("expected error for missing bucket")
+		}
+	})
+	t.Run("size mismatch", func(t *testing.T) {
+		obj, err := ledger.object(ctx, testBucket1, testObject1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		obj.ObjectInfo.Size_++
+		if err := ledger.PutObject(ctx, testBucket1, testObject1, obj); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := gateway.Fsck(ctx, &FsckRequest{Bucket: testBucket1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Issues) != 1 || resp.Issues[0].Type != FsckIssueType_SIZE_MISMATCH || resp.Issues[0].Repaired {
+			t.Fatalf("unexpected fsck response %+v", resp)
+		}
+		res, err := gateway.HealObject(ctx, testBucket1, testObject1, madmin.HealOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, a := res.GetCorruptedCounts(); b != 1 || a != 0 {
+			t.Fatalf("unexpected heal result %+v", res)
+		}
+		if res.ObjectSize != int64(len(testObject1Data)) {
+			t.Fatalf("expected size %d, but got %d", len(testObject1Data), res.ObjectSize)
+		}
+	})
+	t.Run("dangling object", func(t *testing.T) {
+		mh, err := multihash.Sum([]byte("never stored"), multihash.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dangling := cid.NewCidV1(cid.Raw, mh).String()
+		if _, err := ledger.putObjectHash(ctx, testBucket1, testObject1, dangling); err != nil {
+			t.Fatal(err)
+		}
+		tctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		resp, err := gateway.Fsck(tctx, &FsckRequest{Bucket: testBucket1, Repair: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Issues) != 1 || resp.Issues[0].Type != FsckIssueType_OBJECT_UNRESOLVABLE || resp.Issues[0].Repaired {
+			t.Fatalf("unexpected fsck response %+v", resp)
+		}
+		results := make(chan minio.ObjectInfo)
+		if err := gateway.Walk(tctx, testBucket1, "", results); err != nil {
+			t.Fatal(err)
+		}
+		for oi := range results {
+			if oi.Name == testObject1 {
+				t.Fatal("expected the walk to end at the unresolvable object")
+			}
+		}
+		res, err := gateway.HealObject(tctx, testBucket1, testObject1, madmin.HealOpts{Remove: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, a := res.GetMissingCounts(); b != 1 || a != 0 {
+			t.Fatalf("unexpected heal result %+v", res)
+		}
+		if _, err := ledger.GetObjectHash(ctx, testBucket1, testObject1); err != ErrLedgerObjectDoesNotExist {
+			t.Fatal("expected dangling object to be removed, but got", err)
+		}
+	})
+}
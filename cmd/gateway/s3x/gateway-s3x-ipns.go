@@ -0,0 +1,435 @@
+package s3x
+
+import (
+	"context"
+	"crypto/rand"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/RTradeLtd/TxPB/v3/go"
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-merkledag"
+	unixfs "github.com/ipfs/go-unixfs"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	minioCrypto "github.com/minio/minio/cmd/crypto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ipnsKeyPrefix is prepended to the bucket name to get the name of its key in the TemporalX keystore
+	ipnsKeyPrefix = "s3x-bucket-"
+	// ipnsRepublishInterval is the age after which published records are published again, before they expire
+	ipnsRepublishInterval = 12 * time.Hour
+	// ipnsRepublishCheck is how often published records are checked for their age
+	ipnsRepublishCheck = time.Hour
+)
+
+// SetBucketIPNS enables or disables publishing a bucket to IPNS.
+// The key of the bucket is generated the first time publishing is enabled,
+// and kept until the bucket is deleted, so the IPNS name does not change.
+func (x *xObjects) SetBucketIPNS(ctx context.Context, req *BucketIPNSRequest) (*BucketIPNS, error) {
+	if req.GetBucket() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name is empty")
+	}
+	bucket := x.ledgerBucket(ctx, req.GetBucket())
+	if !req.GetEnable() {
+		if err := x.ledgerStore.DisableBucketIPNS(ctx, bucket); err != nil {
+			return nil, ipnsStatusError(err)
+		}
+		return &BucketIPNS{Bucket: req.GetBucket(), Mode: req.GetMode()}, nil
+	}
+	if err := x.ledgerStore.AssertBucketExits(bucket); err != nil {
+		return nil, ipnsStatusError(err)
+	}
+	key, err := ipnsKey(ctx, x.dagClient, bucket)
+	if err != nil {
+		return nil, ipnsStatusError(err)
+	}
+	id, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return nil, ipnsStatusError(err)
+	}
+	state, err := x.ledgerStore.EnableBucketIPNS(ctx, bucket, id.Pretty(), req.GetMode())
+	if err != nil {
+		return nil, ipnsStatusError(err)
+	}
+	state.Bucket = req.GetBucket()
+	return state, nil
+}
+
+func ipnsStatusError(err error) error {
+	if err == ErrLedgerBucketDoesNotExist {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// ipnsPublisher publishes buckets to their IPNS names after their roots change.
+//
+// Publishing is debounced: a bucket is published once its root did not change for the debounce
+// duration, but no later than the interval after the first unpublished change. Each bucket is
+// published at most once per interval, and only one bucket is published at a time, since
+// publishing to the DHT is slow. Records are published again before they expire.
+type ipnsPublisher struct {
+	ls       *ledgerStore
+	dag      pb.NodeAPIClient
+	names    pb.NameSysAPIClient
+	debounce time.Duration
+	interval time.Duration
+
+	mu        sync.Mutex
+	schedules map[string]*ipnsSchedule
+	queue     chan string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// ipnsSchedule is the publishing schedule of a single bucket
+type ipnsSchedule struct {
+	timer     *time.Timer
+	changed   time.Time // the first unpublished change, zero if there is none
+	published time.Time // the last publish attempt
+}
+
+func newIPNSPublisher(ls *ledgerStore, dag pb.NodeAPIClient, names pb.NameSysAPIClient, debounce, interval time.Duration) *ipnsPublisher {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &ipnsPublisher{
+		ls:        ls,
+		dag:       dag,
+		names:     names,
+		debounce:  debounce,
+		interval:  interval,
+		schedules: make(map[string]*ipnsSchedule),
+		queue:     make(chan string),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	ls.ipnsChanged = p.schedule
+	return p
+}
+
+// start publishes buckets that changed while the gateway was stopped, and runs the publisher until close is called
+func (p *ipnsPublisher) start() {
+	p.wg.Add(1)
+	go p.run()
+	p.scheduleAll()
+}
+
+// close stops all scheduled publishes and waits for a running publish to be canceled
+func (p *ipnsPublisher) close() {
+	p.mu.Lock()
+	p.cancel()
+	for _, s := range p.schedules {
+		if s.timer != nil {
+			s.timer.Stop()
+		}
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// schedule publishes the bucket after the debounce duration, subject to the rate limit of the bucket
+func (p *ipnsPublisher) schedule(bucket string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx.Err() != nil {
+		return
+	}
+	s, ok := p.schedules[bucket]
+	if !ok {
+		s = &ipnsSchedule{}
+		p.schedules[bucket] = s
+	}
+	now := time.Now()
+	if s.changed.IsZero() {
+		s.changed = now
+	}
+	at := now.Add(p.debounce)
+	if latest := s.changed.Add(p.interval); at.After(latest) {
+		// constant changes must not postpone publishing forever
+		at = latest
+	}
+	if next := s.published.Add(p.interval); at.Before(next) {
+		at = next
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(at.Sub(now), func() {
+		select {
+		case p.queue <- bucket:
+		case <-p.ctx.Done():
+		}
+	})
+}
+
+// scheduleAll schedules all buckets that are published to IPNS,
+// publish skips buckets that are already published and do not need to be republished.
+func (p *ipnsPublisher) scheduleAll() {
+	buckets, err := p.ls.GetIPNSBuckets()
+	if err != nil {
+		log.Printf("failed to list buckets published to ipns: %v", err)
+		return
+	}
+	for _, bucket := range buckets {
+		p.schedule(bucket)
+	}
+}
+
+// remove stops publishing the deleted bucket and deletes its key
+func (p *ipnsPublisher) remove(ctx context.Context, bucket string) error {
+	p.mu.Lock()
+	if s, ok := p.schedules[bucket]; ok {
+		if s.timer != nil {
+			s.timer.Stop()
+		}
+		delete(p.schedules, bucket)
+	}
+	p.mu.Unlock()
+	// only buckets that were published have a key
+	resp, err := p.dag.Keystore(ctx, &pb.KeystoreRequest{
+		RequestType: pb.KSREQTYPE_KS_HAS,
+		Name:        ipnsKeyPrefix + bucket,
+	})
+	if err != nil || !resp.GetHas() {
+		return err
+	}
+	_, err = p.dag.Keystore(ctx, &pb.KeystoreRequest{
+		RequestType: pb.KSREQTYPE_KS_DELETE,
+		Name:        ipnsKeyPrefix + bucket,
+	})
+	return err
+}
+
+func (p *ipnsPublisher) run() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(ipnsRepublishCheck)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.scheduleAll()
+		case bucket := <-p.queue:
+			p.mu.Lock()
+			if s, ok := p.schedules[bucket]; ok {
+				s.changed = time.Time{}
+				s.published = time.Now()
+			}
+			p.mu.Unlock()
+			if err := p.publish(p.ctx, bucket); err != nil && p.ctx.Err() == nil {
+				log.Printf("failed to publish bucket %s to ipns, retrying: %v", bucket, err)
+				p.schedule(bucket)
+			}
+		}
+	}
+}
+
+// publish publishes the current content of the bucket to its IPNS name
+func (p *ipnsPublisher) publish(ctx context.Context, bucket string) error {
+	state, err := p.ls.GetBucketIPNS(bucket)
+	if err == ErrLedgerBucketDoesNotExist {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		// publishing was disabled
+		return nil
+	}
+	var hash string
+	switch state.Mode {
+	case IPNSMode_UNIXFS_DIRECTORY:
+		hash, err = p.exportBucket(ctx, bucket)
+	default:
+		hash, err = p.ls.GetBucketHash(bucket)
+	}
+	if err != nil {
+		return err
+	}
+	path := "/ipfs/" + hash
+	if path == state.Published && time.Since(state.PublishedAt) < ipnsRepublishInterval {
+		return nil
+	}
+	key, err := ipnsKey(ctx, p.dag, bucket)
+	if err != nil {
+		return err
+	}
+	data, err := crypto.MarshalPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if _, err := p.names.NameSysPublish(ctx, &pb.NameSysPublishRequest{
+		PrivateKey: data,
+		Value:      path,
+	}); err != nil {
+		return err
+	}
+	log.Printf("bucket-name: %s\tipns-name: %s\tpublished: %s", bucket, state.Name, path)
+	return p.ls.SetIPNSPublished(bucket, state.Name, path, time.Now().UTC())
+}
+
+// exportBucket saves a unixfs directory tree of the object data in the bucket and returns its hash.
+// Encrypted objects are left out, since ipfs only stores their ciphertext.
+func (p *ipnsPublisher) exportBucket(ctx context.Context, bucket string) (string, error) {
+	objs, unlock, err := p.ls.GetObjectHashes(ctx, bucket)
+	if err != nil {
+		return "", err
+	}
+	// the objects are read from ipfs without holding the bucket lock,
+	// so the names and hashes are copied while it is held
+	hashes := make(map[string]string, len(objs))
+	for name, h := range objs {
+		hashes[name] = h
+	}
+	unlock()
+	root := newExportDir()
+	for name, h := range hashes {
+		obj, err := ipfsObject(ctx, p.dag, h)
+		if err != nil {
+			return "", err
+		}
+		if minioCrypto.IsEncrypted(obj.ObjectInfo.UserDefined) {
+			continue
+		}
+		root.add(name, obj)
+	}
+	hash, _, err := root.save(ctx, p.dag)
+	return hash, err
+}
+
+// exportDir is a directory of an exported bucket, object names are split into directories by "/"
+type exportDir struct {
+	dirs  map[string]*exportDir
+	files map[string]*Object
+}
+
+func newExportDir() *exportDir {
+	return &exportDir{
+		dirs:  make(map[string]*exportDir),
+		files: make(map[string]*Object),
+	}
+}
+
+func (d *exportDir) add(name string, obj *Object) {
+	parts := strings.Split(name, "/")
+	for _, part := range parts[:len(parts)-1] {
+		if part == "" {
+			continue
+		}
+		child, ok := d.dirs[part]
+		if !ok {
+			child = newExportDir()
+			d.dirs[part] = child
+		}
+		d = child
+	}
+	// directory markers end with "/", so they only create the directory
+	if file := parts[len(parts)-1]; file != "" {
+		d.files[file] = obj
+	}
+}
+
+// save saves the directory and all of its children, it returns the hash and cumulative size of the directory
+func (d *exportDir) save(ctx context.Context, dag pb.NodeAPIClient) (string, uint64, error) {
+	node := unixfs.EmptyDirNode()
+	node.SetCidBuilder(merkledag.V1CidPrefix())
+	var size uint64
+	addLink := func(name, hash string, linkSize uint64) error {
+		c, err := cid.Decode(hash)
+		if err != nil {
+			return err
+		}
+		size += linkSize
+		return node.AddRawLink(name, &format.Link{Cid: c, Size: linkSize})
+	}
+	for name, child := range d.dirs {
+		hash, childSize, err := child.save(ctx, dag)
+		if err != nil {
+			return "", 0, err
+		}
+		if err := addLink(name, hash, childSize); err != nil {
+			return "", 0, err
+		}
+	}
+	for name, obj := range d.files {
+		if _, ok := d.dirs[name]; ok {
+			// a directory with the same name takes precedence
+			log.Printf("object %s is not exported, a directory has the same name", obj.ObjectInfo.GetName())
+			continue
+		}
+		hash := obj.GetDataHash()
+		var linkSize uint64
+		if hash == "" {
+			// zero-byte objects do not have any data in ipfs
+			empty := merkledag.NodeWithData(unixfs.FilePBData(nil, 0))
+			empty.SetCidBuilder(merkledag.V1CidPrefix())
+			h, err := ipfsSaveProtoNode(ctx, dag, empty)
+			if err != nil {
+				return "", 0, err
+			}
+			hash, linkSize = h, uint64(len(empty.RawData()))
+		} else {
+			size, err := ipfsCumulativeSize(ctx, dag, hash)
+			if err != nil {
+				return "", 0, err
+			}
+			linkSize = size
+		}
+		if err := addLink(name, hash, linkSize); err != nil {
+			return "", 0, err
+		}
+	}
+	hash, err := ipfsSaveProtoNode(ctx, dag, node)
+	if err != nil {
+		return "", 0, err
+	}
+	return hash, size + uint64(len(node.RawData())), nil
+}
+
+// ipnsKey returns the key of the bucket from the TemporalX keystore, it is generated if the bucket does not have one
+func ipnsKey(ctx context.Context, dag pb.NodeAPIClient, bucket string) (crypto.PrivKey, error) {
+	name := ipnsKeyPrefix + bucket
+	resp, err := dag.Keystore(ctx, &pb.KeystoreRequest{
+		RequestType: pb.KSREQTYPE_KS_HAS,
+		Name:        name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetHas() {
+		resp, err := dag.Keystore(ctx, &pb.KeystoreRequest{
+			RequestType: pb.KSREQTYPE_KS_GET,
+			Name:        name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return crypto.UnmarshalPrivateKey(resp.GetPrivateKey())
+	}
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	data, err := crypto.MarshalPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dag.Keystore(ctx, &pb.KeystoreRequest{
+		RequestType: pb.KSREQTYPE_KS_PUT,
+		Name:        name,
+		PrivateKey:  data,
+	}); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,179 @@
+package s3x
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	pb "github.com/RTradeLtd/TxPB/v3/go"
+	"github.com/ipfs/go-merkledag"
+	minio "github.com/minio/minio/cmd"
+)
+
+func TestS3X_IPNS_Badger(t *testing.T) {
+	testS3XIPNS(t, DSTypeBadger)
+}
+func TestS3X_IPNS_Crdt(t *testing.T) {
+	testS3XIPNS(t, DSTypeCrdt)
+}
+func testS3XIPNS(t *testing.T, dsType DSType) {
+	const emptyObject = "folder/empty"
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	gateway.ipns.debounce = 10 * time.Millisecond
+	gateway.ipns.interval = 50 * time.Millisecond
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	testPutObject(t, gateway)
+
+	// waitPublished waits until the bucket is published to the expected path,
+	// or to any new path if expected is empty, and returns the published path
+	waitPublished := func(t *testing.T, old, expected string) string {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			state, err := gateway.ledgerStore.GetBucketIPNS(testBucket1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p := state.GetPublished(); p != "" && p != old && (expected == "" || p == expected) {
+				return p
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("bucket was not published to %q", expected)
+		return ""
+	}
+
+	state, err := gateway.SetBucketIPNS(ctx, &BucketIPNSRequest{Bucket: testBucket1, Enable: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := state.GetName()
+	if name == "" {
+		t.Fatal("expected an ipns name")
+	}
+	t.Run("name is visible", func(t *testing.T) {
+		bi, err := gateway.ledgerStore.GetBucketInfo(ctx, testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bi.IpnsName != name {
+			t.Fatalf("expected bucket info ipns name %q, but got %q", name, bi.IpnsName)
+		}
+		resp, err := gateway.GetHash(ctx, &InfoRequest{Bucket: testBucket1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.IpnsName != name {
+			t.Fatalf("expected info ipns name %q, but got %q", name, resp.IpnsName)
+		}
+	})
+	var published string
+	t.Run("bucket root", func(t *testing.T) {
+		hash, err := gateway.ledgerStore.GetBucketHash(testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		published = waitPublished(t, "", "/ipfs/"+hash)
+		// changes are published again
+		if _, err := gateway.PutObject(ctx, testBucket1, emptyObject, getTestPutObjectReader(t, nil), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if hash, err = gateway.ledgerStore.GetBucketHash(testBucket1); err != nil {
+			t.Fatal(err)
+		}
+		published = waitPublished(t, published, "/ipfs/"+hash)
+	})
+	t.Run("unixfs directory", func(t *testing.T) {
+		if _, err := gateway.SetBucketIPNS(ctx, &BucketIPNSRequest{
+			Bucket: testBucket1,
+			Enable: true,
+			Mode:   IPNSMode_UNIXFS_DIRECTORY,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		published = waitPublished(t, published, "")
+		data, err := ipfsBytes(ctx, gateway.dagClient, published[len("/ipfs/"):])
+		if err != nil {
+			t.Fatal(err)
+		}
+		node, err := merkledag.DecodeProtobuf(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, link := range node.Links() {
+			names = append(names, link.Name)
+		}
+		sort.Strings(names)
+		// the empty object is exported in a sub directory
+		if expected := []string{"folder", testObject1}; !reflect.DeepEqual(names, expected) {
+			t.Fatalf("expected directory entries %v, but got %v", expected, names)
+		}
+		// the size of a file link is the size of the blocks of the file
+		link, _, err := node.ResolveLink([]string{testObject1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fileData, err := ipfsBytes(ctx, gateway.dagClient, link.Cid.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if link.Size != uint64(len(fileData)) {
+			t.Fatalf("expected a link size of %d, but got %d", len(fileData), link.Size)
+		}
+	})
+	t.Run("disable", func(t *testing.T) {
+		if _, err := gateway.SetBucketIPNS(ctx, &BucketIPNSRequest{Bucket: testBucket1}); err != nil {
+			t.Fatal(err)
+		}
+		bi, err := gateway.ledgerStore.GetBucketInfo(ctx, testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bi.IpnsName != "" {
+			t.Fatalf("expected no ipns name, but got %q", bi.IpnsName)
+		}
+		// the key is kept, so the name does not change
+		state, err := gateway.SetBucketIPNS(ctx, &BucketIPNSRequest{Bucket: testBucket1, Enable: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.GetName() != name {
+			t.Fatalf("expected ipns name %q, but got %q", name, state.GetName())
+		}
+	})
+	t.Run("delete bucket", func(t *testing.T) {
+		if err := gateway.DeleteObject(ctx, testBucket1, testObject1); err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.DeleteObject(ctx, testBucket1, emptyObject); err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.DeleteBucket(ctx, testBucket1, false); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := gateway.dagClient.Keystore(ctx, &pb.KeystoreRequest{
+			RequestType: pb.KSREQTYPE_KS_HAS,
+			Name:        ipnsKeyPrefix + testBucket1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetHas() {
+			t.Fatal("expected the key of the bucket to be deleted")
+		}
+		if _, err := gateway.SetBucketIPNS(ctx, &BucketIPNSRequest{Bucket: testBucket1, Enable: true}); err == nil {
+			t.Fatal("expected an error for a deleted bucket")
+		}
+	})
+}
@@ -123,7 +123,7 @@ func (ls *ledgerStore) CreateBucket(ctx context.Context, bucket string, b *Bucke
 	}
 
 	// sync lambda call
-	if err := ls.oh.CallPutBucketHandler(ctx, bucket, lb.IpfsHash); err != nil {
+	if err := ls.oh.CallPutBucketHandler(ctx, tenantBucket(bucket), lb.IpfsHash); err != nil {
 		// TODO: remove bucket just created from ledger
 		log.Println("error while calling lambda in PutBucket ")
 		return "", err
@@ -169,10 +169,18 @@ func (ls *ledgerStore) saveBucket(ctx context.Context, bucket string, b *Bucket)
 		Bucket:   b,
 		IpfsHash: bHash,
 	}
+	ls.bucketSaved(bucket, lb)
+	return lb, nil
+}
+
+// bucketSaved caches a bucket after its new root was saved to the datastore
+func (ls *ledgerStore) bucketSaved(bucket string, lb *LedgerBucketEntry) {
 	ls.mapLocker.Lock()
 	ls.l.Buckets[bucket] = lb
 	ls.mapLocker.Unlock()
-	return lb, nil
+	if lb.Bucket.BucketInfo.IpnsName != "" && ls.ipnsChanged != nil {
+		ls.ipnsChanged(bucket)
+	}
 }
 
 func (ls *ledgerStore) AssertBucketExits(bucket string) error {
@@ -205,9 +213,25 @@ func (ls *ledgerStore) DeleteBucket(bucket string) error {
 	if err != nil {
 		return err
 	}
+	return ls.deleteBucket(bucket)
+	//todo: remove from ipfs
+}
+
+func (ls *ledgerStore) deleteBucket(bucket string) error {
 	ls.mapLocker.Lock()
 	delete(ls.l.Buckets, bucket)
 	ls.mapLocker.Unlock()
+	if err := ls.resetUsage(bucket); err != nil {
+		return err
+	}
+	if err := ls.deleteIPNS(bucket); err != nil {
+		return err
+	}
+	if err := ls.deletePins(bucket, ""); err != nil {
+		return err
+	}
+	if err := ls.deleteTransactions(bucket); err != nil {
+		return err
+	}
 	return ls.ds.Delete(dsBucketKey.ChildString(bucket))
-	//todo: remove from ipfs
 }
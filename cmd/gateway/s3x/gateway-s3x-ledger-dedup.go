@@ -0,0 +1,276 @@
+package s3x
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+/* Design Notes
+---------------
+
+Internal functions should never claim or release locks.
+Any claiming or releasing of locks should be done in the public setter+getter functions.
+The reason for this is so that we can enable easy reuse of internal code.
+
+Objects with the same content share a data cid. The data reference index keeps a DataRef for
+every object with data, keyed by data hash, bucket and object, so the objects referencing a cid
+are found with a single prefix query, and the number of references is the number of keys.
+
+The index is maintained together with the usage of a bucket, which records the size and number of
+the distinct data cids in the bucket. When the usage of a bucket is computed again, the references
+of the bucket are indexed again as well, this also indexes buckets of ledgers created before the
+index existed. The references of a bucket are removed when its usage is reset.
+*/
+
+// GetDataRefs returns the references of all buckets to the data hash
+func (ls *ledgerStore) GetDataRefs(ctx context.Context, hash string) ([]DataRef, error) {
+	if err := ls.indexBuckets(ctx); err != nil {
+		return nil, err
+	}
+	//this only reads from the datastore, which have it's own synchronization, so no locking is needed.
+	indexed, err := ls.queryDataRefs(dataRefPrefix(hash, ""))
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]DataRef, 0, len(indexed))
+	for _, ref := range indexed {
+		refs = append(refs, ref.DataRef)
+	}
+	return refs, nil
+}
+
+// GetUniqueData returns the total size and the number of the distinct data cids of all buckets
+func (ls *ledgerStore) GetUniqueData(ctx context.Context) (int64, uint64, error) {
+	if err := ls.indexBuckets(ctx); err != nil {
+		return 0, 0, err
+	}
+	refs, err := ls.queryDataRefs(dsDataRefKey)
+	if err != nil {
+		return 0, 0, err
+	}
+	var (
+		size int64
+		seen = make(map[string]bool)
+	)
+	for _, ref := range refs {
+		if seen[ref.hash] {
+			continue
+		}
+		seen[ref.hash] = true
+		size += ref.Size_
+	}
+	return size, uint64(len(seen)), nil
+}
+
+// indexBuckets makes sure that the references of all buckets are indexed
+func (ls *ledgerStore) indexBuckets(ctx context.Context) error {
+	names, err := ls.GetBucketNames()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		// loading the usage computes it, and indexes the bucket, if it is not recorded
+		_, err := ls.GetBucketUsage(ctx, name)
+		if err != nil && err != ErrLedgerBucketDoesNotExist {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataRefWriter writes changes of the data reference index to w, which is either the datastore,
+// or a batch that saves them together with the bucket root. The datastore does not see the
+// writes of a batch before it is committed, so the references written so far are tracked to
+// count the references of a bucket.
+type dataRefWriter struct {
+	ds     datastore.Datastore
+	w      datastore.Write
+	staged map[datastore.Key]bool // written references, false if they were removed
+}
+
+// dataRefWriter returns a writer of the data reference index to w
+func (ls *ledgerStore) dataRefWriter(w datastore.Write) *dataRefWriter {
+	return &dataRefWriter{
+		ds:     ls.ds,
+		w:      w,
+		staged: make(map[datastore.Key]bool),
+	}
+}
+
+// add adds the data of an object to the index, and accounts for it in the unique usage of the bucket
+func (rw *dataRefWriter) add(bucket, object string, obj *Object, u *BucketUsage) error {
+	hash := obj.GetDataHash()
+	if hash == "" {
+		// zero-byte objects are stored without data
+		return nil
+	}
+	n, err := rw.count(dataRefPrefix(hash, bucket))
+	if err != nil {
+		return err
+	}
+	ref := &DataRef{
+		Bucket: bucket,
+		Object: object,
+		Size_:  obj.ObjectInfo.GetSize_(),
+	}
+	data, err := ref.Marshal()
+	if err != nil {
+		return err
+	}
+	key := dataRefKey(hash, bucket, object)
+	if err := rw.w.Put(key, data); err != nil {
+		return err
+	}
+	rw.staged[key] = true
+	if n == 0 {
+		u.UniqueSize += ref.Size_
+		u.UniqueCount++
+	}
+	return nil
+}
+
+// remove removes the data of an object from the index, and from the unique usage of the bucket
+// if no other object in the bucket references it
+func (rw *dataRefWriter) remove(bucket, object string, obj *Object, u *BucketUsage) error {
+	hash := obj.GetDataHash()
+	if hash == "" {
+		return nil
+	}
+	key := dataRefKey(hash, bucket, object)
+	// batches do not report missing keys on delete, so the reference is looked up first
+	exists, ok := rw.staged[key]
+	if !ok {
+		var err error
+		if exists, err = rw.ds.Has(key); err != nil {
+			return err
+		}
+	}
+	if !exists {
+		return nil
+	}
+	if err := rw.w.Delete(key); err != nil {
+		return err
+	}
+	rw.staged[key] = false
+	n, err := rw.count(dataRefPrefix(hash, bucket))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		u.UniqueSize -= obj.ObjectInfo.GetSize_()
+		if u.UniqueCount > 0 {
+			u.UniqueCount--
+		}
+	}
+	return nil
+}
+
+// count returns the number of references with the prefix, including the written references
+func (rw *dataRefWriter) count(prefix datastore.Key) (int, error) {
+	rs, err := rw.ds.Query(query.Query{
+		// the separator prevents matching hashes and buckets that start with the same name
+		Prefix:   prefix.String() + "/",
+		KeysOnly: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	entries, err := rs.Rest()
+	if err != nil {
+		return 0, err
+	}
+	keys := make(map[datastore.Key]bool, len(entries))
+	for _, e := range entries {
+		keys[datastore.NewKey(e.Key)] = true
+	}
+	for key, exists := range rw.staged {
+		if prefix.IsAncestorOf(key) {
+			keys[key] = exists
+		}
+	}
+	n := 0
+	for _, exists := range keys {
+		if exists {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// deleteDataRefs removes all references of the bucket from the index. The index is keyed by
+// data hash first, so all references are queried, this is only done when usage is reset.
+func (ls *ledgerStore) deleteDataRefs(bucket string) error {
+	rs, err := ls.ds.Query(query.Query{
+		Prefix:   dsDataRefKey.String() + "/",
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	entries, err := rs.Rest()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		key := datastore.NewKey(e.Key)
+		// the namespaces of a key are the index prefix, data hash, bucket and object
+		if ns := key.Namespaces(); len(ns) < 3 || ns[2] != bucket {
+			continue
+		}
+		if err := ls.ds.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexedRef is a reference with the data hash of its key
+type indexedRef struct {
+	DataRef
+	hash string
+}
+
+// queryDataRefs returns the references with the prefix
+func (ls *ledgerStore) queryDataRefs(prefix datastore.Key) ([]indexedRef, error) {
+	rs, err := ls.ds.Query(query.Query{
+		Prefix: prefix.String() + "/",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+	var refs []indexedRef
+	for r := range rs.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		ref := indexedRef{}
+		if err := ref.Unmarshal(r.Value); err != nil {
+			return nil, err
+		}
+		if ns := datastore.NewKey(r.Key).Namespaces(); len(ns) > 1 {
+			ref.hash = ns[1]
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// dataRefKey returns the datastore key of the reference of an object to a data hash, object
+// names are encoded since they may contain the datastore key separator
+func dataRefKey(hash, bucket, object string) datastore.Key {
+	return dataRefPrefix(hash, bucket).ChildString(base64.RawURLEncoding.EncodeToString([]byte(object)))
+}
+
+// dataRefPrefix returns the datastore key prefix of the references to a data hash,
+// from the bucket, or from all buckets if bucket is empty
+func dataRefPrefix(hash, bucket string) datastore.Key {
+	prefix := dsDataRefKey.ChildString(hash)
+	if bucket == "" {
+		return prefix
+	}
+	return prefix.ChildString(bucket)
+}
@@ -0,0 +1,266 @@
+package s3x
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/* Design Notes
+---------------
+
+Internal functions should never claim or release locks.
+Any claiming or releasing of locks should be done in the public setter+getter functions.
+The reason for this is so that we can enable easy reuse of internal code.
+*/
+
+// Fsck walks every bucket root, object protocol buffer and object data dag referenced by the ledger,
+// and reports cids that can not be resolved or data dags whose size does not match the object info.
+func (ls *ledgerStore) Fsck(ctx context.Context, req *FsckRequest) (*FsckResponse, error) {
+	var buckets []string
+	if req.GetBucket() != "" {
+		buckets = []string{req.GetBucket()}
+	} else {
+		names, err := ls.GetBucketNames()
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(names)
+		buckets = names
+	}
+	resp := &FsckResponse{}
+	for _, bucket := range buckets {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := ls.fsckBucketLocked(ctx, bucket, req, resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// FsckBucket checks that the root of the bucket can be resolved,
+// a nil issue is returned if the bucket is consistent.
+func (ls *ledgerStore) FsckBucket(ctx context.Context, bucket string, repair, remove bool) (*FsckIssue, error) {
+	if repair {
+		defer ls.locker.write(bucket)()
+	} else {
+		defer ls.locker.read(bucket)()
+	}
+	b, err := ls.getBucketRequired(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return ls.fsckBucketRoot(ctx, bucket, b, repair, remove)
+}
+
+// FsckObject checks that the object and its data can be resolved and that the data size is consistent,
+// no issues are returned if the object is consistent.
+func (ls *ledgerStore) FsckObject(ctx context.Context, bucket, object string, repair, remove bool) ([]FsckIssue, error) {
+	if repair {
+		defer ls.locker.write(bucket)()
+	} else {
+		defer ls.locker.read(bucket)()
+	}
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	objHash, ok := b.Bucket.Objects[object]
+	if !ok {
+		return nil, ErrLedgerObjectDoesNotExist
+	}
+	issues, changed := ls.fsckObject(ctx, b.Bucket, bucket, object, objHash, repair, remove)
+	if changed {
+		if _, err := ls.saveBucket(ctx, bucket, b.Bucket); err != nil {
+			return nil, err
+		}
+		if err := ls.resetUsage(bucket); err != nil {
+			return nil, err
+		}
+	}
+	return issues, nil
+}
+
+func (ls *ledgerStore) fsckBucketLocked(ctx context.Context, bucket string, req *FsckRequest, resp *FsckResponse) error {
+	if req.GetRepair() {
+		defer ls.locker.write(bucket)()
+	} else {
+		defer ls.locker.read(bucket)()
+	}
+	b, err := ls.getBucketRequired(bucket)
+	if err != nil {
+		return err
+	}
+	resp.Buckets++
+	issue, err := ls.fsckBucketRoot(ctx, bucket, b, req.GetRepair(), req.GetRemove())
+	if err != nil {
+		return err
+	}
+	if issue != nil {
+		resp.Issues = append(resp.Issues, *issue)
+		if b.Bucket == nil {
+			// the objects can not be checked without the bucket root
+			return nil
+		}
+	}
+	var names []string
+	for name := range b.Bucket.Objects {
+		if strings.HasPrefix(name, req.GetPrefix()) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	changed := false
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		resp.Objects++
+		issues, c := ls.fsckObject(ctx, b.Bucket, bucket, name, b.Bucket.Objects[name], req.GetRepair(), req.GetRemove())
+		resp.Issues = append(resp.Issues, issues...)
+		changed = changed || c
+	}
+	if !changed {
+		return nil
+	}
+	if _, err := ls.saveBucket(ctx, bucket, b.Bucket); err != nil {
+		return err
+	}
+	// repairs change sizes and remove objects, so the usage is computed again
+	return ls.resetUsage(bucket)
+}
+
+// fsckBucketRoot checks that the root of a bucket can be resolved, if it can not be resolved and
+// the bucket is removed, the returned issue is marked as repaired and b.Bucket is left nil.
+func (ls *ledgerStore) fsckBucketRoot(ctx context.Context, bucket string, b *LedgerBucketEntry, repair, remove bool) (*FsckIssue, error) {
+	err := b.ensureCache(ctx, ls.dag)
+	if err == nil {
+		return nil, nil
+	}
+	issue := &FsckIssue{
+		Type:   FsckIssueType_BUCKET_UNRESOLVABLE,
+		Bucket: bucket,
+		Hash:   b.IpfsHash,
+		Detail: err.Error(),
+	}
+	if !repair {
+		return issue, nil
+	}
+	if ls.repin(ctx, b.IpfsHash, issue) && b.ensureCache(ctx, ls.dag) == nil {
+		issue.Repaired = true
+		return issue, nil
+	}
+	if remove {
+		if err := ls.deleteBucket(bucket); err != nil {
+			return nil, err
+		}
+		issue.Repaired = true
+		issue.Detail = "removed dangling bucket: " + issue.Detail
+	}
+	return issue, nil
+}
+
+// fsckObject checks a single object of a loaded bucket, changes are made to b.Objects
+// when repairing, and the second return value reports whether b has to be saved.
+func (ls *ledgerStore) fsckObject(ctx context.Context, b *Bucket, bucket, object, objHash string, repair, remove bool) ([]FsckIssue, bool) {
+	var issues []FsckIssue
+	obj, err := ipfsObject(ctx, ls.dag, objHash)
+	if err != nil {
+		issue := FsckIssue{
+			Type:   FsckIssueType_OBJECT_UNRESOLVABLE,
+			Bucket: bucket,
+			Object: object,
+			Hash:   objHash,
+			Detail: err.Error(),
+		}
+		if !repair || !ls.repin(ctx, objHash, &issue) {
+			changed := ls.removeDangling(b, object, &issue, repair && remove)
+			return append(issues, issue), changed
+		}
+		if obj, err = ipfsObject(ctx, ls.dag, objHash); err != nil {
+			changed := ls.removeDangling(b, object, &issue, remove)
+			return append(issues, issue), changed
+		}
+		// the object is resolvable again, so its data is checked as well
+		issue.Repaired = true
+		issues = append(issues, issue)
+	}
+	size, err := ls.dataSize(ctx, obj)
+	if err != nil {
+		issue := FsckIssue{
+			Type:   FsckIssueType_DATA_UNRESOLVABLE,
+			Bucket: bucket,
+			Object: object,
+			Hash:   obj.GetDataHash(),
+			Detail: err.Error(),
+		}
+		if !repair || !ls.repin(ctx, obj.GetDataHash(), &issue) {
+			changed := ls.removeDangling(b, object, &issue, repair && remove)
+			return append(issues, issue), changed
+		}
+		if size, err = ls.dataSize(ctx, obj); err != nil {
+			changed := ls.removeDangling(b, object, &issue, remove)
+			return append(issues, issue), changed
+		}
+		issue.Repaired = true
+		issues = append(issues, issue)
+	}
+	if size == obj.ObjectInfo.GetSize_() {
+		return issues, false
+	}
+	issue := FsckIssue{
+		Type:   FsckIssueType_SIZE_MISMATCH,
+		Bucket: bucket,
+		Object: object,
+		Hash:   obj.GetDataHash(),
+		Detail: fmt.Sprintf("object info size is %d, but data size is %d", obj.ObjectInfo.GetSize_(), size),
+	}
+	if !repair {
+		return append(issues, issue), false
+	}
+	// the data dag is content addressed, so it is the source of truth
+	obj.ObjectInfo.Size_ = size
+	newHash, err := ipfsSave(ctx, ls.dag, obj)
+	if err != nil {
+		issue.Detail += ", failed to correct size: " + err.Error()
+		return append(issues, issue), false
+	}
+	b.Objects[object] = newHash
+	issue.Repaired = true
+	return append(issues, issue), true
+}
+
+// repin attempts to retrieve the hash from the network, failures are recorded in the issue detail.
+func (ls *ledgerStore) repin(ctx context.Context, h string, issue *FsckIssue) bool {
+	ok, err := ipfsPersist(ctx, ls.dag, h)
+	if err != nil {
+		issue.Detail += ", re-pin failed: " + err.Error()
+		return false
+	}
+	if !ok {
+		issue.Detail += ", re-pin failed"
+	}
+	return ok
+}
+
+// removeDangling removes an object that can not be repaired from the bucket if remove is set
+func (ls *ledgerStore) removeDangling(b *Bucket, object string, issue *FsckIssue, remove bool) bool {
+	if !remove {
+		return false
+	}
+	delete(b.Objects, object)
+	issue.Repaired = true
+	issue.Detail = "removed dangling object: " + issue.Detail
+	return true
+}
+
+// dataSize returns the size of the object data, zero-byte objects are stored without data.
+func (ls *ledgerStore) dataSize(ctx context.Context, obj *Object) (int64, error) {
+	if obj.GetDataHash() == "" {
+		return 0, nil
+	}
+	return ipfsDagSize(ctx, ls.dag, obj.GetDataHash())
+}
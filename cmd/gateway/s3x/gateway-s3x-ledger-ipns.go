@@ -0,0 +1,120 @@
+package s3x
+
+import (
+	"context"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+)
+
+/* Design Notes
+---------------
+
+Internal functions should never claim or release locks.
+Any claiming or releasing of locks should be done in the public setter+getter functions.
+The reason for this is so that we can enable easy reuse of internal code.
+
+The IPNS publishing state of a bucket is private to the gateway, so it is kept in the datastore.
+The IPNS name is also saved in the BucketInfo of the bucket root, which makes it visible
+to anyone who resolves the root, and tells saveBucket to schedule a publish.
+*/
+
+// GetBucketIPNS returns the IPNS publishing state of the bucket, or nil if publishing is disabled
+func (ls *ledgerStore) GetBucketIPNS(bucket string) (*BucketIPNS, error) {
+	defer ls.locker.read(bucket)()
+	if err := ls.assertBucketExits(bucket); err != nil {
+		return nil, err
+	}
+	return ls.getIPNS(bucket)
+}
+
+// GetIPNSBuckets returns the names of all buckets that are published to IPNS
+func (ls *ledgerStore) GetIPNSBuckets() ([]string, error) {
+	//this only reads from the datastore, which have it's own synchronization, so no locking is needed.
+	return ls.keyNames(dsIPNSKey)
+}
+
+// EnableBucketIPNS starts publishing the bucket to the IPNS name, the publishing state
+// is kept if the name and mode did not change.
+func (ls *ledgerStore) EnableBucketIPNS(ctx context.Context, bucket, name string, mode IPNSMode) (*BucketIPNS, error) {
+	defer ls.locker.write(bucket)()
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	state, err := ls.getIPNS(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || state.Name != name || state.Mode != mode {
+		state = &BucketIPNS{Bucket: bucket, Name: name, Mode: mode}
+	}
+	if err := ls.putIPNS(state); err != nil {
+		return nil, err
+	}
+	// changing the ipns name changes the bucket root, which schedules a publish
+	b.Bucket.BucketInfo.IpnsName = name
+	if _, err := ls.saveBucket(ctx, bucket, b.Bucket); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// DisableBucketIPNS stops publishing the bucket to IPNS
+func (ls *ledgerStore) DisableBucketIPNS(ctx context.Context, bucket string) error {
+	defer ls.locker.write(bucket)()
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if err := ls.deleteIPNS(bucket); err != nil {
+		return err
+	}
+	if b.Bucket.BucketInfo.IpnsName == "" {
+		return nil
+	}
+	b.Bucket.BucketInfo.IpnsName = ""
+	_, err = ls.saveBucket(ctx, bucket, b.Bucket)
+	return err
+}
+
+// SetIPNSPublished records the path that was published to the IPNS name,
+// it is ignored if publishing was disabled or the name changed in the meantime.
+func (ls *ledgerStore) SetIPNSPublished(bucket, name, path string, at time.Time) error {
+	defer ls.locker.write(bucket)()
+	state, err := ls.getIPNS(bucket)
+	if err != nil || state == nil || state.Name != name {
+		return err
+	}
+	state.Published = path
+	state.PublishedAt = at
+	return ls.putIPNS(state)
+}
+
+func (ls *ledgerStore) getIPNS(bucket string) (*BucketIPNS, error) {
+	data, err := ls.ds.Get(dsIPNSKey.ChildString(bucket))
+	if err == datastore.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &BucketIPNS{}
+	return state, state.Unmarshal(data)
+}
+
+func (ls *ledgerStore) putIPNS(state *BucketIPNS) error {
+	data, err := state.Marshal()
+	if err != nil {
+		return err
+	}
+	return ls.ds.Put(dsIPNSKey.ChildString(state.Bucket), data)
+}
+
+func (ls *ledgerStore) deleteIPNS(bucket string) error {
+	err := ls.ds.Delete(dsIPNSKey.ChildString(bucket))
+	if err == datastore.ErrNotFound {
+		return nil
+	}
+	return err
+}
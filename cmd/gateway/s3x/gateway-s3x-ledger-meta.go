@@ -0,0 +1,40 @@
+package s3x
+
+import (
+	"github.com/ipfs/go-datastore"
+)
+
+/* Design Notes
+---------------
+
+Meta objects are minio's own configuration files, such as bucket quotas.
+They are private to the gateway, so they are kept in the datastore instead of ipfs,
+and they are not protected by bucket locks since the datastore has it's own synchronization.
+*/
+
+// GetMeta returns the meta object at the given path
+func (ls *ledgerStore) GetMeta(path string) ([]byte, error) {
+	data, err := ls.ds.Get(dsMetaKey.ChildString(path))
+	if err == datastore.ErrNotFound {
+		return nil, ErrLedgerObjectDoesNotExist
+	}
+	return data, err
+}
+
+// PutMeta saves the meta object at the given path
+func (ls *ledgerStore) PutMeta(path string, data []byte) error {
+	return ls.ds.Put(dsMetaKey.ChildString(path), data)
+}
+
+// DeleteMeta removes the meta object at the given path
+func (ls *ledgerStore) DeleteMeta(path string) error {
+	key := dsMetaKey.ChildString(path)
+	ok, err := ls.ds.Has(key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLedgerObjectDoesNotExist
+	}
+	return ls.ds.Delete(key)
+}
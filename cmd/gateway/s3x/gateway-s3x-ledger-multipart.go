@@ -18,17 +18,20 @@ The reason for this is so that we can enable easy reuse of internal code.
 /////////////////////
 
 // AbortMultipartUpload is used to abort a multipart upload
-func (ls *ledgerStore) AbortMultipartUpload(bucket, multipartID string) error {
+func (ls *ledgerStore) AbortMultipartUpload(bucket, object, multipartID string) error {
 	err := ls.AssertBucketExits(bucket)
 	if err != nil {
 		return err
 	}
+	defer ls.plocker.write(multipartID)()
+	if _, err := ls.getMultipartOf(bucket, object, multipartID); err != nil {
+		return err
+	}
 	return ls.DeleteMultipartID(multipartID)
 }
 
 // NewMultipartUpload is used to store the initial start of a multipart upload request
-func (ls *ledgerStore) NewMultipartUpload(multipartID string, info *ObjectInfo) error {
-	bucket := info.GetBucket()
+func (ls *ledgerStore) NewMultipartUpload(bucket, multipartID string, info *ObjectInfo) error {
 	err := ls.assertBucketExits(bucket)
 	if err != nil {
 		return err
@@ -61,7 +64,7 @@ func (ls *ledgerStore) PutObjectPart(bucketName, objectName, multipartID string,
 	}
 
 	defer ls.plocker.write(multipartID)()
-	m, err := ls.getMultipartLoaded(multipartID)
+	m, err := ls.getMultipartOf(bucketName, objectName, multipartID)
 	if err != nil {
 		return err
 	}
@@ -89,9 +92,9 @@ func (ls *ledgerStore) PutObjectPart(bucketName, objectName, multipartID string,
 
 // GetObjectParts is used to return multipart upload parts,
 // returned unlock function must be used after map iteration is done.
-func (ls *ledgerStore) GetObjectDetails(id string) (*MultipartUpload, func(), error) {
+func (ls *ledgerStore) GetObjectDetails(bucket, object, id string) (*MultipartUpload, func(), error) {
 	unlock := ls.plocker.read(id)
-	m, err := ls.getMultipartLoaded(id)
+	m, err := ls.getMultipartOf(bucket, object, id)
 	if err != nil {
 		unlock()
 		return nil, nil, err
@@ -135,6 +138,19 @@ func (ls *ledgerStore) assertValidUploadID(uploadID string) error {
 	return err
 }
 
+// getMultipartOf returns the multipart upload if it is an upload of the object in the bucket,
+// the upload ids of other buckets (and so of other tenants) are not valid for it
+func (ls *ledgerStore) getMultipartOf(bucket, object, uploadID string) (*MultipartUpload, error) {
+	m, err := ls.getMultipartLoaded(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if m.GetObjectInfo().GetBucket() != bucket || m.GetObjectInfo().GetName() != object {
+		return nil, ErrInvalidUploadID
+	}
+	return m, nil
+}
+
 func (ls *ledgerStore) getMultipartLoaded(uploadID string) (*MultipartUpload, error) {
 	m, err := ls.getMultipartNilable(uploadID)
 	if err != nil {
@@ -0,0 +1,362 @@
+package s3x
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+/* Design Notes
+---------------
+
+Internal functions should never claim or release locks.
+Any claiming or releasing of locks should be done in the public setter+getter functions.
+The reason for this is so that we can enable easy reuse of internal code.
+
+Pin jobs are the persistent queue of the pinReplicator. They are kept in the datastore,
+keyed by bucket, object and target, so a job is replaced when its object is overwritten.
+Finished jobs are kept to report the pin status of objects until the object is removed.
+The pinLocker protects jobs from being overwritten by the results of a replaced job.
+
+With a crdt datastore the jobs are replicated as well, so every gateway processes them.
+TemporalX targets persist a hash once, however many gateways request it. Pinning services
+create a pin request for every request, so the pinReplicator looks up the existing pins of
+a hash before requesting it. Gateways that process a job at the same time can still both
+request a hash, the duplicate pin requests are left to be removed on the pinning service.
+
+Jobs that are queued or pinning are also indexed by their next attempt, so the due jobs are
+found without reading the finished jobs, which are kept until their objects are removed.
+A job and its index entry are written in a single batch.
+
+The tokens of pinning services are saved encrypted by the pinReplicator, since the
+datastore is replicated to every gateway sharing the ledger.
+*/
+
+// GetPinTargets returns the remote pin targets of the bucket, or nil if it has none
+func (ls *ledgerStore) GetPinTargets(bucket string) (*BucketPinTargets, error) {
+	defer ls.locker.read(bucket)()
+	if err := ls.assertBucketExits(bucket); err != nil {
+		return nil, err
+	}
+	return ls.getPinTargets(bucket)
+}
+
+// SetPinTargets sets the remote pin targets of a bucket. All objects in the bucket are queued to be
+// pinned to new or changed targets, and the jobs of removed targets are deleted.
+func (ls *ledgerStore) SetPinTargets(ctx context.Context, targets *BucketPinTargets) error {
+	bucket := targets.GetBucket()
+	defer ls.locker.write(bucket)()
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	old, err := ls.getPinTargets(bucket)
+	if err != nil {
+		return err
+	}
+	removed := make(map[string]PinTarget)
+	for _, t := range old.GetTargets() {
+		removed[t.Name] = t
+	}
+	var queue []string
+	for _, t := range targets.GetTargets() {
+		if o, ok := removed[t.Name]; !ok || o.Type != t.Type || o.Endpoint != t.Endpoint {
+			queue = append(queue, t.Name)
+		}
+		delete(removed, t.Name)
+	}
+	if len(targets.GetTargets()) == 0 {
+		err = ls.ds.Delete(dsPinTargetsKey.ChildString(bucket))
+		if err == datastore.ErrNotFound {
+			err = nil
+		}
+	} else {
+		var data []byte
+		if data, err = targets.Marshal(); err == nil {
+			err = ls.ds.Put(dsPinTargetsKey.ChildString(bucket), data)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	ls.pinLocker.Lock()
+	defer ls.pinLocker.Unlock()
+	jobs, err := ls.queryPinJobs(pinJobPrefix(bucket, ""))
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if _, ok := removed[job.Target]; ok {
+			if err := ls.deletePinJob(job); err != nil {
+				return err
+			}
+		}
+	}
+	for _, target := range queue {
+		for object, hash := range b.Bucket.Objects {
+			if err := ls.putPinJob(newPinJob(bucket, object, hash, target)); err != nil {
+				return err
+			}
+		}
+	}
+	if len(queue) != 0 && ls.pinsQueued != nil {
+		ls.pinsQueued()
+	}
+	return nil
+}
+
+// GetPinJobs returns the pin jobs of the object, or of all objects in the bucket if object is empty
+func (ls *ledgerStore) GetPinJobs(bucket, object string) ([]*PinJob, error) {
+	defer ls.locker.read(bucket)()
+	if err := ls.assertBucketExits(bucket); err != nil {
+		return nil, err
+	}
+	return ls.queryPinJobs(pinJobPrefix(bucket, object))
+}
+
+// GetAllPinJobs returns the pin jobs of all buckets
+func (ls *ledgerStore) GetAllPinJobs() ([]*PinJob, error) {
+	//this only reads from the datastore, which have it's own synchronization, so no locking is needed.
+	return ls.queryPinJobs(dsPinJobKey)
+}
+
+// GetDuePinJobs returns up to max jobs that are queued or pinning, and due for their next attempt,
+// ordered by their next attempt
+func (ls *ledgerStore) GetDuePinJobs(now time.Time, max int) ([]*PinJob, error) {
+	//this only reads from the datastore, which have it's own synchronization, so no locking is needed.
+	rs, err := ls.ds.Query(query.Query{
+		Prefix:   dsPinDueKey.String() + "/",
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := rs.Rest()
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, e := range entries {
+		// the namespaces of a key are the index prefix, next attempt, bucket, object and target
+		ns := datastore.NewKey(e.Key).Namespaces()
+		if len(ns) != 5 || ns[1] > pinDueTime(now) {
+			continue
+		}
+		keys = append(keys, e.Key)
+	}
+	sort.Strings(keys)
+	var due []*PinJob
+	for _, key := range keys {
+		if len(due) == max {
+			break
+		}
+		ns := datastore.NewKey(key).Namespaces()
+		job, err := ls.getPinJob(dsPinJobKey.Child(datastore.KeyWithNamespaces(ns[2:])))
+		if err != nil {
+			return nil, err
+		}
+		// the entry may be outdated if the job changed after the query
+		if job != nil && job.pending() && pinDueTime(job.NextAttempt) == ns[1] {
+			due = append(due, job)
+		}
+	}
+	return due, nil
+}
+
+// UpdatePinJob saves the result of processing a pin job,
+// it is ignored if the job was deleted or replaced in the meantime.
+func (ls *ledgerStore) UpdatePinJob(job *PinJob) error {
+	ls.pinLocker.Lock()
+	defer ls.pinLocker.Unlock()
+	current, err := ls.getPinJob(pinJobKey(job.Bucket, job.Object, job.Target))
+	if err != nil || current == nil || current.Hash != job.Hash {
+		return err
+	}
+	return ls.putPinJob(job)
+}
+
+func (ls *ledgerStore) getPinTargets(bucket string) (*BucketPinTargets, error) {
+	data, err := ls.ds.Get(dsPinTargetsKey.ChildString(bucket))
+	if err == datastore.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	targets := &BucketPinTargets{}
+	return targets, targets.Unmarshal(data)
+}
+
+// queuePins queues the object to be pinned to all targets of the bucket
+func (ls *ledgerStore) queuePins(bucket, object, hash string) error {
+	targets, err := ls.getPinTargets(bucket)
+	if err != nil || targets == nil {
+		return err
+	}
+	ls.pinLocker.Lock()
+	defer ls.pinLocker.Unlock()
+	for _, t := range targets.Targets {
+		if err := ls.putPinJob(newPinJob(bucket, object, hash, t.Name)); err != nil {
+			return err
+		}
+	}
+	if ls.pinsQueued != nil {
+		ls.pinsQueued()
+	}
+	return nil
+}
+
+// deletePins deletes the pin jobs of the object, or of all objects and the pin targets of the bucket if object is empty
+func (ls *ledgerStore) deletePins(bucket, object string) error {
+	if object == "" {
+		err := ls.ds.Delete(dsPinTargetsKey.ChildString(bucket))
+		if err != nil && err != datastore.ErrNotFound {
+			return err
+		}
+	}
+	ls.pinLocker.Lock()
+	defer ls.pinLocker.Unlock()
+	jobs, err := ls.queryPinJobs(pinJobPrefix(bucket, object))
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if err := ls.deletePinJob(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getPinJob returns the job with the key, or nil if it does not exist
+func (ls *ledgerStore) getPinJob(key datastore.Key) (*PinJob, error) {
+	data, err := ls.ds.Get(key)
+	if err == datastore.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	job := &PinJob{}
+	return job, job.Unmarshal(data)
+}
+
+// putPinJob saves the job, and moves its entry in the index of pending jobs
+func (ls *ledgerStore) putPinJob(job *PinJob) error {
+	key := pinJobKey(job.Bucket, job.Object, job.Target)
+	old, err := ls.getPinJob(key)
+	if err != nil {
+		return err
+	}
+	data, err := job.Marshal()
+	if err != nil {
+		return err
+	}
+	batch, err := ls.ds.Batch()
+	if err != nil {
+		return err
+	}
+	if old != nil && old.pending() {
+		if err := batch.Delete(pinDueKey(old)); err != nil {
+			return err
+		}
+	}
+	if job.pending() {
+		if err := batch.Put(pinDueKey(job), []byte{}); err != nil {
+			return err
+		}
+	}
+	if err := batch.Put(key, data); err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// deletePinJob deletes the job and its entry in the index of pending jobs
+func (ls *ledgerStore) deletePinJob(job *PinJob) error {
+	batch, err := ls.ds.Batch()
+	if err != nil {
+		return err
+	}
+	if job.pending() {
+		if err := batch.Delete(pinDueKey(job)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Delete(pinJobKey(job.Bucket, job.Object, job.Target)); err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+func (ls *ledgerStore) queryPinJobs(prefix datastore.Key) ([]*PinJob, error) {
+	rs, err := ls.ds.Query(query.Query{
+		// the separator prevents matching buckets and objects that start with the same name
+		Prefix: prefix.String() + "/",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+	var jobs []*PinJob
+	for r := range rs.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		job := &PinJob{}
+		if err := job.Unmarshal(r.Value); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func newPinJob(bucket, object, hash, target string) *PinJob {
+	now := time.Now().UTC()
+	return &PinJob{
+		Bucket:      bucket,
+		Object:      object,
+		Hash:        hash,
+		Target:      target,
+		NextAttempt: now,
+		Updated:     now,
+	}
+}
+
+// pending returns whether the job is queued or pinning, and has to be attempted again
+func (job *PinJob) pending() bool {
+	return job.State == PinState_QUEUED || job.State == PinState_PINNING
+}
+
+// pinDueKey returns the key of the job in the index of pending jobs
+func pinDueKey(job *PinJob) datastore.Key {
+	ns := pinJobKey(job.Bucket, job.Object, job.Target).Namespaces()
+	return dsPinDueKey.ChildString(pinDueTime(job.NextAttempt)).Child(datastore.KeyWithNamespaces(ns[1:]))
+}
+
+// pinDueTime formats the time of an attempt, so that the index keys sort by time
+func pinDueTime(t time.Time) string {
+	return fmt.Sprintf("%020d", t.UnixNano())
+}
+
+// pinJobKey returns the datastore key of a pin job, object names are
+// encoded since they may contain the datastore key separator
+func pinJobKey(bucket, object, target string) datastore.Key {
+	return pinJobPrefix(bucket, object).ChildString(target)
+}
+
+// pinJobPrefix returns the datastore key prefix of the pin jobs of the object,
+// or of all objects in the bucket if object is empty
+func pinJobPrefix(bucket, object string) datastore.Key {
+	prefix := dsPinJobKey.ChildString(bucket)
+	if object == "" {
+		return prefix
+	}
+	return prefix.ChildString(base64.RawURLEncoding.EncodeToString([]byte(object)))
+}
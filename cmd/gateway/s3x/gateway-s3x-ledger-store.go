@@ -22,6 +22,16 @@ var (
 	dsPrefix    = datastore.NewKey("ledgerRoot")
 	dsBucketKey = datastore.NewKey("b") //bucket name to ipfsHash of LedgerBucketEntry
 	dsPartKey   = datastore.NewKey("p") //part ID to MultipartUpload
+	dsUsageKey  = datastore.NewKey("u") //bucket name to BucketUsage
+	dsMetaKey   = datastore.NewKey("m") //minio meta object path to data
+	dsIPNSKey   = datastore.NewKey("n") //bucket name to BucketIPNS
+
+	dsPinTargetsKey = datastore.NewKey("t") //bucket name to BucketPinTargets
+	dsPinJobKey     = datastore.NewKey("r") //bucket name, encoded object name and target name to PinJob
+	dsPinDueKey     = datastore.NewKey("q") //next attempt, bucket name, encoded object name and target name of pending PinJobs
+
+	dsTransactionKey = datastore.NewKey("x") //bucket name and transaction ID to Transaction
+	dsDataRefKey     = datastore.NewKey("d") //data hash, bucket name and encoded object name to DataRef
 )
 
 // ledgerStore is an internal bookkeeper that
@@ -38,11 +48,18 @@ type ledgerStore struct {
 	locker     bucketLocker //a locker to protect buckets from concurrent access (per bucket)
 	plocker    bucketLocker //a locker to protect MultipartUploads from concurrent access (per upload ID)
 	mapLocker  sync.Mutex   //a lock to protect the l.Buckets map from concurrent access
+	tlocker    bucketLocker //a locker to protect Transactions from concurrent updates (per transaction ID)
 	pmapLocker sync.Mutex   //a lock to protect the l.MultipartUploads map from concurrent access
+	pinLocker  sync.Mutex   //a lock to protect pin jobs from concurrent updates
 
 	cleanup []func() error //a list of functions to call before we close the backing database.
 
 	oh OperationHelper // hook operations that can be called after an operation is finished
+
+	ipnsChanged func(bucket string) // called after the root of a bucket that is published to IPNS is saved, must not block
+	pinsQueued  func()              // called after pin jobs are queued, must not block
+	// called after an object is saved, or removed with a nil obj, must not block
+	objectChanged func(ctx context.Context, bucket, object string, obj *Object, objHash, bucketHash string)
 }
 
 func newLedgerStore(ds datastore.Batching, dag pb.NodeAPIClient) (*ledgerStore, error) {
@@ -108,6 +125,10 @@ func (ls *ledgerStore) ObjectData(ctx context.Context, bucket, object string) ([
 	if err != nil {
 		return nil, err
 	}
+	if obj.GetDataHash() == "" {
+		// zero-byte object
+		return []byte{}, nil
+	}
 	return ipfsBytes(ctx, ls.dag, obj.GetDataHash())
 }
 
@@ -140,8 +161,14 @@ func (ls *ledgerStore) removeObjects(ctx context.Context, bucket string, objects
 	if b.Bucket.Objects == nil {
 		return objects, nil
 	}
+	u, err := ls.getUsage(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
 
 	missing := []string{}
+	removed := []string{}
+	olds := make(map[string]*Object, len(objects))
 	for _, o := range objects {
 		_, ok := b.Bucket.Objects[o]
 		if !ok {
@@ -155,16 +182,35 @@ func (ls *ledgerStore) removeObjects(ctx context.Context, bucket string, objects
 			return nil, err
 		}
 
-		if err := ls.oh.CallRemoveObjectHandler(ctx, bucket, obj, o); err != nil {
+		if err := ls.oh.CallRemoveObjectHandler(ctx, tenantBucket(bucket), obj, o); err != nil {
 			// TODO: remove bucket just created from ledger
 			log.Println("error while calling lambda in RemoveObject ")
 			return nil, err
 		}
 
 		delete(b.Bucket.Objects, o)
+		removed = append(removed, o)
+		olds[o] = obj
+		u.remove(obj.ObjectInfo.GetSize_())
+		if err := ls.deletePins(bucket, o); err != nil {
+			return nil, err
+		}
 	}
-	_, err = ls.saveBucket(ctx, bucket, b.Bucket)
-	return missing, err
+	lb, err := ls.commitBucket(ctx, bucket, b.Bucket, func(batch datastore.Batch) error {
+		if err := ls.updateDataRefs(ls.dataRefWriter(batch), bucket, u, olds, nil); err != nil {
+			return err
+		}
+		return putUsage(batch, bucket, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ls.objectChanged != nil {
+		for _, o := range removed {
+			ls.objectChanged(ctx, bucket, o, nil, "", lb.IpfsHash)
+		}
+	}
+	return missing, nil
 	//todo: gc on ipfs
 }
 
@@ -177,7 +223,7 @@ func (ls *ledgerStore) PutObject(ctx context.Context, bucket, object string, obj
 	}
 
 	// sync lambda call
-	if err := ls.oh.CallPutObjectHandler(ctx, bucket, obj, object); err != nil {
+	if err := ls.oh.CallPutObjectHandler(ctx, tenantBucket(bucket), obj, object); err != nil {
 		// TODO: remove bucket just created from ledger
 		log.Println("error while calling lambda in PutObject ")
 		return err
@@ -192,19 +238,65 @@ func (ls *ledgerStore) putObject(ctx context.Context, bucket, object string, obj
 	if err != nil {
 		return err
 	}
-	return ls.putObjectHash(ctx, bucket, object, oHash)
+	u, err := ls.getUsage(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	old, err := ls.object(ctx, bucket, object)
+	switch err {
+	case nil:
+		u.remove(old.ObjectInfo.GetSize_())
+	case ErrLedgerObjectDoesNotExist:
+	default:
+		return err
+	}
+	u.add(obj.ObjectInfo.GetSize_())
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if b.Bucket.Objects == nil {
+		b.Bucket.Objects = make(map[string]string)
+	}
+	b.Bucket.Objects[object] = oHash
+	// the usage and the data references are saved with the bucket root, so they never account for a different set of objects
+	lb, err := ls.commitBucket(ctx, bucket, b.Bucket, func(batch datastore.Batch) error {
+		refs := ls.dataRefWriter(batch)
+		if old != nil {
+			if err := refs.remove(bucket, object, old, u); err != nil {
+				return err
+			}
+		}
+		if err := refs.add(bucket, object, obj, u); err != nil {
+			return err
+		}
+		return putUsage(batch, bucket, u)
+	})
+	if err != nil {
+		return err
+	}
+	if err := ls.queuePins(bucket, object, oHash); err != nil {
+		return err
+	}
+	if ls.objectChanged != nil {
+		ls.objectChanged(ctx, bucket, object, obj, oHash, lb.IpfsHash)
+	}
+	return nil
 }
 
-// putObjectHash saves an object by hash into the given bucket
-func (ls *ledgerStore) putObjectHash(ctx context.Context, bucket, object, objHash string) error {
+// putObjectHash saves an object by hash into the given bucket, and returns the new bucket hash
+func (ls *ledgerStore) putObjectHash(ctx context.Context, bucket, object, objHash string) (string, error) {
 	b, err := ls.getBucketLoaded(ctx, bucket)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if b.Bucket.Objects == nil {
 		b.Bucket.Objects = make(map[string]string)
 	}
 	b.Bucket.Objects[object] = objHash
-	_, err = ls.saveBucket(ctx, bucket, b.Bucket)
-	return err
+	lb, err := ls.saveBucket(ctx, bucket, b.Bucket)
+	if err != nil {
+		return "", err
+	}
+	return lb.IpfsHash, nil
 }
@@ -0,0 +1,284 @@
+package s3x
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+/* Design Notes
+---------------
+
+Internal functions should never claim or release locks.
+Any claiming or releasing of locks should be done in the public setter+getter functions.
+The reason for this is so that we can enable easy reuse of internal code.
+
+Transactions stage operations on a bucket, and apply them as one new bucket root when they are
+committed, so clients never see a partially applied transaction, and the bucket root is saved
+once instead of once per object.
+
+The data and the object protocol buffers of puts are saved to ipfs when they are staged, so a
+commit only resolves the staged objects and copies. Copies and deletes are resolved against the
+bucket at commit time, including the earlier operations of the transaction. The new bucket root,
+the bucket usage, the data references and the removal of the transaction are written in a single
+datastore batch. Pins and the lambda hooks are handled once the batch is committed, since the
+transaction is applied by then their errors are logged rather than returned.
+Aborted transactions are removed from the datastore, their data is left in ipfs.
+*/
+
+// BeginTransaction starts a new transaction of the bucket with the given id
+func (ls *ledgerStore) BeginTransaction(bucket, id string) (*Transaction, error) {
+	defer ls.locker.read(bucket)()
+	if err := ls.assertBucketExits(bucket); err != nil {
+		return nil, err
+	}
+	txn := &Transaction{
+		Bucket:  bucket,
+		Id:      id,
+		Created: time.Now().UTC(),
+	}
+	return txn, ls.putTransaction(txn)
+}
+
+// StageTransaction adds operations to a transaction, the objects of puts must already be saved to ipfs
+func (ls *ledgerStore) StageTransaction(bucket, id string, ops []TransactionOp) (*Transaction, error) {
+	defer ls.tlocker.write(id)()
+	txn, err := ls.getTransaction(bucket, id)
+	if err != nil {
+		return nil, err
+	}
+	txn.Ops = append(txn.Ops, ops...)
+	return txn, ls.putTransaction(txn)
+}
+
+// AbortTransaction discards a transaction and returns it
+func (ls *ledgerStore) AbortTransaction(bucket, id string) (*Transaction, error) {
+	defer ls.tlocker.write(id)()
+	txn, err := ls.getTransaction(bucket, id)
+	if err != nil {
+		return nil, err
+	}
+	return txn, ls.ds.Delete(transactionKey(bucket, id))
+}
+
+// CommitTransaction applies the operations of a transaction to the bucket, and returns the new bucket hash.
+// The transaction is kept if it can not be applied, such as when the source of a copy does not exist.
+func (ls *ledgerStore) CommitTransaction(ctx context.Context, bucket, id string) (string, error) {
+	defer ls.locker.write(bucket)()
+	defer ls.tlocker.write(id)()
+	txn, err := ls.getTransaction(bucket, id)
+	if err != nil {
+		return "", err
+	}
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return "", err
+	}
+	u, err := ls.getUsage(ctx, bucket)
+	if err != nil {
+		return "", err
+	}
+
+	// apply the operations to a copy of the objects, so the cached bucket is unchanged until the commit succeeds
+	objects := make(map[string]string, len(b.Bucket.Objects)+len(txn.Ops))
+	for name, hash := range b.Bucket.Objects {
+		objects[name] = hash
+	}
+	staged := make(map[string]*Object) // staged and copied objects by hash
+	for _, op := range txn.Ops {
+		switch op.Type {
+		case TransactionOpType_PUT:
+			obj, err := ipfsObject(ctx, ls.dag, op.Hash)
+			if err != nil {
+				return "", err
+			}
+			staged[op.Hash] = obj
+			objects[op.Object] = op.Hash
+		case TransactionOpType_COPY:
+			src, ok := objects[op.Source]
+			if !ok {
+				return "", ErrLedgerObjectDoesNotExist
+			}
+			obj, err := ipfsObject(ctx, ls.dag, src)
+			if err != nil {
+				return "", err
+			}
+			obj.ObjectInfo.Name = op.Object
+			obj.ObjectInfo.ModTime = time.Now().UTC()
+			hash, err := ipfsSave(ctx, ls.dag, obj)
+			if err != nil {
+				return "", err
+			}
+			staged[hash] = obj
+			objects[op.Object] = hash
+		case TransactionOpType_DELETE:
+			delete(objects, op.Object)
+		}
+	}
+
+	// compare the result with the bucket, objects that were put and removed again are not changed
+	var changed, removed []string
+	for name, hash := range objects {
+		if b.Bucket.Objects[name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	for name := range b.Bucket.Objects {
+		if _, ok := objects[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(removed)
+	replaced := append(append([]string{}, removed...), changed...)
+	olds := make(map[string]*Object, len(replaced))
+	for _, name := range replaced {
+		hash, ok := b.Bucket.Objects[name]
+		if !ok {
+			continue
+		}
+		old, err := ipfsObject(ctx, ls.dag, hash)
+		if err != nil {
+			return "", err
+		}
+		olds[name] = old
+		u.remove(old.ObjectInfo.GetSize_())
+	}
+	news := make(map[string]*Object, len(changed))
+	for _, name := range changed {
+		news[name] = staged[objects[name]]
+		u.add(news[name].ObjectInfo.GetSize_())
+	}
+
+	nb := *b.Bucket
+	nb.Objects = objects
+	lb, err := ls.commitBucket(ctx, bucket, &nb, func(batch datastore.Batch) error {
+		if err := ls.updateDataRefs(ls.dataRefWriter(batch), bucket, u, olds, news); err != nil {
+			return err
+		}
+		if err := putUsage(batch, bucket, u); err != nil {
+			return err
+		}
+		return batch.Delete(transactionKey(bucket, id))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range removed {
+		if err := ls.deletePins(bucket, name); err != nil {
+			log.Println("failed to delete pins in CommitTransaction", err)
+		}
+		if err := ls.oh.CallRemoveObjectHandler(ctx, tenantBucket(bucket), olds[name], name); err != nil {
+			log.Println("error while calling lambda in CommitTransaction", err)
+		}
+		if ls.objectChanged != nil {
+			ls.objectChanged(ctx, bucket, name, nil, "", lb.IpfsHash)
+		}
+	}
+	for _, name := range changed {
+		hash := objects[name]
+		if err := ls.queuePins(bucket, name, hash); err != nil {
+			log.Println("failed to queue pins in CommitTransaction", err)
+		}
+		if err := ls.oh.CallPutObjectHandler(ctx, tenantBucket(bucket), staged[hash], name); err != nil {
+			log.Println("error while calling lambda in CommitTransaction", err)
+		}
+		if ls.objectChanged != nil {
+			ls.objectChanged(ctx, bucket, name, staged[hash], hash, lb.IpfsHash)
+		}
+	}
+	return lb.IpfsHash, nil
+}
+
+// updateDataRefs removes the data references of the replaced objects, and adds the references of the new objects
+func (ls *ledgerStore) updateDataRefs(refs *dataRefWriter, bucket string, u *BucketUsage, olds, news map[string]*Object) error {
+	for name, obj := range olds {
+		if err := refs.remove(bucket, name, obj, u); err != nil {
+			return err
+		}
+	}
+	for name, obj := range news {
+		if err := refs.add(bucket, name, obj, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitBucket saves the bucket root together with the writes of fn in a single datastore batch
+func (ls *ledgerStore) commitBucket(ctx context.Context, bucket string, b *Bucket, fn func(datastore.Batch) error) (*LedgerBucketEntry, error) {
+	bHash, err := ipfsSave(ctx, ls.dag, b)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := ls.ds.Batch()
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Put(dsBucketKey.ChildString(bucket), []byte(bHash)); err != nil {
+		return nil, err
+	}
+	if err := fn(batch); err != nil {
+		return nil, err
+	}
+	if err := batch.Commit(); err != nil {
+		return nil, err
+	}
+	lb := &LedgerBucketEntry{
+		Bucket:   b,
+		IpfsHash: bHash,
+	}
+	ls.bucketSaved(bucket, lb)
+	return lb, nil
+}
+
+// getTransaction returns the transaction of the bucket with the given id
+func (ls *ledgerStore) getTransaction(bucket, id string) (*Transaction, error) {
+	data, err := ls.ds.Get(transactionKey(bucket, id))
+	if err == datastore.ErrNotFound {
+		return nil, ErrLedgerTransactionDoesNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	txn := &Transaction{}
+	return txn, txn.Unmarshal(data)
+}
+
+func (ls *ledgerStore) putTransaction(txn *Transaction) error {
+	data, err := txn.Marshal()
+	if err != nil {
+		return err
+	}
+	return ls.ds.Put(transactionKey(txn.Bucket, txn.Id), data)
+}
+
+// deleteTransactions removes all transactions of the bucket
+func (ls *ledgerStore) deleteTransactions(bucket string) error {
+	rs, err := ls.ds.Query(query.Query{
+		Prefix:   dsTransactionKey.ChildString(bucket).String(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	entries, err := rs.Rest()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := ls.ds.Delete(datastore.NewKey(e.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func transactionKey(bucket, id string) datastore.Key {
+	return dsTransactionKey.ChildString(bucket).ChildString(id)
+}
@@ -0,0 +1,140 @@
+package s3x
+
+import (
+	"context"
+
+	"github.com/ipfs/go-datastore"
+	minio "github.com/minio/minio/cmd"
+)
+
+/* Design Notes
+---------------
+
+Internal functions should never claim or release locks.
+Any claiming or releasing of locks should be done in the public setter+getter functions.
+The reason for this is so that we can enable easy reuse of internal code.
+
+The usage of a bucket must be loaded before objects in the bucket are changed,
+because missing usage is computed from the objects currently in the bucket.
+*/
+
+// GetBucketUsage returns the data usage of the bucket
+func (ls *ledgerStore) GetBucketUsage(ctx context.Context, bucket string) (*BucketUsage, error) {
+	unlock := ls.locker.read(bucket)
+	u, err := ls.getRecordedUsage(bucket)
+	unlock()
+	if u != nil || err != nil {
+		return u, err
+	}
+	// the usage is not recorded, it is computed under the write lock,
+	// because computing it rewrites the data references of the bucket
+	defer ls.locker.write(bucket)()
+	if err := ls.assertBucketExits(bucket); err != nil {
+		return nil, err
+	}
+	return ls.getUsage(ctx, bucket)
+}
+
+// getRecordedUsage returns the recorded usage of the bucket, or nil if it needs to be computed
+func (ls *ledgerStore) getRecordedUsage(bucket string) (*BucketUsage, error) {
+	if err := ls.assertBucketExits(bucket); err != nil {
+		return nil, err
+	}
+	return ls.readUsage(bucket)
+}
+
+// getUsage returns the recorded usage of the bucket, if no usage is recorded,
+// such as for ledgers created without usage accounting, it is computed and saved.
+func (ls *ledgerStore) getUsage(ctx context.Context, bucket string) (*BucketUsage, error) {
+	u, err := ls.readUsage(bucket)
+	if u != nil || err != nil {
+		return u, err
+	}
+	return ls.computeUsage(ctx, bucket)
+}
+
+// readUsage returns the recorded usage of the bucket, or nil if no usage is recorded,
+// or the data of the bucket is not indexed
+func (ls *ledgerStore) readUsage(bucket string) (*BucketUsage, error) {
+	data, err := ls.ds.Get(dsUsageKey.ChildString(bucket))
+	if err == datastore.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	u := &BucketUsage{}
+	if err := u.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	if !u.DataIndexed {
+		return nil, nil
+	}
+	return u, nil
+}
+
+// computeUsage computes the usage of the bucket from all of its objects, and indexes their data again
+func (ls *ledgerStore) computeUsage(ctx context.Context, bucket string) (*BucketUsage, error) {
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if err := ls.deleteDataRefs(bucket); err != nil {
+		return nil, err
+	}
+	u := &BucketUsage{DataIndexed: true}
+	refs := ls.dataRefWriter(ls.ds)
+	for name, objHash := range b.Bucket.Objects {
+		obj, err := ipfsObject(ctx, ls.dag, objHash)
+		if err != nil {
+			return nil, err
+		}
+		u.add(obj.ObjectInfo.GetSize_())
+		if err := refs.add(bucket, name, obj, u); err != nil {
+			return nil, err
+		}
+	}
+	return u, putUsage(ls.ds, bucket, u)
+}
+
+// putUsage writes the usage of the bucket to w, which is either the datastore,
+// or a batch that saves the usage together with the bucket root
+func putUsage(w datastore.Write, bucket string, u *BucketUsage) error {
+	data, err := u.Marshal()
+	if err != nil {
+		return err
+	}
+	return w.Put(dsUsageKey.ChildString(bucket), data)
+}
+
+// resetUsage removes the recorded usage and the data references of the bucket,
+// so they are computed again when needed
+func (ls *ledgerStore) resetUsage(bucket string) error {
+	err := ls.ds.Delete(dsUsageKey.ChildString(bucket))
+	if err != nil && err != datastore.ErrNotFound {
+		return err
+	}
+	return ls.deleteDataRefs(bucket)
+}
+
+// add accounts for a new object of the given size
+func (u *BucketUsage) add(size int64) {
+	u.Size_ += size
+	u.ObjectsCount++
+	if u.ObjectsSizesHistogram == nil {
+		u.ObjectsSizesHistogram = make(map[string]uint64)
+	}
+	u.ObjectsSizesHistogram[minio.ObjectsHistogramIntervalName(size)]++
+}
+
+// remove accounts for a removed object of the given size
+func (u *BucketUsage) remove(size int64) {
+	u.Size_ -= size
+	if u.ObjectsCount > 0 {
+		u.ObjectsCount--
+	}
+	interval := minio.ObjectsHistogramIntervalName(size)
+	if u.ObjectsSizesHistogram[interval] > 0 {
+		u.ObjectsSizesHistogram[interval]--
+	}
+}
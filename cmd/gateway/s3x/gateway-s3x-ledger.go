@@ -60,6 +60,23 @@ func (ls *ledgerStore) GetObjectInfos(ctx context.Context, bucket, prefix, start
 	return list, nil
 }
 
+// GetObjectNames returns the sorted names of all objects in the bucket with given prefix
+func (ls *ledgerStore) GetObjectNames(ctx context.Context, bucket, prefix string) ([]string, error) {
+	defer ls.locker.read(bucket)()
+	b, err := ls.getBucketLoaded(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range b.GetBucket().GetObjects() {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // GetObjectHash is used to retrieve the corresponding IPFS CID for an object
 func (ls *ledgerStore) GetObjectHash(ctx context.Context, bucket, object string) (string, error) {
 	objs, unlock, err := ls.GetObjectHashes(ctx, bucket)
@@ -89,8 +106,13 @@ func (ls *ledgerStore) GetObjectHashes(ctx context.Context, bucket string) (map[
 // GetBucketNames is used to get a slice of all bucket names our ledger currently tracks
 func (ls *ledgerStore) GetBucketNames() ([]string, error) {
 	//this only reads from the datastore, which have it's own synchronization, so no locking is needed.
+	return ls.keyNames(dsBucketKey)
+}
+
+// keyNames returns the base names of all datastore keys under the prefix
+func (ls *ledgerStore) keyNames(prefix datastore.Key) ([]string, error) {
 	rs, err := ls.ds.Query(query.Query{
-		Prefix:   dsBucketKey.String(),
+		Prefix:   prefix.String(),
 		KeysOnly: true,
 	})
 	if err != nil {
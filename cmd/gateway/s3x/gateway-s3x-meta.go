@@ -0,0 +1,69 @@
+package s3x
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"time"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+// minioMetaBucket is the bucket minio saves its configuration files in, such as bucket quotas.
+// s3x keeps these objects in the ledger datastore, see ledgerStore.GetMeta.
+const minioMetaBucket = ".minio.sys"
+
+func (x *xObjects) getMetaObjectInfo(object string) (minio.ObjectInfo, error) {
+	data, err := x.ledgerStore.GetMeta(object)
+	if err != nil {
+		return minio.ObjectInfo{}, x.toMinioErr(err, minioMetaBucket, object, "")
+	}
+	return newMetaObjectInfo(object, data), nil
+}
+
+func (x *xObjects) getMetaObject(object string, startOffset, length int64, writer io.Writer) error {
+	data, err := x.ledgerStore.GetMeta(object)
+	if err != nil {
+		return x.toMinioErr(err, minioMetaBucket, object, "")
+	}
+	size := int64(len(data))
+	if length < 0 {
+		length = size - startOffset
+	}
+	if startOffset < 0 || startOffset+length > size {
+		return minio.InvalidRange{
+			OffsetBegin:  startOffset,
+			OffsetEnd:    startOffset + length,
+			ResourceSize: size,
+		}
+	}
+	_, err = writer.Write(data[startOffset : startOffset+length])
+	return err
+}
+
+func (x *xObjects) putMetaObject(object string, r io.Reader) (minio.ObjectInfo, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+	if err := x.ledgerStore.PutMeta(object, data); err != nil {
+		return minio.ObjectInfo{}, x.toMinioErr(err, minioMetaBucket, object, "")
+	}
+	return newMetaObjectInfo(object, data), nil
+}
+
+func (x *xObjects) deleteMetaObject(object string) error {
+	return x.toMinioErr(x.ledgerStore.DeleteMeta(object), minioMetaBucket, object, "")
+}
+
+func newMetaObjectInfo(object string, data []byte) minio.ObjectInfo {
+	sum := md5.Sum(data)
+	return minio.ObjectInfo{
+		Bucket:  minioMetaBucket,
+		Name:    object,
+		ETag:    hex.EncodeToString(sum[:]),
+		Size:    int64(len(data)),
+		ModTime: time.Now().UTC(),
+	}
+}
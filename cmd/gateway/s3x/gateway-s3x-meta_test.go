@@ -0,0 +1,78 @@
+package s3x
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+func TestS3X_Meta_Badger(t *testing.T) {
+	testS3XMeta(t, DSTypeBadger)
+}
+func TestS3X_Meta_Crdt(t *testing.T) {
+	testS3XMeta(t, DSTypeCrdt)
+}
+func testS3XMeta(t *testing.T, dsType DSType) {
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	const (
+		configFile = "buckets/bucket1/quota.json"
+		configData = `{"quota":1024,"quotatype":"hard"}`
+	)
+	if _, err := gateway.GetObjectInfo(ctx, minioMetaBucket, configFile, minio.ObjectOptions{}); !isObjectNotFound(err) {
+		t.Fatal("expected ObjectNotFound, but got", err)
+	}
+	oi, err := gateway.PutObject(ctx, minioMetaBucket, configFile, getTestPutObjectReader(t, []byte(configData)), minio.ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.Size != int64(len(configData)) {
+		t.Fatalf("expected size %d, but got %d", len(configData), oi.Size)
+	}
+	t.Run("GetObject", func(t *testing.T) {
+		gateway.restart(t)
+		buf := &bytes.Buffer{}
+		if err := gateway.GetObject(ctx, minioMetaBucket, configFile, 0, -1, buf, "", minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != configData {
+			t.Fatalf("expected %s, but got %s", configData, buf.String())
+		}
+		buf.Reset()
+		if err := gateway.GetObject(ctx, minioMetaBucket, configFile, 1, 7, buf, "", minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != configData[1:8] {
+			t.Fatalf("expected %s, but got %s", configData[1:8], buf.String())
+		}
+	})
+	t.Run("not a bucket", func(t *testing.T) {
+		buckets, err := gateway.ListBuckets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(buckets) != 0 {
+			t.Fatalf("expected no buckets, but got %v", buckets)
+		}
+	})
+	t.Run("DeleteObject", func(t *testing.T) {
+		if err := gateway.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.DeleteObject(ctx, minioMetaBucket, configFile); !isObjectNotFound(err) {
+			t.Fatal("expected ObjectNotFound, but got", err)
+		}
+	})
+}
+
+func isObjectNotFound(err error) bool {
+	_, ok := err.(minio.ObjectNotFound)
+	return ok
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ipfs/go-merkledag"
 	unixfs_pb "github.com/ipfs/go-unixfs/pb"
 	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/segmentio/ksuid"
 )
 
@@ -28,9 +29,10 @@ func (x *xObjects) NewMultipartUpload(
 	opts minio.ObjectOptions,
 ) (uploadID string, err error) {
 	uploadID = ksuid.New().String()
-	info := newObjectInfo(bucket, object, 0, opts)
+	// the upload keeps the ledger bucket, so only the tenant that started it can use it
+	info := newObjectInfo(x.ledgerBucket(ctx, bucket), object, 0, opts)
 	return uploadID, x.toMinioErr(
-		x.ledgerStore.NewMultipartUpload(uploadID, &info),
+		x.ledgerStore.NewMultipartUpload(info.Bucket, uploadID, &info),
 		bucket, object, uploadID,
 	)
 }
@@ -43,7 +45,7 @@ func (x *xObjects) PutObjectPart(
 	r *minio.PutObjReader,
 	opts minio.ObjectOptions,
 ) (pi minio.PartInfo, e error) {
-	err := x.ledgerStore.AssertBucketExits(bucket)
+	err := x.ledgerStore.AssertBucketExits(x.ledgerBucket(ctx, bucket))
 	if err != nil {
 		return pi, x.toMinioErr(err, bucket, "", "")
 	}
@@ -59,7 +61,7 @@ func (x *xObjects) PutObjectPart(
 		ActualSize:   int64(size),
 	}
 	return pi, x.toMinioErr(
-		x.ledgerStore.PutObjectPart(bucket, object, uploadID, pi),
+		x.ledgerStore.PutObjectPart(x.ledgerBucket(ctx, bucket), object, uploadID, pi),
 		bucket, object, uploadID)
 }
 
@@ -86,20 +88,23 @@ func (x *xObjects) ListObjectParts(
 		MaxParts:         maxParts,
 		PartNumberMarker: partNumberMarker,
 	}
-	m, unlock, err := x.ledgerStore.GetObjectDetails(uploadID)
-	defer unlock()
+	m, unlock, err := x.ledgerStore.GetObjectDetails(x.ledgerBucket(ctx, bucket), object, uploadID)
 	if err != nil {
 		return lpi, x.toMinioErr(err, bucket, object, uploadID)
 	}
-	if m.GetObjectInfo().GetBucket() != bucket ||
-		m.GetObjectInfo().GetName() != object {
-		return lpi, x.toMinioErr(ErrInvalidUploadID, bucket, object, uploadID)
-	}
+	defer unlock()
 
+	lpi.UserDefined = m.GetObjectInfo().GetUserDefined()
+	encrypted := crypto.IsEncrypted(lpi.UserDefined)
 	for _, part := range m.ObjectParts {
+		etag := minio.ToS3ETag(part.GetDataHash())
+		if encrypted {
+			// minio compares the etag it returned from PutObjectPart when completing encrypted uploads
+			etag = part.GetDataHash()
+		}
 		lpi.Parts = append(lpi.Parts, minio.PartInfo{
 			PartNumber: int(part.GetNumber()),
-			ETag:       minio.ToS3ETag(part.GetDataHash()),
+			ETag:       etag,
 			Size:       part.GetSize_(),
 		})
 	}
@@ -114,7 +119,7 @@ func (x *xObjects) AbortMultipartUpload(
 ) error {
 	// TODO(bonedaddy): remove the corresponding objects from ipfs
 	return x.toMinioErr(
-		x.ledgerStore.AbortMultipartUpload(bucket, uploadID),
+		x.ledgerStore.AbortMultipartUpload(x.ledgerBucket(ctx, bucket), object, uploadID),
 		bucket,
 		object,
 		uploadID,
@@ -129,11 +134,11 @@ func (x *xObjects) CompleteMultipartUpload(
 	uploadedParts []minio.CompletePart,
 	opts minio.ObjectOptions,
 ) (oi minio.ObjectInfo, e error) {
-	err := x.ledgerStore.AssertBucketExits(bucket)
+	err := x.ledgerStore.AssertBucketExits(x.ledgerBucket(ctx, bucket))
 	if err != nil {
 		return oi, x.toMinioErr(err, bucket, object, uploadID)
 	}
-	m, unlock, err := x.ledgerStore.GetObjectDetails(uploadID)
+	m, unlock, err := x.ledgerStore.GetObjectDetails(x.ledgerBucket(ctx, bucket), object, uploadID)
 	if err != nil {
 		return oi, x.toMinioErr(err, bucket, object, uploadID)
 	}
@@ -141,6 +146,7 @@ func (x *xObjects) CompleteMultipartUpload(
 	totalSize := uint64(0)
 	links := make([]*ipld.Link, 0, len(uploadedParts))
 	blocks := make([]uint64, 0, len(uploadedParts))
+	parts := make([]ObjectPartInfo, 0, len(uploadedParts))
 	for _, p := range uploadedParts {
 		number := int64(p.PartNumber)
 		pi, ok := m.ObjectParts[number]
@@ -161,6 +167,12 @@ func (x *xObjects) CompleteMultipartUpload(
 			Cid:  cid,
 		})
 		blocks = append(blocks, size)
+		parts = append(parts, ObjectPartInfo{
+			Number:     number,
+			Size_:      pi.Size_,
+			ActualSize: pi.ActualSize,
+			DataHash:   pi.DataHash,
+		})
 	}
 	protoNode := &merkledag.ProtoNode{}
 	protoNode.SetCidBuilder(merkledag.V1CidPrefix())
@@ -178,15 +190,20 @@ func (x *xObjects) CompleteMultipartUpload(
 	if err != nil {
 		return oi, x.toMinioErr(err, bucket, object, uploadID)
 	}
-	loi := m.ObjectInfo
-	if loi == nil || len(opts.UserDefined) != 0 {
+	var loi *ObjectInfo
+	if m.ObjectInfo == nil || len(opts.UserDefined) != 0 {
 		noi := newObjectInfo(bucket, object, int(totalSize), opts)
 		loi = &noi
 	} else {
+		noi := *m.ObjectInfo
+		loi = &noi
+		loi.Bucket = bucket
 		loi.Size_ = int64(totalSize)
 		loi.ModTime = time.Now().UTC()
 	}
-	err = x.ledgerStore.PutObject(ctx, bucket, object, &Object{
+	// the part sizes are needed to decrypt encrypted uploads, since each part is encrypted separately
+	loi.Parts = parts
+	err = x.ledgerStore.PutObject(ctx, x.ledgerBucket(ctx, bucket), object, &Object{
 		DataHash:   dataHash,
 		ObjectInfo: *loi,
 	})
@@ -194,12 +211,10 @@ func (x *xObjects) CompleteMultipartUpload(
 		return oi, x.toMinioErr(err, bucket, object, uploadID)
 	}
 	// Add Fleek content hash header
-	loi.UserDefined = map[string]string{
-		fleekIpfsContentHash:   dataHash,
-		fleekIpfsContentHashV0: convertToHashV0(dataHash),
-	}
+	loi.UserDefined = withContentHash(loi.UserDefined, dataHash)
 	// ping gateways for hashes
 	pingHash(dataHash)
 
-	return getMinioObjectInfo(loi), x.AbortMultipartUpload(ctx, bucket, object, uploadID)
+	// the upload is read locked and was checked above, so it is deleted without aborting it
+	return getMinioObjectInfo(loi), x.toMinioErr(x.ledgerStore.DeleteMultipartID(uploadID), bucket, object, uploadID)
 }
@@ -3,18 +3,16 @@ package s3x
 import (
 	"context"
 	"io"
-	"io/ioutil"
 	"log"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
 	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/crypto"
 )
 
 const (
-	placeHolderFileName    = ".keep"
 	fleekIpfsContentHash   = "X-FLEEK-IPFS-HASH"
 	fleekIpfsContentHashV0 = "X-FLEEK-IPFS-HASH-V0"
 )
@@ -25,18 +23,7 @@ func (x *xObjects) ListObjects(
 	bucket, prefix, marker, delimiter string,
 	maxKeys int,
 ) (loi minio.ListObjectsInfo, e error) {
-	// TODO(bonedaddy): implement complex search (George: prefix implemented)
-	objs, err := x.ledgerStore.GetObjectInfos(ctx, bucket, prefix, "", 0)
-	if err != nil {
-		return loi, x.toMinioErr(err, bucket, "", "")
-	}
-	loi.Objects = make([]minio.ObjectInfo, 0, len(objs))
-	for _, obj := range objs {
-		loi.Objects = append(loi.Objects, getMinioObjectInfo(&obj))
-	}
-	// TODO(bonedaddy): consider if we should use the following helper func
-	// return minio.FromMinioClientListBucketResult(bucket, result), nil
-	return loi, nil
+	return x.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
 }
 
 // ListObjectsV2 lists all objects in B2 bucket filtered by prefix, returns upto max 1000 entries at a time.
@@ -47,25 +34,105 @@ func (x *xObjects) ListObjectsV2(
 	fetchOwner bool,
 	startAfter string,
 ) (loi minio.ListObjectsV2Info, err error) {
-	objs, err := x.ledgerStore.GetObjectInfos(ctx, bucket, prefix, startAfter, 1000)
+	marker := continuationToken
+	if marker == "" {
+		marker = startAfter
+	}
+	resultV1, err := x.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
 	if err != nil {
-		return loi, x.toMinioErr(err, bucket, "", "")
+		return loi, err
 	}
+	loi.Objects = resultV1.Objects
+	loi.Prefixes = resultV1.Prefixes
+	loi.IsTruncated = resultV1.IsTruncated
+	loi.ContinuationToken = continuationToken
+	loi.NextContinuationToken = resultV1.NextMarker
+	return loi, nil
+}
 
-	if delimiter != "" {
-		objects, prefixes := filterObjectsWithDelimiter(objs, prefix, delimiter)
-		loi.Objects = objects
-		loi.Prefixes = prefixes
-	} else {
-		loi.Objects = make([]minio.ObjectInfo, 0, len(objs))
-		for _, obj := range objs {
-			loi.Objects = append(loi.Objects, getMinioObjectInfo(&obj))
+// listObjects returns up to maxKeys objects and common prefixes of the objects after marker in the
+// bucket, ordered by name. With a delimiter, the objects sharing the prefix up to the delimiter
+// are listed once as their common prefix. NextMarker is set to the last entry if more are left.
+// Only the infos of the listed objects are read.
+func (x *xObjects) listObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi minio.ListObjectsInfo, err error) {
+	lbucket := x.ledgerBucket(ctx, bucket)
+	names, err := x.ledgerStore.GetObjectNames(ctx, lbucket, prefix)
+	if err != nil {
+		return loi, x.toMinioErr(err, bucket, "", "")
+	}
+	if maxKeys == 0 {
+		return loi, nil
+	}
+	var last string
+	for _, name := range names {
+		entry, isPrefix := name, false
+		if delimiter != "" {
+			if idx := strings.Index(name[len(prefix):], delimiter); idx >= 0 {
+				entry, isPrefix = name[:len(prefix)+idx+len(delimiter)], true
+			}
+		}
+		// names are sorted, so the objects of a common prefix follow each other
+		if entry <= marker || (isPrefix && entry == last) {
+			continue
+		}
+		if maxKeys > 0 && len(loi.Objects)+len(loi.Prefixes) == maxKeys {
+			loi.IsTruncated = true
+			loi.NextMarker = last
+			break
 		}
+		if isPrefix {
+			loi.Prefixes = append(loi.Prefixes, entry)
+		} else {
+			oi, err := x.ledgerStore.ObjectInfo(ctx, lbucket, name)
+			if err == ErrLedgerObjectDoesNotExist {
+				// removed since the names were listed
+				continue
+			}
+			if err != nil {
+				return minio.ListObjectsInfo{}, x.toMinioErr(err, bucket, name, "")
+			}
+			loi.Objects = append(loi.Objects, getMinioObjectInfo(oi))
+		}
+		last = entry
 	}
-
 	return loi, nil
 }
 
+// Walk sends the info of all objects in the bucket with the given prefix to results ordered by name.
+// results is closed when the walk is done, ctx is canceled or an object can not be read.
+// The object names are captured upfront, so the bucket is only locked while each object is read,
+// and objects removed since the names were listed are skipped.
+func (x *xObjects) Walk(ctx context.Context, bucket, prefix string, results chan<- minio.ObjectInfo) error {
+	lbucket := x.ledgerBucket(ctx, bucket)
+	names, err := x.ledgerStore.GetObjectNames(ctx, lbucket, prefix)
+	if err != nil {
+		close(results)
+		return x.toMinioErr(err, bucket, "", "")
+	}
+	go func() {
+		defer close(results)
+		for _, name := range names {
+			if ctx.Err() != nil {
+				return
+			}
+			oi, err := x.ledgerStore.ObjectInfo(ctx, lbucket, name)
+			if err == ErrLedgerObjectDoesNotExist {
+				continue
+			}
+			if err != nil {
+				log.Printf("failed to walk object %s/%s: %v", bucket, name, err)
+				return
+			}
+			select {
+			case results <- getMinioObjectInfo(oi):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
 // GetObjectNInfo - returns object info and locked object ReadCloser
 func (x *xObjects) GetObjectNInfo(
 	ctx context.Context,
@@ -79,8 +146,9 @@ func (x *xObjects) GetObjectNInfo(
 	if err != nil {
 		return gr, err // the error from this is already properly converted
 	}
-	var startOffset, length int64
-	startOffset, length, err = rs.GetOffsetLength(objinfo.Size)
+	// for encrypted objects, the range is translated to the encrypted packages to read,
+	// and objReaderFn decrypts them.
+	objReaderFn, startOffset, length, err := minio.NewGetObjectReader(rs, objinfo, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +160,7 @@ func (x *xObjects) GetObjectNInfo(
 	// Setup cleanup function to cause the above go-routine to
 	// exit in case of partial read
 	pipeCloser := func() { pr.Close() }
-	return minio.NewGetObjectReaderFromReader(pr, objinfo, opts, pipeCloser)
+	return objReaderFn(pr, h, opts.CheckCopyPrecondFn, pipeCloser)
 }
 
 // GetObject reads an object from TemporalX. Supports additional
@@ -109,7 +177,10 @@ func (x *xObjects) GetObject(
 	etag string,
 	opts minio.ObjectOptions,
 ) error {
-	fileHash, size, err := x.ledgerStore.GetObjectDataHash(ctx, bucket, object)
+	if bucket == minioMetaBucket {
+		return x.getMetaObject(object, startOffset, length, writer)
+	}
+	fileHash, size, err := x.ledgerStore.GetObjectDataHash(ctx, x.ledgerBucket(ctx, bucket), object)
 	if err != nil {
 		return x.toMinioErr(err, bucket, object, "")
 	}
@@ -120,7 +191,28 @@ func (x *xObjects) GetObject(
 			ResourceSize: size,
 		}
 	}
-	if _, err := ipfsFileDownload(ctx, x.fileClient, writer, fileHash, startOffset, length); err != nil {
+	if fileHash == "" {
+		// zero-byte object
+		return nil
+	}
+	if startOffset == 0 && (length == size || length == 0) {
+		// a length of 0 reads the whole object, like ipfsFileDownload
+		if _, err := ipfsFileDownload(ctx, x.fileClient, writer, fileHash, startOffset, length); err != nil {
+			return x.toMinioErr(err, bucket, object, "")
+		}
+		return nil
+	}
+	// ranges are read from the unixfs dag, which skips the blocks before the range,
+	// such as for the footers of parquet files queried by S3 Select.
+	r, err := ipfsFileReader(ctx, x.dagClient, fileHash)
+	if err != nil {
+		return x.toMinioErr(err, bucket, object, "")
+	}
+	defer r.Close()
+	if _, err := r.Seek(startOffset, io.SeekStart); err != nil {
+		return x.toMinioErr(err, bucket, object, "")
+	}
+	if _, err := io.CopyN(writer, r, length); err != nil {
 		return x.toMinioErr(err, bucket, object, "")
 	}
 	return nil
@@ -132,7 +224,10 @@ func (x *xObjects) GetObjectInfo(
 	bucket, object string,
 	opts minio.ObjectOptions,
 ) (objInfo minio.ObjectInfo, err error) {
-	oi, err := x.ledgerStore.ObjectInfo(ctx, bucket, object)
+	if bucket == minioMetaBucket {
+		return x.getMetaObjectInfo(object)
+	}
+	oi, err := x.ledgerStore.ObjectInfo(ctx, x.ledgerBucket(ctx, bucket), object)
 	return getMinioObjectInfo(oi), x.toMinioErr(err, bucket, object, "")
 }
 
@@ -148,6 +243,11 @@ func newObjectInfo(bucket, object string, size int, opts minio.ObjectOptions) Ob
 		obinfo.ModTime = time.Now().UTC()
 	}
 	for k, v := range opts.UserDefined {
+		if obinfo.UserDefined == nil {
+			obinfo.UserDefined = make(map[string]string, len(opts.UserDefined))
+		}
+		// this includes the sealed keys of encrypted objects
+		obinfo.UserDefined[k] = v
 		switch strings.ToLower(k) {
 		case "content-encoding":
 			obinfo.ContentEncoding = v
@@ -170,37 +270,37 @@ func (x *xObjects) PutObject(
 	r *minio.PutObjReader,
 	opts minio.ObjectOptions,
 ) (minio.ObjectInfo, error) {
-	err := x.ledgerStore.AssertBucketExits(bucket)
+	if bucket == minioMetaBucket {
+		return x.putMetaObject(object, r)
+	}
+	err := x.ledgerStore.AssertBucketExits(x.ledgerBucket(ctx, bucket))
 	if err != nil {
 		return minio.ObjectInfo{}, x.toMinioErr(err, bucket, "", "")
 	}
-	data := r.Reader
-	// NOTE: dont support files with bytes size 0 without a folder suffix
-	if data.Size() == 0 && !strings.HasSuffix(object, "/") {
-		return minio.ObjectInfo{}, x.toMinioErr(ErrObjectSizeZero, bucket, object, "")
-	}
-	// emulates creation of an empty folder to keep parity with S3
-	if data.Size() == 0 && strings.HasSuffix(object, "/") {
-		// TODO: this may need to support several folders and subfolder structure recursively?
-		newObject, newR, err := x.emulateCreateFolder(object)
-		if err != nil {
-			return minio.ObjectInfo{}, x.toMinioErr(err, bucket, object, "")
-		}
-		defer newR.Close()
-		return x.putObject(ctx, newR, bucket, newObject, opts)
-	} else {
-		return x.putObject(ctx, r, bucket, object, opts)
-	}
+	return x.putObject(ctx, r, bucket, object, opts)
 }
 
 // Helper function for putObject
-func (x *xObjects) putObject(ctx context.Context, r io.Reader, bucket string, object string, opts minio.ObjectOptions) (minio.ObjectInfo, error) {
-	hash, size, err := ipfsFileUpload(ctx, x.fileClient, r)
-	if err != nil {
-		return minio.ObjectInfo{}, x.toMinioErr(err, bucket, object, "")
+func (x *xObjects) putObject(ctx context.Context, r *minio.PutObjReader, bucket string, object string, opts minio.ObjectOptions) (minio.ObjectInfo, error) {
+	var (
+		hash string
+		size int
+		err  error
+	)
+	// zero-byte objects, such as directory markers, are stored without data
+	if r.Reader.Size() != 0 {
+		hash, size, err = ipfsFileUpload(ctx, x.fileClient, r)
+		if err != nil {
+			return minio.ObjectInfo{}, x.toMinioErr(err, bucket, object, "")
+		}
 	}
 	obinfo := newObjectInfo(bucket, object, size, opts)
-	err = x.ledgerStore.PutObject(ctx, bucket, object, &Object{
+	if crypto.IsEncrypted(obinfo.UserDefined) {
+		// r has been encrypted by minio, so the data hash refers to the ciphertext,
+		// and the etag must be sealed with the object key.
+		obinfo.Etag = r.MD5CurrentHexString()
+	}
+	err = x.ledgerStore.PutObject(ctx, x.ledgerBucket(ctx, bucket), object, &Object{
 		DataHash:   hash,
 		ObjectInfo: obinfo,
 	})
@@ -208,10 +308,10 @@ func (x *xObjects) putObject(ctx context.Context, r io.Reader, bucket string, ob
 		return minio.ObjectInfo{}, x.toMinioErr(err, bucket, object, "")
 	}
 
-	obinfo.UserDefined = map[string]string{
-		fleekIpfsContentHash:   hash,
-		fleekIpfsContentHashV0: convertToHashV0(hash),
+	if hash == "" {
+		return getMinioObjectInfo(&obinfo), nil
 	}
+	obinfo.UserDefined = withContentHash(obinfo.UserDefined, hash)
 
 	pingHash(hash)
 
@@ -233,24 +333,40 @@ func (x *xObjects) CopyObject(
 	// TODO(bonedaddy): ensure we properly update the ledger with the destination object
 	// TODO(bonedaddy): ensure the destination object is properly adjusted with metadata
 
+	lsrc, ldst := x.ledgerBucket(ctx, srcBucket), x.ledgerBucket(ctx, dstBucket)
+	src, err := x.ledgerStore.ObjectInfo(ctx, lsrc, srcObject)
+	if err != nil {
+		return objInfo, x.toMinioErr(err, srcBucket, srcObject, "")
+	}
+	// Encrypted objects are sealed to their bucket and object name, so minio decrypts
+	// and re-encrypts them into srcInfo.PutObjReader, which must be stored as a new object.
+	// The only exception is a SSE-C key rotation, where only the sealed key is changed.
+	keyRotation := srcBucket == dstBucket && srcObject == dstObject &&
+		crypto.SSEC.IsEncrypted(src.GetUserDefined()) && crypto.SSEC.IsEncrypted(srcInfo.UserDefined)
+	if (crypto.IsEncrypted(src.GetUserDefined()) || crypto.IsEncrypted(srcInfo.UserDefined)) && !keyRotation {
+		return x.putObject(ctx, srcInfo.PutObjReader, dstBucket, dstObject, minio.ObjectOptions{
+			UserDefined: srcInfo.UserDefined,
+		})
+	}
+
 	//lock ordering by bucket name
-	if srcBucket == dstBucket {
-		defer x.ledgerStore.locker.write(dstBucket)()
-	} else if strings.Compare(srcBucket, dstBucket) > 0 {
-		defer x.ledgerStore.locker.read(srcBucket)()
-		defer x.ledgerStore.locker.write(dstBucket)()
+	if lsrc == ldst {
+		defer x.ledgerStore.locker.write(ldst)()
+	} else if strings.Compare(lsrc, ldst) > 0 {
+		defer x.ledgerStore.locker.read(lsrc)()
+		defer x.ledgerStore.locker.write(ldst)()
 	} else {
-		defer x.ledgerStore.locker.write(dstBucket)()
-		defer x.ledgerStore.locker.read(srcBucket)()
+		defer x.ledgerStore.locker.write(ldst)()
+		defer x.ledgerStore.locker.read(lsrc)()
 	}
 
 	// ensure destination bucket exists
-	err = x.ledgerStore.assertBucketExits(dstBucket)
+	err = x.ledgerStore.assertBucketExits(ldst)
 	if err != nil {
 		return objInfo, x.toMinioErr(err, dstBucket, "", "")
 	}
 
-	obj1, err := x.ledgerStore.object(ctx, srcBucket, srcObject)
+	obj1, err := x.ledgerStore.object(ctx, lsrc, srcObject)
 	if err != nil {
 		return objInfo, x.toMinioErr(err, srcBucket, srcObject, "")
 	}
@@ -272,8 +388,11 @@ func (x *xObjects) CopyObject(
 	obj.ObjectInfo.Name = dstObject
 	obj.ObjectInfo.Bucket = dstBucket
 	obj.ObjectInfo.ModTime = time.Now().UTC()
+	if keyRotation {
+		obj.ObjectInfo.UserDefined = srcInfo.UserDefined
+	}
 
-	err = x.ledgerStore.putObject(ctx, dstBucket, dstObject, obj)
+	err = x.ledgerStore.putObject(ctx, ldst, dstObject, obj)
 	if err != nil {
 		return objInfo, x.toMinioErr(err, dstBucket, dstObject, "")
 	}
@@ -290,7 +409,10 @@ func (x *xObjects) DeleteObject(
 	ctx context.Context,
 	bucket, object string,
 ) error {
-	err := x.ledgerStore.RemoveObject(ctx, bucket, object)
+	if bucket == minioMetaBucket {
+		return x.deleteMetaObject(object)
+	}
+	err := x.ledgerStore.RemoveObject(ctx, x.ledgerBucket(ctx, bucket), object)
 	return x.toMinioErr(err, bucket, object, "")
 }
 
@@ -299,7 +421,7 @@ func (x *xObjects) DeleteObjects(
 	bucket string,
 	objects []string,
 ) ([]error, error) {
-	missing, err := x.ledgerStore.RemoveObjects(ctx, bucket, objects...)
+	missing, err := x.ledgerStore.RemoveObjects(ctx, x.ledgerBucket(ctx, bucket), objects...)
 	if err != nil {
 		return nil, x.toMinioErr(err, bucket, "", "")
 	}
@@ -310,72 +432,3 @@ func (x *xObjects) DeleteObjects(
 	}
 	return errs, nil
 }
-
-func (x *xObjects) emulateCreateFolder(
-	object string,
-) (string, io.ReadCloser, error) {
-	modifiedObject := object + ".keep"
-
-	// check if local .keep file exists with more than 0 bytes
-	if exists := fileExists(placeHolderFileName); !exists {
-		log.Println("did not find a .keep file, attempting to create one")
-		// create file
-		linesToWrite := "KEEP FILE"
-		err := ioutil.WriteFile(".keep", []byte(linesToWrite), 0777)
-		if err != nil {
-			log.Println("system error while creating file .keep")
-			return "", nil, ErrCreatingEmptyFolder
-		}
-	}
-	// read keep file
-	f, err := os.Open(placeHolderFileName)
-	if err != nil {
-		log.Println("system error while opening .keep file")
-		return "", nil, ErrCreatingEmptyFolder
-	}
-
-	// we should be careful to close the file in the calling code
-	return modifiedObject, f, nil
-}
-
-// helper function for applying delimiter
-func filterObjectsWithDelimiter(objs []ObjectInfo, prefix string, delimiter string) ([]minio.ObjectInfo, []string) {
-	set := make(map[string]struct{})
-	prefixLen := len(prefix)
-	objects := make([]minio.ObjectInfo, 0, len(objs))
-	prefixes := make([]string, 0)
-	for _, obj := range objs {
-		sub := obj.Name[prefixLen:]
-		if idx := strings.Index(sub, delimiter); idx >= 0 {
-			// if we found delimiter we add prefix and filter object
-			currPrefix := prefix + sub[:idx] + delimiter
-			if _, ok := set[currPrefix]; !ok {
-				// only add elements that we dont have repeated
-				set[currPrefix] = struct{}{}
-				prefixes = append(prefixes, currPrefix)
-				// create folder obj representation
-				folderObj := minio.ObjectInfo{
-					Bucket:  obj.Bucket,
-					Name:    currPrefix,
-					ModTime: obj.ModTime,
-					ETag:    obj.Etag,
-					Size:    0,
-				}
-				objects = append(objects, folderObj)
-			}
-		} else {
-			// include object if delimiter not found
-			objects = append(objects, getMinioObjectInfo(&obj))
-		}
-	}
-
-	return objects, prefixes
-}
-
-func fileExists(name string) bool {
-	_, err := os.Stat(name)
-	if os.IsNotExist(err) {
-		return false
-	}
-	return err != nil
-}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"math"
+	"reflect"
 	"testing"
 
 	minio "github.com/minio/minio/cmd"
@@ -154,7 +155,32 @@ func testS3XGObject(t *testing.T, dsType DSType) {
 					t.Fatalf("got unexpected list: %v", list)
 				}
 			})
+			t.Run("Walk/"+tt.name, func(t *testing.T) {
+				results := make(chan minio.ObjectInfo)
+				err := gateway.Walk(ctx, tt.args.bucketName, "", results)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("err %v, wantErr %v", err, tt.wantErr)
+				}
+				var list []minio.ObjectInfo
+				for oi := range results {
+					list = append(list, oi)
+				}
+				if err == nil && len(list) != expectedLength {
+					t.Fatalf("got unexpected list: %v", list)
+				}
+			})
 		}
+		t.Run("Walk/canceled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+			results := make(chan minio.ObjectInfo)
+			if err := gateway.Walk(ctx, testBucket1, "", results); err != nil {
+				t.Fatal(err)
+			}
+			for oi := range results {
+				t.Fatalf("got unexpected object: %v", oi)
+			}
+		})
 	})
 	t.Run("GetObjectInfo", func(t *testing.T) {
 		tests := []struct {
@@ -282,6 +308,151 @@ func testS3XGObject(t *testing.T, dsType DSType) {
 	})
 }
 
+func TestS3X_ZeroByteObject_Badger(t *testing.T) {
+	testS3XZeroByteObject(t, DSTypeBadger)
+}
+func TestS3X_ZeroByteObject_Crdt(t *testing.T) {
+	testS3XZeroByteObject(t, DSTypeCrdt)
+}
+func testS3XZeroByteObject(t *testing.T, dsType DSType) {
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	const (
+		emptyObject = "empty"
+		dirMarker   = "folder/"
+		dirObject   = "folder/object"
+	)
+	for _, object := range []string{emptyObject, dirMarker} {
+		oi, err := gateway.PutObject(ctx, testBucket1, object, getTestPutObjectReader(t, nil), minio.ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.Name != object || oi.Size != 0 {
+			t.Fatalf("unexpected object info %+v", oi)
+		}
+	}
+	if _, err := gateway.PutObject(ctx, testBucket1, dirObject, getTestPutObjectReader(t, []byte(testObject1Data)), minio.ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("GetObject", func(t *testing.T) {
+		gateway.restart(t)
+		for _, object := range []string{emptyObject, dirMarker} {
+			buf := &bytes.Buffer{}
+			if err := gateway.GetObject(ctx, testBucket1, object, 0, 0, buf, "", minio.ObjectOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			gr, err := gateway.GetObjectNInfo(ctx, testBucket1, object, nil, nil, 0, minio.ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := buf.ReadFrom(gr); err != nil {
+				t.Fatal(err)
+			}
+			gr.Close()
+			if buf.Len() != 0 {
+				t.Fatalf("expected no data, but got %q", buf.String())
+			}
+		}
+	})
+	t.Run("ListObjects", func(t *testing.T) {
+		tests := []struct {
+			name, prefix, delimiter string
+			objects, prefixes       []string
+		}{
+			{"recursive", "", "", []string{emptyObject, dirMarker, dirObject}, nil},
+			{"root", "", "/", []string{emptyObject}, []string{dirMarker}},
+			{"folder", dirMarker, "/", []string{dirMarker, dirObject}, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				loi, err := gateway.ListObjects(ctx, testBucket1, tt.prefix, "", tt.delimiter, 1000)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var objects []string
+				for _, oi := range loi.Objects {
+					objects = append(objects, oi.Name)
+				}
+				if !reflect.DeepEqual(objects, tt.objects) {
+					t.Fatalf("expected objects %v, but got %v", tt.objects, objects)
+				}
+				if len(loi.Prefixes) != 0 || len(tt.prefixes) != 0 {
+					if !reflect.DeepEqual(loi.Prefixes, tt.prefixes) {
+						t.Fatalf("expected prefixes %v, but got %v", tt.prefixes, loi.Prefixes)
+					}
+				}
+				loi2, err := gateway.ListObjectsV2(ctx, testBucket1, tt.prefix, "", tt.delimiter, 1000, false, "")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(loi2.Objects) != len(tt.objects) || len(loi2.Prefixes) != len(tt.prefixes) {
+					t.Fatalf("unexpected V2 listing %+v", loi2)
+				}
+			})
+		}
+	})
+	t.Run("ListObjects/paged", func(t *testing.T) {
+		tests := []struct {
+			name, delimiter string
+			entries         []string
+		}{
+			{"recursive", "", []string{emptyObject, dirMarker, dirObject}},
+			{"root", "/", []string{emptyObject, dirMarker}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var entries, entries2 []string
+				var marker, token string
+				for i := 0; i <= len(tt.entries); i++ {
+					loi, err := gateway.ListObjects(ctx, testBucket1, "", marker, tt.delimiter, 1)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, oi := range loi.Objects {
+						entries = append(entries, oi.Name)
+					}
+					entries = append(entries, loi.Prefixes...)
+					loi2, err := gateway.ListObjectsV2(ctx, testBucket1, "", token, tt.delimiter, 1, false, "")
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, oi := range loi2.Objects {
+						entries2 = append(entries2, oi.Name)
+					}
+					entries2 = append(entries2, loi2.Prefixes...)
+					if loi.IsTruncated != loi2.IsTruncated {
+						t.Fatalf("expected the same pages, but got %+v and %+v", loi, loi2)
+					}
+					if !loi.IsTruncated {
+						break
+					}
+					marker, token = loi.NextMarker, loi2.NextContinuationToken
+				}
+				if !reflect.DeepEqual(entries, tt.entries) || !reflect.DeepEqual(entries2, tt.entries) {
+					t.Fatalf("expected entries %v, but got %v and %v", tt.entries, entries, entries2)
+				}
+			})
+		}
+	})
+	t.Run("Fsck", func(t *testing.T) {
+		resp, err := gateway.Fsck(ctx, &FsckRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Objects != 3 || len(resp.Issues) != 0 {
+			t.Fatalf("unexpected fsck response %+v", resp)
+		}
+	})
+}
+
 func getTestHashReader(t testing.TB, input io.Reader, size int64) *hash.Reader {
 	r, err := hash.NewReader(input, size, "", "", size, false)
 	if err != nil {
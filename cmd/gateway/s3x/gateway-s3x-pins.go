@@ -0,0 +1,523 @@
+package s3x
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/RTradeLtd/TxPB/v3/go"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPinBackoff is the time before the first retry of a pin job, if none is configured
+	defaultPinBackoff = 30 * time.Second
+	// defaultPinAttempts is the number of attempts before a pin job fails, if none is configured
+	defaultPinAttempts = 10
+	// pinMaxBackoff is the maximum time between two attempts of a pin job
+	pinMaxBackoff = time.Hour
+	// pinPersistTimeout is the maximum time a TemporalX target takes to retrieve the hashes of an attempt
+	pinPersistTimeout = 10 * time.Minute
+	// pinBatchSize is the maximum number of jobs that are processed between checks for new jobs
+	pinBatchSize = 100
+	// pinMetricsInterval is how long the number of jobs reported in metrics is cached
+	pinMetricsInterval = time.Minute
+)
+
+var pinJobsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName("s3x", "pin", "jobs"),
+	"Number of pin jobs by bucket, target and state",
+	[]string{"bucket", "target", "state"}, nil,
+)
+
+// SetBucketPinTargets sets the remote targets the objects of a bucket are pinned to,
+// existing objects are queued to be pinned to new targets.
+func (x *xObjects) SetBucketPinTargets(ctx context.Context, req *BucketPinTargets) (*BucketPinTargets, error) {
+	if req.GetBucket() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name is empty")
+	}
+	names := make(map[string]bool)
+	for _, t := range req.GetTargets() {
+		if err := validatePinTarget(t); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if names[t.Name] {
+			return nil, status.Errorf(codes.InvalidArgument, "target name %q is not unique", t.Name)
+		}
+		names[t.Name] = true
+	}
+	targets := &BucketPinTargets{
+		Bucket: x.ledgerBucket(ctx, req.GetBucket()),
+	}
+	for _, t := range req.GetTargets() {
+		if t.Token != "" {
+			token, err := x.pins.encryptToken(t.Token)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			t.Token = token
+		}
+		targets.Targets = append(targets.Targets, t)
+	}
+	if err := x.ledgerStore.SetPinTargets(ctx, targets); err != nil {
+		return nil, pinStatusError(err)
+	}
+	resp := &BucketPinTargets{Bucket: req.GetBucket()}
+	for _, t := range req.GetTargets() {
+		t.Token = ""
+		resp.Targets = append(resp.Targets, t)
+	}
+	return resp, nil
+}
+
+// GetPinStatus returns the pin jobs of an object, or of all objects in a bucket
+func (x *xObjects) GetPinStatus(ctx context.Context, req *PinStatusRequest) (*PinStatusResponse, error) {
+	if req.GetBucket() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name is empty")
+	}
+	jobs, err := x.ledgerStore.GetPinJobs(x.ledgerBucket(ctx, req.GetBucket()), req.GetObject())
+	if err != nil {
+		return nil, pinStatusError(err)
+	}
+	resp := &PinStatusResponse{}
+	for _, job := range jobs {
+		job.Bucket = req.GetBucket()
+		resp.Jobs = append(resp.Jobs, *job)
+	}
+	return resp, nil
+}
+
+func validatePinTarget(t PinTarget) error {
+	if t.Name == "" || strings.Contains(t.Name, "/") {
+		return fmt.Errorf("invalid target name %q", t.Name)
+	}
+	if t.Endpoint == "" {
+		return fmt.Errorf("target %q does not have an endpoint", t.Name)
+	}
+	if t.Type == PinTargetType_PINNING_SERVICE {
+		u, err := url.Parse(t.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("target %q does not have a valid pinning service url", t.Name)
+		}
+	}
+	return nil
+}
+
+func pinStatusError(err error) error {
+	if err == ErrLedgerBucketDoesNotExist {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// pinReplicator processes the pin jobs of the ledger, pinning objects to the remote targets of their buckets.
+//
+// Failed attempts are retried with exponential backoff, starting at backoff and
+// doubling up to pinMaxBackoff, until the job failed maxAttempts times.
+// Pin requests accepted by pinning services are checked for completion with the same backoff.
+// Every attempt is bounded by a timeout, so a stalled target does not block the jobs of other targets.
+type pinReplicator struct {
+	ls             *ledgerStore
+	dag            pb.NodeAPIClient // resolves the data hashes of objects
+	httpClient     *http.Client
+	persistTimeout time.Duration // bounds the attempts of TemporalX targets, as httpClient does for pinning services
+	backoff        time.Duration
+	maxAttempts    int32
+	secret         string // encrypts the tokens of pinning services
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn // TemporalX targets by endpoint
+
+	wake     chan struct{}
+	attempts *prometheus.CounterVec
+
+	countsMu  sync.Mutex
+	counts    map[pinJobsKey]int // jobs by bucket, target and state
+	countedAt time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// pinJobsKey identifies the jobs counted in metrics
+type pinJobsKey struct {
+	bucket, target string
+	state          PinState
+}
+
+func newPinReplicator(ls *ledgerStore, dag pb.NodeAPIClient, secret string, backoff time.Duration, maxAttempts int) *pinReplicator {
+	if backoff <= 0 {
+		backoff = defaultPinBackoff
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = defaultPinAttempts
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &pinReplicator{
+		ls:             ls,
+		dag:            dag,
+		httpClient:     &http.Client{Timeout: time.Minute},
+		persistTimeout: pinPersistTimeout,
+		backoff:        backoff,
+		maxAttempts:    int32(maxAttempts),
+		secret:         secret,
+		conns:          make(map[string]*grpc.ClientConn),
+		wake:           make(chan struct{}, 1),
+		attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "s3x",
+			Subsystem: "pin",
+			Name:      "attempts_total",
+			Help:      "Number of pin attempts by bucket, target and result",
+		}, []string{"bucket", "target", "result"}),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	ls.pinsQueued = p.notify
+	return p
+}
+
+// start processes the pin jobs until close is called, and registers the pin metrics
+func (p *pinReplicator) start() {
+	if err := prometheus.Register(p); err != nil {
+		log.Printf("failed to register pin metrics: %v", err)
+	}
+	p.wg.Add(1)
+	go p.run()
+}
+
+// close stops processing pin jobs, unfinished jobs are processed after a restart
+func (p *pinReplicator) close() {
+	p.cancel()
+	p.wg.Wait()
+	prometheus.Unregister(p)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+}
+
+// notify wakes up the replicator after jobs are queued
+func (p *pinReplicator) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (p *pinReplicator) run() {
+	defer p.wg.Done()
+	// due retries are picked up by the ticker
+	ticker := time.NewTicker(p.backoff)
+	defer ticker.Stop()
+	for {
+		jobs, err := p.ls.GetDuePinJobs(time.Now(), pinBatchSize)
+		if err != nil {
+			log.Printf("failed to list pin jobs: %v", err)
+		}
+		for _, job := range jobs {
+			if p.ctx.Err() != nil {
+				return
+			}
+			p.process(job)
+		}
+		if len(jobs) == pinBatchSize {
+			continue
+		}
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-p.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// process makes an attempt to pin the object of the job to its target, and saves the result
+func (p *pinReplicator) process(job *PinJob) {
+	state, err := p.pin(p.ctx, job)
+	if p.ctx.Err() != nil {
+		// the attempt was canceled by close
+		return
+	}
+	now := time.Now().UTC()
+	job.Attempts++
+	job.Updated = now
+	job.LastError = ""
+	if err != nil {
+		job.LastError = err.Error()
+	} else if state == PinState_PINNING && job.Attempts >= p.maxAttempts {
+		job.LastError = "timed out waiting for the target to finish pinning"
+	}
+	switch {
+	case job.LastError == "":
+		job.State = state
+	case job.Attempts >= p.maxAttempts:
+		job.State = PinState_FAILED
+	default:
+		job.State = state
+		if err != nil {
+			job.State = PinState_QUEUED
+		}
+	}
+	if job.State == PinState_QUEUED || job.State == PinState_PINNING {
+		backoff := p.backoff << uint(job.Attempts-1)
+		if backoff > pinMaxBackoff || backoff <= 0 {
+			backoff = pinMaxBackoff
+		}
+		job.NextAttempt = now.Add(backoff)
+	}
+	result := strings.ToLower(job.State.String())
+	if err != nil {
+		result = "error"
+	}
+	p.attempts.WithLabelValues(job.Bucket, job.Target, result).Inc()
+	if err := p.ls.UpdatePinJob(job); err != nil {
+		log.Printf("failed to update pin job of %s/%s for target %s: %v", job.Bucket, job.Object, job.Target, err)
+	}
+}
+
+// pin pins the object protocol buffer and the object data of the job to its target
+func (p *pinReplicator) pin(ctx context.Context, job *PinJob) (PinState, error) {
+	targets, err := p.ls.GetPinTargets(job.Bucket)
+	if err != nil {
+		return PinState_QUEUED, err
+	}
+	var target *PinTarget
+	for i, t := range targets.GetTargets() {
+		if t.Name == job.Target {
+			target = &targets.Targets[i]
+		}
+	}
+	if target == nil {
+		return PinState_QUEUED, fmt.Errorf("target %s does not exist", job.Target)
+	}
+	hashes := []string{job.Hash}
+	obj, err := ipfsObject(ctx, p.dag, job.Hash)
+	if err != nil {
+		return PinState_QUEUED, err
+	}
+	if obj.GetDataHash() != "" {
+		hashes = append(hashes, obj.GetDataHash())
+	}
+	switch target.Type {
+	case PinTargetType_PINNING_SERVICE:
+		return p.pinService(ctx, target, job, hashes)
+	default:
+		return p.pinTemporalX(ctx, target, hashes)
+	}
+}
+
+// pinTemporalX persists the hashes on a TemporalX node, which retrieves them from the network
+func (p *pinReplicator) pinTemporalX(ctx context.Context, target *PinTarget, hashes []string) (PinState, error) {
+	p.mu.Lock()
+	conn, ok := p.conns[target.Endpoint]
+	if !ok {
+		// the certificates of remote targets are not verified, targets are often self-hosted nodes
+		config, err := temporalXTLSConfig(target.Insecure, "", true)
+		if err == nil {
+			conn, err = dialTemporalX(target.Endpoint, config)
+		}
+		if err != nil {
+			p.mu.Unlock()
+			return PinState_QUEUED, err
+		}
+		p.conns[target.Endpoint] = conn
+	}
+	p.mu.Unlock()
+	ctx, cancel := context.WithTimeout(ctx, p.persistTimeout)
+	defer cancel()
+	resp, err := pb.NewNodeAPIClient(conn).Persist(ctx, &pb.PersistRequest{Cids: hashes})
+	if err != nil {
+		return PinState_QUEUED, err
+	}
+	for _, h := range hashes {
+		if !resp.GetStatus()[h] {
+			return PinState_QUEUED, fmt.Errorf("%s could not be persisted", h)
+		}
+	}
+	return PinState_PINNED, nil
+}
+
+// pinningServiceStatus is the pin status object of the IPFS pinning service api
+type pinningServiceStatus struct {
+	RequestID string `json:"requestid"`
+	Status    string `json:"status"`
+}
+
+// pinningServiceResults is the list of pin status objects of the IPFS pinning service api
+type pinningServiceResults struct {
+	Count   int                    `json:"count"`
+	Results []pinningServiceStatus `json:"results"`
+}
+
+// pinService requests a pinning service to pin the hashes, hashes that were already
+// requested are checked for completion using the request id of the job.
+//
+// With a crdt datastore every gateway processes the job, so before a hash is requested
+// the pins of the service are looked up, and a request for the hash that is not failed
+// is used instead, such as one made by another gateway.
+func (p *pinReplicator) pinService(ctx context.Context, target *PinTarget, job *PinJob, hashes []string) (PinState, error) {
+	if job.RequestIds == nil {
+		job.RequestIds = make(map[string]string)
+	}
+	state := PinState_PINNED
+	for _, h := range hashes {
+		var (
+			st  pinningServiceStatus
+			err error
+		)
+		if id, ok := job.RequestIds[h]; ok {
+			err = p.serviceRequest(ctx, target, http.MethodGet, "/pins/"+url.PathEscape(id), nil, &st)
+		} else {
+			st, err = p.requestServicePin(ctx, target, h, job.Bucket+"/"+job.Object)
+		}
+		if err != nil {
+			return PinState_QUEUED, err
+		}
+		switch st.Status {
+		case "pinned":
+			job.RequestIds[h] = st.RequestID
+		case "failed":
+			// the next attempt makes a new request
+			delete(job.RequestIds, h)
+			return PinState_QUEUED, fmt.Errorf("pinning service failed to pin %s", h)
+		default:
+			// queued or pinning
+			job.RequestIds[h] = st.RequestID
+			state = PinState_PINNING
+		}
+	}
+	return state, nil
+}
+
+// requestServicePin returns the existing request of the pinning service to pin the hash,
+// the hash is requested to be pinned if there is none.
+func (p *pinReplicator) requestServicePin(ctx context.Context, target *PinTarget, hash, name string) (pinningServiceStatus, error) {
+	var results pinningServiceResults
+	query := url.Values{
+		"cid":    {hash},
+		"status": {"queued,pinning,pinned"},
+	}
+	if err := p.serviceRequest(ctx, target, http.MethodGet, "/pins?"+query.Encode(), nil, &results); err != nil {
+		return pinningServiceStatus{}, err
+	}
+	if len(results.Results) > 0 {
+		return results.Results[0], nil
+	}
+	var st pinningServiceStatus
+	err := p.serviceRequest(ctx, target, http.MethodPost, "/pins", map[string]string{
+		"cid":  hash,
+		"name": name,
+	}, &st)
+	return st, err
+}
+
+// serviceRequest sends a request to the pinning service api of the target, and decodes the response into v
+func (p *pinReplicator) serviceRequest(ctx context.Context, target *PinTarget, method, path string, body, v interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(target.Endpoint, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if target.Token != "" {
+		token, err := p.decryptToken(target.Token)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("pinning service responded with %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// encryptToken encrypts the token of a pinning service to be saved in the ledger
+func (p *pinReplicator) encryptToken(token string) (string, error) {
+	data, err := madmin.EncryptData(p.secret, []byte(token))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// decryptToken decrypts the token of a pinning service saved in the ledger
+func (p *pinReplicator) decryptToken(token string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+	data, err = madmin.DecryptData(p.secret, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the token of the pinning service: %v", err)
+	}
+	return string(data), nil
+}
+
+// Describe implements prometheus.Collector
+func (p *pinReplicator) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pinJobsDesc
+	p.attempts.Describe(ch)
+}
+
+// Collect implements prometheus.Collector, the number of jobs in each state is counted from the ledger
+func (p *pinReplicator) Collect(ch chan<- prometheus.Metric) {
+	p.attempts.Collect(ch)
+	counts, err := p.countJobs()
+	if err != nil {
+		log.Printf("failed to list pin jobs: %v", err)
+		return
+	}
+	for k, n := range counts {
+		ch <- prometheus.MustNewConstMetric(pinJobsDesc, prometheus.GaugeValue, float64(n),
+			k.bucket, k.target, strings.ToLower(k.state.String()))
+	}
+}
+
+// countJobs returns the number of jobs by bucket, target and state, counting all jobs
+// of the ledger at most once every pinMetricsInterval
+func (p *pinReplicator) countJobs() (map[pinJobsKey]int, error) {
+	p.countsMu.Lock()
+	defer p.countsMu.Unlock()
+	if p.counts != nil && time.Since(p.countedAt) < pinMetricsInterval {
+		return p.counts, nil
+	}
+	jobs, err := p.ls.GetAllPinJobs()
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[pinJobsKey]int)
+	for _, job := range jobs {
+		counts[pinJobsKey{job.Bucket, job.Target, job.State}]++
+	}
+	p.counts = counts
+	p.countedAt = time.Now()
+	return counts, nil
+}
@@ -0,0 +1,279 @@
+package s3x
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestS3X_Pins_Badger(t *testing.T) {
+	testS3XPins(t, DSTypeBadger)
+}
+func TestS3X_Pins_Crdt(t *testing.T) {
+	testS3XPins(t, DSTypeCrdt)
+}
+func testS3XPins(t *testing.T, dsType DSType) {
+	const (
+		testObject2 = "testobject2"
+		testToken   = "secret"
+	)
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	// retry quickly, and give up after the second attempt
+	gateway.pins.close()
+	gateway.pins = newPinReplicator(gateway.ledgerStore, gateway.dagClient, "access:secret", 10*time.Millisecond, 2)
+	gateway.pins.persistTimeout = 100 * time.Millisecond
+	gateway.pins.start()
+
+	// service is a pinning service, that queues requests before pinning them
+	var (
+		mu       sync.Mutex
+		pinned   = make(map[string]bool)
+		requests int
+	)
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/pins":
+			results := pinningServiceResults{Results: []pinningServiceStatus{}}
+			if _, ok := pinned[r.URL.Query().Get("cid")]; ok {
+				results.Results = append(results.Results, pinningServiceStatus{RequestID: r.URL.Query().Get("cid"), Status: "pinning"})
+			}
+			results.Count = len(results.Results)
+			_ = json.NewEncoder(w).Encode(results)
+		case r.Method == http.MethodPost && r.URL.Path == "/pins":
+			requests++
+			var req struct {
+				Cid string `json:"cid"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			pinned[req.Cid] = false
+			_ = json.NewEncoder(w).Encode(pinningServiceStatus{RequestID: req.Cid, Status: "queued"})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/pins/"):
+			id := strings.TrimPrefix(r.URL.Path, "/pins/")
+			if _, ok := pinned[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			pinned[id] = true
+			_ = json.NewEncoder(w).Encode(pinningServiceStatus{RequestID: id, Status: "pinned"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer service.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+
+	xaddr := os.Getenv("TEST_XAPI")
+	if xaddr == "" {
+		xaddr = "xapi.temporal.cloud:9090"
+	}
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	testPutObject(t, gateway)
+
+	// waitStates waits until all jobs of the object reached their expected state
+	waitStates := func(t *testing.T, object string, expected map[string]PinState) []PinJob {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		var jobs []PinJob
+		for time.Now().Before(deadline) {
+			resp, err := gateway.GetPinStatus(ctx, &PinStatusRequest{Bucket: testBucket1, Object: object})
+			if err != nil {
+				t.Fatal(err)
+			}
+			jobs = resp.GetJobs()
+			done := len(jobs) == len(expected)
+			for _, job := range jobs {
+				if job.Object != object || job.State != expected[job.Target] {
+					done = false
+				}
+			}
+			if done {
+				return jobs
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("expected jobs in states %v, but got %+v", expected, jobs)
+		return nil
+	}
+
+	t.Run("invalid targets", func(t *testing.T) {
+		for _, targets := range [][]PinTarget{
+			{{Name: "", Endpoint: xaddr}},
+			{{Name: "a/b", Endpoint: xaddr}},
+			{{Name: "x"}},
+			{{Name: "x", Endpoint: xaddr}, {Name: "x", Endpoint: xaddr}},
+			{{Name: "x", Type: PinTargetType_PINNING_SERVICE, Endpoint: xaddr}},
+		} {
+			if _, err := gateway.SetBucketPinTargets(ctx, &BucketPinTargets{Bucket: testBucket1, Targets: targets}); err == nil {
+				t.Fatalf("expected an error for targets %+v", targets)
+			}
+		}
+		if _, err := gateway.SetBucketPinTargets(ctx, &BucketPinTargets{Bucket: testBucket2}); err == nil {
+			t.Fatal("expected an error for a missing bucket")
+		}
+	})
+	expected := map[string]PinState{"x": PinState_PINNED, "service": PinState_PINNED}
+	t.Run("existing objects", func(t *testing.T) {
+		resp, err := gateway.SetBucketPinTargets(ctx, &BucketPinTargets{
+			Bucket: testBucket1,
+			Targets: []PinTarget{
+				{Name: "x", Type: PinTargetType_TEMPORALX, Endpoint: xaddr, Insecure: true},
+				{Name: "service", Type: PinTargetType_PINNING_SERVICE, Endpoint: service.URL, Token: testToken},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, target := range resp.GetTargets() {
+			if target.Token != "" {
+				t.Fatal("expected the token to be redacted")
+			}
+		}
+		targets, err := gateway.ledgerStore.GetPinTargets(testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token := targets.GetTargets()[1].Token; token == "" || strings.Contains(token, testToken) {
+			t.Fatalf("expected the token to be saved encrypted, but got %q", token)
+		}
+		jobs := waitStates(t, testObject1, expected)
+		obj, err := ipfsObject(ctx, gateway.dagClient, jobs[0].Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, h := range []string{jobs[0].Hash, obj.GetDataHash()} {
+			if !pinned[h] {
+				t.Fatalf("expected %s to be pinned by the service", h)
+			}
+		}
+	})
+	t.Run("new objects", func(t *testing.T) {
+		if _, err := gateway.PutObject(ctx, testBucket1, testObject2, getTestPutObjectReader(t, []byte(testObject1Data)), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		waitStates(t, testObject2, expected)
+		// the data is the same as of the first object, so its existing pin request is used
+		mu.Lock()
+		defer mu.Unlock()
+		if requests != 3 {
+			t.Fatalf("expected 3 pin requests, but got %d", requests)
+		}
+	})
+	t.Run("failed", func(t *testing.T) {
+		if _, err := gateway.SetBucketPinTargets(ctx, &BucketPinTargets{
+			Bucket: testBucket1,
+			Targets: []PinTarget{
+				{Name: "x", Type: PinTargetType_TEMPORALX, Endpoint: xaddr, Insecure: true},
+				{Name: "broken", Type: PinTargetType_PINNING_SERVICE, Endpoint: broken.URL},
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		// the jobs of the removed target are deleted, and the unchanged target is not queued again
+		for _, object := range []string{testObject1, testObject2} {
+			jobs := waitStates(t, object, map[string]PinState{"x": PinState_PINNED, "broken": PinState_FAILED})
+			for _, job := range jobs {
+				if job.Target == "broken" && (job.Attempts != 2 || job.LastError == "") {
+					t.Fatalf("expected 2 failed attempts, but got %+v", job)
+				}
+			}
+		}
+		due, err := gateway.ledgerStore.GetDuePinJobs(time.Now().Add(pinMaxBackoff), pinBatchSize)
+		if err != nil || len(due) != 0 {
+			t.Fatalf("expected no pending jobs, but got %+v, %v", due, err)
+		}
+	})
+	t.Run("metrics", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+		if err := reg.Register(gateway.pins); err != nil {
+			t.Fatal(err)
+		}
+		families, err := reg.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var failed float64
+		for _, f := range families {
+			if f.GetName() != "s3x_pin_jobs" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				for _, l := range m.GetLabel() {
+					if l.GetName() == "state" && l.GetValue() == "failed" {
+						failed += m.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		if failed != 2 {
+			t.Fatalf("expected 2 failed jobs, but got %v", failed)
+		}
+	})
+	t.Run("remove object", func(t *testing.T) {
+		if err := gateway.DeleteObject(ctx, testBucket1, testObject2); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := gateway.GetPinStatus(ctx, &PinStatusRequest{Bucket: testBucket1, Object: testObject2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.GetJobs()) != 0 {
+			t.Fatalf("expected no jobs, but got %+v", resp.GetJobs())
+		}
+	})
+	t.Run("stalled target", func(t *testing.T) {
+		// the target accepts connections, but never responds
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l.Close()
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+		if _, err := gateway.SetBucketPinTargets(ctx, &BucketPinTargets{
+			Bucket:  testBucket1,
+			Targets: []PinTarget{{Name: "stalled", Type: PinTargetType_TEMPORALX, Endpoint: l.Addr().String(), Insecure: true}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		waitStates(t, testObject1, map[string]PinState{"stalled": PinState_FAILED})
+	})
+}
@@ -0,0 +1,199 @@
+package s3x
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	minioclient "github.com/minio/minio-go/v6"
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/s3select"
+)
+
+func TestS3X_Select_Badger(t *testing.T) {
+	testS3XSelect(t, DSTypeBadger)
+}
+func TestS3X_Select_Crdt(t *testing.T) {
+	testS3XSelect(t, DSTypeCrdt)
+}
+func testS3XSelect(t *testing.T, dsType DSType) {
+	const (
+		csvObject     = "logs.csv"
+		jsonObject    = "logs.json"
+		parquetObject = "data.parquet"
+		// the header and records are 13 bytes each
+		csvData  = "id,level,msg\n1,info,start\n2,warn,retry\n3,fail,abort\n"
+		jsonData = "{\"id\":1,\"level\":\"info\"}\n{\"id\":2,\"level\":\"warn\"}\n"
+	)
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	parquetData, err := ioutil.ReadFile("../../../pkg/s3select/testdata.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for object, data := range map[string][]byte{
+		csvObject:     []byte(csvData),
+		jsonObject:    []byte(jsonData),
+		parquetObject: parquetData,
+	} {
+		if _, err := gateway.PutObject(ctx, testBucket1, object, getTestPutObjectReader(t, data), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("range reads", func(t *testing.T) {
+		// ranges within and across the blocks of the file, and suffixes like parquet footer reads
+		for _, rs := range []*minio.HTTPRangeSpec{
+			{Start: 0, End: 9},
+			{Start: 100, End: 899},
+			{Start: 300, End: -1},
+			{IsSuffixLength: true, Start: -8},
+			{IsSuffixLength: true, Start: -600},
+		} {
+			start, length, err := rs.GetOffsetLength(int64(len(parquetData)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			gr, err := gateway.GetObjectNInfo(ctx, testBucket1, parquetObject, rs, nil, 0, minio.ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := ioutil.ReadAll(gr)
+			gr.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, parquetData[start:start+length]) {
+				t.Fatalf("unexpected data for range %+v", rs)
+			}
+		}
+	})
+
+	tests := []struct {
+		name, object string
+		input        string
+		query        string
+		scanRange    string
+		want         string
+	}{
+		{
+			name:   "csv",
+			object: csvObject,
+			input:  "<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>",
+			query:  "SELECT msg FROM S3Object s WHERE s.level = 'warn'",
+			want:   "retry",
+		},
+		{
+			name:      "csv scan range",
+			object:    csvObject,
+			input:     "<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>",
+			query:     "SELECT id FROM S3Object",
+			scanRange: "<Start>14</Start><End>27</End>",
+			want:      "2",
+		},
+		{
+			name:      "csv suffix scan range",
+			object:    csvObject,
+			input:     "<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>",
+			query:     "SELECT id FROM S3Object",
+			scanRange: "<End>13</End>",
+			want:      "3",
+		},
+		{
+			name:   "json lines",
+			object: jsonObject,
+			input:  "<JSON><Type>LINES</Type></JSON>",
+			query:  "SELECT s.id FROM S3Object s WHERE s.level = 'info'",
+			want:   "1",
+		},
+		{
+			name:   "parquet",
+			object: parquetObject,
+			input:  "<Parquet></Parquet>",
+			query:  "SELECT two FROM S3Object WHERE one = 2.5",
+			want:   "baz",
+		},
+		{
+			name:   "parquet aggregate",
+			object: parquetObject,
+			input:  "<Parquet></Parquet>",
+			query:  "SELECT COUNT(*) FROM S3Object",
+			want:   "3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanRange := ""
+			if tt.scanRange != "" {
+				scanRange = "<ScanRange>" + tt.scanRange + "</ScanRange>"
+			}
+			req := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization><CompressionType>NONE</CompressionType>%s</InputSerialization>
+    <OutputSerialization><CSV></CSV></OutputSerialization>
+    %s
+</SelectObjectContentRequest>`, tt.query, tt.input, scanRange)
+			got := testSelect(t, gateway, tt.object, req)
+			if got != tt.want {
+				t.Fatalf("expected %q, but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// testSelect runs an S3 Select request on the object the same way SelectObjectContentHandler
+// does, and returns the selected records
+func testSelect(t *testing.T, gateway *testGateway, object, request string) string {
+	t.Helper()
+	ctx := context.Background()
+	objInfo, err := gateway.GetObjectInfo(ctx, testBucket1, object, minio.ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3Select, err := s3select.NewS3Select(strings.NewReader(request))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3Select.SetObjectSize(objInfo.Size)
+	if err := s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+		end := offset + length - 1
+		if length < 0 {
+			end = -1
+		}
+		rs := &minio.HTTPRangeSpec{IsSuffixLength: offset < 0, Start: offset, End: end}
+		return gateway.GetObjectNInfo(ctx, testBucket1, object, rs, nil, 0, minio.ObjectOptions{})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	s3Select.Evaluate(w)
+	s3Select.Close()
+	res, err := minioclient.NewSelectResults(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(w.Body),
+	}, testBucket1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,105 @@
+package s3x
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/auth"
+)
+
+/* Design Notes
+---------------
+
+With tenants enabled, every account has its own bucket namespace, so that two accounts can
+both own a bucket named "site". The tenant of a request is the parent user of its credentials,
+or the access key for accounts without a parent, like the lambda hook reports the owner.
+
+The ledger is shared by all tenants, the buckets of a tenant are saved as "<tenant>:<bucket>",
+with the tenant hex encoded. ':' is not allowed in bucket names, so these ledger names never
+collide with buckets of other tenants, or with the buckets of requests without credentials,
+which stay in the global namespace with their plain names.
+
+xObjects translates bucket names to ledger names before calling the ledgerStore, while objects
+keep the bucket name of their tenant in their ObjectInfo. The ledger strips the tenant when
+calling the lambda hook and sending events, which identify the account on their own.
+Heal, usage and the deduplication stats of all buckets work on the ledger names of all tenants,
+since they are admin operations.
+
+xObjects implements minio.GatewayBucketNamespacer, so the bucket configs the server keeps in
+the meta bucket, like the quota and the owner of a bucket, are saved under the ledger names as
+well. The quota of a bucket is then checked against the usage of the same ledger bucket, and
+the buckets of other tenants with the same name have configs of their own. Bucket policies are
+not supported by the gateway, so there are no policies to share between tenants.
+*/
+
+// tenantSeparator separates the encoded tenant from the bucket name in ledger names
+const tenantSeparator = ":"
+
+// tenant returns the bucket namespace of the credentials in ctx, an empty tenant is the global namespace.
+// Anonymous requests have no credentials in ctx, or empty ones, so they always use the global namespace
+// and can't reach the buckets of a tenant.
+func (x *xObjects) tenant(ctx context.Context) string {
+	if !x.tenants {
+		return ""
+	}
+	cred, ok := ctx.Value(authHeader).(auth.Credentials)
+	if !ok {
+		return ""
+	}
+	if cred.ParentUser != "" {
+		return cred.ParentUser
+	}
+	return cred.AccessKey
+}
+
+// ledgerBucket returns the name of the bucket of the tenant in ctx in the ledger
+func (x *xObjects) ledgerBucket(ctx context.Context, bucket string) string {
+	return ledgerBucketName(x.tenant(ctx), bucket)
+}
+
+// NamespacedBucket implements minio.GatewayBucketNamespacer
+func (x *xObjects) NamespacedBucket(ctx context.Context, bucket string) string {
+	return x.ledgerBucket(ctx, bucket)
+}
+
+// ListNamespacedBuckets implements minio.GatewayBucketNamespacer
+func (x *xObjects) ListNamespacedBuckets(ctx context.Context) ([]minio.BucketInfo, error) {
+	names, err := x.ledgerStore.GetBucketNames()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]minio.BucketInfo, 0, len(names))
+	for _, name := range names {
+		infos = append(infos, minio.BucketInfo{Name: name})
+	}
+	return infos, nil
+}
+
+// ledgerBucketName returns the ledger name of the bucket of a tenant
+func ledgerBucketName(tenant, bucket string) string {
+	if tenant == "" || bucket == "" || bucket == minioMetaBucket {
+		return bucket
+	}
+	return hex.EncodeToString([]byte(tenant)) + tenantSeparator + bucket
+}
+
+// splitLedgerBucket returns the tenant and bucket name of a ledger bucket name
+func splitLedgerBucket(name string) (tenant, bucket string) {
+	i := strings.Index(name, tenantSeparator)
+	if i < 0 {
+		return "", name
+	}
+	t, err := hex.DecodeString(name[:i])
+	if err != nil {
+		return "", name
+	}
+	return string(t), name[i+len(tenantSeparator):]
+}
+
+// tenantBucket returns the bucket name of a ledger bucket name, without its tenant
+func tenantBucket(name string) string {
+	_, bucket := splitLedgerBucket(name)
+	return bucket
+}
@@ -0,0 +1,211 @@
+package s3x
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestS3X_Tenants_Badger(t *testing.T) {
+	testS3XTenants(t, DSTypeBadger)
+}
+func TestS3X_Tenants_Crdt(t *testing.T) {
+	testS3XTenants(t, DSTypeCrdt)
+}
+func testS3XTenants(t *testing.T, dsType DSType) {
+	const bucket = "site"
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	gateway.tenants = true
+
+	// bob and his service account share a namespace, requests without credentials use the global namespace
+	tenants := map[string]context.Context{
+		"alice":  context.WithValue(ctx, authHeader, auth.Credentials{AccessKey: "alice"}),
+		"bob":    context.WithValue(ctx, authHeader, auth.Credentials{AccessKey: "bob"}),
+		"global": ctx,
+	}
+	bobService := context.WithValue(ctx, authHeader, auth.Credentials{AccessKey: "bob-service", ParentUser: "bob"})
+	for name, tctx := range tenants {
+		if err := gateway.MakeBucketWithLocation(tctx, bucket, "us-east-1"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.PutObject(tctx, bucket, name, getTestPutObjectReader(t, []byte(name)), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("isolated buckets", func(t *testing.T) {
+		for name, tctx := range tenants {
+			buckets, err := gateway.ListBuckets(tctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(buckets) != 1 || buckets[0].Name != bucket {
+				t.Fatalf("expected only bucket %s for %s, but got %+v", bucket, name, buckets)
+			}
+			loi, err := gateway.ListObjects(tctx, bucket, "", "", "", 1000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(loi.Objects) != 1 || loi.Objects[0].Name != name || loi.Objects[0].Bucket != bucket {
+				t.Fatalf("expected only object %s for %s, but got %+v", name, name, loi.Objects)
+			}
+			var buf bytes.Buffer
+			if err := gateway.GetObject(tctx, bucket, name, 0, int64(len(name)), &buf, "", minio.ObjectOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != name {
+				t.Fatalf("expected data %s, but got %s", name, buf.String())
+			}
+		}
+	})
+	t.Run("parent user", func(t *testing.T) {
+		if _, err := gateway.GetObjectInfo(bobService, bucket, "bob", minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.GetObjectInfo(bobService, bucket, "alice", minio.ObjectOptions{}); err == nil {
+			t.Fatal("expected an error for an object of another tenant")
+		}
+	})
+	t.Run("hashes", func(t *testing.T) {
+		hashes := make(map[string]string)
+		for name, tctx := range tenants {
+			resp, err := gateway.GetHash(tctx, &InfoRequest{Bucket: bucket})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.GetBucket() != bucket {
+				t.Fatalf("expected bucket %s, but got %s", bucket, resp.GetBucket())
+			}
+			if other, ok := hashes[resp.GetHash()]; ok {
+				t.Fatalf("%s and %s have the same bucket hash", name, other)
+			}
+			hashes[resp.GetHash()] = name
+			if _, err := gateway.GetHash(tctx, &InfoRequest{Bucket: bucket, Object: name}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := gateway.GetHash(tenants["alice"], &InfoRequest{Bucket: bucket, Object: "bob"}); err == nil {
+			t.Fatal("expected an error for an object of another tenant")
+		}
+	})
+	t.Run("namespaced bucket", func(t *testing.T) {
+		// the server keeps the configs of a bucket, like its quota, by the namespaced bucket
+		namespaced := make(map[string]string)
+		for name, tctx := range tenants {
+			nb := gateway.NamespacedBucket(tctx, bucket)
+			if other, ok := namespaced[nb]; ok {
+				t.Fatalf("%s and %s have the same namespaced bucket %s", name, other, nb)
+			}
+			namespaced[nb] = name
+			if size, err := gateway.GetBucketUsage(tctx, bucket); err != nil || size != uint64(len(name)) {
+				t.Fatalf("expected usage %d for %s, but got %d, %v", len(name), name, size, err)
+			}
+		}
+		if nb := gateway.NamespacedBucket(ctx, bucket); nb != bucket {
+			t.Fatalf("expected the global bucket %s, but got %s", bucket, nb)
+		}
+		buckets, err := gateway.ListNamespacedBuckets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(buckets) != len(tenants) {
+			t.Fatalf("expected %d namespaced buckets, but got %+v", len(tenants), buckets)
+		}
+		for _, b := range buckets {
+			if _, ok := namespaced[b.Name]; !ok {
+				t.Fatalf("unexpected namespaced bucket %s", b.Name)
+			}
+		}
+	})
+	t.Run("copy", func(t *testing.T) {
+		alice := tenants["alice"]
+		if _, err := gateway.CopyObject(alice, bucket, "alice", bucket, "copy", minio.ObjectInfo{},
+			minio.ObjectOptions{}, minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		oi, err := gateway.GetObjectInfo(alice, bucket, "copy", minio.ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.Bucket != bucket {
+			t.Fatalf("expected bucket %s, but got %s", bucket, oi.Bucket)
+		}
+		if _, err := gateway.GetObjectInfo(tenants["bob"], bucket, "copy", minio.ObjectOptions{}); err == nil {
+			t.Fatal("expected an error for an object of another tenant")
+		}
+	})
+	t.Run("anonymous", func(t *testing.T) {
+		// anonymous requests carry no credentials, or empty ones, and always use the global namespace
+		for _, actx := range []context.Context{ctx, context.WithValue(ctx, authHeader, auth.Credentials{})} {
+			if tenant := gateway.tenant(actx); tenant != "" {
+				t.Fatalf("expected the global namespace, but got tenant %s", tenant)
+			}
+			if _, err := gateway.GetObjectInfo(actx, bucket, "global", minio.ObjectOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := gateway.GetObjectInfo(actx, bucket, "alice", minio.ObjectOptions{}); err == nil {
+				t.Fatal("expected an error for an object of a tenant")
+			}
+		}
+	})
+	t.Run("multipart", func(t *testing.T) {
+		alice, bob := tenants["alice"], tenants["bob"]
+		uploadID, err := gateway.NewMultipartUpload(alice, bucket, "multipart", minio.ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.PutObjectPart(bob, bucket, "multipart", uploadID, 1,
+			getTestPutObjectReader(t, []byte("bob")), minio.ObjectOptions{}); err == nil {
+			t.Fatal("expected an error for an upload of another tenant")
+		}
+		if _, err := gateway.ListObjectParts(bob, bucket, "multipart", uploadID, 0, 1000, minio.ObjectOptions{}); err == nil {
+			t.Fatal("expected an error for an upload of another tenant")
+		}
+		if _, err := gateway.CompleteMultipartUpload(bob, bucket, "multipart", uploadID, nil, minio.ObjectOptions{}); err == nil {
+			t.Fatal("expected an error for an upload of another tenant")
+		}
+		if err := gateway.AbortMultipartUpload(bob, bucket, "multipart", uploadID); err == nil {
+			t.Fatal("expected an error for an upload of another tenant")
+		}
+		pi, err := gateway.PutObjectPart(alice, bucket, "multipart", uploadID, 1,
+			getTestPutObjectReader(t, []byte("alice")), minio.ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		oi, err := gateway.CompleteMultipartUpload(alice, bucket, "multipart", uploadID,
+			[]minio.CompletePart{{PartNumber: 1, ETag: pi.ETag}}, minio.ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.Bucket != bucket {
+			t.Fatalf("expected bucket %s, but got %s", bucket, oi.Bucket)
+		}
+		if _, err := gateway.GetObjectInfo(bob, bucket, "multipart", minio.ObjectOptions{}); err == nil {
+			t.Fatal("expected an error for an object of another tenant")
+		}
+	})
+	t.Run("delete bucket", func(t *testing.T) {
+		alice := tenants["alice"]
+		if _, err := gateway.DeleteObjects(alice, bucket, []string{"alice", "copy", "multipart"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.DeleteBucket(alice, bucket, false); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.GetBucketInfo(alice, bucket); err == nil {
+			t.Fatal("expected the bucket to be deleted")
+		}
+		if _, err := gateway.GetBucketInfo(tenants["bob"], bucket); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
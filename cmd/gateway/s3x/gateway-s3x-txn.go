@@ -0,0 +1,141 @@
+package s3x
+
+import (
+	"context"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BeginTransaction starts a transaction of a bucket, the returned id is used to stage operations
+// and commit them together, such as all files of a static site deploy.
+func (x *xObjects) BeginTransaction(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
+	if req.GetBucket() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name is empty")
+	}
+	txn, err := x.ledgerStore.BeginTransaction(x.ledgerBucket(ctx, req.GetBucket()), ksuid.New().String())
+	if err != nil {
+		return nil, txnStatusError(err)
+	}
+	txn.Bucket = req.GetBucket()
+	return txn, nil
+}
+
+// StageTransaction adds operations to a transaction, the data of puts is uploaded before the
+// operations are staged, and is not returned with the transaction.
+func (x *xObjects) StageTransaction(ctx context.Context, req *TransactionOps) (*Transaction, error) {
+	if req.GetBucket() == "" || req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name or transaction id is empty")
+	}
+	ops := make([]TransactionOp, 0, len(req.GetOps()))
+	for _, op := range req.GetOps() {
+		if op.Object == "" {
+			return nil, status.Error(codes.InvalidArgument, "object name is empty")
+		}
+		switch op.Type {
+		case TransactionOpType_PUT:
+			hash, err := x.stageObject(ctx, req.GetBucket(), op)
+			if _, ok := err.(minio.BucketQuotaExceeded); ok {
+				return nil, status.Error(codes.ResourceExhausted, err.Error())
+			}
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			op.Hash = hash
+			op.Data = nil
+		case TransactionOpType_COPY:
+			if op.Source == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "copy to %q does not have a source", op.Object)
+			}
+			op.Hash = ""
+		case TransactionOpType_DELETE:
+			op.Hash = ""
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid operation type %v", op.Type)
+		}
+		ops = append(ops, op)
+	}
+	txn, err := x.ledgerStore.StageTransaction(x.ledgerBucket(ctx, req.GetBucket()), req.GetId(), ops)
+	if err != nil {
+		return nil, txnStatusError(err)
+	}
+	txn.Bucket = req.GetBucket()
+	return txn, nil
+}
+
+// stageObject uploads the data of a staged put, and saves its object protocol buffer to ipfs.
+// Like with PutObject, the quota of the bucket is enforced and the data is encrypted if required.
+func (x *xObjects) stageObject(ctx context.Context, bucket string, op TransactionOp) (string, error) {
+	metadata := make(map[string]string, len(op.UserDefined))
+	for k, v := range op.UserDefined {
+		metadata[k] = v
+	}
+	r, err := minio.NewGatewayPutObjReader(ctx, x, bucket, op.Object, op.Data, metadata)
+	if err != nil {
+		return "", err
+	}
+	var (
+		hash string
+		size int
+	)
+	// zero-byte objects are stored without data, like by PutObject
+	if r.Reader.Size() != 0 {
+		hash, size, err = ipfsFileUpload(ctx, x.fileClient, r)
+		if err != nil {
+			return "", err
+		}
+	}
+	obinfo := newObjectInfo(bucket, op.Object, size, minio.ObjectOptions{
+		UserDefined: metadata,
+	})
+	if crypto.IsEncrypted(obinfo.UserDefined) {
+		// the data hash refers to the ciphertext, and the etag must be sealed with the object key
+		obinfo.Etag = r.MD5CurrentHexString()
+	}
+	return ipfsSave(ctx, x.dagClient, &Object{
+		DataHash:   hash,
+		ObjectInfo: obinfo,
+	})
+}
+
+// CommitTransaction applies the staged operations of a transaction as one new bucket root
+func (x *xObjects) CommitTransaction(ctx context.Context, req *TransactionRequest) (*InfoResponse, error) {
+	if req.GetBucket() == "" || req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name or transaction id is empty")
+	}
+	hash, err := x.ledgerStore.CommitTransaction(ctx, x.ledgerBucket(ctx, req.GetBucket()), req.GetId())
+	if err != nil {
+		return nil, txnStatusError(err)
+	}
+	return &InfoResponse{
+		Bucket: req.GetBucket(),
+		Hash:   hash,
+	}, nil
+}
+
+// AbortTransaction discards a transaction and its staged operations
+func (x *xObjects) AbortTransaction(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
+	if req.GetBucket() == "" || req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name or transaction id is empty")
+	}
+	txn, err := x.ledgerStore.AbortTransaction(x.ledgerBucket(ctx, req.GetBucket()), req.GetId())
+	if err != nil {
+		return nil, txnStatusError(err)
+	}
+	txn.Bucket = req.GetBucket()
+	return txn, nil
+}
+
+func txnStatusError(err error) error {
+	switch err {
+	case ErrLedgerBucketDoesNotExist, ErrLedgerTransactionDoesNotExist:
+		return status.Error(codes.NotFound, err.Error())
+	case ErrLedgerObjectDoesNotExist:
+		// the source of a copy does not exist, the transaction can be aborted
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,215 @@
+package s3x
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestS3X_Transactions_Badger(t *testing.T) {
+	testS3XTransactions(t, DSTypeBadger)
+}
+func TestS3X_Transactions_Crdt(t *testing.T) {
+	testS3XTransactions(t, DSTypeCrdt)
+}
+func testS3XTransactions(t *testing.T, dsType DSType) {
+	const (
+		testObject2 = "testobject2"
+		indexData   = "<html></html>"
+	)
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{testObject1, testObject2} {
+		if _, err := gateway.PutObject(ctx, testBucket1, object, getTestPutObjectReader(t, []byte(testObject1Data)), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// objects returns the names of all objects in the bucket
+	objects := func(t *testing.T) []string {
+		t.Helper()
+		names, err := gateway.ledgerStore.GetObjectNames(ctx, testBucket1, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return names
+	}
+	begin := func(t *testing.T) string {
+		t.Helper()
+		txn, err := gateway.BeginTransaction(ctx, &TransactionRequest{Bucket: testBucket1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if txn.Bucket != testBucket1 || txn.Id == "" {
+			t.Fatalf("unexpected transaction %+v", txn)
+		}
+		return txn.Id
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := gateway.BeginTransaction(ctx, &TransactionRequest{Bucket: testBucket2}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound for a missing bucket, but got %v", err)
+		}
+		if _, err := gateway.CommitTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: "missing"}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound for a missing transaction, but got %v", err)
+		}
+		id := begin(t)
+		for _, op := range []TransactionOp{
+			{Type: TransactionOpType_PUT},
+			{Type: TransactionOpType_COPY, Object: testObject2},
+			{Type: TransactionOpType(10), Object: testObject2},
+		} {
+			if _, err := gateway.StageTransaction(ctx, &TransactionOps{Bucket: testBucket1, Id: id, Ops: []TransactionOp{op}}); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("expected InvalidArgument for %+v, but got %v", op, err)
+			}
+		}
+		if _, err := gateway.AbortTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("commit", func(t *testing.T) {
+		before, err := gateway.ledgerStore.GetBucketHash(testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := begin(t)
+		txn, err := gateway.StageTransaction(ctx, &TransactionOps{Bucket: testBucket1, Id: id, Ops: []TransactionOp{
+			{Type: TransactionOpType_PUT, Object: "index.html", Data: []byte(indexData), UserDefined: map[string]string{"content-type": "text/html"}},
+			{Type: TransactionOpType_PUT, Object: "empty"},
+			{Type: TransactionOpType_COPY, Object: "index2.html", Source: "index.html"},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, op := range txn.Ops {
+			if len(op.Data) != 0 || (op.Type == TransactionOpType_PUT && op.Hash == "") {
+				t.Fatalf("unexpected staged operation %+v", op)
+			}
+		}
+		if _, err := gateway.StageTransaction(ctx, &TransactionOps{Bucket: testBucket1, Id: id, Ops: []TransactionOp{
+			{Type: TransactionOpType_DELETE, Object: testObject2},
+			{Type: TransactionOpType_DELETE, Object: "empty"},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		// nothing is visible before the commit
+		if names := objects(t); len(names) != 2 {
+			t.Fatalf("expected the staged operations to be invisible, but got %v", names)
+		}
+		if _, err := gateway.GetObjectInfo(ctx, testBucket1, "index.html", minio.ObjectOptions{}); err == nil {
+			t.Fatal("expected the staged object to be invisible")
+		}
+
+		resp, err := gateway.CommitTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		after, err := gateway.ledgerStore.GetBucketHash(testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Hash != after || after == before {
+			t.Fatalf("expected the new bucket hash %s, but got %s", after, resp.Hash)
+		}
+		names := objects(t)
+		if len(names) != 3 || names[0] != "index.html" || names[1] != "index2.html" || names[2] != testObject1 {
+			t.Fatalf("unexpected objects %v", names)
+		}
+		for _, object := range []string{"index.html", "index2.html"} {
+			oi, err := gateway.GetObjectInfo(ctx, testBucket1, object, minio.ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if oi.Bucket != testBucket1 || oi.Name != object || oi.ContentType != "text/html" {
+				t.Fatalf("unexpected object info %+v", oi)
+			}
+			var buf bytes.Buffer
+			if err := gateway.GetObject(ctx, testBucket1, object, 0, oi.Size, &buf, "", minio.ObjectOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != indexData {
+				t.Fatalf("expected data %s, but got %s", indexData, buf.String())
+			}
+		}
+		u, err := gateway.ledgerStore.GetBucketUsage(ctx, testBucket1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.ObjectsCount != 3 || u.Size_ != int64(len(testObject1Data)+2*len(indexData)) {
+			t.Fatalf("unexpected usage %+v", u)
+		}
+		// the data references are written in the batch of the commit, the copy shares the data of
+		// index.html, and testObject1 still references the data of the deleted testObject2
+		if u.UniqueCount != 2 || u.UniqueSize != int64(len(testObject1Data)+len(indexData)) {
+			t.Fatalf("unexpected unique usage %+v", u)
+		}
+		if _, err := gateway.CommitTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: id}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected the committed transaction to be removed, but got %v", err)
+		}
+	})
+	t.Run("abort", func(t *testing.T) {
+		id := begin(t)
+		if _, err := gateway.StageTransaction(ctx, &TransactionOps{Bucket: testBucket1, Id: id, Ops: []TransactionOp{
+			{Type: TransactionOpType_PUT, Object: "aborted", Data: []byte(indexData)},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		txn, err := gateway.AbortTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txn.Ops) != 1 {
+			t.Fatalf("unexpected aborted transaction %+v", txn)
+		}
+		if _, err := gateway.StageTransaction(ctx, &TransactionOps{Bucket: testBucket1, Id: id}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected the aborted transaction to be removed, but got %v", err)
+		}
+		if names := objects(t); len(names) != 3 {
+			t.Fatalf("unexpected objects %v", names)
+		}
+	})
+	t.Run("missing copy source", func(t *testing.T) {
+		id := begin(t)
+		if _, err := gateway.StageTransaction(ctx, &TransactionOps{Bucket: testBucket1, Id: id, Ops: []TransactionOp{
+			{Type: TransactionOpType_DELETE, Object: testObject1},
+			{Type: TransactionOpType_COPY, Object: "copy", Source: testObject1},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.CommitTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: id}); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition, but got %v", err)
+		}
+		if names := objects(t); len(names) != 3 {
+			t.Fatalf("expected no changes, but got %v", names)
+		}
+		if _, err := gateway.AbortTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("delete bucket", func(t *testing.T) {
+		id := begin(t)
+		if _, err := gateway.DeleteObjects(ctx, testBucket1, objects(t)); err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.DeleteBucket(ctx, testBucket1, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.MakeBucketWithLocation(ctx, testBucket1, "us-east-1"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gateway.AbortTransaction(ctx, &TransactionRequest{Bucket: testBucket1, Id: id}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected the transactions of the bucket to be removed, but got %v", err)
+		}
+	})
+}
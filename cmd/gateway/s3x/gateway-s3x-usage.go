@@ -0,0 +1,92 @@
+package s3x
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+// storageUsedInterval is how long the storage used reported by StorageInfo is cached.
+const storageUsedInterval = 30 * time.Second
+
+// storageUsedCache caches the size of all objects between calls of StorageInfo,
+// which is called on admin and metrics requests, computing it reads the usage of every bucket.
+type storageUsedCache struct {
+	sync.Mutex
+	used       uint64
+	lastUpdate time.Time
+}
+
+// getStorageUsed returns the size of all objects, it is computed again at most every storageUsedInterval
+func (x *xObjects) getStorageUsed(ctx context.Context) (uint64, error) {
+	x.storageUsed.Lock()
+	defer x.storageUsed.Unlock()
+	if time.Since(x.storageUsed.lastUpdate) < storageUsedInterval {
+		return x.storageUsed.used, nil
+	}
+	dataUsageInfo, err := x.dataUsageInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	x.storageUsed.used = dataUsageInfo.ObjectsTotalSize
+	x.storageUsed.lastUpdate = time.Now()
+	return x.storageUsed.used, nil
+}
+
+// CrawlAndGetDataUsage sends the data usage accounted by the ledger,
+// usage is updated as objects change so nothing needs to be crawled.
+func (x *xObjects) CrawlAndGetDataUsage(ctx context.Context, bf *minio.BloomFilter, updates chan<- minio.DataUsageInfo) error {
+	dataUsageInfo, err := x.dataUsageInfo(ctx)
+	if err != nil {
+		return err
+	}
+	select {
+	case updates <- dataUsageInfo:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsDataUsageSupported returns whether data usage crawling is implemented for this layer.
+func (x *xObjects) IsDataUsageSupported() bool {
+	return true
+}
+
+// GetBucketUsage returns the size of the objects in the bucket, the quota of the bucket is checked against it
+func (x *xObjects) GetBucketUsage(ctx context.Context, bucket string) (uint64, error) {
+	u, err := x.ledgerStore.GetBucketUsage(ctx, x.ledgerBucket(ctx, bucket))
+	if err != nil {
+		return 0, x.toMinioErr(err, bucket, "", "")
+	}
+	return uint64(u.GetSize_()), nil
+}
+
+// dataUsageInfo sums the usage of all buckets
+func (x *xObjects) dataUsageInfo(ctx context.Context) (minio.DataUsageInfo, error) {
+	names, err := x.ledgerStore.GetBucketNames()
+	if err != nil {
+		return minio.DataUsageInfo{}, err
+	}
+	dataUsageInfo := minio.DataUsageInfo{
+		LastUpdate:            time.Now().UTC(),
+		ObjectsSizesHistogram: make(map[string]uint64),
+		BucketsCount:          uint64(len(names)),
+		BucketsSizes:          make(map[string]uint64, len(names)),
+	}
+	for _, name := range names {
+		u, err := x.ledgerStore.GetBucketUsage(ctx, name)
+		if err != nil {
+			return minio.DataUsageInfo{}, x.toMinioErr(err, name, "", "")
+		}
+		dataUsageInfo.ObjectsCount += u.GetObjectsCount()
+		dataUsageInfo.ObjectsTotalSize += uint64(u.GetSize_())
+		dataUsageInfo.BucketsSizes[name] = uint64(u.GetSize_())
+		for interval, count := range u.GetObjectsSizesHistogram() {
+			dataUsageInfo.ObjectsSizesHistogram[interval] += count
+		}
+	}
+	return dataUsageInfo, nil
+}
@@ -0,0 +1,130 @@
+package s3x
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+func TestS3X_DataUsage_Badger(t *testing.T) {
+	testS3XDataUsage(t, DSTypeBadger)
+}
+func TestS3X_DataUsage_Crdt(t *testing.T) {
+	testS3XDataUsage(t, DSTypeCrdt)
+}
+func testS3XDataUsage(t *testing.T, dsType DSType) {
+	ctx := context.Background()
+	gateway := newTestGateway(t, dsType)
+	defer func() {
+		if err := gateway.Shutdown(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	for _, bucket := range []string{testBucket1, testBucket2} {
+		if err := gateway.MakeBucketWithLocation(ctx, bucket, "us-east-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	put := func(t *testing.T, object, data string) {
+		_, err := gateway.PutObject(ctx, testBucket1, object, getTestPutObjectReader(t, []byte(data)), minio.ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func(t *testing.T, objects, size uint64) {
+		updates := make(chan minio.DataUsageInfo, 1)
+		if err := gateway.CrawlAndGetDataUsage(ctx, nil, updates); err != nil {
+			t.Fatal(err)
+		}
+		dui := <-updates
+		if dui.BucketsCount != 2 {
+			t.Fatalf("expected 2 buckets, but got %d", dui.BucketsCount)
+		}
+		if dui.ObjectsCount != objects || dui.ObjectsTotalSize != size {
+			t.Fatalf("expected %d objects of %d bytes, but got %d objects of %d bytes",
+				objects, size, dui.ObjectsCount, dui.ObjectsTotalSize)
+		}
+		if dui.BucketsSizes[testBucket1] != size || dui.BucketsSizes[testBucket2] != 0 {
+			t.Fatalf("unexpected bucket sizes %v", dui.BucketsSizes)
+		}
+		if got := dui.ObjectsSizesHistogram["LESS_THAN_1024_B"]; got != objects {
+			t.Fatalf("expected %d small objects, but got %d", objects, got)
+		}
+		if got, err := gateway.GetBucketUsage(ctx, testBucket1); err != nil || got != size {
+			t.Fatalf("expected a bucket usage of %d bytes, but got %d, %v", size, got, err)
+		}
+		// the storage used is cached, expire it
+		gateway.storageUsed.lastUpdate = time.Time{}
+		if si := gateway.StorageInfo(ctx, false); len(si.Used) != 1 || si.Used[0] != size {
+			t.Fatalf("unexpected storage used %v", si.Used)
+		}
+	}
+	t.Run("empty", func(t *testing.T) {
+		check(t, 0, 0)
+	})
+	t.Run("put", func(t *testing.T) {
+		put(t, testObject1, testObject1Data)
+		put(t, "testobject2", "1234")
+		check(t, 2, uint64(len(testObject1Data)+4))
+	})
+	t.Run("overwrite", func(t *testing.T) {
+		put(t, "testobject2", "12")
+		check(t, 2, uint64(len(testObject1Data)+2))
+	})
+	t.Run("cached storage used", func(t *testing.T) {
+		put(t, "testobject3", "1")
+		if si := gateway.StorageInfo(ctx, false); len(si.Used) != 1 || si.Used[0] != uint64(len(testObject1Data)+2) {
+			t.Fatalf("expected the cached storage used, but got %v", si.Used)
+		}
+		if err := gateway.DeleteObject(ctx, testBucket1, "testobject3"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("recompute", func(t *testing.T) {
+		if err := gateway.ledgerStore.resetUsage(testBucket1); err != nil {
+			t.Fatal(err)
+		}
+		gateway.restart(t)
+		check(t, 2, uint64(len(testObject1Data)+2))
+	})
+	t.Run("concurrent recompute", func(t *testing.T) {
+		size, count, err := gateway.ledgerStore.GetUniqueData(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gateway.ledgerStore.resetUsage(testBucket1); err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		errs := make(chan error, 4)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := gateway.ledgerStore.GetBucketUsage(ctx, testBucket1)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		check(t, 2, uint64(len(testObject1Data)+2))
+		// the data references are indexed once
+		if gotSize, gotCount, err := gateway.ledgerStore.GetUniqueData(ctx); err != nil || gotSize != size || gotCount != count {
+			t.Fatalf("expected %d unique objects of %d bytes, but got %d of %d bytes, %v", count, size, gotCount, gotSize, err)
+		}
+	})
+	t.Run("remove", func(t *testing.T) {
+		if err := gateway.DeleteObject(ctx, testBucket1, "testobject2"); err != nil {
+			t.Fatal(err)
+		}
+		check(t, 1, uint64(len(testObject1Data)))
+	})
+}
@@ -2,6 +2,7 @@ package s3x
 
 import (
 	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/crypto"
 )
 
 /* Design Notes
@@ -16,13 +17,38 @@ func getMinioObjectInfo(o *ObjectInfo) minio.ObjectInfo {
 	if o == nil {
 		return minio.ObjectInfo{}
 	}
+	etag := minio.ToS3ETag(o.Etag)
+	if crypto.IsEncrypted(o.UserDefined) && !crypto.IsMultiPart(o.UserDefined) {
+		// the etag is sealed with the object key, and is unsealed by minio
+		etag = o.Etag
+	}
+	var parts []minio.ObjectPartInfo
+	for _, p := range o.Parts {
+		parts = append(parts, minio.ObjectPartInfo{
+			Number:     int(p.Number),
+			Size:       p.Size_,
+			ActualSize: p.ActualSize,
+		})
+	}
 	return minio.ObjectInfo{
 		Bucket:      o.Bucket,
 		Name:        o.Name,
-		ETag:        minio.ToS3ETag(o.Etag),
+		ETag:        etag,
 		Size:        o.Size_,
 		ModTime:     o.ModTime,
 		ContentType: o.ContentType,
 		UserDefined: o.UserDefined,
+		Parts:       parts,
+	}
+}
+
+// withContentHash returns a copy of userDefined with the fleek content hash headers set to the given data hash
+func withContentHash(userDefined map[string]string, hash string) map[string]string {
+	m := make(map[string]string, len(userDefined)+2)
+	for k, v := range userDefined {
+		m[k] = v
 	}
+	m[fleekIpfsContentHash] = hash
+	m[fleekIpfsContentHashV0] = convertToHashV0(hash)
+	return m
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	pb "github.com/RTradeLtd/TxPB/v3/go"
 	badger "github.com/RTradeLtd/go-ds-badger/v2"
@@ -44,13 +45,30 @@ type TEMX struct {
 	CrdtTopic string
 	XAddr     string
 	Insecure  bool // whether or not we have an insecure connection to TemporalX
+
+	XCAFile          string        // the CA certificates TemporalX is verified with, the system roots if empty
+	XSkipVerify      bool          // whether the certificate of TemporalX is not verified
+	XTimeout         time.Duration // the deadline of calls to TemporalX
+	XRetries         int           // the number of retries of idempotent calls to TemporalX
+	XBreakerFailures int           // the number of consecutive failed calls before calls to TemporalX fail fast
+	XBreakerCooldown time.Duration // the time calls to TemporalX fail fast before it is tried again
+
+	IPNSDebounce time.Duration // the time a bucket must not change before it is published to IPNS
+	IPNSInterval time.Duration // the minimum time between two IPNS publishes of a bucket
+
+	PinBackoff  time.Duration // the time before the first retry of a failed pin to a remote target
+	PinAttempts int           // the number of attempts before a pin to a remote target fails
+
+	Tenants bool // whether every account has its own bucket namespace
 }
 
 // infoAPIServer provides access to the InfoAPI
 // allowing retrieval of the corresponding ipfs cids
-// for our various buckets and objects
+// for our various buckets and objects, callers are
+// authenticated by the embedded authInfoAPIServer
 type infoAPIServer struct {
 	InfoAPIServer
+	getCert    func(*tls.ClientHelloInfo) (*tls.Certificate, error) // nil if TLS is disabled
 	httpServer *http.Server
 	httpMux    *runtime.ServeMux
 	grpcServer *grpc.Server
@@ -60,6 +78,7 @@ type infoAPIServer struct {
 type xObjects struct {
 	minio.GatewayUnsupported
 	ctx        context.Context
+	cancel     context.CancelFunc
 	dagClient  pb.NodeAPIClient
 	fileClient pb.FileAPIClient
 
@@ -68,6 +87,19 @@ type xObjects struct {
 
 	infoAPI *infoAPIServer
 
+	// backend guards the connection to TemporalX and reports whether it is online
+	backend *xBackend
+	// ipns publishes buckets to their IPNS names
+	ipns *ipnsPublisher
+	// pins replicates objects to the remote pin targets of their buckets
+	pins *pinReplicator
+
+	// tenants is set if bucket names are namespaced by the account of the request
+	tenants bool
+
+	// storageUsed caches the size of all objects reported by StorageInfo
+	storageUsed storageUsedCache
+
 	listener net.Listener
 }
 
@@ -112,6 +144,58 @@ func init() {
 				Name:  "temporalx.insecure",
 				Usage: "initiate an insecure connection to the temporalx endpoint",
 			},
+			cli.StringFlag{
+				Name:  "temporalx.ca",
+				Usage: "the pem encoded CA certificates to verify the temporalx endpoint with, the system roots are used if not set",
+			},
+			cli.BoolFlag{
+				Name:  "temporalx.skip-verify",
+				Usage: "do not verify the certificate of the temporalx endpoint",
+			},
+			cli.DurationFlag{
+				Name:  "temporalx.timeout",
+				Usage: "the deadline of calls to temporalx",
+				Value: defaultXTimeout,
+			},
+			cli.IntFlag{
+				Name:  "temporalx.retries",
+				Usage: "the number of retries of idempotent calls to temporalx, such as dag reads",
+				Value: defaultXRetries,
+			},
+			cli.IntFlag{
+				Name:  "temporalx.breaker.failures",
+				Usage: "the number of consecutive failed calls after which calls to temporalx fail fast",
+				Value: defaultXBreakerFailures,
+			},
+			cli.DurationFlag{
+				Name:  "temporalx.breaker.cooldown",
+				Usage: "the time calls to temporalx fail fast before it is tried again",
+				Value: defaultXBreakerCooldown,
+			},
+			cli.DurationFlag{
+				Name:  "ipns.debounce",
+				Usage: "the time a bucket must not change before it is published to ipns",
+				Value: 10 * time.Second,
+			},
+			cli.DurationFlag{
+				Name:  "ipns.interval",
+				Usage: "the minimum time between two ipns publishes of a bucket",
+				Value: time.Minute,
+			},
+			cli.DurationFlag{
+				Name:  "pins.retry.backoff",
+				Usage: "the time before the first retry of a failed pin to a remote target, doubled on every retry",
+				Value: defaultPinBackoff,
+			},
+			cli.IntFlag{
+				Name:  "pins.retry.attempts",
+				Usage: "the number of attempts before a pin to a remote target fails",
+				Value: defaultPinAttempts,
+			},
+			cli.BoolFlag{
+				Name:  "tenants",
+				Usage: "give every account its own bucket namespace, by its parent user or access key",
+			},
 		},
 	}); err != nil {
 		panic(err)
@@ -127,6 +211,21 @@ func temxGatewayMain(ctx *cli.Context) {
 		CrdtTopic: ctx.String("ds.topic"),
 		XAddr:     ctx.String("temporalx.endpoint"),
 		Insecure:  ctx.Bool("temporalx.insecure"),
+
+		XCAFile:          ctx.String("temporalx.ca"),
+		XSkipVerify:      ctx.Bool("temporalx.skip-verify"),
+		XTimeout:         ctx.Duration("temporalx.timeout"),
+		XRetries:         ctx.Int("temporalx.retries"),
+		XBreakerFailures: ctx.Int("temporalx.breaker.failures"),
+		XBreakerCooldown: ctx.Duration("temporalx.breaker.cooldown"),
+
+		IPNSDebounce: ctx.Duration("ipns.debounce"),
+		IPNSInterval: ctx.Duration("ipns.interval"),
+
+		PinBackoff:  ctx.Duration("pins.retry.backoff"),
+		PinAttempts: ctx.Int("pins.retry.attempts"),
+
+		Tenants: ctx.Bool("tenants"),
 	})
 }
 
@@ -188,24 +287,24 @@ func (g *TEMX) newCrdtLedgerStore(ctx context.Context, dag pb.NodeAPIClient, pub
 
 // returns an instance of xObjects
 func (g *TEMX) getXObjects(creds auth.Credentials) (*xObjects, error) {
-	ctx := context.TODO()
-	var dialOpts []grpc.DialOption
-	if g.Insecure {
-		dialOpts = append(dialOpts, grpc.WithInsecure())
-	} else {
-		dialOpts = append(dialOpts, grpc.WithTransportCredentials(
-			credentials.NewTLS(
-				&tls.Config{
-					InsecureSkipVerify: true,
-				},
-			),
-		))
-	}
 	// connect to TemporalX
-	conn, err := grpc.Dial(g.XAddr, dialOpts...)
+	tlsConfig, err := temporalXTLSConfig(g.Insecure, g.XCAFile, g.XSkipVerify)
 	if err != nil {
 		return nil, err
 	}
+	backend := newXBackend(g.XTimeout, g.XRetries, g.XBreakerFailures, g.XBreakerCooldown)
+	conn, err := dialTemporalX(g.XAddr, tlsConfig, backend.dialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	backend.conn = conn
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		if cancel != nil {
+			cancel()
+			_ = conn.Close()
+		}
+	}()
 	dag := pb.NewNodeAPIClient(conn)
 	pub := pb.NewPubSubAPIClient(conn)
 	// instantiate our internal ledger
@@ -218,34 +317,58 @@ func (g *TEMX) getXObjects(creds auth.Credentials) (*xObjects, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the InfoAPI is served with the certificates of the gateway if TLS is configured
+	var grpcOpts []grpc.ServerOption
+	getCert := minio.GetTLSCertificateFunc()
+	if getCert != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(
+			&tls.Config{
+				GetCertificate: getCert,
+			},
+		)))
+	}
 	// instantiate initial xObjects type
 	// responsible for bridging S3 -> TemporalX (IPFS)
 	xobj := &xObjects{
 		ctx:         ctx,
+		cancel:      cancel,
 		dagClient:   dag,
 		fileClient:  pb.NewFileAPIClient(conn),
 		ledgerStore: ledger,
 		infoAPI: &infoAPIServer{
+			getCert:    getCert,
 			httpMux:    runtime.NewServeMux(),
-			grpcServer: grpc.NewServer(),
+			grpcServer: grpc.NewServer(grpcOpts...),
 		},
+		backend:  backend,
 		listener: listener,
+		tenants:  g.Tenants,
 	}
+	xobj.ipns = newIPNSPublisher(ledger, dag, pb.NewNameSysAPIClient(conn), g.IPNSDebounce, g.IPNSInterval)
+	xobj.pins = newPinReplicator(ledger, dag, creds.String(), g.PinBackoff, g.PinAttempts)
+	ledger.objectChanged = objectChanged
+	xobj.infoAPI.InfoAPIServer = &authInfoAPIServer{x: xobj, tls: getCert != nil}
 	xobj.infoAPI.httpServer = &http.Server{
 		Addr:    g.HTTPAddr,
 		Handler: xobj.infoAPI.httpMux,
 	}
+	if getCert != nil {
+		xobj.infoAPI.httpServer.TLSConfig = &tls.Config{
+			GetCertificate: getCert,
+		}
+	}
 	// register the grpc server
-	RegisterInfoAPIServer(xobj.infoAPI.grpcServer, xobj)
-	// register the grpc-gateway http endpoint
-	if err := RegisterInfoAPIHandlerFromEndpoint(
+	RegisterInfoAPIServer(xobj.infoAPI.grpcServer, xobj.infoAPI)
+	// register the grpc-gateway http endpoint, requests are passed to
+	// the InfoAPIServer directly instead of dialing the grpc endpoint
+	if err := RegisterInfoAPIHandlerServer(
 		xobj.ctx,
 		xobj.infoAPI.httpMux,
-		g.GRPCAddr,
-		[]grpc.DialOption{grpc.WithInsecure()},
+		xobj.infoAPI,
 	); err != nil {
 		return nil, err
 	}
+	cancel = nil //the context and connection are closed by Shutdown
 	return xobj, nil
 }
 
@@ -258,8 +381,16 @@ func (g *TEMX) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error
 	go func() {
 		_ = xobj.infoAPI.grpcServer.Serve(xobj.listener)
 	}()
+	xobj.backend.start()
+	xobj.ipns.start()
+	xobj.pins.start()
 	go func() {
-		_ = xobj.infoAPI.httpServer.ListenAndServe()
+		if xobj.infoAPI.getCert != nil {
+			// the certificates are provided by the TLS config
+			_ = xobj.infoAPI.httpServer.ListenAndServeTLS("", "")
+		} else {
+			_ = xobj.infoAPI.httpServer.ListenAndServe()
+		}
 	}()
 	return xobj, nil
 }
@@ -278,13 +409,24 @@ func (g *TEMX) Production() bool {
 func (x *xObjects) Shutdown(ctx context.Context) error {
 	x.infoAPI.grpcServer.Stop()
 	x.infoAPI.httpServer.Close()
-	return x.ledgerStore.Close()
+	x.ipns.close()
+	x.pins.close()
+	err := x.ledgerStore.Close()
+	x.cancel()
+	if cerr := x.backend.close(); err == nil {
+		err = cerr
+	}
+	return err
 }
 
-// StorageInfo is not relevant to TemporalX backend.
+// StorageInfo reports the total size of all objects as used space,
+// disk space is not relevant to TemporalX backend.
 func (x *xObjects) StorageInfo(ctx context.Context, local bool) (si minio.StorageInfo) {
 	si.Backend.Type = minio.BackendGateway
-	//si.Backend.GatewayOnline = minio.IsBackendOnline(ctx, x.httpClient, "https://docsx.temporal.cloud")
+	if used, err := x.getStorageUsed(ctx); err == nil {
+		si.Used = []uint64{used}
+	}
+	si.Backend.GatewayOnline = x.backend.online()
 	return si
 }
 
@@ -306,22 +448,32 @@ func (x *xObjects) GetHash(ctx context.Context, req *InfoRequest) (*InfoResponse
 	if req.GetBucket() == "" {
 		return nil, status.Error(codes.InvalidArgument, "bucket name is empty")
 	}
+	bucket := x.ledgerBucket(ctx, req.GetBucket())
 	if req.GetObject() == "" {
 		// get bucket hash when object is not specified
-		hash, err = x.ledgerStore.GetBucketHash(req.GetBucket())
+		hash, err = x.ledgerStore.GetBucketHash(bucket)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		state, err := x.ledgerStore.GetBucketIPNS(bucket)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		return &InfoResponse{
+			Bucket:   req.GetBucket(),
+			Hash:     hash,
+			IpnsName: state.GetName(),
+		}, nil
 	} else if req.ObjectDataOnly {
 		// get object data hash
-		h, _, err := x.ledgerStore.GetObjectDataHash(ctx, req.GetBucket(), req.GetObject())
+		h, _, err := x.ledgerStore.GetObjectDataHash(ctx, bucket, req.GetObject())
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 		hash = h
 	} else {
 		// get protocol buffer object hash
-		hash, err = x.ledgerStore.GetObjectHash(ctx, req.GetBucket(), req.GetObject())
+		hash, err = x.ledgerStore.GetObjectHash(ctx, bucket, req.GetObject())
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
@@ -7,7 +7,10 @@ import (
 
 	pb "github.com/RTradeLtd/TxPB/v3/go"
 	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
 	"github.com/ipfs/go-merkledag"
+	unixfs "github.com/ipfs/go-unixfs"
+	uio "github.com/ipfs/go-unixfs/io"
 	"github.com/pkg/errors"
 )
 
@@ -95,6 +98,154 @@ func ipfsSaveProtoNode(ctx context.Context, dag pb.NodeAPIClient, node *merkleda
 	return resp.GetHashes()[0], nil
 }
 
+// ipfsBlockSize returns the size of a single block without retrieving its data
+func ipfsBlockSize(ctx context.Context, dag pb.NodeAPIClient, h string) (int64, error) {
+	resp, err := dag.Blockstore(ctx, &pb.BlockstoreRequest{
+		RequestType: pb.BSREQTYPE_BS_GET_STATS,
+		Cids:        []string{h},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.GetBlocks()) != 1 {
+		return 0, errors.New("unexpected number of blocks returned")
+	}
+	return resp.GetBlocks()[0].GetSize_(), nil
+}
+
+// ipfsDagSize walks the unixfs dag rooted at h, making sure every block in it
+// can be resolved, and returns the size of the file data it represents.
+func ipfsDagSize(ctx context.Context, dag pb.NodeAPIClient, h string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	c, err := cid.Decode(h)
+	if err != nil {
+		return 0, err
+	}
+	if c.Type() != cid.DagProtobuf {
+		// raw leaves are file data without any unixfs framing
+		return ipfsBlockSize(ctx, dag, h)
+	}
+	data, err := ipfsBytes(ctx, dag, h)
+	if err != nil {
+		return 0, errors.Wrapf(err, "block %s", h)
+	}
+	node, err := merkledag.DecodeProtobuf(data)
+	if err != nil {
+		return 0, errors.Wrapf(err, "block %s", h)
+	}
+	fsNode, err := unixfs.FSNodeFromBytes(node.Data())
+	if err != nil {
+		return 0, errors.Wrapf(err, "block %s", h)
+	}
+	size := int64(len(fsNode.Data()))
+	for _, link := range node.Links() {
+		n, err := ipfsDagSize(ctx, dag, link.Cid.String())
+		if err != nil {
+			return 0, err
+		}
+		size += n
+	}
+	return size, nil
+}
+
+// ipfsCumulativeSize returns the size of all blocks of the dag rooted at h, which is the size of a link to it.
+// The links of a dag-pb node record the cumulative sizes of its children, so only the root block is read.
+func ipfsCumulativeSize(ctx context.Context, dag pb.NodeAPIClient, h string) (uint64, error) {
+	c, err := cid.Decode(h)
+	if err != nil {
+		return 0, err
+	}
+	if c.Type() != cid.DagProtobuf {
+		size, err := ipfsBlockSize(ctx, dag, h)
+		return uint64(size), err
+	}
+	data, err := ipfsBytes(ctx, dag, h)
+	if err != nil {
+		return 0, err
+	}
+	node, err := merkledag.DecodeProtobuf(data)
+	if err != nil {
+		return 0, err
+	}
+	size := uint64(len(data))
+	for _, link := range node.Links() {
+		size += link.Size
+	}
+	return size, nil
+}
+
+// ipfsPersist retrieves the given hash from the network and makes it available locally,
+// it returns false if the hash could not be persisted.
+func ipfsPersist(ctx context.Context, dag pb.NodeAPIClient, h string) (bool, error) {
+	resp, err := dag.Persist(ctx, &pb.PersistRequest{
+		Cids: []string{h},
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetStatus()[h], nil
+}
+
+// ipfsFileReader returns a seekable reader of the unixfs file with the given hash. Blocks are
+// only retrieved when they are read, so a range can be read without downloading the whole file.
+func ipfsFileReader(ctx context.Context, dag pb.NodeAPIClient, h string) (uio.DagReader, error) {
+	c, err := cid.Decode(h)
+	if err != nil {
+		return nil, err
+	}
+	getter := dagNodeGetter{dag: dag}
+	node, err := getter.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return uio.NewDagReader(ctx, node, getter)
+}
+
+// dagNodeGetter retrieves the nodes of unixfs files from TemporalX
+type dagNodeGetter struct {
+	dag pb.NodeAPIClient
+}
+
+// Get implements format.NodeGetter
+func (g dagNodeGetter) Get(ctx context.Context, c cid.Cid) (format.Node, error) {
+	data, err := ipfsBytes(ctx, g.dag, c.String())
+	if err != nil {
+		return nil, err
+	}
+	switch c.Type() {
+	case cid.Raw:
+		return merkledag.NewRawNodeWPrefix(data, c.Prefix())
+	case cid.DagProtobuf:
+		node, err := merkledag.DecodeProtobuf(data)
+		if err != nil {
+			return nil, err
+		}
+		node.SetCidBuilder(c.Prefix())
+		return node, nil
+	default:
+		return nil, fmt.Errorf("unsupported codec of %s", c)
+	}
+}
+
+// GetMany implements format.NodeGetter
+func (g dagNodeGetter) GetMany(ctx context.Context, cids []cid.Cid) <-chan *format.NodeOption {
+	out := make(chan *format.NodeOption, len(cids))
+	go func() {
+		defer close(out)
+		for _, c := range cids {
+			node, err := g.Get(ctx, c)
+			select {
+			case out <- &format.NodeOption{Node: node, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
 const chunkSize = 4*1024*1024 - 1024 //1KB less than 4MB for a good safety buffer
 
 func ipfsFileUpload(ctx context.Context, fileClient pb.FileAPIClient, r io.Reader) (string, int, error) {
@@ -32,6 +32,163 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// IPNSMode is the content that is published to the IPNS name of a bucket
+type IPNSMode int32
+
+const (
+	// the bucket root, which is a Bucket protocol buffer
+	IPNSMode_BUCKET_ROOT IPNSMode = 0
+	// a unixfs directory of the object data, which can be browsed through any ipfs gateway
+	IPNSMode_UNIXFS_DIRECTORY IPNSMode = 1
+)
+
+var IPNSMode_name = map[int32]string{
+	0: "BUCKET_ROOT",
+	1: "UNIXFS_DIRECTORY",
+}
+
+var IPNSMode_value = map[string]int32{
+	"BUCKET_ROOT":      0,
+	"UNIXFS_DIRECTORY": 1,
+}
+
+func (x IPNSMode) String() string {
+	return proto.EnumName(IPNSMode_name, int32(x))
+}
+
+func (IPNSMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{0}
+}
+
+// FsckIssueType is the kind of inconsistency found by a ledger check
+type FsckIssueType int32
+
+const (
+	// the bucket root cid can not be resolved
+	FsckIssueType_BUCKET_UNRESOLVABLE FsckIssueType = 0
+	// the object protocol buffer cid can not be resolved
+	FsckIssueType_OBJECT_UNRESOLVABLE FsckIssueType = 1
+	// the object data dag, or a block within it, can not be resolved
+	FsckIssueType_DATA_UNRESOLVABLE FsckIssueType = 2
+	// the size of the object data dag does not match the object info size
+	FsckIssueType_SIZE_MISMATCH FsckIssueType = 3
+)
+
+var FsckIssueType_name = map[int32]string{
+	0: "BUCKET_UNRESOLVABLE",
+	1: "OBJECT_UNRESOLVABLE",
+	2: "DATA_UNRESOLVABLE",
+	3: "SIZE_MISMATCH",
+}
+
+var FsckIssueType_value = map[string]int32{
+	"BUCKET_UNRESOLVABLE": 0,
+	"OBJECT_UNRESOLVABLE": 1,
+	"DATA_UNRESOLVABLE":   2,
+	"SIZE_MISMATCH":       3,
+}
+
+func (x FsckIssueType) String() string {
+	return proto.EnumName(FsckIssueType_name, int32(x))
+}
+
+func (FsckIssueType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{1}
+}
+
+// PinTargetType is the api of a remote pinning target
+type PinTargetType int32
+
+const (
+	// a TemporalX node, which retrieves the data from the network
+	PinTargetType_TEMPORALX PinTargetType = 0
+	// a service implementing the IPFS pinning service api
+	PinTargetType_PINNING_SERVICE PinTargetType = 1
+)
+
+var PinTargetType_name = map[int32]string{
+	0: "TEMPORALX",
+	1: "PINNING_SERVICE",
+}
+
+var PinTargetType_value = map[string]int32{
+	"TEMPORALX":       0,
+	"PINNING_SERVICE": 1,
+}
+
+func (x PinTargetType) String() string {
+	return proto.EnumName(PinTargetType_name, int32(x))
+}
+
+func (PinTargetType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{2}
+}
+
+// PinState is the state of a pin job
+type PinState int32
+
+const (
+	// waiting to be pinned, this includes retries of failed attempts
+	PinState_QUEUED PinState = 0
+	// the target accepted the pin request, but did not finish pinning yet
+	PinState_PINNING PinState = 1
+	PinState_PINNED  PinState = 2
+	// all attempts failed
+	PinState_FAILED PinState = 3
+)
+
+var PinState_name = map[int32]string{
+	0: "QUEUED",
+	1: "PINNING",
+	2: "PINNED",
+	3: "FAILED",
+}
+
+var PinState_value = map[string]int32{
+	"QUEUED":  0,
+	"PINNING": 1,
+	"PINNED":  2,
+	"FAILED":  3,
+}
+
+func (x PinState) String() string {
+	return proto.EnumName(PinState_name, int32(x))
+}
+
+func (PinState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{3}
+}
+
+// TransactionOpType is the type of an operation staged in a transaction
+type TransactionOpType int32
+
+const (
+	TransactionOpType_PUT TransactionOpType = 0
+	// copies an object within the bucket
+	TransactionOpType_COPY   TransactionOpType = 1
+	TransactionOpType_DELETE TransactionOpType = 2
+)
+
+var TransactionOpType_name = map[int32]string{
+	0: "PUT",
+	1: "COPY",
+	2: "DELETE",
+}
+
+var TransactionOpType_value = map[string]int32{
+	"PUT":    0,
+	"COPY":   1,
+	"DELETE": 2,
+}
+
+func (x TransactionOpType) String() string {
+	return proto.EnumName(TransactionOpType_name, int32(x))
+}
+
+func (TransactionOpType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{4}
+}
+
 type InfoRequest struct {
 	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
@@ -98,6 +255,8 @@ type InfoResponse struct {
 	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
 	Hash   string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	// the ipns name the bucket is published to, only set for bucket requests
+	IpnsName string `protobuf:"bytes,4,opt,name=ipnsName,proto3" json:"ipnsName,omitempty"`
 }
 
 func (m *InfoResponse) Reset()         { *m = InfoResponse{} }
@@ -154,27 +313,33 @@ func (m *InfoResponse) GetHash() string {
 	return ""
 }
 
-// Ledger is our internal state keeper, and is responsible
-// for keeping track of buckets, objects, and their corresponding IPFS hashes
-type Ledger struct {
-	// key = bucket name
-	Buckets map[string]*LedgerBucketEntry `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// key = partID
-	MultipartUploads map[string]*MultipartUpload `protobuf:"bytes,2,rep,name=multipartUploads,proto3" json:"multipartUploads,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (m *InfoResponse) GetIpnsName() string {
+	if m != nil {
+		return m.IpnsName
+	}
+	return ""
 }
 
-func (m *Ledger) Reset()         { *m = Ledger{} }
-func (m *Ledger) String() string { return proto.CompactTextString(m) }
-func (*Ledger) ProtoMessage()    {}
-func (*Ledger) Descriptor() ([]byte, []int) {
+// BucketIPNSRequest is used to enable or disable publishing a bucket to IPNS
+type BucketIPNSRequest struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// if not set, publishing is disabled but the key of the bucket is kept
+	Enable bool     `protobuf:"varint,2,opt,name=enable,proto3" json:"enable,omitempty"`
+	Mode   IPNSMode `protobuf:"varint,3,opt,name=mode,proto3,enum=s3x.IPNSMode" json:"mode,omitempty"`
+}
+
+func (m *BucketIPNSRequest) Reset()         { *m = BucketIPNSRequest{} }
+func (m *BucketIPNSRequest) String() string { return proto.CompactTextString(m) }
+func (*BucketIPNSRequest) ProtoMessage()    {}
+func (*BucketIPNSRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_005e34be4304e022, []int{2}
 }
-func (m *Ledger) XXX_Unmarshal(b []byte) error {
+func (m *BucketIPNSRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Ledger) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BucketIPNSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Ledger.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BucketIPNSRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -184,51 +349,62 @@ func (m *Ledger) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Ledger) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Ledger.Merge(m, src)
+func (m *BucketIPNSRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketIPNSRequest.Merge(m, src)
 }
-func (m *Ledger) XXX_Size() int {
+func (m *BucketIPNSRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *Ledger) XXX_DiscardUnknown() {
-	xxx_messageInfo_Ledger.DiscardUnknown(m)
+func (m *BucketIPNSRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketIPNSRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Ledger proto.InternalMessageInfo
+var xxx_messageInfo_BucketIPNSRequest proto.InternalMessageInfo
 
-func (m *Ledger) GetBuckets() map[string]*LedgerBucketEntry {
+func (m *BucketIPNSRequest) GetBucket() string {
 	if m != nil {
-		return m.Buckets
+		return m.Bucket
 	}
-	return nil
+	return ""
 }
 
-func (m *Ledger) GetMultipartUploads() map[string]*MultipartUpload {
+func (m *BucketIPNSRequest) GetEnable() bool {
 	if m != nil {
-		return m.MultipartUploads
+		return m.Enable
 	}
-	return nil
+	return false
 }
 
-// LedgerBucketEntry is an individual entry within the ledger containing information about a bucket
-type LedgerBucketEntry struct {
-	//if bucket is nil, this entry can be lazy loaded from ifps
-	Bucket   *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	IpfsHash string  `protobuf:"bytes,2,opt,name=ipfsHash,proto3" json:"ipfsHash,omitempty"`
+func (m *BucketIPNSRequest) GetMode() IPNSMode {
+	if m != nil {
+		return m.Mode
+	}
+	return IPNSMode_BUCKET_ROOT
 }
 
-func (m *LedgerBucketEntry) Reset()         { *m = LedgerBucketEntry{} }
-func (m *LedgerBucketEntry) String() string { return proto.CompactTextString(m) }
-func (*LedgerBucketEntry) ProtoMessage()    {}
-func (*LedgerBucketEntry) Descriptor() ([]byte, []int) {
+// BucketIPNS is the IPNS publishing state of a bucket
+type BucketIPNS struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// the ipns name, which is the peer id of the bucket key, empty if publishing is disabled
+	Name string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Mode IPNSMode `protobuf:"varint,3,opt,name=mode,proto3,enum=s3x.IPNSMode" json:"mode,omitempty"`
+	// the path that was last published to the name
+	Published   string    `protobuf:"bytes,4,opt,name=published,proto3" json:"published,omitempty"`
+	PublishedAt time.Time `protobuf:"bytes,5,opt,name=publishedAt,proto3,stdtime" json:"publishedAt"`
+}
+
+func (m *BucketIPNS) Reset()         { *m = BucketIPNS{} }
+func (m *BucketIPNS) String() string { return proto.CompactTextString(m) }
+func (*BucketIPNS) ProtoMessage()    {}
+func (*BucketIPNS) Descriptor() ([]byte, []int) {
 	return fileDescriptor_005e34be4304e022, []int{3}
 }
-func (m *LedgerBucketEntry) XXX_Unmarshal(b []byte) error {
+func (m *BucketIPNS) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LedgerBucketEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BucketIPNS) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LedgerBucketEntry.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BucketIPNS.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -238,53 +414,77 @@ func (m *LedgerBucketEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *LedgerBucketEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LedgerBucketEntry.Merge(m, src)
+func (m *BucketIPNS) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketIPNS.Merge(m, src)
 }
-func (m *LedgerBucketEntry) XXX_Size() int {
+func (m *BucketIPNS) XXX_Size() int {
 	return m.Size()
 }
-func (m *LedgerBucketEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_LedgerBucketEntry.DiscardUnknown(m)
+func (m *BucketIPNS) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketIPNS.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LedgerBucketEntry proto.InternalMessageInfo
+var xxx_messageInfo_BucketIPNS proto.InternalMessageInfo
 
-func (m *LedgerBucketEntry) GetBucket() *Bucket {
+func (m *BucketIPNS) GetBucket() string {
 	if m != nil {
 		return m.Bucket
 	}
-	return nil
+	return ""
 }
 
-func (m *LedgerBucketEntry) GetIpfsHash() string {
+func (m *BucketIPNS) GetName() string {
 	if m != nil {
-		return m.IpfsHash
+		return m.Name
 	}
 	return ""
 }
 
-// BucketInfo is used to store s3 bucket metadata
-type BucketInfo struct {
-	// name is the name of the bucket
-	Name    string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Created time.Time `protobuf:"bytes,2,opt,name=created,proto3,stdtime" json:"created"`
-	// the location of the bucket
-	Location string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+func (m *BucketIPNS) GetMode() IPNSMode {
+	if m != nil {
+		return m.Mode
+	}
+	return IPNSMode_BUCKET_ROOT
 }
 
-func (m *BucketInfo) Reset()         { *m = BucketInfo{} }
-func (m *BucketInfo) String() string { return proto.CompactTextString(m) }
-func (*BucketInfo) ProtoMessage()    {}
-func (*BucketInfo) Descriptor() ([]byte, []int) {
+func (m *BucketIPNS) GetPublished() string {
+	if m != nil {
+		return m.Published
+	}
+	return ""
+}
+
+func (m *BucketIPNS) GetPublishedAt() time.Time {
+	if m != nil {
+		return m.PublishedAt
+	}
+	return time.Time{}
+}
+
+// FsckRequest is used to check the consistency of the ledger
+type FsckRequest struct {
+	// if set only this bucket is checked, otherwise all buckets are checked
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// if set only objects with this prefix are checked
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// if set unresolvable cids are re-pinned and size mismatches are corrected
+	Repair bool `protobuf:"varint,3,opt,name=repair,proto3" json:"repair,omitempty"`
+	// if set along with repair, ledger keys whose cids can not be re-pinned are removed
+	Remove bool `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (m *FsckRequest) Reset()         { *m = FsckRequest{} }
+func (m *FsckRequest) String() string { return proto.CompactTextString(m) }
+func (*FsckRequest) ProtoMessage()    {}
+func (*FsckRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_005e34be4304e022, []int{4}
 }
-func (m *BucketInfo) XXX_Unmarshal(b []byte) error {
+func (m *FsckRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BucketInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FsckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BucketInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FsckRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -294,61 +494,67 @@ func (m *BucketInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *BucketInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BucketInfo.Merge(m, src)
+func (m *FsckRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckRequest.Merge(m, src)
 }
-func (m *BucketInfo) XXX_Size() int {
+func (m *FsckRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *BucketInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_BucketInfo.DiscardUnknown(m)
+func (m *FsckRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BucketInfo proto.InternalMessageInfo
+var xxx_messageInfo_FsckRequest proto.InternalMessageInfo
 
-func (m *BucketInfo) GetName() string {
+func (m *FsckRequest) GetBucket() string {
 	if m != nil {
-		return m.Name
+		return m.Bucket
 	}
 	return ""
 }
 
-func (m *BucketInfo) GetCreated() time.Time {
+func (m *FsckRequest) GetPrefix() string {
 	if m != nil {
-		return m.Created
+		return m.Prefix
 	}
-	return time.Time{}
+	return ""
 }
 
-func (m *BucketInfo) GetLocation() string {
+func (m *FsckRequest) GetRepair() bool {
 	if m != nil {
-		return m.Location
+		return m.Repair
 	}
-	return ""
+	return false
 }
 
-// Bucket is a data repositroy for S3 objects
-type Bucket struct {
-	// data associated with the object
-	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	// information associated with this bucket
-	BucketInfo BucketInfo `protobuf:"bytes,2,opt,name=bucketInfo,proto3" json:"bucketInfo"`
-	// maps object names to object hashes
-	Objects map[string]string `protobuf:"bytes,3,rep,name=objects,proto3" json:"objects" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (m *FsckRequest) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
 }
 
-func (m *Bucket) Reset()         { *m = Bucket{} }
-func (m *Bucket) String() string { return proto.CompactTextString(m) }
-func (*Bucket) ProtoMessage()    {}
-func (*Bucket) Descriptor() ([]byte, []int) {
+// FsckResponse contains the result of a ledger consistency check
+type FsckResponse struct {
+	// number of buckets that were checked
+	Buckets int64 `protobuf:"varint,1,opt,name=buckets,proto3" json:"buckets,omitempty"`
+	// number of objects that were checked
+	Objects int64       `protobuf:"varint,2,opt,name=objects,proto3" json:"objects,omitempty"`
+	Issues  []FsckIssue `protobuf:"bytes,3,rep,name=issues,proto3" json:"issues"`
+}
+
+func (m *FsckResponse) Reset()         { *m = FsckResponse{} }
+func (m *FsckResponse) String() string { return proto.CompactTextString(m) }
+func (*FsckResponse) ProtoMessage()    {}
+func (*FsckResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_005e34be4304e022, []int{5}
 }
-func (m *Bucket) XXX_Unmarshal(b []byte) error {
+func (m *FsckResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Bucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FsckResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Bucket.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FsckResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -358,61 +564,64 @@ func (m *Bucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Bucket) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Bucket.Merge(m, src)
+func (m *FsckResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckResponse.Merge(m, src)
 }
-func (m *Bucket) XXX_Size() int {
+func (m *FsckResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *Bucket) XXX_DiscardUnknown() {
-	xxx_messageInfo_Bucket.DiscardUnknown(m)
+func (m *FsckResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Bucket proto.InternalMessageInfo
+var xxx_messageInfo_FsckResponse proto.InternalMessageInfo
 
-func (m *Bucket) GetData() []byte {
+func (m *FsckResponse) GetBuckets() int64 {
 	if m != nil {
-		return m.Data
+		return m.Buckets
 	}
-	return nil
+	return 0
 }
 
-func (m *Bucket) GetBucketInfo() BucketInfo {
+func (m *FsckResponse) GetObjects() int64 {
 	if m != nil {
-		return m.BucketInfo
+		return m.Objects
 	}
-	return BucketInfo{}
+	return 0
 }
 
-func (m *Bucket) GetObjects() map[string]string {
+func (m *FsckResponse) GetIssues() []FsckIssue {
 	if m != nil {
-		return m.Objects
+		return m.Issues
 	}
 	return nil
 }
 
-// Object is a singular s3 object.
-// the data field contains the actual data
-// referred to by this object, while the objectInfo
-// field is used to contain the information associated
-// wth the object
-type Object struct {
-	DataHash   string     `protobuf:"bytes,1,opt,name=dataHash,proto3" json:"dataHash,omitempty"`
-	ObjectInfo ObjectInfo `protobuf:"bytes,2,opt,name=objectInfo,proto3" json:"objectInfo"`
+// FsckIssue is a single inconsistency found by a ledger check
+type FsckIssue struct {
+	Type   FsckIssueType `protobuf:"varint,1,opt,name=type,proto3,enum=s3x.FsckIssueType" json:"type,omitempty"`
+	Bucket string        `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// empty for bucket issues
+	Object string `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
+	// the cid the issue refers to
+	Hash   string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Detail string `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	// whether or not the issue was repaired
+	Repaired bool `protobuf:"varint,6,opt,name=repaired,proto3" json:"repaired,omitempty"`
 }
 
-func (m *Object) Reset()         { *m = Object{} }
-func (m *Object) String() string { return proto.CompactTextString(m) }
-func (*Object) ProtoMessage()    {}
-func (*Object) Descriptor() ([]byte, []int) {
+func (m *FsckIssue) Reset()         { *m = FsckIssue{} }
+func (m *FsckIssue) String() string { return proto.CompactTextString(m) }
+func (*FsckIssue) ProtoMessage()    {}
+func (*FsckIssue) Descriptor() ([]byte, []int) {
 	return fileDescriptor_005e34be4304e022, []int{6}
 }
-func (m *Object) XXX_Unmarshal(b []byte) error {
+func (m *FsckIssue) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Object) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FsckIssue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Object.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FsckIssue.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -422,65 +631,85 @@ func (m *Object) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Object) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Object.Merge(m, src)
+func (m *FsckIssue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FsckIssue.Merge(m, src)
 }
-func (m *Object) XXX_Size() int {
+func (m *FsckIssue) XXX_Size() int {
 	return m.Size()
 }
-func (m *Object) XXX_DiscardUnknown() {
-	xxx_messageInfo_Object.DiscardUnknown(m)
+func (m *FsckIssue) XXX_DiscardUnknown() {
+	xxx_messageInfo_FsckIssue.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Object proto.InternalMessageInfo
+var xxx_messageInfo_FsckIssue proto.InternalMessageInfo
 
-func (m *Object) GetDataHash() string {
+func (m *FsckIssue) GetType() FsckIssueType {
 	if m != nil {
-		return m.DataHash
+		return m.Type
 	}
-	return ""
+	return FsckIssueType_BUCKET_UNRESOLVABLE
 }
 
-func (m *Object) GetObjectInfo() ObjectInfo {
+func (m *FsckIssue) GetBucket() string {
 	if m != nil {
-		return m.ObjectInfo
+		return m.Bucket
 	}
-	return ObjectInfo{}
+	return ""
 }
 
-// ObjectInfo contains information about the object
-type ObjectInfo struct {
-	Bucket             string            `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Name               string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ModTime            time.Time         `protobuf:"bytes,3,opt,name=modTime,proto3,stdtime" json:"modTime"`
-	Size_              int64             `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	IsDir              bool              `protobuf:"varint,5,opt,name=isDir,proto3" json:"isDir,omitempty"`
-	Etag               string            `protobuf:"bytes,6,opt,name=etag,proto3" json:"etag,omitempty"`
-	ContentType        string            `protobuf:"bytes,7,opt,name=contentType,proto3" json:"contentType,omitempty"`
-	ContentEncoding    string            `protobuf:"bytes,8,opt,name=contentEncoding,proto3" json:"contentEncoding,omitempty"`
-	Expires            string            `protobuf:"bytes,9,opt,name=expires,proto3" json:"expires,omitempty"`
-	StorageClass       string            `protobuf:"bytes,10,opt,name=storageClass,proto3" json:"storageClass,omitempty"`
-	Parts              []ObjectPartInfo  `protobuf:"bytes,11,rep,name=parts,proto3" json:"parts"`
-	UserDefined        map[string]string `protobuf:"bytes,12,rep,name=userDefined,proto3" json:"userDefined,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	MetadataOnly       bool              `protobuf:"varint,13,opt,name=metadataOnly,proto3" json:"metadataOnly,omitempty"`
-	AccTime            *time.Time        `protobuf:"bytes,14,opt,name=accTime,proto3,stdtime" json:"accTime,omitempty"`
-	BackendType        string            `protobuf:"bytes,15,opt,name=backendType,proto3" json:"backendType,omitempty"`
-	ContentDisposition string            `protobuf:"bytes,16,opt,name=contentDisposition,proto3" json:"contentDisposition,omitempty"`
-	ContentLanguage    string            `protobuf:"bytes,17,opt,name=contentLanguage,proto3" json:"contentLanguage,omitempty"`
+func (m *FsckIssue) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
 }
 
-func (m *ObjectInfo) Reset()         { *m = ObjectInfo{} }
-func (m *ObjectInfo) String() string { return proto.CompactTextString(m) }
-func (*ObjectInfo) ProtoMessage()    {}
-func (*ObjectInfo) Descriptor() ([]byte, []int) {
+func (m *FsckIssue) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *FsckIssue) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+func (m *FsckIssue) GetRepaired() bool {
+	if m != nil {
+		return m.Repaired
+	}
+	return false
+}
+
+// PinTarget is a remote service that object data is replicated to
+type PinTarget struct {
+	// identifies the target in pin jobs and metrics, must be unique within a bucket
+	Name string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type PinTargetType `protobuf:"varint,2,opt,name=type,proto3,enum=s3x.PinTargetType" json:"type,omitempty"`
+	// the grpc address of a TemporalX node, or the base url of a pinning service
+	Endpoint string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// the access token of a pinning service, it is not returned by the InfoAPI
+	Token string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	// connect to a TemporalX node without TLS
+	Insecure bool `protobuf:"varint,5,opt,name=insecure,proto3" json:"insecure,omitempty"`
+}
+
+func (m *PinTarget) Reset()         { *m = PinTarget{} }
+func (m *PinTarget) String() string { return proto.CompactTextString(m) }
+func (*PinTarget) ProtoMessage()    {}
+func (*PinTarget) Descriptor() ([]byte, []int) {
 	return fileDescriptor_005e34be4304e022, []int{7}
 }
-func (m *ObjectInfo) XXX_Unmarshal(b []byte) error {
+func (m *PinTarget) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ObjectInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PinTarget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ObjectInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PinTarget.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -490,168 +719,244 @@ func (m *ObjectInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *ObjectInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ObjectInfo.Merge(m, src)
+func (m *PinTarget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinTarget.Merge(m, src)
 }
-func (m *ObjectInfo) XXX_Size() int {
+func (m *PinTarget) XXX_Size() int {
 	return m.Size()
 }
-func (m *ObjectInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_ObjectInfo.DiscardUnknown(m)
+func (m *PinTarget) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinTarget.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ObjectInfo proto.InternalMessageInfo
+var xxx_messageInfo_PinTarget proto.InternalMessageInfo
 
-func (m *ObjectInfo) GetBucket() string {
+func (m *PinTarget) GetName() string {
 	if m != nil {
-		return m.Bucket
+		return m.Name
 	}
 	return ""
 }
 
-func (m *ObjectInfo) GetName() string {
+func (m *PinTarget) GetType() PinTargetType {
 	if m != nil {
-		return m.Name
+		return m.Type
 	}
-	return ""
+	return PinTargetType_TEMPORALX
 }
 
-func (m *ObjectInfo) GetModTime() time.Time {
+func (m *PinTarget) GetEndpoint() string {
 	if m != nil {
-		return m.ModTime
+		return m.Endpoint
 	}
-	return time.Time{}
+	return ""
 }
 
-func (m *ObjectInfo) GetSize_() int64 {
+func (m *PinTarget) GetToken() string {
 	if m != nil {
-		return m.Size_
+		return m.Token
 	}
-	return 0
+	return ""
 }
 
-func (m *ObjectInfo) GetIsDir() bool {
+func (m *PinTarget) GetInsecure() bool {
 	if m != nil {
-		return m.IsDir
+		return m.Insecure
 	}
 	return false
 }
 
-func (m *ObjectInfo) GetEtag() string {
+// BucketPinTargets are the remote targets the objects of a bucket are pinned to
+type BucketPinTargets struct {
+	Bucket  string      `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Targets []PinTarget `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets"`
+}
+
+func (m *BucketPinTargets) Reset()         { *m = BucketPinTargets{} }
+func (m *BucketPinTargets) String() string { return proto.CompactTextString(m) }
+func (*BucketPinTargets) ProtoMessage()    {}
+func (*BucketPinTargets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{8}
+}
+func (m *BucketPinTargets) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BucketPinTargets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BucketPinTargets.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BucketPinTargets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketPinTargets.Merge(m, src)
+}
+func (m *BucketPinTargets) XXX_Size() int {
+	return m.Size()
+}
+func (m *BucketPinTargets) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketPinTargets.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketPinTargets proto.InternalMessageInfo
+
+func (m *BucketPinTargets) GetBucket() string {
 	if m != nil {
-		return m.Etag
+		return m.Bucket
 	}
 	return ""
 }
 
-func (m *ObjectInfo) GetContentType() string {
+func (m *BucketPinTargets) GetTargets() []PinTarget {
 	if m != nil {
-		return m.ContentType
+		return m.Targets
 	}
-	return ""
+	return nil
 }
 
-func (m *ObjectInfo) GetContentEncoding() string {
+// PinJob pins an object to a remote target, pin jobs are kept in the ledger datastore
+type PinJob struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// the hash of the object protocol buffer, the object data is pinned along with it
+	Hash        string    `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Target      string    `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	State       PinState  `protobuf:"varint,5,opt,name=state,proto3,enum=s3x.PinState" json:"state,omitempty"`
+	Attempts    int32     `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttempt time.Time `protobuf:"bytes,7,opt,name=nextAttempt,proto3,stdtime" json:"nextAttempt"`
+	LastError   string    `protobuf:"bytes,8,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	// pinning service request ids by hash, used to check the status of accepted pin requests
+	RequestIds map[string]string `protobuf:"bytes,9,rep,name=requestIds,proto3" json:"requestIds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Updated    time.Time         `protobuf:"bytes,10,opt,name=updated,proto3,stdtime" json:"updated"`
+}
+
+func (m *PinJob) Reset()         { *m = PinJob{} }
+func (m *PinJob) String() string { return proto.CompactTextString(m) }
+func (*PinJob) ProtoMessage()    {}
+func (*PinJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{9}
+}
+func (m *PinJob) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PinJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PinJob.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PinJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinJob.Merge(m, src)
+}
+func (m *PinJob) XXX_Size() int {
+	return m.Size()
+}
+func (m *PinJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinJob proto.InternalMessageInfo
+
+func (m *PinJob) GetBucket() string {
 	if m != nil {
-		return m.ContentEncoding
+		return m.Bucket
 	}
 	return ""
 }
 
-func (m *ObjectInfo) GetExpires() string {
+func (m *PinJob) GetObject() string {
 	if m != nil {
-		return m.Expires
+		return m.Object
 	}
 	return ""
 }
 
-func (m *ObjectInfo) GetStorageClass() string {
+func (m *PinJob) GetHash() string {
 	if m != nil {
-		return m.StorageClass
+		return m.Hash
 	}
 	return ""
 }
 
-func (m *ObjectInfo) GetParts() []ObjectPartInfo {
+func (m *PinJob) GetTarget() string {
 	if m != nil {
-		return m.Parts
+		return m.Target
 	}
-	return nil
+	return ""
 }
 
-func (m *ObjectInfo) GetUserDefined() map[string]string {
+func (m *PinJob) GetState() PinState {
 	if m != nil {
-		return m.UserDefined
+		return m.State
 	}
-	return nil
+	return PinState_QUEUED
 }
 
-func (m *ObjectInfo) GetMetadataOnly() bool {
+func (m *PinJob) GetAttempts() int32 {
 	if m != nil {
-		return m.MetadataOnly
+		return m.Attempts
 	}
-	return false
+	return 0
 }
 
-func (m *ObjectInfo) GetAccTime() *time.Time {
+func (m *PinJob) GetNextAttempt() time.Time {
 	if m != nil {
-		return m.AccTime
+		return m.NextAttempt
 	}
-	return nil
+	return time.Time{}
 }
 
-func (m *ObjectInfo) GetBackendType() string {
+func (m *PinJob) GetLastError() string {
 	if m != nil {
-		return m.BackendType
+		return m.LastError
 	}
 	return ""
 }
 
-func (m *ObjectInfo) GetContentDisposition() string {
+func (m *PinJob) GetRequestIds() map[string]string {
 	if m != nil {
-		return m.ContentDisposition
+		return m.RequestIds
 	}
-	return ""
+	return nil
 }
 
-func (m *ObjectInfo) GetContentLanguage() string {
+func (m *PinJob) GetUpdated() time.Time {
 	if m != nil {
-		return m.ContentLanguage
+		return m.Updated
 	}
-	return ""
+	return time.Time{}
 }
 
-// ObjectPartInfo contains information an individual object client.
-// For Etag, use dataHash
-type ObjectPartInfo struct {
-	// convertable to "int" type in minio.PartInfo
-	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
-	// name of object in bucket
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Date and time at which the part was uploaded.
-	LastModified time.Time `protobuf:"bytes,3,opt,name=lastModified,proto3,stdtime" json:"lastModified"`
-	// Size in bytes of the part.
-	Size_ int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	// Decompressed Size.
-	ActualSize int64 `protobuf:"varint,5,opt,name=actualSize,proto3" json:"actualSize,omitempty"`
-	// the hash of the data on ipfs
-	// in the case of multipart uploads
-	// this will refer to a unixfs object
-	DataHash string `protobuf:"bytes,6,opt,name=dataHash,proto3" json:"dataHash,omitempty"`
+// PinStatusRequest is used to get the pin jobs of an object
+type PinStatusRequest struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// if empty the jobs of all objects in the bucket are returned
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
 }
 
-func (m *ObjectPartInfo) Reset()         { *m = ObjectPartInfo{} }
-func (m *ObjectPartInfo) String() string { return proto.CompactTextString(m) }
-func (*ObjectPartInfo) ProtoMessage()    {}
-func (*ObjectPartInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_005e34be4304e022, []int{8}
+func (m *PinStatusRequest) Reset()         { *m = PinStatusRequest{} }
+func (m *PinStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*PinStatusRequest) ProtoMessage()    {}
+func (*PinStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{10}
 }
-func (m *ObjectPartInfo) XXX_Unmarshal(b []byte) error {
+func (m *PinStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ObjectPartInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PinStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ObjectPartInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PinStatusRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -661,79 +966,94 @@ func (m *ObjectPartInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *ObjectPartInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ObjectPartInfo.Merge(m, src)
+func (m *PinStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinStatusRequest.Merge(m, src)
 }
-func (m *ObjectPartInfo) XXX_Size() int {
+func (m *PinStatusRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ObjectPartInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_ObjectPartInfo.DiscardUnknown(m)
+func (m *PinStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ObjectPartInfo proto.InternalMessageInfo
+var xxx_messageInfo_PinStatusRequest proto.InternalMessageInfo
 
-func (m *ObjectPartInfo) GetNumber() int64 {
+func (m *PinStatusRequest) GetBucket() string {
 	if m != nil {
-		return m.Number
+		return m.Bucket
 	}
-	return 0
+	return ""
 }
 
-func (m *ObjectPartInfo) GetName() string {
+func (m *PinStatusRequest) GetObject() string {
 	if m != nil {
-		return m.Name
+		return m.Object
 	}
 	return ""
 }
 
-func (m *ObjectPartInfo) GetLastModified() time.Time {
-	if m != nil {
-		return m.LastModified
-	}
-	return time.Time{}
+type PinStatusResponse struct {
+	Jobs []PinJob `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs"`
 }
 
-func (m *ObjectPartInfo) GetSize_() int64 {
-	if m != nil {
-		return m.Size_
-	}
-	return 0
+func (m *PinStatusResponse) Reset()         { *m = PinStatusResponse{} }
+func (m *PinStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*PinStatusResponse) ProtoMessage()    {}
+func (*PinStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{11}
 }
-
-func (m *ObjectPartInfo) GetActualSize() int64 {
-	if m != nil {
-		return m.ActualSize
+func (m *PinStatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PinStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PinStatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (m *PinStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinStatusResponse.Merge(m, src)
+}
+func (m *PinStatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PinStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinStatusResponse.DiscardUnknown(m)
 }
 
-func (m *ObjectPartInfo) GetDataHash() string {
+var xxx_messageInfo_PinStatusResponse proto.InternalMessageInfo
+
+func (m *PinStatusResponse) GetJobs() []PinJob {
 	if m != nil {
-		return m.DataHash
+		return m.Jobs
 	}
-	return ""
+	return nil
 }
 
-type MultipartUpload struct {
-	ObjectInfo *ObjectInfo `protobuf:"bytes,1,opt,name=objectInfo,proto3" json:"objectInfo,omitempty"`
-	Id         string      `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	//map of index to parts
-	ObjectParts map[int64]ObjectPartInfo `protobuf:"bytes,3,rep,name=objectParts,proto3" json:"objectParts" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+// TransactionRequest identifies a transaction, the id is empty when a transaction is started
+type TransactionRequest struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Id     string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (m *MultipartUpload) Reset()         { *m = MultipartUpload{} }
-func (m *MultipartUpload) String() string { return proto.CompactTextString(m) }
-func (*MultipartUpload) ProtoMessage()    {}
-func (*MultipartUpload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_005e34be4304e022, []int{9}
+func (m *TransactionRequest) Reset()         { *m = TransactionRequest{} }
+func (m *TransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*TransactionRequest) ProtoMessage()    {}
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{12}
 }
-func (m *MultipartUpload) XXX_Unmarshal(b []byte) error {
+func (m *TransactionRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MultipartUpload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MultipartUpload.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TransactionRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -743,1154 +1063,8215 @@ func (m *MultipartUpload) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *MultipartUpload) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MultipartUpload.Merge(m, src)
+func (m *TransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionRequest.Merge(m, src)
 }
-func (m *MultipartUpload) XXX_Size() int {
+func (m *TransactionRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MultipartUpload) XXX_DiscardUnknown() {
-	xxx_messageInfo_MultipartUpload.DiscardUnknown(m)
+func (m *TransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MultipartUpload proto.InternalMessageInfo
+var xxx_messageInfo_TransactionRequest proto.InternalMessageInfo
 
-func (m *MultipartUpload) GetObjectInfo() *ObjectInfo {
+func (m *TransactionRequest) GetBucket() string {
 	if m != nil {
-		return m.ObjectInfo
+		return m.Bucket
 	}
-	return nil
+	return ""
 }
 
-func (m *MultipartUpload) GetId() string {
+func (m *TransactionRequest) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *MultipartUpload) GetObjectParts() map[int64]ObjectPartInfo {
+// TransactionOp is an operation staged in a transaction
+type TransactionOp struct {
+	Type TransactionOpType `protobuf:"varint,1,opt,name=type,proto3,enum=s3x.TransactionOpType" json:"type,omitempty"`
+	// the object that is put, copied to or deleted
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// the data of a put, it is uploaded when the operation is staged and not kept in the transaction
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// the user defined metadata of a put, including the content type
+	UserDefined map[string]string `protobuf:"bytes,4,rep,name=userDefined,proto3" json:"userDefined,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the source object of a copy, as it is when the transaction is committed
+	Source string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	// the hash of the object protocol buffer of a put, set when the operation is staged
+	Hash string `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *TransactionOp) Reset()         { *m = TransactionOp{} }
+func (m *TransactionOp) String() string { return proto.CompactTextString(m) }
+func (*TransactionOp) ProtoMessage()    {}
+func (*TransactionOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{13}
+}
+func (m *TransactionOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransactionOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransactionOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransactionOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionOp.Merge(m, src)
+}
+func (m *TransactionOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransactionOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionOp proto.InternalMessageInfo
+
+func (m *TransactionOp) GetType() TransactionOpType {
 	if m != nil {
-		return m.ObjectParts
+		return m.Type
 	}
-	return nil
+	return TransactionOpType_PUT
 }
 
-func init() {
-	proto.RegisterType((*InfoRequest)(nil), "s3x.InfoRequest")
-	proto.RegisterType((*InfoResponse)(nil), "s3x.InfoResponse")
-	proto.RegisterType((*Ledger)(nil), "s3x.Ledger")
-	proto.RegisterMapType((map[string]*LedgerBucketEntry)(nil), "s3x.Ledger.BucketsEntry")
-	proto.RegisterMapType((map[string]*MultipartUpload)(nil), "s3x.Ledger.MultipartUploadsEntry")
-	proto.RegisterType((*LedgerBucketEntry)(nil), "s3x.LedgerBucketEntry")
-	proto.RegisterType((*BucketInfo)(nil), "s3x.BucketInfo")
-	proto.RegisterType((*Bucket)(nil), "s3x.Bucket")
-	proto.RegisterMapType((map[string]string)(nil), "s3x.Bucket.ObjectsEntry")
-	proto.RegisterType((*Object)(nil), "s3x.Object")
-	proto.RegisterType((*ObjectInfo)(nil), "s3x.ObjectInfo")
-	proto.RegisterMapType((map[string]string)(nil), "s3x.ObjectInfo.UserDefinedEntry")
-	proto.RegisterType((*ObjectPartInfo)(nil), "s3x.ObjectPartInfo")
-	proto.RegisterType((*MultipartUpload)(nil), "s3x.MultipartUpload")
-	proto.RegisterMapType((map[int64]ObjectPartInfo)(nil), "s3x.MultipartUpload.ObjectPartsEntry")
+func (m *TransactionOp) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("s3.proto", fileDescriptor_005e34be4304e022) }
-
-var fileDescriptor_005e34be4304e022 = []byte{
-	// 982 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xcf, 0xd9, 0xf5, 0x9f, 0x8c, 0xdd, 0xc4, 0x59, 0x4a, 0xb5, 0x3a, 0x21, 0xc7, 0x1c, 0x02,
-	0x19, 0x04, 0x67, 0xc9, 0x11, 0x52, 0x55, 0x89, 0x4a, 0x84, 0x54, 0xa4, 0x52, 0xa3, 0x54, 0xd7,
-	0xf4, 0x01, 0xf1, 0xb4, 0xbe, 0x5b, 0x5f, 0x96, 0xd8, 0xb7, 0xc7, 0xed, 0x1e, 0x4a, 0x10, 0x4f,
-	0x7c, 0x82, 0x4a, 0x7c, 0x1b, 0x3e, 0x41, 0x79, 0xab, 0x84, 0x40, 0x3c, 0x01, 0x4a, 0xf8, 0x0c,
-	0x3c, 0xa3, 0xdd, 0xbd, 0x73, 0xf6, 0x9c, 0x43, 0x6a, 0xde, 0x76, 0x66, 0x7e, 0xbf, 0x99, 0xdb,
-	0xf9, 0xcd, 0xac, 0x0d, 0x5d, 0xb1, 0xe7, 0xa7, 0x19, 0x97, 0x1c, 0x35, 0xc5, 0xde, 0xb9, 0xfb,
-	0x49, 0xcc, 0xe4, 0x69, 0x3e, 0xf3, 0x43, 0xbe, 0x9c, 0xc4, 0x3c, 0xe6, 0x13, 0x1d, 0x9b, 0xe5,
-	0x73, 0x6d, 0x69, 0x43, 0x9f, 0x0c, 0xc7, 0xdd, 0x8d, 0x39, 0x8f, 0x17, 0xf4, 0x1a, 0x25, 0xd9,
-	0x92, 0x0a, 0x49, 0x96, 0x69, 0x01, 0x78, 0xa7, 0x00, 0x90, 0x94, 0x4d, 0x48, 0x92, 0x70, 0x49,
-	0x24, 0xe3, 0x89, 0x30, 0x51, 0x8f, 0x42, 0xef, 0x49, 0x32, 0xe7, 0x01, 0xfd, 0x36, 0xa7, 0x42,
-	0xa2, 0xfb, 0xd0, 0x9e, 0xe5, 0xe1, 0x19, 0x95, 0xd8, 0x19, 0x39, 0xe3, 0xcd, 0xa0, 0xb0, 0x94,
-	0x9f, 0xcf, 0xbe, 0xa1, 0xa1, 0xc4, 0x0d, 0xe3, 0x37, 0x16, 0xfa, 0x00, 0xb6, 0xcc, 0xe9, 0x80,
-	0x48, 0x72, 0x9c, 0x2c, 0x2e, 0x70, 0x73, 0xe4, 0x8c, 0xbb, 0xc1, 0x9a, 0xd7, 0x0b, 0xa0, 0x6f,
-	0xca, 0x88, 0x94, 0x27, 0x82, 0xde, 0xba, 0x0e, 0x82, 0x3b, 0xa7, 0x44, 0x9c, 0xea, 0xec, 0x9b,
-	0x81, 0x3e, 0x7b, 0x3f, 0x37, 0xa0, 0xfd, 0x94, 0x46, 0x31, 0xcd, 0xd0, 0x14, 0x3a, 0x26, 0x81,
-	0xc0, 0xce, 0xa8, 0x39, 0xee, 0x4d, 0xb1, 0x2f, 0xf6, 0xce, 0x7d, 0x13, 0xf5, 0xf7, 0x4d, 0xe8,
-	0x71, 0x22, 0xb3, 0x8b, 0xa0, 0x04, 0xa2, 0x23, 0x18, 0x2c, 0xf3, 0x85, 0x64, 0x29, 0xc9, 0xe4,
-	0x8b, 0x74, 0xc1, 0x49, 0x24, 0x70, 0x43, 0x93, 0xdf, 0xb5, 0xc9, 0x47, 0x6b, 0x18, 0x93, 0xe5,
-	0x06, 0xd5, 0x0d, 0xa0, 0x6f, 0xd7, 0x41, 0x03, 0x68, 0x9e, 0xd1, 0x8b, 0xe2, 0x7a, 0xea, 0x88,
-	0x3e, 0x86, 0xd6, 0x77, 0x64, 0x91, 0x53, 0x7d, 0xb5, 0xde, 0xf4, 0xbe, 0x55, 0xc5, 0x30, 0x4d,
-	0x6a, 0x03, 0x7a, 0xd8, 0x78, 0xe0, 0xb8, 0x5f, 0xc1, 0xdb, 0xb5, 0xe5, 0x6b, 0x92, 0x7f, 0x54,
-	0x4d, 0x7e, 0x4f, 0x27, 0x5f, 0x23, 0x5b, 0xa9, 0xbd, 0x13, 0xd8, 0xb9, 0x51, 0x1a, 0xbd, 0x57,
-	0x51, 0xa5, 0x37, 0xed, 0xe9, 0x2c, 0x06, 0xb1, 0x92, 0xc8, 0x85, 0x2e, 0x4b, 0xe7, 0xe2, 0x50,
-	0xc9, 0x61, 0x44, 0x5a, 0xd9, 0xde, 0x0f, 0x00, 0x06, 0xad, 0xc4, 0x56, 0xa2, 0x25, 0x64, 0x49,
-	0x8b, 0xcf, 0xd4, 0x67, 0xf4, 0x08, 0x3a, 0x61, 0x46, 0x89, 0xa4, 0x51, 0xf1, 0xa5, 0xae, 0x6f,
-	0xe6, 0xd3, 0x2f, 0x07, 0xd8, 0x3f, 0x29, 0x07, 0x78, 0xbf, 0xfb, 0xea, 0xcf, 0xdd, 0x8d, 0x97,
-	0x7f, 0xed, 0x3a, 0x41, 0x49, 0x52, 0xd5, 0x17, 0x3c, 0xd4, 0x23, 0x5c, 0x0c, 0xc3, 0xca, 0xf6,
-	0x7e, 0x71, 0xa0, 0x6d, 0xca, 0xab, 0xd2, 0x11, 0x91, 0x44, 0x97, 0xee, 0x07, 0xfa, 0x8c, 0x3e,
-	0x05, 0x98, 0xad, 0x3e, 0xae, 0xa8, 0xbe, 0x6d, 0xdd, 0x50, 0xb9, 0xf7, 0xef, 0xa8, 0x92, 0x81,
-	0x05, 0x44, 0x0f, 0xa0, 0x63, 0x86, 0x50, 0xe0, 0xa6, 0x35, 0x5b, 0x86, 0xe3, 0x1f, 0x9b, 0x90,
-	0xee, 0x5f, 0x41, 0x2e, 0xe1, 0xee, 0x43, 0xe8, 0xdb, 0xe1, 0x1a, 0xd5, 0xee, 0xd9, 0xaa, 0x6d,
-	0xda, 0xfa, 0x7c, 0x0d, 0x6d, 0xc3, 0x55, 0x37, 0x56, 0x9f, 0xaf, 0xfb, 0x6d, 0xa8, 0x2b, 0x5b,
-	0x5d, 0xc9, 0x14, 0xbb, 0x71, 0xa5, 0xe3, 0x95, 0xbb, 0xbc, 0xd2, 0x35, 0xd0, 0xfb, 0xad, 0x05,
-	0x70, 0x0d, 0xf8, 0xdf, 0x65, 0x2c, 0xf5, 0x6b, 0x54, 0xf5, 0x5b, 0xf2, 0x48, 0x49, 0xa4, 0xdb,
-	0xff, 0xc6, 0xfa, 0x15, 0x24, 0x95, 0x53, 0xb0, 0xef, 0x29, 0xbe, 0x33, 0x72, 0xc6, 0xcd, 0x40,
-	0x9f, 0x55, 0x17, 0x98, 0x38, 0x60, 0x19, 0x6e, 0xe9, 0xb7, 0xc3, 0x18, 0x0a, 0x49, 0x25, 0x89,
-	0x71, 0xdb, 0x54, 0x57, 0x67, 0x34, 0x82, 0x5e, 0xc8, 0x13, 0x49, 0x13, 0x79, 0x72, 0x91, 0x52,
-	0xdc, 0xd1, 0x21, 0xdb, 0x85, 0xc6, 0xb0, 0x5d, 0x98, 0x8f, 0x93, 0x90, 0x47, 0x2c, 0x89, 0x71,
-	0x57, 0xa3, 0xd6, 0xdd, 0x08, 0x43, 0x87, 0x9e, 0xa7, 0x2c, 0xa3, 0x02, 0x6f, 0x6a, 0x44, 0x69,
-	0x22, 0x0f, 0xfa, 0x42, 0xf2, 0x8c, 0xc4, 0xf4, 0x8b, 0x05, 0x11, 0x02, 0x83, 0x0e, 0x57, 0x7c,
-	0x68, 0x02, 0x2d, 0xb5, 0x58, 0x02, 0xf7, 0xf4, 0x4c, 0xbc, 0x65, 0x35, 0xfd, 0x19, 0xc9, 0xec,
-	0xc6, 0x1b, 0x1c, 0xda, 0x87, 0x5e, 0x2e, 0x68, 0x76, 0x40, 0xe7, 0x2c, 0xa1, 0x11, 0xee, 0x6b,
-	0xda, 0x68, 0x4d, 0x2b, 0xff, 0xc5, 0x35, 0xc4, 0xbc, 0x06, 0x36, 0x49, 0x7d, 0xd8, 0x92, 0x4a,
-	0x12, 0x95, 0x6f, 0xed, 0x5d, 0xdd, 0xaf, 0x8a, 0x4f, 0x09, 0x44, 0xc2, 0x50, 0x0b, 0xb4, 0xf5,
-	0x46, 0x02, 0x39, 0x46, 0xa0, 0x82, 0xa4, 0x5a, 0x3c, 0x23, 0xe1, 0x19, 0x4d, 0x22, 0xdd, 0xe2,
-	0x6d, 0xd3, 0x62, 0xcb, 0x85, 0x7c, 0x40, 0x45, 0x2f, 0x0f, 0x98, 0x48, 0xb9, 0x60, 0x7a, 0x19,
-	0x07, 0x1a, 0x58, 0x13, 0xb1, 0x24, 0x79, 0x4a, 0x92, 0x38, 0x27, 0x31, 0xc5, 0x3b, 0x15, 0x49,
-	0x4a, 0xb7, 0xfb, 0x08, 0x06, 0xeb, 0x0d, 0xb8, 0xd5, 0xd2, 0xfc, 0xee, 0xc0, 0x56, 0x55, 0x03,
-	0x35, 0xdb, 0x49, 0xbe, 0x9c, 0xd1, 0x4c, 0x67, 0x68, 0x06, 0x85, 0x55, 0x3b, 0xdb, 0x87, 0xd0,
-	0x5f, 0x10, 0x21, 0x8f, 0x78, 0xc4, 0xe6, 0x8c, 0x46, 0xb7, 0x1a, 0xf0, 0x0a, 0xb3, 0x76, 0xca,
-	0x87, 0x00, 0x24, 0x94, 0x39, 0x59, 0x3c, 0x57, 0x91, 0x96, 0x8e, 0x58, 0x9e, 0xca, 0x9e, 0xb7,
-	0xab, 0x7b, 0xee, 0xfd, 0xeb, 0xc0, 0xf6, 0xda, 0x63, 0x8e, 0x26, 0x95, 0xdd, 0x77, 0x6a, 0x77,
-	0xdf, 0xde, 0x7a, 0xb4, 0x05, 0x0d, 0x16, 0x15, 0x17, 0x6e, 0xb0, 0x08, 0x1d, 0x41, 0x8f, 0xaf,
-	0x9a, 0x55, 0x3e, 0x6e, 0xef, 0xd7, 0xfd, 0x70, 0x58, 0x83, 0x5d, 0x79, 0xe9, 0x6c, 0xbe, 0xfb,
-	0x1c, 0x06, 0xeb, 0x30, 0x5b, 0xbc, 0xa6, 0x11, 0xef, 0xc3, 0xea, 0xef, 0x54, 0xdd, 0xde, 0x58,
-	0x8a, 0x4e, 0x0f, 0xa1, 0xa3, 0x5c, 0x9f, 0x3f, 0x7b, 0x82, 0x3e, 0x83, 0xce, 0x97, 0x54, 0xea,
-	0x67, 0x6f, 0xa0, 0x59, 0xd6, 0xff, 0x16, 0x77, 0xc7, 0xf2, 0x98, 0xbf, 0x18, 0xde, 0xdd, 0x1f,
-	0x7f, 0xfd, 0xe7, 0xa7, 0x46, 0x07, 0xb5, 0x26, 0x4c, 0x2d, 0x23, 0x7e, 0x75, 0x39, 0x74, 0x5e,
-	0x5f, 0x0e, 0x9d, 0xbf, 0x2f, 0x87, 0xce, 0xcb, 0xab, 0xe1, 0xc6, 0xeb, 0xab, 0xe1, 0xc6, 0x1f,
-	0x57, 0xc3, 0x8d, 0x59, 0x5b, 0x0b, 0xbb, 0xf7, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc9, 0x2d,
-	0xa6, 0x73, 0x88, 0x09, 0x00, 0x00,
+func (m *TransactionOp) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *TransactionOp) GetUserDefined() map[string]string {
+	if m != nil {
+		return m.UserDefined
+	}
+	return nil
+}
 
-// InfoAPIClient is the client API for InfoAPI service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type InfoAPIClient interface {
-	GetHash(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+func (m *TransactionOp) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
 }
 
-type infoAPIClient struct {
-	cc *grpc.ClientConn
+func (m *TransactionOp) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
 }
 
-func NewInfoAPIClient(cc *grpc.ClientConn) InfoAPIClient {
-	return &infoAPIClient{cc}
+// TransactionOps are operations that are added to a transaction
+type TransactionOps struct {
+	Bucket string          `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Id     string          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Ops    []TransactionOp `protobuf:"bytes,3,rep,name=ops,proto3" json:"ops"`
 }
 
-func (c *infoAPIClient) GetHash(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
-	out := new(InfoResponse)
-	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/GetHash", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TransactionOps) Reset()         { *m = TransactionOps{} }
+func (m *TransactionOps) String() string { return proto.CompactTextString(m) }
+func (*TransactionOps) ProtoMessage()    {}
+func (*TransactionOps) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{14}
+}
+func (m *TransactionOps) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransactionOps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransactionOps.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
 }
-
-// InfoAPIServer is the server API for InfoAPI service.
-type InfoAPIServer interface {
-	GetHash(context.Context, *InfoRequest) (*InfoResponse, error)
+func (m *TransactionOps) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionOps.Merge(m, src)
 }
-
-// UnimplementedInfoAPIServer can be embedded to have forward compatible implementations.
-type UnimplementedInfoAPIServer struct {
+func (m *TransactionOps) XXX_Size() int {
+	return m.Size()
 }
-
-func (*UnimplementedInfoAPIServer) GetHash(ctx context.Context, req *InfoRequest) (*InfoResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetHash not implemented")
+func (m *TransactionOps) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionOps.DiscardUnknown(m)
 }
 
-func RegisterInfoAPIServer(s *grpc.Server, srv InfoAPIServer) {
-	s.RegisterService(&_InfoAPI_serviceDesc, srv)
-}
+var xxx_messageInfo_TransactionOps proto.InternalMessageInfo
 
-func _InfoAPI_GetHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InfoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(InfoAPIServer).GetHash(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/s3x.InfoAPI/GetHash",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InfoAPIServer).GetHash(ctx, req.(*InfoRequest))
+func (m *TransactionOps) GetBucket() string {
+	if m != nil {
+		return m.Bucket
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _InfoAPI_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "s3x.InfoAPI",
-	HandlerType: (*InfoAPIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetHash",
-			Handler:    _InfoAPI_GetHash_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "s3.proto",
+func (m *TransactionOps) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func (m *InfoRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *TransactionOps) GetOps() []TransactionOp {
+	if m != nil {
+		return m.Ops
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *InfoRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Transaction is a set of staged operations on a bucket,
+// transactions are kept in the ledger datastore until they are committed or aborted
+type Transaction struct {
+	Bucket  string          `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Id      string          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Ops     []TransactionOp `protobuf:"bytes,3,rep,name=ops,proto3" json:"ops"`
+	Created time.Time       `protobuf:"bytes,4,opt,name=created,proto3,stdtime" json:"created"`
 }
 
-func (m *InfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ObjectDataOnly {
-		i--
-		if m.ObjectDataOnly {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{15}
+}
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Object) > 0 {
-		i -= len(m.Object)
-		copy(dAtA[i:], m.Object)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Bucket) > 0 {
-		i -= len(m.Bucket)
-		copy(dAtA[i:], m.Bucket)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return m.Size()
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
 }
 
-func (m *InfoResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetBucket() string {
+	if m != nil {
+		return m.Bucket
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *InfoResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Transaction) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func (m *InfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		i -= len(m.Hash)
-		copy(dAtA[i:], m.Hash)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Hash)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Object) > 0 {
-		i -= len(m.Object)
-		copy(dAtA[i:], m.Object)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Bucket) > 0 {
-		i -= len(m.Bucket)
-		copy(dAtA[i:], m.Bucket)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
-		i--
-		dAtA[i] = 0xa
+func (m *Transaction) GetOps() []TransactionOp {
+	if m != nil {
+		return m.Ops
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *Ledger) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Transaction) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
 	}
-	return dAtA[:n], nil
+	return time.Time{}
 }
 
-func (m *Ledger) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Ledger is our internal state keeper, and is responsible
+// for keeping track of buckets, objects, and their corresponding IPFS hashes
+type Ledger struct {
+	// key = bucket name
+	Buckets map[string]*LedgerBucketEntry `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// key = partID
+	MultipartUploads map[string]*MultipartUpload `protobuf:"bytes,2,rep,name=multipartUploads,proto3" json:"multipartUploads,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (m *Ledger) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.MultipartUploads) > 0 {
-		for k := range m.MultipartUploads {
-			v := m.MultipartUploads[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintS3(dAtA, i, uint64(size))
-				}
-				i--
-				dAtA[i] = 0x12
-			}
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintS3(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x12
+func (m *Ledger) Reset()         { *m = Ledger{} }
+func (m *Ledger) String() string { return proto.CompactTextString(m) }
+func (*Ledger) ProtoMessage()    {}
+func (*Ledger) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{16}
+}
+func (m *Ledger) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Ledger) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Ledger.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Buckets) > 0 {
-		for k := range m.Buckets {
-			v := m.Buckets[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintS3(dAtA, i, uint64(size))
-				}
-				i--
-				dAtA[i] = 0x12
-			}
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintS3(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0xa
-		}
+}
+func (m *Ledger) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ledger.Merge(m, src)
+}
+func (m *Ledger) XXX_Size() int {
+	return m.Size()
+}
+func (m *Ledger) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ledger.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ledger proto.InternalMessageInfo
+
+func (m *Ledger) GetBuckets() map[string]*LedgerBucketEntry {
+	if m != nil {
+		return m.Buckets
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *LedgerBucketEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Ledger) GetMultipartUploads() map[string]*MultipartUpload {
+	if m != nil {
+		return m.MultipartUploads
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *LedgerBucketEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// LedgerBucketEntry is an individual entry within the ledger containing information about a bucket
+type LedgerBucketEntry struct {
+	//if bucket is nil, this entry can be lazy loaded from ifps
+	Bucket   *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	IpfsHash string  `protobuf:"bytes,2,opt,name=ipfsHash,proto3" json:"ipfsHash,omitempty"`
 }
 
-func (m *LedgerBucketEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.IpfsHash) > 0 {
-		i -= len(m.IpfsHash)
-		copy(dAtA[i:], m.IpfsHash)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.IpfsHash)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Bucket != nil {
-		{
-			size, err := m.Bucket.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintS3(dAtA, i, uint64(size))
+func (m *LedgerBucketEntry) Reset()         { *m = LedgerBucketEntry{} }
+func (m *LedgerBucketEntry) String() string { return proto.CompactTextString(m) }
+func (*LedgerBucketEntry) ProtoMessage()    {}
+func (*LedgerBucketEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{17}
+}
+func (m *LedgerBucketEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LedgerBucketEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LedgerBucketEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *LedgerBucketEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LedgerBucketEntry.Merge(m, src)
+}
+func (m *LedgerBucketEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *LedgerBucketEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LedgerBucketEntry.DiscardUnknown(m)
 }
 
-func (m *BucketInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LedgerBucketEntry proto.InternalMessageInfo
+
+func (m *LedgerBucketEntry) GetBucket() *Bucket {
+	if m != nil {
+		return m.Bucket
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *BucketInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LedgerBucketEntry) GetIpfsHash() string {
+	if m != nil {
+		return m.IpfsHash
+	}
+	return ""
 }
 
-func (m *BucketInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Location) > 0 {
-		i -= len(m.Location)
-		copy(dAtA[i:], m.Location)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Location)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
-	if err4 != nil {
-		return 0, err4
+// BucketUsage is the data usage of a bucket, it is kept up to date by the ledger
+// as objects are put and removed, so it does not have to be crawled
+type BucketUsage struct {
+	// total size of all objects
+	Size_ int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// number of objects
+	ObjectsCount uint64 `protobuf:"varint,2,opt,name=objectsCount,proto3" json:"objectsCount,omitempty"`
+	// number of objects per size interval, keyed by the minio histogram interval name
+	ObjectsSizesHistogram map[string]uint64 `protobuf:"bytes,3,rep,name=objectsSizesHistogram,proto3" json:"objectsSizesHistogram,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// total size of the distinct object data, objects with the same data cid are counted once
+	UniqueSize int64 `protobuf:"varint,4,opt,name=uniqueSize,proto3" json:"uniqueSize,omitempty"`
+	// number of distinct object data cids
+	UniqueCount uint64 `protobuf:"varint,5,opt,name=uniqueCount,proto3" json:"uniqueCount,omitempty"`
+	// whether the data of the objects is in the data reference index, usage recorded
+	// before the index existed is computed again to index the objects
+	DataIndexed bool `protobuf:"varint,6,opt,name=dataIndexed,proto3" json:"dataIndexed,omitempty"`
+}
+
+func (m *BucketUsage) Reset()         { *m = BucketUsage{} }
+func (m *BucketUsage) String() string { return proto.CompactTextString(m) }
+func (*BucketUsage) ProtoMessage()    {}
+func (*BucketUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{18}
+}
+func (m *BucketUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BucketUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BucketUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i -= n4
-	i = encodeVarintS3(dAtA, i, uint64(n4))
-	i--
-	dAtA[i] = 0x12
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *BucketUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketUsage.Merge(m, src)
+}
+func (m *BucketUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *BucketUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketUsage proto.InternalMessageInfo
+
+func (m *BucketUsage) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *Bucket) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *BucketUsage) GetObjectsCount() uint64 {
+	if m != nil {
+		return m.ObjectsCount
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Bucket) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *BucketUsage) GetObjectsSizesHistogram() map[string]uint64 {
+	if m != nil {
+		return m.ObjectsSizesHistogram
+	}
+	return nil
 }
 
-func (m *Bucket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Objects) > 0 {
-		for k := range m.Objects {
-			v := m.Objects[k]
-			baseI := i
-			i -= len(v)
-			copy(dAtA[i:], v)
-			i = encodeVarintS3(dAtA, i, uint64(len(v)))
-			i--
-			dAtA[i] = 0x12
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintS3(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x1a
-		}
+func (m *BucketUsage) GetUniqueSize() int64 {
+	if m != nil {
+		return m.UniqueSize
 	}
-	{
-		size, err := m.BucketInfo.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintS3(dAtA, i, uint64(size))
+	return 0
+}
+
+func (m *BucketUsage) GetUniqueCount() uint64 {
+	if m != nil {
+		return m.UniqueCount
 	}
-	i--
-	dAtA[i] = 0x12
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *Object) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *BucketUsage) GetDataIndexed() bool {
+	if m != nil {
+		return m.DataIndexed
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *Object) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// DataRef is a reference from a data cid to an object, the data reference index
+// contains one for every object that has data
+type DataRef struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	// size of the object data
+	Size_ int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// the account of the bucket, only set if buckets are namespaced per account
+	Tenant string `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`
 }
 
-func (m *Object) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.ObjectInfo.MarshalToSizedBuffer(dAtA[:i])
+func (m *DataRef) Reset()         { *m = DataRef{} }
+func (m *DataRef) String() string { return proto.CompactTextString(m) }
+func (*DataRef) ProtoMessage()    {}
+func (*DataRef) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{19}
+}
+func (m *DataRef) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataRef) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataRef.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintS3(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0x12
-	if len(m.DataHash) > 0 {
-		i -= len(m.DataHash)
-		copy(dAtA[i:], m.DataHash)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.DataHash)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DataRef) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRef.Merge(m, src)
+}
+func (m *DataRef) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataRef) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRef.DiscardUnknown(m)
 }
 
-func (m *ObjectInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DataRef proto.InternalMessageInfo
+
+func (m *DataRef) GetBucket() string {
+	if m != nil {
+		return m.Bucket
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ObjectInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DataRef) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
 }
 
-func (m *ObjectInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ContentLanguage) > 0 {
-		i -= len(m.ContentLanguage)
-		copy(dAtA[i:], m.ContentLanguage)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentLanguage)))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x8a
-	}
-	if len(m.ContentDisposition) > 0 {
-		i -= len(m.ContentDisposition)
-		copy(dAtA[i:], m.ContentDisposition)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentDisposition)))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x82
+func (m *DataRef) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	if len(m.BackendType) > 0 {
-		i -= len(m.BackendType)
-		copy(dAtA[i:], m.BackendType)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.BackendType)))
-		i--
-		dAtA[i] = 0x7a
+	return 0
+}
+
+func (m *DataRef) GetTenant() string {
+	if m != nil {
+		return m.Tenant
 	}
-	if m.AccTime != nil {
-		n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.AccTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.AccTime):])
-		if err7 != nil {
-			return 0, err7
+	return ""
+}
+
+// DedupStatsRequest is used to get the deduplication statistics of the ledger
+type DedupStatsRequest struct {
+	// if set only the stats of this bucket are returned, otherwise the stats of all buckets
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+}
+
+func (m *DedupStatsRequest) Reset()         { *m = DedupStatsRequest{} }
+func (m *DedupStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*DedupStatsRequest) ProtoMessage()    {}
+func (*DedupStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{20}
+}
+func (m *DedupStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DedupStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DedupStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i -= n7
-		i = encodeVarintS3(dAtA, i, uint64(n7))
-		i--
-		dAtA[i] = 0x72
+		return b[:n], nil
 	}
-	if m.MetadataOnly {
-		i--
-		if m.MetadataOnly {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x68
+}
+func (m *DedupStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DedupStatsRequest.Merge(m, src)
+}
+func (m *DedupStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DedupStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DedupStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DedupStatsRequest proto.InternalMessageInfo
+
+func (m *DedupStatsRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
 	}
-	if len(m.UserDefined) > 0 {
-		for k := range m.UserDefined {
-			v := m.UserDefined[k]
-			baseI := i
-			i -= len(v)
-			copy(dAtA[i:], v)
-			i = encodeVarintS3(dAtA, i, uint64(len(v)))
-			i--
-			dAtA[i] = 0x12
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintS3(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x62
+	return ""
+}
+
+// DedupStats compares the logical size of objects with the size of their distinct data.
+// Objects with the same content share a data cid, so the data is only stored once.
+type DedupStats struct {
+	// empty for the stats of all buckets
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// the account of the bucket, only set if buckets are namespaced per account
+	Tenant       string `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	ObjectsCount uint64 `protobuf:"varint,3,opt,name=objectsCount,proto3" json:"objectsCount,omitempty"`
+	// total size of all objects
+	LogicalSize int64 `protobuf:"varint,4,opt,name=logicalSize,proto3" json:"logicalSize,omitempty"`
+	// total size of the distinct object data
+	UniqueSize int64 `protobuf:"varint,5,opt,name=uniqueSize,proto3" json:"uniqueSize,omitempty"`
+	// number of distinct object data cids
+	UniqueCount uint64 `protobuf:"varint,6,opt,name=uniqueCount,proto3" json:"uniqueCount,omitempty"`
+	// the stats of each bucket, only set for the stats of all buckets
+	Buckets []*DedupStats `protobuf:"bytes,7,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (m *DedupStats) Reset()         { *m = DedupStats{} }
+func (m *DedupStats) String() string { return proto.CompactTextString(m) }
+func (*DedupStats) ProtoMessage()    {}
+func (*DedupStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{21}
+}
+func (m *DedupStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DedupStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DedupStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Parts) > 0 {
-		for iNdEx := len(m.Parts) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Parts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintS3(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x5a
-		}
+}
+func (m *DedupStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DedupStats.Merge(m, src)
+}
+func (m *DedupStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *DedupStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_DedupStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DedupStats proto.InternalMessageInfo
+
+func (m *DedupStats) GetBucket() string {
+	if m != nil {
+		return m.Bucket
 	}
-	if len(m.StorageClass) > 0 {
-		i -= len(m.StorageClass)
-		copy(dAtA[i:], m.StorageClass)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.StorageClass)))
-		i--
-		dAtA[i] = 0x52
+	return ""
+}
+
+func (m *DedupStats) GetTenant() string {
+	if m != nil {
+		return m.Tenant
 	}
-	if len(m.Expires) > 0 {
-		i -= len(m.Expires)
-		copy(dAtA[i:], m.Expires)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Expires)))
-		i--
-		dAtA[i] = 0x4a
+	return ""
+}
+
+func (m *DedupStats) GetObjectsCount() uint64 {
+	if m != nil {
+		return m.ObjectsCount
 	}
-	if len(m.ContentEncoding) > 0 {
-		i -= len(m.ContentEncoding)
-		copy(dAtA[i:], m.ContentEncoding)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentEncoding)))
-		i--
-		dAtA[i] = 0x42
+	return 0
+}
+
+func (m *DedupStats) GetLogicalSize() int64 {
+	if m != nil {
+		return m.LogicalSize
 	}
-	if len(m.ContentType) > 0 {
-		i -= len(m.ContentType)
-		copy(dAtA[i:], m.ContentType)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentType)))
-		i--
-		dAtA[i] = 0x3a
+	return 0
+}
+
+func (m *DedupStats) GetUniqueSize() int64 {
+	if m != nil {
+		return m.UniqueSize
 	}
-	if len(m.Etag) > 0 {
-		i -= len(m.Etag)
-		copy(dAtA[i:], m.Etag)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Etag)))
-		i--
-		dAtA[i] = 0x32
+	return 0
+}
+
+func (m *DedupStats) GetUniqueCount() uint64 {
+	if m != nil {
+		return m.UniqueCount
 	}
-	if m.IsDir {
-		i--
-		if m.IsDir {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Size_ != 0 {
-		i = encodeVarintS3(dAtA, i, uint64(m.Size_))
-		i--
-		dAtA[i] = 0x20
-	}
-	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ModTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ModTime):])
-	if err8 != nil {
-		return 0, err8
-	}
-	i -= n8
-	i = encodeVarintS3(dAtA, i, uint64(n8))
-	i--
-	dAtA[i] = 0x1a
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Bucket) > 0 {
-		i -= len(m.Bucket)
-		copy(dAtA[i:], m.Bucket)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ObjectPartInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *DedupStats) GetBuckets() []*DedupStats {
+	if m != nil {
+		return m.Buckets
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ObjectPartInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// DataRefsRequest is used to get the objects referencing a data cid
+type DataRefsRequest struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (m *ObjectPartInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.DataHash) > 0 {
-		i -= len(m.DataHash)
-		copy(dAtA[i:], m.DataHash)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.DataHash)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if m.ActualSize != 0 {
-		i = encodeVarintS3(dAtA, i, uint64(m.ActualSize))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Size_ != 0 {
-		i = encodeVarintS3(dAtA, i, uint64(m.Size_))
-		i--
-		dAtA[i] = 0x20
-	}
-	n9, err9 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastModified, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastModified):])
-	if err9 != nil {
-		return 0, err9
-	}
-	i -= n9
-	i = encodeVarintS3(dAtA, i, uint64(n9))
-	i--
-	dAtA[i] = 0x1a
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Number != 0 {
-		i = encodeVarintS3(dAtA, i, uint64(m.Number))
-		i--
-		dAtA[i] = 0x8
+func (m *DataRefsRequest) Reset()         { *m = DataRefsRequest{} }
+func (m *DataRefsRequest) String() string { return proto.CompactTextString(m) }
+func (*DataRefsRequest) ProtoMessage()    {}
+func (*DataRefsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{22}
+}
+func (m *DataRefsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataRefsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataRefsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DataRefsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRefsRequest.Merge(m, src)
+}
+func (m *DataRefsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataRefsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRefsRequest.DiscardUnknown(m)
 }
 
-func (m *MultipartUpload) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DataRefsRequest proto.InternalMessageInfo
+
+func (m *DataRefsRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *MultipartUpload) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// DataRefs are the objects referencing a data cid, the reference count is the number of refs
+type DataRefs struct {
+	Hash string    `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Refs []DataRef `protobuf:"bytes,2,rep,name=refs,proto3" json:"refs"`
 }
 
-func (m *MultipartUpload) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ObjectParts) > 0 {
-		for k := range m.ObjectParts {
-			v := m.ObjectParts[k]
-			baseI := i
-			{
-				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintS3(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-			i = encodeVarintS3(dAtA, i, uint64(k))
-			i--
-			dAtA[i] = 0x8
-			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if len(m.Id) > 0 {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintS3(dAtA, i, uint64(len(m.Id)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.ObjectInfo != nil {
-		{
-			size, err := m.ObjectInfo.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintS3(dAtA, i, uint64(size))
+func (m *DataRefs) Reset()         { *m = DataRefs{} }
+func (m *DataRefs) String() string { return proto.CompactTextString(m) }
+func (*DataRefs) ProtoMessage()    {}
+func (*DataRefs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{23}
+}
+func (m *DataRefs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataRefs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataRefs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DataRefs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRefs.Merge(m, src)
+}
+func (m *DataRefs) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataRefs) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRefs.DiscardUnknown(m)
 }
 
-func encodeVarintS3(dAtA []byte, offset int, v uint64) int {
-	offset -= sovS3(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_DataRefs proto.InternalMessageInfo
+
+func (m *DataRefs) GetHash() string {
+	if m != nil {
+		return m.Hash
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return ""
 }
-func (m *InfoRequest) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *DataRefs) GetRefs() []DataRef {
+	if m != nil {
+		return m.Refs
 	}
-	var l int
-	_ = l
-	l = len(m.Bucket)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return nil
+}
+
+// BucketInfo is used to store s3 bucket metadata
+type BucketInfo struct {
+	// name is the name of the bucket
+	Name    string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Created time.Time `protobuf:"bytes,2,opt,name=created,proto3,stdtime" json:"created"`
+	// the location of the bucket
+	Location string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	// the ipns name the bucket is published to, if publishing is enabled
+	IpnsName string `protobuf:"bytes,4,opt,name=ipnsName,proto3" json:"ipnsName,omitempty"`
+}
+
+func (m *BucketInfo) Reset()         { *m = BucketInfo{} }
+func (m *BucketInfo) String() string { return proto.CompactTextString(m) }
+func (*BucketInfo) ProtoMessage()    {}
+func (*BucketInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{24}
+}
+func (m *BucketInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BucketInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BucketInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.Object)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+}
+func (m *BucketInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketInfo.Merge(m, src)
+}
+func (m *BucketInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *BucketInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketInfo proto.InternalMessageInfo
+
+func (m *BucketInfo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	if m.ObjectDataOnly {
-		n += 2
+	return ""
+}
+
+func (m *BucketInfo) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
 	}
-	return n
+	return time.Time{}
 }
 
-func (m *InfoResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BucketInfo) GetLocation() string {
+	if m != nil {
+		return m.Location
 	}
-	var l int
-	_ = l
-	l = len(m.Bucket)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
-	}
-	l = len(m.Object)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
-	}
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
-	}
-	return n
+	return ""
 }
 
-func (m *Ledger) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Buckets) > 0 {
-		for k, v := range m.Buckets {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovS3(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
-		}
+func (m *BucketInfo) GetIpnsName() string {
+	if m != nil {
+		return m.IpnsName
 	}
-	if len(m.MultipartUploads) > 0 {
-		for k, v := range m.MultipartUploads {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovS3(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+	return ""
+}
+
+// Bucket is a data repositroy for S3 objects
+type Bucket struct {
+	// data associated with the object
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// information associated with this bucket
+	BucketInfo BucketInfo `protobuf:"bytes,2,opt,name=bucketInfo,proto3" json:"bucketInfo"`
+	// maps object names to object hashes
+	Objects map[string]string `protobuf:"bytes,3,rep,name=objects,proto3" json:"objects" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Bucket) Reset()         { *m = Bucket{} }
+func (m *Bucket) String() string { return proto.CompactTextString(m) }
+func (*Bucket) ProtoMessage()    {}
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{25}
+}
+func (m *Bucket) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Bucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Bucket.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *Bucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Bucket.Merge(m, src)
+}
+func (m *Bucket) XXX_Size() int {
+	return m.Size()
+}
+func (m *Bucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_Bucket.DiscardUnknown(m)
 }
 
-func (m *LedgerBucketEntry) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Bucket != nil {
-		l = m.Bucket.Size()
-		n += 1 + l + sovS3(uint64(l))
-	}
-	l = len(m.IpfsHash)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+var xxx_messageInfo_Bucket proto.InternalMessageInfo
+
+func (m *Bucket) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return n
+	return nil
 }
 
-func (m *BucketInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
-	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
-	n += 1 + l + sovS3(uint64(l))
-	l = len(m.Location)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+func (m *Bucket) GetBucketInfo() BucketInfo {
+	if m != nil {
+		return m.BucketInfo
 	}
-	return n
+	return BucketInfo{}
 }
 
-func (m *Bucket) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+func (m *Bucket) GetObjects() map[string]string {
+	if m != nil {
+		return m.Objects
 	}
-	l = m.BucketInfo.Size()
-	n += 1 + l + sovS3(uint64(l))
-	if len(m.Objects) > 0 {
-		for k, v := range m.Objects {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + 1 + len(v) + sovS3(uint64(len(v)))
-			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+	return nil
+}
+
+// Object is a singular s3 object.
+// the data field contains the actual data
+// referred to by this object, while the objectInfo
+// field is used to contain the information associated
+// wth the object
+type Object struct {
+	DataHash   string     `protobuf:"bytes,1,opt,name=dataHash,proto3" json:"dataHash,omitempty"`
+	ObjectInfo ObjectInfo `protobuf:"bytes,2,opt,name=objectInfo,proto3" json:"objectInfo"`
+}
+
+func (m *Object) Reset()         { *m = Object{} }
+func (m *Object) String() string { return proto.CompactTextString(m) }
+func (*Object) ProtoMessage()    {}
+func (*Object) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{26}
+}
+func (m *Object) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Object) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Object.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *Object) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Object.Merge(m, src)
+}
+func (m *Object) XXX_Size() int {
+	return m.Size()
+}
+func (m *Object) XXX_DiscardUnknown() {
+	xxx_messageInfo_Object.DiscardUnknown(m)
 }
 
-func (m *Object) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_Object proto.InternalMessageInfo
+
+func (m *Object) GetDataHash() string {
+	if m != nil {
+		return m.DataHash
 	}
-	var l int
-	_ = l
-	l = len(m.DataHash)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return ""
+}
+
+func (m *Object) GetObjectInfo() ObjectInfo {
+	if m != nil {
+		return m.ObjectInfo
 	}
-	l = m.ObjectInfo.Size()
-	n += 1 + l + sovS3(uint64(l))
-	return n
+	return ObjectInfo{}
 }
 
-func (m *ObjectInfo) Size() (n int) {
-	if m == nil {
-		return 0
+// ObjectInfo contains information about the object
+type ObjectInfo struct {
+	Bucket             string            `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name               string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ModTime            time.Time         `protobuf:"bytes,3,opt,name=modTime,proto3,stdtime" json:"modTime"`
+	Size_              int64             `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	IsDir              bool              `protobuf:"varint,5,opt,name=isDir,proto3" json:"isDir,omitempty"`
+	Etag               string            `protobuf:"bytes,6,opt,name=etag,proto3" json:"etag,omitempty"`
+	ContentType        string            `protobuf:"bytes,7,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	ContentEncoding    string            `protobuf:"bytes,8,opt,name=contentEncoding,proto3" json:"contentEncoding,omitempty"`
+	Expires            string            `protobuf:"bytes,9,opt,name=expires,proto3" json:"expires,omitempty"`
+	StorageClass       string            `protobuf:"bytes,10,opt,name=storageClass,proto3" json:"storageClass,omitempty"`
+	Parts              []ObjectPartInfo  `protobuf:"bytes,11,rep,name=parts,proto3" json:"parts"`
+	UserDefined        map[string]string `protobuf:"bytes,12,rep,name=userDefined,proto3" json:"userDefined,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MetadataOnly       bool              `protobuf:"varint,13,opt,name=metadataOnly,proto3" json:"metadataOnly,omitempty"`
+	AccTime            *time.Time        `protobuf:"bytes,14,opt,name=accTime,proto3,stdtime" json:"accTime,omitempty"`
+	BackendType        string            `protobuf:"bytes,15,opt,name=backendType,proto3" json:"backendType,omitempty"`
+	ContentDisposition string            `protobuf:"bytes,16,opt,name=contentDisposition,proto3" json:"contentDisposition,omitempty"`
+	ContentLanguage    string            `protobuf:"bytes,17,opt,name=contentLanguage,proto3" json:"contentLanguage,omitempty"`
+}
+
+func (m *ObjectInfo) Reset()         { *m = ObjectInfo{} }
+func (m *ObjectInfo) String() string { return proto.CompactTextString(m) }
+func (*ObjectInfo) ProtoMessage()    {}
+func (*ObjectInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{27}
+}
+func (m *ObjectInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ObjectInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ObjectInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.Bucket)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+}
+func (m *ObjectInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectInfo.Merge(m, src)
+}
+func (m *ObjectInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ObjectInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectInfo proto.InternalMessageInfo
+
+func (m *ObjectInfo) GetBucket() string {
+	if m != nil {
+		return m.Bucket
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return ""
+}
+
+func (m *ObjectInfo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ModTime)
-	n += 1 + l + sovS3(uint64(l))
-	if m.Size_ != 0 {
-		n += 1 + sovS3(uint64(m.Size_))
+	return ""
+}
+
+func (m *ObjectInfo) GetModTime() time.Time {
+	if m != nil {
+		return m.ModTime
 	}
-	if m.IsDir {
-		n += 2
+	return time.Time{}
+}
+
+func (m *ObjectInfo) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	l = len(m.Etag)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return 0
+}
+
+func (m *ObjectInfo) GetIsDir() bool {
+	if m != nil {
+		return m.IsDir
 	}
-	l = len(m.ContentType)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return false
+}
+
+func (m *ObjectInfo) GetEtag() string {
+	if m != nil {
+		return m.Etag
 	}
-	l = len(m.ContentEncoding)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return ""
+}
+
+func (m *ObjectInfo) GetContentType() string {
+	if m != nil {
+		return m.ContentType
 	}
-	l = len(m.Expires)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return ""
+}
+
+func (m *ObjectInfo) GetContentEncoding() string {
+	if m != nil {
+		return m.ContentEncoding
 	}
-	l = len(m.StorageClass)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return ""
+}
+
+func (m *ObjectInfo) GetExpires() string {
+	if m != nil {
+		return m.Expires
 	}
-	if len(m.Parts) > 0 {
-		for _, e := range m.Parts {
-			l = e.Size()
-			n += 1 + l + sovS3(uint64(l))
-		}
+	return ""
+}
+
+func (m *ObjectInfo) GetStorageClass() string {
+	if m != nil {
+		return m.StorageClass
 	}
-	if len(m.UserDefined) > 0 {
-		for k, v := range m.UserDefined {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + 1 + len(v) + sovS3(uint64(len(v)))
-			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
-		}
+	return ""
+}
+
+func (m *ObjectInfo) GetParts() []ObjectPartInfo {
+	if m != nil {
+		return m.Parts
 	}
-	if m.MetadataOnly {
-		n += 2
+	return nil
+}
+
+func (m *ObjectInfo) GetUserDefined() map[string]string {
+	if m != nil {
+		return m.UserDefined
 	}
-	if m.AccTime != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.AccTime)
-		n += 1 + l + sovS3(uint64(l))
+	return nil
+}
+
+func (m *ObjectInfo) GetMetadataOnly() bool {
+	if m != nil {
+		return m.MetadataOnly
 	}
-	l = len(m.BackendType)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return false
+}
+
+func (m *ObjectInfo) GetAccTime() *time.Time {
+	if m != nil {
+		return m.AccTime
 	}
-	l = len(m.ContentDisposition)
-	if l > 0 {
-		n += 2 + l + sovS3(uint64(l))
+	return nil
+}
+
+func (m *ObjectInfo) GetBackendType() string {
+	if m != nil {
+		return m.BackendType
 	}
-	l = len(m.ContentLanguage)
-	if l > 0 {
-		n += 2 + l + sovS3(uint64(l))
+	return ""
+}
+
+func (m *ObjectInfo) GetContentDisposition() string {
+	if m != nil {
+		return m.ContentDisposition
 	}
-	return n
+	return ""
 }
 
-func (m *ObjectPartInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ObjectInfo) GetContentLanguage() string {
+	if m != nil {
+		return m.ContentLanguage
 	}
-	var l int
-	_ = l
-	if m.Number != 0 {
-		n += 1 + sovS3(uint64(m.Number))
+	return ""
+}
+
+// ObjectPartInfo contains information an individual object client.
+// For Etag, use dataHash
+type ObjectPartInfo struct {
+	// convertable to "int" type in minio.PartInfo
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// name of object in bucket
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Date and time at which the part was uploaded.
+	LastModified time.Time `protobuf:"bytes,3,opt,name=lastModified,proto3,stdtime" json:"lastModified"`
+	// Size in bytes of the part.
+	Size_ int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// Decompressed Size.
+	ActualSize int64 `protobuf:"varint,5,opt,name=actualSize,proto3" json:"actualSize,omitempty"`
+	// the hash of the data on ipfs
+	// in the case of multipart uploads
+	// this will refer to a unixfs object
+	DataHash string `protobuf:"bytes,6,opt,name=dataHash,proto3" json:"dataHash,omitempty"`
+}
+
+func (m *ObjectPartInfo) Reset()         { *m = ObjectPartInfo{} }
+func (m *ObjectPartInfo) String() string { return proto.CompactTextString(m) }
+func (*ObjectPartInfo) ProtoMessage()    {}
+func (*ObjectPartInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{28}
+}
+func (m *ObjectPartInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ObjectPartInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ObjectPartInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+}
+func (m *ObjectPartInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectPartInfo.Merge(m, src)
+}
+func (m *ObjectPartInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ObjectPartInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectPartInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectPartInfo proto.InternalMessageInfo
+
+func (m *ObjectPartInfo) GetNumber() int64 {
+	if m != nil {
+		return m.Number
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastModified)
-	n += 1 + l + sovS3(uint64(l))
-	if m.Size_ != 0 {
-		n += 1 + sovS3(uint64(m.Size_))
+	return 0
+}
+
+func (m *ObjectPartInfo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	if m.ActualSize != 0 {
-		n += 1 + sovS3(uint64(m.ActualSize))
+	return ""
+}
+
+func (m *ObjectPartInfo) GetLastModified() time.Time {
+	if m != nil {
+		return m.LastModified
 	}
-	l = len(m.DataHash)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return time.Time{}
+}
+
+func (m *ObjectPartInfo) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return n
+	return 0
 }
 
-func (m *MultipartUpload) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ObjectInfo != nil {
-		l = m.ObjectInfo.Size()
-		n += 1 + l + sovS3(uint64(l))
+func (m *ObjectPartInfo) GetActualSize() int64 {
+	if m != nil {
+		return m.ActualSize
 	}
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovS3(uint64(l))
+	return 0
+}
+
+func (m *ObjectPartInfo) GetDataHash() string {
+	if m != nil {
+		return m.DataHash
 	}
-	if len(m.ObjectParts) > 0 {
-		for k, v := range m.ObjectParts {
-			_ = k
-			_ = v
-			l = v.Size()
-			mapEntrySize := 1 + sovS3(uint64(k)) + 1 + l + sovS3(uint64(l))
-			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+	return ""
+}
+
+type MultipartUpload struct {
+	ObjectInfo *ObjectInfo `protobuf:"bytes,1,opt,name=objectInfo,proto3" json:"objectInfo,omitempty"`
+	Id         string      `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	//map of index to parts
+	ObjectParts map[int64]ObjectPartInfo `protobuf:"bytes,3,rep,name=objectParts,proto3" json:"objectParts" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *MultipartUpload) Reset()         { *m = MultipartUpload{} }
+func (m *MultipartUpload) String() string { return proto.CompactTextString(m) }
+func (*MultipartUpload) ProtoMessage()    {}
+func (*MultipartUpload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_005e34be4304e022, []int{29}
+}
+func (m *MultipartUpload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MultipartUpload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MultipartUpload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *MultipartUpload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MultipartUpload.Merge(m, src)
+}
+func (m *MultipartUpload) XXX_Size() int {
+	return m.Size()
+}
+func (m *MultipartUpload) XXX_DiscardUnknown() {
+	xxx_messageInfo_MultipartUpload.DiscardUnknown(m)
 }
 
-func sovS3(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+var xxx_messageInfo_MultipartUpload proto.InternalMessageInfo
+
+func (m *MultipartUpload) GetObjectInfo() *ObjectInfo {
+	if m != nil {
+		return m.ObjectInfo
+	}
+	return nil
 }
-func sozS3(x uint64) (n int) {
-	return sovS3(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *MultipartUpload) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MultipartUpload) GetObjectParts() map[int64]ObjectPartInfo {
+	if m != nil {
+		return m.ObjectParts
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("s3x.IPNSMode", IPNSMode_name, IPNSMode_value)
+	proto.RegisterEnum("s3x.FsckIssueType", FsckIssueType_name, FsckIssueType_value)
+	proto.RegisterEnum("s3x.PinTargetType", PinTargetType_name, PinTargetType_value)
+	proto.RegisterEnum("s3x.PinState", PinState_name, PinState_value)
+	proto.RegisterEnum("s3x.TransactionOpType", TransactionOpType_name, TransactionOpType_value)
+	proto.RegisterType((*InfoRequest)(nil), "s3x.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "s3x.InfoResponse")
+	proto.RegisterType((*BucketIPNSRequest)(nil), "s3x.BucketIPNSRequest")
+	proto.RegisterType((*BucketIPNS)(nil), "s3x.BucketIPNS")
+	proto.RegisterType((*FsckRequest)(nil), "s3x.FsckRequest")
+	proto.RegisterType((*FsckResponse)(nil), "s3x.FsckResponse")
+	proto.RegisterType((*FsckIssue)(nil), "s3x.FsckIssue")
+	proto.RegisterType((*PinTarget)(nil), "s3x.PinTarget")
+	proto.RegisterType((*BucketPinTargets)(nil), "s3x.BucketPinTargets")
+	proto.RegisterType((*PinJob)(nil), "s3x.PinJob")
+	proto.RegisterMapType((map[string]string)(nil), "s3x.PinJob.RequestIdsEntry")
+	proto.RegisterType((*PinStatusRequest)(nil), "s3x.PinStatusRequest")
+	proto.RegisterType((*PinStatusResponse)(nil), "s3x.PinStatusResponse")
+	proto.RegisterType((*TransactionRequest)(nil), "s3x.TransactionRequest")
+	proto.RegisterType((*TransactionOp)(nil), "s3x.TransactionOp")
+	proto.RegisterMapType((map[string]string)(nil), "s3x.TransactionOp.UserDefinedEntry")
+	proto.RegisterType((*TransactionOps)(nil), "s3x.TransactionOps")
+	proto.RegisterType((*Transaction)(nil), "s3x.Transaction")
+	proto.RegisterType((*Ledger)(nil), "s3x.Ledger")
+	proto.RegisterMapType((map[string]*LedgerBucketEntry)(nil), "s3x.Ledger.BucketsEntry")
+	proto.RegisterMapType((map[string]*MultipartUpload)(nil), "s3x.Ledger.MultipartUploadsEntry")
+	proto.RegisterType((*LedgerBucketEntry)(nil), "s3x.LedgerBucketEntry")
+	proto.RegisterType((*BucketUsage)(nil), "s3x.BucketUsage")
+	proto.RegisterMapType((map[string]uint64)(nil), "s3x.BucketUsage.ObjectsSizesHistogramEntry")
+	proto.RegisterType((*DataRef)(nil), "s3x.DataRef")
+	proto.RegisterType((*DedupStatsRequest)(nil), "s3x.DedupStatsRequest")
+	proto.RegisterType((*DedupStats)(nil), "s3x.DedupStats")
+	proto.RegisterType((*DataRefsRequest)(nil), "s3x.DataRefsRequest")
+	proto.RegisterType((*DataRefs)(nil), "s3x.DataRefs")
+	proto.RegisterType((*BucketInfo)(nil), "s3x.BucketInfo")
+	proto.RegisterType((*Bucket)(nil), "s3x.Bucket")
+	proto.RegisterMapType((map[string]string)(nil), "s3x.Bucket.ObjectsEntry")
+	proto.RegisterType((*Object)(nil), "s3x.Object")
+	proto.RegisterType((*ObjectInfo)(nil), "s3x.ObjectInfo")
+	proto.RegisterMapType((map[string]string)(nil), "s3x.ObjectInfo.UserDefinedEntry")
+	proto.RegisterType((*ObjectPartInfo)(nil), "s3x.ObjectPartInfo")
+	proto.RegisterType((*MultipartUpload)(nil), "s3x.MultipartUpload")
+	proto.RegisterMapType((map[int64]ObjectPartInfo)(nil), "s3x.MultipartUpload.ObjectPartsEntry")
+}
+
+func init() { proto.RegisterFile("s3.proto", fileDescriptor_005e34be4304e022) }
+
+var fileDescriptor_005e34be4304e022 = []byte{
+	// 2260 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x18, 0x4b, 0x6f, 0x1b, 0xc7,
+	0x59, 0xcb, 0x37, 0x3f, 0x92, 0xd2, 0x72, 0x24, 0x2b, 0x2c, 0x1b, 0xc8, 0xca, 0x1a, 0x0e, 0x1c,
+	0x25, 0x25, 0x51, 0x19, 0x05, 0x02, 0xa7, 0x36, 0x20, 0x8a, 0xb4, 0x45, 0x57, 0x12, 0xd9, 0x25,
+	0x95, 0xc6, 0x29, 0x02, 0x77, 0xc9, 0x1d, 0xd2, 0x1b, 0x91, 0xbb, 0x9b, 0x9d, 0xdd, 0x40, 0xce,
+	0xb1, 0xa7, 0x1e, 0x03, 0x14, 0x05, 0x8a, 0xde, 0x7a, 0xee, 0x3f, 0xe8, 0xad, 0xa7, 0xa6, 0xb7,
+	0x00, 0x45, 0x83, 0x9e, 0xda, 0xc2, 0xee, 0xbd, 0xb7, 0x9e, 0x8b, 0x79, 0xec, 0xee, 0x2c, 0x49,
+	0xc5, 0x92, 0xdb, 0xde, 0xe6, 0x7b, 0xcf, 0x7c, 0xaf, 0xf9, 0x66, 0xa0, 0x40, 0xee, 0x36, 0x5c,
+	0xcf, 0xf1, 0x1d, 0x94, 0x26, 0x77, 0x2f, 0xea, 0xdf, 0x9b, 0x5a, 0xfe, 0xb3, 0x60, 0xd4, 0x18,
+	0x3b, 0xf3, 0xe6, 0xd4, 0x99, 0x3a, 0x4d, 0x46, 0x1b, 0x05, 0x13, 0x06, 0x31, 0x80, 0xad, 0xb8,
+	0x4c, 0xfd, 0xe6, 0xd4, 0x71, 0xa6, 0x33, 0x1c, 0x73, 0xf9, 0xd6, 0x1c, 0x13, 0xdf, 0x98, 0xbb,
+	0x82, 0xe1, 0x4d, 0xc1, 0x60, 0xb8, 0x56, 0xd3, 0xb0, 0x6d, 0xc7, 0x37, 0x7c, 0xcb, 0xb1, 0x09,
+	0xa7, 0x6a, 0x18, 0x4a, 0x5d, 0x7b, 0xe2, 0xe8, 0xf8, 0xb3, 0x00, 0x13, 0x1f, 0x6d, 0x43, 0x6e,
+	0x14, 0x8c, 0xcf, 0xb1, 0x5f, 0x53, 0x76, 0x95, 0x3b, 0x45, 0x5d, 0x40, 0x14, 0xef, 0x8c, 0x3e,
+	0xc5, 0x63, 0xbf, 0x96, 0xe2, 0x78, 0x0e, 0xa1, 0xb7, 0x61, 0x9d, 0xaf, 0xda, 0x86, 0x6f, 0xf4,
+	0xec, 0xd9, 0xf3, 0x5a, 0x7a, 0x57, 0xb9, 0x53, 0xd0, 0x17, 0xb0, 0x9a, 0x0d, 0x65, 0x6e, 0x86,
+	0xb8, 0x8e, 0x4d, 0xf0, 0xb5, 0xed, 0x20, 0xc8, 0x3c, 0x33, 0xc8, 0x33, 0xa6, 0xbd, 0xa8, 0xb3,
+	0x35, 0xaa, 0x43, 0xc1, 0x72, 0x6d, 0x72, 0x6a, 0xcc, 0x71, 0x2d, 0xc3, 0xf0, 0x11, 0xac, 0x4d,
+	0xa0, 0xda, 0x62, 0x1a, 0xbb, 0xfd, 0xd3, 0xc1, 0x15, 0x0e, 0x87, 0x6d, 0x63, 0x34, 0xc3, 0xcc,
+	0x68, 0x41, 0x17, 0x10, 0x7a, 0x0b, 0x32, 0x73, 0xc7, 0xc4, 0xcc, 0xe8, 0xfa, 0x7e, 0xa5, 0x41,
+	0xee, 0x5e, 0x34, 0xa8, 0xbe, 0x13, 0xc7, 0xc4, 0x3a, 0x23, 0x69, 0x7f, 0x50, 0x00, 0x62, 0x43,
+	0x97, 0x5a, 0x40, 0x90, 0xb1, 0x8d, 0x39, 0xd7, 0x5f, 0xd4, 0xd9, 0xfa, 0x0a, 0xda, 0xd1, 0x9b,
+	0x50, 0x74, 0x83, 0xd1, 0xcc, 0x22, 0xcf, 0xb0, 0x29, 0x8e, 0x18, 0x23, 0xd0, 0x43, 0x28, 0x45,
+	0xc0, 0x81, 0x5f, 0xcb, 0xee, 0x2a, 0x77, 0x4a, 0xfb, 0xf5, 0x06, 0x0f, 0x77, 0x23, 0xcc, 0x87,
+	0xc6, 0x30, 0xcc, 0x87, 0x56, 0xe1, 0xab, 0xbf, 0xdd, 0x5c, 0xfb, 0xf2, 0xef, 0x37, 0x15, 0x5d,
+	0x16, 0xd4, 0xe6, 0x50, 0x7a, 0x48, 0xc6, 0xe7, 0x57, 0xf0, 0x92, 0xeb, 0xe1, 0x89, 0x75, 0x11,
+	0x86, 0x86, 0x43, 0x14, 0xef, 0x61, 0xd7, 0xb0, 0x3c, 0x11, 0x7a, 0x01, 0x71, 0xfc, 0xdc, 0xf9,
+	0x9c, 0x07, 0xa7, 0xa0, 0x0b, 0x48, 0x73, 0xa1, 0xcc, 0xcd, 0x89, 0x54, 0xa8, 0x41, 0x9e, 0x5b,
+	0x20, 0xcc, 0x60, 0x5a, 0x0f, 0x41, 0x4a, 0xe1, 0xe1, 0x27, 0xcc, 0x64, 0x5a, 0x0f, 0x41, 0xf4,
+	0x1e, 0xe4, 0x2c, 0x42, 0x02, 0x4c, 0x6a, 0xe9, 0xdd, 0xf4, 0x9d, 0xd2, 0xfe, 0x3a, 0xf3, 0x1e,
+	0x55, 0xdb, 0xa5, 0xe8, 0x56, 0x86, 0x9e, 0x54, 0x17, 0x3c, 0xda, 0xef, 0x14, 0x28, 0x46, 0x34,
+	0xf4, 0x36, 0x64, 0xfc, 0xe7, 0x2e, 0x66, 0xc6, 0xd6, 0xf7, 0x51, 0x52, 0x72, 0xf8, 0xdc, 0xc5,
+	0x3a, 0xa3, 0x4b, 0x7e, 0x48, 0x5d, 0x92, 0xa2, 0xe9, 0x95, 0x29, 0x9a, 0x91, 0x52, 0x74, 0x1b,
+	0x72, 0x26, 0xf6, 0x0d, 0x6b, 0xc6, 0xa2, 0x53, 0xd4, 0x05, 0x44, 0x53, 0x97, 0x7b, 0x09, 0x9b,
+	0xb5, 0x1c, 0xf3, 0x4e, 0x04, 0x6b, 0xbf, 0x52, 0xa0, 0xd8, 0xb7, 0xec, 0xa1, 0xe1, 0x4d, 0xa5,
+	0xcc, 0x51, 0xa4, 0xcc, 0x09, 0x4f, 0x90, 0x92, 0x4e, 0x10, 0x49, 0x48, 0x27, 0xa8, 0x43, 0x01,
+	0xdb, 0xa6, 0xeb, 0x58, 0x76, 0xb8, 0xd7, 0x08, 0x46, 0x5b, 0x90, 0xf5, 0x9d, 0x73, 0x6c, 0x8b,
+	0xed, 0x72, 0x80, 0x95, 0x94, 0x4d, 0xf0, 0x38, 0xf0, 0x30, 0xdb, 0x71, 0x41, 0x8f, 0x60, 0xed,
+	0x63, 0x50, 0x79, 0xa6, 0x47, 0xa6, 0xc8, 0xa5, 0xb9, 0xd2, 0x80, 0xbc, 0xcf, 0x59, 0x6a, 0x29,
+	0x29, 0x40, 0x91, 0xa4, 0x08, 0x50, 0xc8, 0xa4, 0xfd, 0x31, 0x0d, 0xb9, 0xbe, 0x65, 0x3f, 0x76,
+	0x46, 0xff, 0x93, 0xce, 0xb0, 0x0d, 0x39, 0xae, 0x59, 0x9c, 0x4e, 0x40, 0xe8, 0x16, 0x64, 0x89,
+	0x6f, 0xf8, 0xfc, 0x6c, 0x61, 0xcd, 0xf5, 0x2d, 0x7b, 0x40, 0x91, 0x3a, 0xa7, 0x51, 0x1f, 0x18,
+	0xbe, 0x8f, 0xe7, 0xae, 0x4f, 0x58, 0x6c, 0xb2, 0x7a, 0x04, 0xd3, 0x92, 0xb3, 0xf1, 0x85, 0x7f,
+	0xc0, 0xe1, 0x5a, 0xfe, 0x3a, 0x25, 0x27, 0x09, 0xd2, 0xc2, 0x9e, 0x19, 0xc4, 0xef, 0x78, 0x9e,
+	0xe3, 0xd5, 0x0a, 0xbc, 0xb0, 0x23, 0x04, 0xfa, 0x00, 0xc0, 0xe3, 0xc5, 0xd8, 0x35, 0x49, 0xad,
+	0xc8, 0x1c, 0xf8, 0xdd, 0x70, 0xaf, 0x8f, 0x9d, 0x51, 0x43, 0x8f, 0xa8, 0x1d, 0xdb, 0xf7, 0x9e,
+	0xeb, 0x12, 0x3b, 0x7a, 0x00, 0xf9, 0xc0, 0x35, 0x0d, 0x1f, 0x9b, 0x35, 0xb8, 0xc6, 0xf6, 0x42,
+	0xa1, 0xfa, 0x7d, 0xd8, 0x58, 0x50, 0x8f, 0x54, 0x48, 0x9f, 0xe3, 0xe7, 0x22, 0x1e, 0x74, 0x49,
+	0xb3, 0xe7, 0x73, 0x63, 0x16, 0x84, 0x0d, 0x8d, 0x03, 0xf7, 0x52, 0xef, 0x2b, 0x5a, 0x0b, 0x54,
+	0xe1, 0xd0, 0x80, 0xbc, 0xe6, 0xa5, 0xa2, 0xdd, 0x83, 0xaa, 0xa4, 0x43, 0xb4, 0x89, 0xdb, 0x90,
+	0xf9, 0xd4, 0x19, 0xd1, 0x1e, 0x41, 0xdd, 0x51, 0x92, 0xdc, 0x21, 0x92, 0x89, 0x91, 0xb5, 0x1f,
+	0x02, 0x1a, 0x7a, 0x86, 0x4d, 0x8c, 0x31, 0xbd, 0xe5, 0x5e, 0xb5, 0x83, 0x75, 0x48, 0x59, 0xa6,
+	0xb0, 0x9e, 0xb2, 0x4c, 0xed, 0x37, 0x29, 0xa8, 0x48, 0xe2, 0x3d, 0x17, 0xed, 0x25, 0xba, 0xc5,
+	0x36, 0x33, 0x9b, 0xe0, 0x48, 0x76, 0x8c, 0xcb, 0x52, 0xd4, 0x34, 0x7c, 0x83, 0xa5, 0x68, 0x59,
+	0x67, 0x6b, 0xd4, 0x81, 0x52, 0x40, 0xb0, 0xd7, 0xc6, 0x13, 0xcb, 0x66, 0xcd, 0x9d, 0x9e, 0xea,
+	0xd6, 0xb2, 0xfa, 0xc6, 0x59, 0xcc, 0xc5, 0x83, 0x2d, 0xcb, 0x51, 0x93, 0xc4, 0x09, 0xbc, 0x31,
+	0x0e, 0x1b, 0x0c, 0x87, 0xa2, 0xaa, 0xc8, 0xc5, 0x55, 0x51, 0x7f, 0x00, 0xea, 0xa2, 0xb2, 0x6b,
+	0x85, 0xd6, 0x84, 0xf5, 0xc4, 0xd6, 0xc8, 0x55, 0xdd, 0x8a, 0xf6, 0x20, 0xed, 0xb8, 0x61, 0xaf,
+	0x46, 0xcb, 0x87, 0x14, 0x11, 0xa4, 0x4c, 0xda, 0x6f, 0x15, 0x28, 0x49, 0xc4, 0xff, 0x87, 0x0d,
+	0x5a, 0x23, 0x63, 0x0f, 0xb3, 0x1a, 0xc9, 0x5c, 0xa7, 0x46, 0x84, 0x90, 0xf6, 0xfb, 0x14, 0xe4,
+	0x8e, 0xb1, 0x39, 0xc5, 0x1e, 0xda, 0x97, 0x6f, 0x2f, 0x6a, 0xba, 0xc6, 0x4c, 0x73, 0x6a, 0x83,
+	0x37, 0x4c, 0x51, 0xa5, 0x21, 0x23, 0x3a, 0x01, 0x75, 0x1e, 0xcc, 0x7c, 0xcb, 0x35, 0x3c, 0xff,
+	0xcc, 0x9d, 0x39, 0x86, 0x19, 0xb6, 0xc9, 0xb7, 0x64, 0xe1, 0x93, 0x05, 0x1e, 0xae, 0x65, 0x49,
+	0xb4, 0xae, 0x43, 0x59, 0xb6, 0xb3, 0x22, 0xa6, 0xef, 0xc9, 0x31, 0x2d, 0xed, 0x6f, 0x4b, 0x56,
+	0xb8, 0x24, 0x57, 0x1d, 0xc7, 0xba, 0xfe, 0x04, 0x6e, 0xac, 0x34, 0xbf, 0x42, 0xf9, 0x5e, 0x52,
+	0xf9, 0x16, 0x53, 0xbe, 0x20, 0x2c, 0xa7, 0xd1, 0x10, 0xaa, 0x4b, 0xa6, 0xd1, 0xad, 0x44, 0x94,
+	0xc3, 0xfa, 0xe6, 0x1c, 0x51, 0xc8, 0xd9, 0xc0, 0x37, 0x21, 0x47, 0x34, 0xb1, 0x53, 0xe1, 0xc0,
+	0xc7, 0x61, 0xed, 0x9b, 0x14, 0x94, 0x38, 0xfb, 0x19, 0x31, 0xa6, 0xac, 0x00, 0x88, 0xf5, 0x05,
+	0x16, 0x23, 0x05, 0x5b, 0x23, 0x0d, 0xca, 0x62, 0x80, 0x38, 0x74, 0x02, 0x9b, 0x57, 0x69, 0x46,
+	0x4f, 0xe0, 0x90, 0x01, 0x37, 0x04, 0x3c, 0xb0, 0xbe, 0xc0, 0xe4, 0xc8, 0x22, 0xbe, 0x33, 0xf5,
+	0x8c, 0xb9, 0x48, 0xac, 0x77, 0xa5, 0x7d, 0x31, 0x43, 0x8d, 0xde, 0x2a, 0x6e, 0xee, 0xcf, 0xd5,
+	0x9a, 0xd0, 0x0e, 0x40, 0x60, 0x5b, 0x9f, 0x05, 0x98, 0xe2, 0x59, 0x02, 0xa6, 0x75, 0x09, 0x83,
+	0x76, 0xa1, 0xc4, 0x21, 0xbe, 0xcb, 0x2c, 0xdb, 0xa5, 0x8c, 0xa2, 0x1c, 0xb4, 0x89, 0x74, 0x6d,
+	0x13, 0x5f, 0x44, 0x13, 0x84, 0x8c, 0xaa, 0x1f, 0x41, 0xfd, 0xf2, 0x8d, 0xbd, 0xaa, 0xea, 0x33,
+	0x72, 0xb8, 0x30, 0xe4, 0xe9, 0x14, 0xaf, 0xe3, 0xc9, 0xeb, 0x5c, 0xcd, 0x2c, 0x06, 0x69, 0x29,
+	0x06, 0xf4, 0x6a, 0xc6, 0xb6, 0x61, 0xc7, 0x57, 0x33, 0x83, 0xb4, 0x77, 0xa1, 0xda, 0xc6, 0x66,
+	0xe0, 0xd2, 0xae, 0xff, 0xaa, 0x8b, 0x43, 0xfb, 0x97, 0x02, 0x10, 0x73, 0x7f, 0xdb, 0xbe, 0x84,
+	0xad, 0x94, 0x6c, 0x6b, 0x29, 0x0f, 0xd2, 0x2b, 0xf2, 0x60, 0x17, 0x4a, 0x33, 0x67, 0x6a, 0x8d,
+	0x8d, 0x99, 0x14, 0x25, 0x19, 0xb5, 0x10, 0xc6, 0xec, 0xab, 0xc2, 0x98, 0x5b, 0x0e, 0xe3, 0x3b,
+	0x71, 0xef, 0xc8, 0xb3, 0xec, 0xda, 0x60, 0xd9, 0x25, 0xf9, 0x21, 0xa4, 0x6b, 0xb7, 0x61, 0x43,
+	0x44, 0x21, 0x72, 0x4e, 0xd8, 0xe2, 0x95, 0xb8, 0xc5, 0x6b, 0x0f, 0xa1, 0x10, 0xb2, 0xad, 0xa2,
+	0xd3, 0xc9, 0xd1, 0xc3, 0x93, 0xb0, 0xdb, 0x94, 0xb9, 0x39, 0x2e, 0x10, 0xde, 0xa2, 0x94, 0xae,
+	0xfd, 0x3a, 0x7e, 0xd6, 0xd8, 0x13, 0x67, 0xe5, 0x10, 0x2a, 0xf5, 0xd0, 0xd4, 0x6b, 0xf4, 0x50,
+	0x5a, 0xcc, 0x33, 0x67, 0xcc, 0xde, 0xa2, 0xe1, 0x70, 0x1a, 0xc2, 0xdf, 0xfa, 0xb2, 0xfb, 0x93,
+	0x02, 0xb9, 0x56, 0xf4, 0xaa, 0x62, 0xf7, 0xaa, 0x22, 0xdd, 0xab, 0x3f, 0x00, 0x18, 0x45, 0x1b,
+	0x17, 0x3b, 0xdb, 0x90, 0x8a, 0x96, 0xa2, 0xc5, 0x51, 0x25, 0x46, 0xf4, 0x7e, 0xfc, 0xd4, 0x48,
+	0x4b, 0x6d, 0x9c, 0xcb, 0x84, 0x35, 0xce, 0x8a, 0x27, 0x1c, 0x5d, 0x05, 0x7b, 0xfd, 0x1e, 0x94,
+	0x65, 0xf2, 0xb5, 0x6e, 0xd4, 0x9f, 0x42, 0x8e, 0xcb, 0xd2, 0x13, 0xd3, 0xed, 0x1f, 0xc5, 0x01,
+	0x8b, 0x60, 0x7a, 0x24, 0x6e, 0x6c, 0xe9, 0x48, 0xbd, 0x08, 0x1d, 0x1e, 0x29, 0x66, 0xd4, 0xfe,
+	0x92, 0x05, 0x88, 0x19, 0xae, 0xf5, 0x34, 0x7d, 0x00, 0xf9, 0xb9, 0x63, 0xd2, 0xf0, 0xd5, 0xd2,
+	0xd7, 0x89, 0xad, 0x10, 0x8a, 0x0a, 0x3f, 0x23, 0x15, 0xfe, 0x16, 0x64, 0x2d, 0xd2, 0xb6, 0x3c,
+	0xf1, 0xae, 0xe0, 0x00, 0xe5, 0xc4, 0xbe, 0x31, 0x0d, 0xe7, 0x14, 0xba, 0xa6, 0x85, 0x33, 0x76,
+	0x6c, 0x1f, 0xdb, 0xec, 0x2d, 0xc3, 0x86, 0xec, 0xa2, 0x2e, 0xa3, 0xd0, 0x1d, 0xd8, 0x10, 0x60,
+	0xc7, 0x1e, 0x3b, 0xa6, 0x65, 0x4f, 0xc5, 0x10, 0xbd, 0x88, 0xa6, 0x4f, 0x48, 0x7c, 0xe1, 0x5a,
+	0x1e, 0xa6, 0x73, 0x34, 0xe5, 0x08, 0x41, 0xda, 0x04, 0x88, 0xef, 0x78, 0xc6, 0x14, 0x1f, 0xce,
+	0x0c, 0x42, 0xd8, 0xb0, 0x5c, 0xd4, 0x13, 0x38, 0xd4, 0x84, 0x2c, 0xbd, 0xc3, 0x48, 0xad, 0xc4,
+	0x72, 0x62, 0x53, 0x72, 0x7a, 0xdf, 0xf0, 0x64, 0xc7, 0x73, 0x3e, 0xd4, 0x4a, 0x4e, 0x75, 0x65,
+	0x26, 0xb6, 0xbb, 0x10, 0xab, 0x57, 0x8c, 0x74, 0x1a, 0x94, 0xe7, 0xd8, 0x37, 0xcc, 0xf0, 0x43,
+	0xa5, 0xc2, 0xfc, 0x95, 0xc0, 0xd1, 0x00, 0x19, 0xe3, 0x31, 0x0b, 0xd0, 0xfa, 0x95, 0x02, 0xa4,
+	0xf0, 0x00, 0x09, 0x21, 0xea, 0xe2, 0x91, 0x31, 0x3e, 0xc7, 0xb6, 0xc9, 0x5c, 0xbc, 0xc1, 0x5d,
+	0x2c, 0xa1, 0x50, 0x03, 0x90, 0xf0, 0x65, 0xdb, 0x22, 0xae, 0x43, 0x2c, 0x56, 0xa8, 0x2a, 0x63,
+	0x5c, 0x41, 0x91, 0x42, 0x72, 0x6c, 0xd8, 0xd3, 0xc0, 0x98, 0xe2, 0x5a, 0x35, 0x11, 0x92, 0x10,
+	0xfd, 0x5f, 0x8f, 0xa1, 0xdf, 0x28, 0xb0, 0x9e, 0x8c, 0x01, 0xcd, 0x6d, 0x3b, 0x98, 0x8f, 0xb0,
+	0x27, 0xae, 0x7b, 0x01, 0xad, 0xcc, 0xed, 0x23, 0x28, 0xd3, 0x97, 0xd6, 0x89, 0x63, 0x5a, 0x13,
+	0x0b, 0x9b, 0xd7, 0x4a, 0xf0, 0x84, 0xe4, 0xca, 0x2c, 0xdf, 0x01, 0x30, 0xc6, 0x7e, 0x20, 0x6e,
+	0x0d, 0x71, 0x29, 0xc4, 0x98, 0x44, 0x9d, 0xe7, 0x92, 0x75, 0xae, 0xfd, 0x5b, 0x81, 0x8d, 0x85,
+	0xb9, 0x09, 0x35, 0x13, 0xb5, 0xaf, 0xac, 0xac, 0x7d, 0xb9, 0xea, 0x97, 0xc6, 0xe2, 0x13, 0x28,
+	0x39, 0x91, 0xb3, 0xc2, 0xe6, 0x76, 0x7b, 0xd5, 0x8c, 0x26, 0x25, 0x76, 0xa2, 0xd3, 0xc9, 0xf2,
+	0xf5, 0x01, 0xa8, 0x8b, 0x6c, 0x72, 0xf0, 0xd2, 0x3c, 0x78, 0xef, 0x24, 0x47, 0xc2, 0x55, 0x75,
+	0x23, 0x45, 0x74, 0xef, 0xfb, 0x50, 0x08, 0x3f, 0xbe, 0xd0, 0x06, 0x94, 0x5a, 0x67, 0x87, 0x3f,
+	0xea, 0x0c, 0x9f, 0xea, 0xbd, 0xde, 0x50, 0x5d, 0x43, 0x5b, 0xa0, 0x9e, 0x9d, 0x76, 0x3f, 0x7a,
+	0x38, 0x78, 0xda, 0xee, 0xea, 0x9d, 0xc3, 0x61, 0x4f, 0x7f, 0xa2, 0x2a, 0x7b, 0xe7, 0x50, 0x49,
+	0xfc, 0xd9, 0xa0, 0x37, 0x60, 0x53, 0xc8, 0x9d, 0x9d, 0xea, 0x9d, 0x41, 0xef, 0xf8, 0xc3, 0x83,
+	0xd6, 0x71, 0x47, 0x5d, 0xa3, 0x84, 0x5e, 0xeb, 0x71, 0xe7, 0x70, 0x81, 0xa0, 0xa0, 0x1b, 0x50,
+	0x6d, 0x1f, 0x0c, 0x0f, 0x92, 0xe8, 0x14, 0xaa, 0x42, 0x65, 0xd0, 0xfd, 0xb8, 0xf3, 0xf4, 0xa4,
+	0x3b, 0x38, 0x39, 0x18, 0x1e, 0x1e, 0xa9, 0xe9, 0xbd, 0xbb, 0x50, 0x49, 0x7c, 0xaf, 0xa0, 0x0a,
+	0x14, 0x87, 0x9d, 0x93, 0x7e, 0x4f, 0x3f, 0x38, 0xfe, 0x48, 0x5d, 0x43, 0x9b, 0xb0, 0xd1, 0xef,
+	0x9e, 0x9e, 0x76, 0x4f, 0x1f, 0x3d, 0x1d, 0x74, 0xf4, 0x0f, 0xbb, 0x87, 0x1d, 0x55, 0xd9, 0xfb,
+	0x00, 0x0a, 0xe1, 0xcf, 0x02, 0x02, 0xc8, 0xfd, 0xf8, 0xac, 0x73, 0xd6, 0x69, 0xab, 0x6b, 0xa8,
+	0x04, 0x79, 0xc1, 0xac, 0x2a, 0x94, 0x40, 0x81, 0x4e, 0x5b, 0x4d, 0xd1, 0xf5, 0xc3, 0x83, 0xee,
+	0x71, 0xa7, 0xad, 0xa6, 0xf7, 0xf6, 0xa1, 0xba, 0xf4, 0xc8, 0x44, 0x79, 0x48, 0xf7, 0xcf, 0xa8,
+	0x4b, 0x0a, 0x90, 0x39, 0xec, 0xf5, 0x9f, 0x70, 0xf9, 0x76, 0xe7, 0xb8, 0x33, 0xec, 0xa8, 0xa9,
+	0xfd, 0x5f, 0xe4, 0x21, 0x4f, 0x3d, 0x7b, 0xd0, 0xef, 0xa2, 0xfb, 0x90, 0x7f, 0x84, 0x7d, 0x76,
+	0x7b, 0xa8, 0xfc, 0x63, 0x31, 0xfe, 0xe3, 0xad, 0x57, 0x25, 0x0c, 0x7f, 0x5c, 0x6b, 0x95, 0x9f,
+	0xff, 0xf9, 0x9f, 0xbf, 0x4c, 0xe5, 0x51, 0xb6, 0x69, 0xd1, 0x24, 0xba, 0x0f, 0x19, 0xea, 0x5d,
+	0x21, 0x2b, 0x7d, 0x0e, 0xd6, 0xab, 0x12, 0x46, 0xc8, 0xaa, 0x4c, 0x16, 0xee, 0x29, 0x7b, 0x5a,
+	0xb6, 0x39, 0xa1, 0x62, 0x8f, 0xa1, 0x32, 0xc0, 0xbe, 0xfc, 0x2d, 0x2a, 0x5f, 0xc0, 0xf1, 0x87,
+	0x6c, 0x7d, 0x63, 0x01, 0x9f, 0xd4, 0x45, 0xaf, 0x7c, 0xf4, 0x09, 0x6c, 0x46, 0xba, 0xa4, 0x8f,
+	0xa7, 0x1b, 0x92, 0x64, 0x8c, 0xae, 0xaf, 0x46, 0x6b, 0x35, 0xa6, 0x16, 0x51, 0xb5, 0x95, 0xa6,
+	0x6b, 0xd9, 0xa4, 0x29, 0x3e, 0x9e, 0xd0, 0x31, 0x94, 0x1f, 0x61, 0x5f, 0x04, 0x2a, 0x08, 0xf5,
+	0x2e, 0xfe, 0x60, 0xd4, 0xb7, 0x17, 0xd1, 0x4b, 0x7e, 0xa3, 0x5a, 0xd1, 0x4f, 0x40, 0x6d, 0xe1,
+	0xa9, 0x65, 0xcb, 0xef, 0xd7, 0x37, 0x16, 0x9f, 0xa2, 0xa1, 0x4e, 0x75, 0x91, 0x90, 0xdc, 0xa6,
+	0x1f, 0x13, 0x08, 0x7a, 0x02, 0xea, 0xc0, 0x37, 0xa6, 0x58, 0x56, 0xbc, 0xb9, 0xfc, 0xc6, 0x25,
+	0x2b, 0x94, 0xee, 0x30, 0xa5, 0x35, 0xaa, 0x74, 0x33, 0xa1, 0xb4, 0x49, 0xa8, 0x46, 0xf4, 0x33,
+	0xa8, 0x1e, 0x3a, 0xf3, 0xb9, 0xe5, 0x5f, 0x69, 0xd3, 0x2b, 0x72, 0xe7, 0x26, 0x33, 0xf0, 0x1d,
+	0x6a, 0x60, 0x2b, 0x69, 0x60, 0xcc, 0xf4, 0xa2, 0x4f, 0x40, 0x3d, 0x18, 0x39, 0x9e, 0xff, 0x9a,
+	0x5e, 0xb9, 0xfc, 0x00, 0x06, 0xd5, 0x8a, 0x8e, 0xa0, 0xf2, 0x08, 0xfb, 0xf2, 0x73, 0x60, 0x71,
+	0x8a, 0x4e, 0x64, 0x5b, 0x8c, 0xd7, 0xd6, 0x99, 0xe6, 0x02, 0xca, 0x35, 0x4d, 0x8a, 0x44, 0x8f,
+	0xa0, 0x44, 0x35, 0x85, 0x03, 0xf4, 0x96, 0x3c, 0x1e, 0x47, 0x5a, 0x2a, 0x09, 0xac, 0xb6, 0xc9,
+	0x74, 0x54, 0x50, 0x89, 0xeb, 0x68, 0xd2, 0xf1, 0xb9, 0x55, 0xfb, 0xea, 0xc5, 0x8e, 0xf2, 0xf5,
+	0x8b, 0x1d, 0xe5, 0x1f, 0x2f, 0x76, 0x94, 0x2f, 0x5f, 0xee, 0xac, 0x7d, 0xfd, 0x72, 0x67, 0xed,
+	0xaf, 0x2f, 0x77, 0xd6, 0x46, 0x39, 0x76, 0xbf, 0xdc, 0xfd, 0xcf, 0x00, 0xd9, 0x2d, 0x48, 0xfd,
+	0xf4, 0x19, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// InfoAPIClient is the client API for InfoAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InfoAPIClient interface {
+	GetHash(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	// Fsck checks that every cid referenced by the ledger can be resolved,
+	// optionally repairing the inconsistencies that are found
+	Fsck(ctx context.Context, in *FsckRequest, opts ...grpc.CallOption) (*FsckResponse, error)
+	// SetBucketIPNS enables or disables publishing a bucket to its IPNS name
+	SetBucketIPNS(ctx context.Context, in *BucketIPNSRequest, opts ...grpc.CallOption) (*BucketIPNS, error)
+	// SetBucketPinTargets sets the remote targets the objects of a bucket are pinned to
+	SetBucketPinTargets(ctx context.Context, in *BucketPinTargets, opts ...grpc.CallOption) (*BucketPinTargets, error)
+	// GetPinStatus returns the pin jobs of an object, or of all objects in a bucket
+	GetPinStatus(ctx context.Context, in *PinStatusRequest, opts ...grpc.CallOption) (*PinStatusResponse, error)
+	// BeginTransaction starts a transaction of a bucket, its operations are staged
+	// and only become visible together when the transaction is committed
+	BeginTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// StageTransaction adds operations to a transaction, the data of puts is uploaded right away
+	StageTransaction(ctx context.Context, in *TransactionOps, opts ...grpc.CallOption) (*Transaction, error)
+	// CommitTransaction applies the staged operations as one new bucket root, and returns its hash
+	CommitTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	// AbortTransaction discards a transaction and its staged operations
+	AbortTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// GetDedupStats returns the logical and unique data size of a bucket, or of all buckets
+	GetDedupStats(ctx context.Context, in *DedupStatsRequest, opts ...grpc.CallOption) (*DedupStats, error)
+	// GetDataRefs returns the objects of all buckets that reference a data cid
+	GetDataRefs(ctx context.Context, in *DataRefsRequest, opts ...grpc.CallOption) (*DataRefs, error)
+}
+
+type infoAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInfoAPIClient(cc *grpc.ClientConn) InfoAPIClient {
+	return &infoAPIClient{cc}
+}
+
+func (c *infoAPIClient) GetHash(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/GetHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) Fsck(ctx context.Context, in *FsckRequest, opts ...grpc.CallOption) (*FsckResponse, error) {
+	out := new(FsckResponse)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/Fsck", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) SetBucketIPNS(ctx context.Context, in *BucketIPNSRequest, opts ...grpc.CallOption) (*BucketIPNS, error) {
+	out := new(BucketIPNS)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/SetBucketIPNS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) SetBucketPinTargets(ctx context.Context, in *BucketPinTargets, opts ...grpc.CallOption) (*BucketPinTargets, error) {
+	out := new(BucketPinTargets)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/SetBucketPinTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) GetPinStatus(ctx context.Context, in *PinStatusRequest, opts ...grpc.CallOption) (*PinStatusResponse, error) {
+	out := new(PinStatusResponse)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/GetPinStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) BeginTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/BeginTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) StageTransaction(ctx context.Context, in *TransactionOps, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/StageTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) CommitTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/CommitTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) AbortTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/AbortTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) GetDedupStats(ctx context.Context, in *DedupStatsRequest, opts ...grpc.CallOption) (*DedupStats, error) {
+	out := new(DedupStats)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/GetDedupStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoAPIClient) GetDataRefs(ctx context.Context, in *DataRefsRequest, opts ...grpc.CallOption) (*DataRefs, error) {
+	out := new(DataRefs)
+	err := c.cc.Invoke(ctx, "/s3x.InfoAPI/GetDataRefs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InfoAPIServer is the server API for InfoAPI service.
+type InfoAPIServer interface {
+	GetHash(context.Context, *InfoRequest) (*InfoResponse, error)
+	// Fsck checks that every cid referenced by the ledger can be resolved,
+	// optionally repairing the inconsistencies that are found
+	Fsck(context.Context, *FsckRequest) (*FsckResponse, error)
+	// SetBucketIPNS enables or disables publishing a bucket to its IPNS name
+	SetBucketIPNS(context.Context, *BucketIPNSRequest) (*BucketIPNS, error)
+	// SetBucketPinTargets sets the remote targets the objects of a bucket are pinned to
+	SetBucketPinTargets(context.Context, *BucketPinTargets) (*BucketPinTargets, error)
+	// GetPinStatus returns the pin jobs of an object, or of all objects in a bucket
+	GetPinStatus(context.Context, *PinStatusRequest) (*PinStatusResponse, error)
+	// BeginTransaction starts a transaction of a bucket, its operations are staged
+	// and only become visible together when the transaction is committed
+	BeginTransaction(context.Context, *TransactionRequest) (*Transaction, error)
+	// StageTransaction adds operations to a transaction, the data of puts is uploaded right away
+	StageTransaction(context.Context, *TransactionOps) (*Transaction, error)
+	// CommitTransaction applies the staged operations as one new bucket root, and returns its hash
+	CommitTransaction(context.Context, *TransactionRequest) (*InfoResponse, error)
+	// AbortTransaction discards a transaction and its staged operations
+	AbortTransaction(context.Context, *TransactionRequest) (*Transaction, error)
+	// GetDedupStats returns the logical and unique data size of a bucket, or of all buckets
+	GetDedupStats(context.Context, *DedupStatsRequest) (*DedupStats, error)
+	// GetDataRefs returns the objects of all buckets that reference a data cid
+	GetDataRefs(context.Context, *DataRefsRequest) (*DataRefs, error)
+}
+
+// UnimplementedInfoAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedInfoAPIServer struct {
+}
+
+func (*UnimplementedInfoAPIServer) GetHash(ctx context.Context, req *InfoRequest) (*InfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHash not implemented")
+}
+func (*UnimplementedInfoAPIServer) Fsck(ctx context.Context, req *FsckRequest) (*FsckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fsck not implemented")
+}
+func (*UnimplementedInfoAPIServer) SetBucketIPNS(ctx context.Context, req *BucketIPNSRequest) (*BucketIPNS, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBucketIPNS not implemented")
+}
+func (*UnimplementedInfoAPIServer) SetBucketPinTargets(ctx context.Context, req *BucketPinTargets) (*BucketPinTargets, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBucketPinTargets not implemented")
+}
+func (*UnimplementedInfoAPIServer) GetPinStatus(ctx context.Context, req *PinStatusRequest) (*PinStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPinStatus not implemented")
+}
+func (*UnimplementedInfoAPIServer) BeginTransaction(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginTransaction not implemented")
+}
+func (*UnimplementedInfoAPIServer) StageTransaction(ctx context.Context, req *TransactionOps) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StageTransaction not implemented")
+}
+func (*UnimplementedInfoAPIServer) CommitTransaction(ctx context.Context, req *TransactionRequest) (*InfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitTransaction not implemented")
+}
+func (*UnimplementedInfoAPIServer) AbortTransaction(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortTransaction not implemented")
+}
+func (*UnimplementedInfoAPIServer) GetDedupStats(ctx context.Context, req *DedupStatsRequest) (*DedupStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDedupStats not implemented")
+}
+func (*UnimplementedInfoAPIServer) GetDataRefs(ctx context.Context, req *DataRefsRequest) (*DataRefs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataRefs not implemented")
+}
+
+func RegisterInfoAPIServer(s *grpc.Server, srv InfoAPIServer) {
+	s.RegisterService(&_InfoAPI_serviceDesc, srv)
+}
+
+func _InfoAPI_GetHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).GetHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/GetHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).GetHash(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_Fsck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).Fsck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/Fsck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).Fsck(ctx, req.(*FsckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_SetBucketIPNS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BucketIPNSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).SetBucketIPNS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/SetBucketIPNS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).SetBucketIPNS(ctx, req.(*BucketIPNSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_SetBucketPinTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BucketPinTargets)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).SetBucketPinTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/SetBucketPinTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).SetBucketPinTargets(ctx, req.(*BucketPinTargets))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_GetPinStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).GetPinStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/GetPinStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).GetPinStatus(ctx, req.(*PinStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_BeginTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).BeginTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/BeginTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).BeginTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_StageTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionOps)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).StageTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/StageTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).StageTransaction(ctx, req.(*TransactionOps))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_CommitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).CommitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/CommitTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).CommitTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_AbortTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).AbortTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/AbortTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).AbortTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_GetDedupStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DedupStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).GetDedupStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/GetDedupStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).GetDedupStats(ctx, req.(*DedupStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InfoAPI_GetDataRefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataRefsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoAPIServer).GetDataRefs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/s3x.InfoAPI/GetDataRefs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoAPIServer).GetDataRefs(ctx, req.(*DataRefsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _InfoAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "s3x.InfoAPI",
+	HandlerType: (*InfoAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHash",
+			Handler:    _InfoAPI_GetHash_Handler,
+		},
+		{
+			MethodName: "Fsck",
+			Handler:    _InfoAPI_Fsck_Handler,
+		},
+		{
+			MethodName: "SetBucketIPNS",
+			Handler:    _InfoAPI_SetBucketIPNS_Handler,
+		},
+		{
+			MethodName: "SetBucketPinTargets",
+			Handler:    _InfoAPI_SetBucketPinTargets_Handler,
+		},
+		{
+			MethodName: "GetPinStatus",
+			Handler:    _InfoAPI_GetPinStatus_Handler,
+		},
+		{
+			MethodName: "BeginTransaction",
+			Handler:    _InfoAPI_BeginTransaction_Handler,
+		},
+		{
+			MethodName: "StageTransaction",
+			Handler:    _InfoAPI_StageTransaction_Handler,
+		},
+		{
+			MethodName: "CommitTransaction",
+			Handler:    _InfoAPI_CommitTransaction_Handler,
+		},
+		{
+			MethodName: "AbortTransaction",
+			Handler:    _InfoAPI_AbortTransaction_Handler,
+		},
+		{
+			MethodName: "GetDedupStats",
+			Handler:    _InfoAPI_GetDedupStats_Handler,
+		},
+		{
+			MethodName: "GetDataRefs",
+			Handler:    _InfoAPI_GetDataRefs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "s3.proto",
+}
+
+func (m *InfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ObjectDataOnly {
+		i--
+		if m.ObjectDataOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IpnsName) > 0 {
+		i -= len(m.IpnsName)
+		copy(dAtA[i:], m.IpnsName)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.IpnsName)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BucketIPNSRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BucketIPNSRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BucketIPNSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Mode != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Mode))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Enable {
+		i--
+		if m.Enable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BucketIPNS) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BucketIPNS) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BucketIPNS) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.PublishedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.PublishedAt):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintS3(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Published) > 0 {
+		i -= len(m.Published)
+		copy(dAtA[i:], m.Published)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Published)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Mode != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Mode))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FsckRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FsckRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FsckRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Remove {
+		i--
+		if m.Remove {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Repair {
+		i--
+		if m.Repair {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FsckResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FsckResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FsckResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Issues) > 0 {
+		for iNdEx := len(m.Issues) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Issues[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Objects != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Objects))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Buckets != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Buckets))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FsckIssue) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FsckIssue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FsckIssue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Repaired {
+		i--
+		if m.Repaired {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Detail) > 0 {
+		i -= len(m.Detail)
+		copy(dAtA[i:], m.Detail)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Detail)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PinTarget) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PinTarget) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PinTarget) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Insecure {
+		i--
+		if m.Insecure {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Endpoint)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Type != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BucketPinTargets) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BucketPinTargets) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BucketPinTargets) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Targets) > 0 {
+		for iNdEx := len(m.Targets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Targets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PinJob) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PinJob) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PinJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Updated, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintS3(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x52
+	if len(m.RequestIds) > 0 {
+		for k := range m.RequestIds {
+			v := m.RequestIds[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintS3(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintS3(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.LastError) > 0 {
+		i -= len(m.LastError)
+		copy(dAtA[i:], m.LastError)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.LastError)))
+		i--
+		dAtA[i] = 0x42
+	}
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.NextAttempt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.NextAttempt):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintS3(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x3a
+	if m.Attempts != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Attempts))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.State != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Target) > 0 {
+		i -= len(m.Target)
+		copy(dAtA[i:], m.Target)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Target)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PinStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PinStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PinStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PinStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PinStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PinStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Jobs) > 0 {
+		for iNdEx := len(m.Jobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Jobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransactionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransactionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransactionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransactionOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransactionOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransactionOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.UserDefined) > 0 {
+		for k := range m.UserDefined {
+			v := m.UserDefined[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintS3(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintS3(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransactionOps) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransactionOps) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransactionOps) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ops) > 0 {
+		for iNdEx := len(m.Ops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Transaction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Transaction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintS3(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x22
+	if len(m.Ops) > 0 {
+		for iNdEx := len(m.Ops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Ledger) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Ledger) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Ledger) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MultipartUploads) > 0 {
+		for k := range m.MultipartUploads {
+			v := m.MultipartUploads[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintS3(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintS3(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Buckets) > 0 {
+		for k := range m.Buckets {
+			v := m.Buckets[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintS3(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintS3(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LedgerBucketEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LedgerBucketEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LedgerBucketEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IpfsHash) > 0 {
+		i -= len(m.IpfsHash)
+		copy(dAtA[i:], m.IpfsHash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.IpfsHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Bucket != nil {
+		{
+			size, err := m.Bucket.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintS3(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BucketUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BucketUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BucketUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DataIndexed {
+		i--
+		if m.DataIndexed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.UniqueCount != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.UniqueCount))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.UniqueSize != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.UniqueSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ObjectsSizesHistogram) > 0 {
+		for k := range m.ObjectsSizesHistogram {
+			v := m.ObjectsSizesHistogram[k]
+			baseI := i
+			i = encodeVarintS3(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintS3(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ObjectsCount != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.ObjectsCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DataRef) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataRef) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataRef) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tenant) > 0 {
+		i -= len(m.Tenant)
+		copy(dAtA[i:], m.Tenant)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Tenant)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Object) > 0 {
+		i -= len(m.Object)
+		copy(dAtA[i:], m.Object)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Object)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DedupStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DedupStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DedupStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DedupStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DedupStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DedupStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Buckets) > 0 {
+		for iNdEx := len(m.Buckets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Buckets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.UniqueCount != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.UniqueCount))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.UniqueSize != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.UniqueSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.LogicalSize != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.LogicalSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ObjectsCount != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.ObjectsCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Tenant) > 0 {
+		i -= len(m.Tenant)
+		copy(dAtA[i:], m.Tenant)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Tenant)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DataRefsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataRefsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataRefsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DataRefs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataRefs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataRefs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Refs) > 0 {
+		for iNdEx := len(m.Refs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Refs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BucketInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BucketInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BucketInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IpnsName) > 0 {
+		i -= len(m.IpnsName)
+		copy(dAtA[i:], m.IpnsName)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.IpnsName)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Location) > 0 {
+		i -= len(m.Location)
+		copy(dAtA[i:], m.Location)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Location)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err8 != nil {
+		return 0, err8
+	}
+	i -= n8
+	i = encodeVarintS3(dAtA, i, uint64(n8))
+	i--
+	dAtA[i] = 0x12
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Bucket) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Bucket) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Bucket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for k := range m.Objects {
+			v := m.Objects[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintS3(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintS3(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	{
+		size, err := m.BucketInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintS3(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Object) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Object) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Object) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.ObjectInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintS3(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.DataHash) > 0 {
+		i -= len(m.DataHash)
+		copy(dAtA[i:], m.DataHash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.DataHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ObjectInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObjectInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ContentLanguage) > 0 {
+		i -= len(m.ContentLanguage)
+		copy(dAtA[i:], m.ContentLanguage)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentLanguage)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if len(m.ContentDisposition) > 0 {
+		i -= len(m.ContentDisposition)
+		copy(dAtA[i:], m.ContentDisposition)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentDisposition)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if len(m.BackendType) > 0 {
+		i -= len(m.BackendType)
+		copy(dAtA[i:], m.BackendType)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.BackendType)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.AccTime != nil {
+		n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.AccTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.AccTime):])
+		if err11 != nil {
+			return 0, err11
+		}
+		i -= n11
+		i = encodeVarintS3(dAtA, i, uint64(n11))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.MetadataOnly {
+		i--
+		if m.MetadataOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if len(m.UserDefined) > 0 {
+		for k := range m.UserDefined {
+			v := m.UserDefined[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintS3(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintS3(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if len(m.Parts) > 0 {
+		for iNdEx := len(m.Parts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Parts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.StorageClass) > 0 {
+		i -= len(m.StorageClass)
+		copy(dAtA[i:], m.StorageClass)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.StorageClass)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Expires) > 0 {
+		i -= len(m.Expires)
+		copy(dAtA[i:], m.Expires)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Expires)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.ContentEncoding) > 0 {
+		i -= len(m.ContentEncoding)
+		copy(dAtA[i:], m.ContentEncoding)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentEncoding)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.ContentType) > 0 {
+		i -= len(m.ContentType)
+		copy(dAtA[i:], m.ContentType)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.ContentType)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Etag) > 0 {
+		i -= len(m.Etag)
+		copy(dAtA[i:], m.Etag)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Etag)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.IsDir {
+		i--
+		if m.IsDir {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x20
+	}
+	n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ModTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ModTime):])
+	if err12 != nil {
+		return 0, err12
+	}
+	i -= n12
+	i = encodeVarintS3(dAtA, i, uint64(n12))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ObjectPartInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectPartInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObjectPartInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DataHash) > 0 {
+		i -= len(m.DataHash)
+		copy(dAtA[i:], m.DataHash)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.DataHash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.ActualSize != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.ActualSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x20
+	}
+	n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastModified, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastModified):])
+	if err13 != nil {
+		return 0, err13
+	}
+	i -= n13
+	i = encodeVarintS3(dAtA, i, uint64(n13))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Number != 0 {
+		i = encodeVarintS3(dAtA, i, uint64(m.Number))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MultipartUpload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MultipartUpload) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MultipartUpload) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ObjectParts) > 0 {
+		for k := range m.ObjectParts {
+			v := m.ObjectParts[k]
+			baseI := i
+			{
+				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintS3(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+			i = encodeVarintS3(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintS3(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintS3(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ObjectInfo != nil {
+		{
+			size, err := m.ObjectInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintS3(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintS3(dAtA []byte, offset int, v uint64) int {
+	offset -= sovS3(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *InfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.ObjectDataOnly {
+		n += 2
+	}
+	return n
+}
+
+func (m *InfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.IpnsName)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *BucketIPNSRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.Enable {
+		n += 2
+	}
+	if m.Mode != 0 {
+		n += 1 + sovS3(uint64(m.Mode))
+	}
+	return n
+}
+
+func (m *BucketIPNS) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovS3(uint64(m.Mode))
+	}
+	l = len(m.Published)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.PublishedAt)
+	n += 1 + l + sovS3(uint64(l))
+	return n
+}
+
+func (m *FsckRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.Repair {
+		n += 2
+	}
+	if m.Remove {
+		n += 2
+	}
+	return n
+}
+
+func (m *FsckResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Buckets != 0 {
+		n += 1 + sovS3(uint64(m.Buckets))
+	}
+	if m.Objects != 0 {
+		n += 1 + sovS3(uint64(m.Objects))
+	}
+	if len(m.Issues) > 0 {
+		for _, e := range m.Issues {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FsckIssue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovS3(uint64(m.Type))
+	}
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Detail)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.Repaired {
+		n += 2
+	}
+	return n
+}
+
+func (m *PinTarget) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovS3(uint64(m.Type))
+	}
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.Insecure {
+		n += 2
+	}
+	return n
+}
+
+func (m *BucketPinTargets) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.Targets) > 0 {
+		for _, e := range m.Targets {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PinJob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.State != 0 {
+		n += 1 + sovS3(uint64(m.State))
+	}
+	if m.Attempts != 0 {
+		n += 1 + sovS3(uint64(m.Attempts))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.NextAttempt)
+	n += 1 + l + sovS3(uint64(l))
+	l = len(m.LastError)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.RequestIds) > 0 {
+		for k, v := range m.RequestIds {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + 1 + len(v) + sovS3(uint64(len(v)))
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated)
+	n += 1 + l + sovS3(uint64(l))
+	return n
+}
+
+func (m *PinStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *PinStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Jobs) > 0 {
+		for _, e := range m.Jobs {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TransactionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *TransactionOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovS3(uint64(m.Type))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.UserDefined) > 0 {
+		for k, v := range m.UserDefined {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + 1 + len(v) + sovS3(uint64(len(v)))
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *TransactionOps) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.Ops) > 0 {
+		for _, e := range m.Ops {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Transaction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.Ops) > 0 {
+		for _, e := range m.Ops {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovS3(uint64(l))
+	return n
+}
+
+func (m *Ledger) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Buckets) > 0 {
+		for k, v := range m.Buckets {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovS3(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	if len(m.MultipartUploads) > 0 {
+		for k, v := range m.MultipartUploads {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovS3(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *LedgerBucketEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Bucket != nil {
+		l = m.Bucket.Size()
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.IpfsHash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *BucketUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		n += 1 + sovS3(uint64(m.Size_))
+	}
+	if m.ObjectsCount != 0 {
+		n += 1 + sovS3(uint64(m.ObjectsCount))
+	}
+	if len(m.ObjectsSizesHistogram) > 0 {
+		for k, v := range m.ObjectsSizesHistogram {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + 1 + sovS3(uint64(v))
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	if m.UniqueSize != 0 {
+		n += 1 + sovS3(uint64(m.UniqueSize))
+	}
+	if m.UniqueCount != 0 {
+		n += 1 + sovS3(uint64(m.UniqueCount))
+	}
+	if m.DataIndexed {
+		n += 2
+	}
+	return n
+}
+
+func (m *DataRef) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Object)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovS3(uint64(m.Size_))
+	}
+	l = len(m.Tenant)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *DedupStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *DedupStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Tenant)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if m.ObjectsCount != 0 {
+		n += 1 + sovS3(uint64(m.ObjectsCount))
+	}
+	if m.LogicalSize != 0 {
+		n += 1 + sovS3(uint64(m.LogicalSize))
+	}
+	if m.UniqueSize != 0 {
+		n += 1 + sovS3(uint64(m.UniqueSize))
+	}
+	if m.UniqueCount != 0 {
+		n += 1 + sovS3(uint64(m.UniqueCount))
+	}
+	if len(m.Buckets) > 0 {
+		for _, e := range m.Buckets {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DataRefsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *DataRefs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.Refs) > 0 {
+		for _, e := range m.Refs {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BucketInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovS3(uint64(l))
+	l = len(m.Location)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.IpnsName)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *Bucket) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = m.BucketInfo.Size()
+	n += 1 + l + sovS3(uint64(l))
+	if len(m.Objects) > 0 {
+		for k, v := range m.Objects {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + 1 + len(v) + sovS3(uint64(len(v)))
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *Object) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DataHash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = m.ObjectInfo.Size()
+	n += 1 + l + sovS3(uint64(l))
+	return n
+}
+
+func (m *ObjectInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ModTime)
+	n += 1 + l + sovS3(uint64(l))
+	if m.Size_ != 0 {
+		n += 1 + sovS3(uint64(m.Size_))
+	}
+	if m.IsDir {
+		n += 2
+	}
+	l = len(m.Etag)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.ContentEncoding)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Expires)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.StorageClass)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.Parts) > 0 {
+		for _, e := range m.Parts {
+			l = e.Size()
+			n += 1 + l + sovS3(uint64(l))
+		}
+	}
+	if len(m.UserDefined) > 0 {
+		for k, v := range m.UserDefined {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovS3(uint64(len(k))) + 1 + len(v) + sovS3(uint64(len(v)))
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	if m.MetadataOnly {
+		n += 2
+	}
+	if m.AccTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.AccTime)
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.BackendType)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.ContentDisposition)
+	if l > 0 {
+		n += 2 + l + sovS3(uint64(l))
+	}
+	l = len(m.ContentLanguage)
+	if l > 0 {
+		n += 2 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *ObjectPartInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Number != 0 {
+		n += 1 + sovS3(uint64(m.Number))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastModified)
+	n += 1 + l + sovS3(uint64(l))
+	if m.Size_ != 0 {
+		n += 1 + sovS3(uint64(m.Size_))
+	}
+	if m.ActualSize != 0 {
+		n += 1 + sovS3(uint64(m.ActualSize))
+	}
+	l = len(m.DataHash)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	return n
+}
+
+func (m *MultipartUpload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ObjectInfo != nil {
+		l = m.ObjectInfo.Size()
+		n += 1 + l + sovS3(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovS3(uint64(l))
+	}
+	if len(m.ObjectParts) > 0 {
+		for k, v := range m.ObjectParts {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + sovS3(uint64(k)) + 1 + l + sovS3(uint64(l))
+			n += mapEntrySize + 1 + sovS3(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func sovS3(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozS3(x uint64) (n int) {
+	return sovS3(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *InfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Object = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectDataOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ObjectDataOnly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InfoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InfoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Object = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpnsName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpnsName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BucketIPNSRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BucketIPNSRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BucketIPNSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enable = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= IPNSMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BucketIPNS) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BucketIPNS: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BucketIPNS: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= IPNSMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Published", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Published = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublishedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.PublishedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FsckRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FsckRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FsckRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repair", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Repair = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remove", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Remove = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FsckResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FsckResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FsckResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+			}
+			m.Buckets = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Buckets |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+			}
+			m.Objects = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Objects |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Issues", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Issues = append(m.Issues, FsckIssue{})
+			if err := m.Issues[len(m.Issues)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FsckIssue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FsckIssue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FsckIssue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= FsckIssueType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Object = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Detail", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Detail = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repaired", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Repaired = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PinTarget) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PinTarget: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PinTarget: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= PinTargetType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Insecure", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Insecure = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BucketPinTargets) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BucketPinTargets: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BucketPinTargets: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Targets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Targets = append(m.Targets, PinTarget{})
+			if err := m.Targets[len(m.Targets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PinJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PinJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PinJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Object = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Target = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= PinState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
+			}
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Attempts |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextAttempt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.NextAttempt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestIds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RequestIds == nil {
+				m.RequestIds = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowS3
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthS3
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipS3(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthS3
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.RequestIds[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Updated, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PinStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PinStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PinStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Object = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PinStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PinStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PinStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Jobs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Jobs = append(m.Jobs, PinJob{})
+			if err := m.Jobs[len(m.Jobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransactionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransactionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransactionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransactionOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransactionOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransactionOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= TransactionOpType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Object = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserDefined", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UserDefined == nil {
+				m.UserDefined = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowS3
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthS3
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipS3(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthS3
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.UserDefined[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransactionOps) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransactionOps: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransactionOps: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ops", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ops = append(m.Ops, TransactionOp{})
+			if err := m.Ops[len(m.Ops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Transaction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Transaction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Transaction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ops", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ops = append(m.Ops, TransactionOp{})
+			if err := m.Ops[len(m.Ops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Ledger) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Ledger: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Ledger: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Buckets == nil {
+				m.Buckets = make(map[string]*LedgerBucketEntry)
+			}
+			var mapkey string
+			var mapvalue *LedgerBucketEntry
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowS3
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthS3
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &LedgerBucketEntry{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipS3(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthS3
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Buckets[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MultipartUploads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MultipartUploads == nil {
+				m.MultipartUploads = make(map[string]*MultipartUpload)
+			}
+			var mapkey string
+			var mapvalue *MultipartUpload
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowS3
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthS3
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &MultipartUpload{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipS3(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthS3
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.MultipartUploads[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LedgerBucketEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LedgerBucketEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LedgerBucketEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Bucket == nil {
+				m.Bucket = &Bucket{}
+			}
+			if err := m.Bucket.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpfsHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpfsHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BucketUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BucketUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BucketUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectsCount", wireType)
+			}
+			m.ObjectsCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ObjectsCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectsSizesHistogram", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ObjectsSizesHistogram == nil {
+				m.ObjectsSizesHistogram = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowS3
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthS3
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowS3
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipS3(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthS3
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ObjectsSizesHistogram[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UniqueSize", wireType)
+			}
+			m.UniqueSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UniqueSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UniqueCount", wireType)
+			}
+			m.UniqueCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UniqueCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataIndexed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DataIndexed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DataRef) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DataRef: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DataRef: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Object = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tenant", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tenant = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *InfoRequest) Unmarshal(dAtA []byte) error {
+func (m *DedupStatsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1913,10 +9294,10 @@ func (m *InfoRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InfoRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DedupStatsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DedupStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1951,58 +9332,6 @@ func (m *InfoRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Bucket = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowS3
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthS3
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthS3
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Object = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectDataOnly", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowS3
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.ObjectDataOnly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipS3(dAtA[iNdEx:])
@@ -2027,7 +9356,7 @@ func (m *InfoRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InfoResponse) Unmarshal(dAtA []byte) error {
+func (m *DedupStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2050,10 +9379,10 @@ func (m *InfoResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InfoResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DedupStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DedupStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2090,7 +9419,7 @@ func (m *InfoResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tenant", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2118,13 +9447,13 @@ func (m *InfoResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Object = string(dAtA[iNdEx:postIndex])
+			m.Tenant = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectsCount", wireType)
 			}
-			var stringLen uint64
+			m.ObjectsCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowS3
@@ -2134,82 +9463,16 @@ func (m *InfoResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.ObjectsCount |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthS3
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthS3
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipS3(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthS3
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthS3
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Ledger) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowS3
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Ledger: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Ledger: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogicalSize", wireType)
 			}
-			var msglen int
+			m.LogicalSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowS3
@@ -2219,124 +9482,52 @@ func (m *Ledger) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.LogicalSize |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthS3
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthS3
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Buckets == nil {
-				m.Buckets = make(map[string]*LedgerBucketEntry)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UniqueSize", wireType)
 			}
-			var mapkey string
-			var mapvalue *LedgerBucketEntry
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowS3
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			m.UniqueSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowS3
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthS3
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthS3
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowS3
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthS3
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthS3
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &LedgerBucketEntry{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipS3(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthS3
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UniqueSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UniqueCount", wireType)
+			}
+			m.UniqueCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UniqueCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Buckets[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MultipartUploads", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2363,105 +9554,10 @@ func (m *Ledger) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.MultipartUploads == nil {
-				m.MultipartUploads = make(map[string]*MultipartUpload)
-			}
-			var mapkey string
-			var mapvalue *MultipartUpload
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowS3
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowS3
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthS3
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthS3
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowS3
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthS3
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthS3
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &MultipartUpload{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipS3(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthS3
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			m.Buckets = append(m.Buckets, &DedupStats{})
+			if err := m.Buckets[len(m.Buckets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.MultipartUploads[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2487,7 +9583,7 @@ func (m *Ledger) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LedgerBucketEntry) Unmarshal(dAtA []byte) error {
+func (m *DataRefsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2510,17 +9606,17 @@ func (m *LedgerBucketEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LedgerBucketEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: DataRefsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LedgerBucketEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DataRefsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowS3
@@ -2530,31 +9626,80 @@ func (m *LedgerBucketEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthS3
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthS3
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Bucket == nil {
-				m.Bucket = &Bucket{}
-			}
-			if err := m.Bucket.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipS3(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 2:
+			if skippy < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthS3
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DataRefs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowS3
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DataRefs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DataRefs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpfsHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2582,7 +9727,41 @@ func (m *LedgerBucketEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IpfsHash = string(dAtA[iNdEx:postIndex])
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Refs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Refs = append(m.Refs, DataRef{})
+			if err := m.Refs[len(m.Refs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2734,6 +9913,38 @@ func (m *BucketInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.Location = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpnsName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowS3
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthS3
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthS3
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpnsName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipS3(dAtA[iNdEx:])
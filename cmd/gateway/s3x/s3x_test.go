@@ -30,11 +30,16 @@ func (o *operationMockHelper) CallPutBucketHandler(ctx context.Context, bucket s
 	return nil
 }
 
-func (o *operationMockHelper) CallPutObjectHandler(ctx context.Context, bucket string, hash string, object string) error {
+func (o *operationMockHelper) CallPutObjectHandler(ctx context.Context, bucket string, obj *Object, object string) error {
 	log.Println("called mock CallPutObjectHandler")
 	return nil
 }
 
+func (o *operationMockHelper) CallRemoveObjectHandler(ctx context.Context, bucket string, obj *Object, object string) error {
+	log.Println("called mock CallRemoveObjectHandler")
+	return nil
+}
+
 func NewOperationMockHelper() OperationHelper {
 	return &operationMockHelper{}
 }
@@ -389,7 +389,8 @@ type resourceHandler struct {
 	handler http.Handler
 }
 
-// setCorsHandler handler for CORS (Cross Origin Resource Sharing)
+// setCorsHandler handler for CORS (Cross Origin Resource Sharing), the
+// server wide defaults apply to buckets without a CORS configuration.
 func setCorsHandler(h http.Handler) http.Handler {
 	commonS3Headers := []string{
 		xhttp.Date,
@@ -422,7 +423,7 @@ func setCorsHandler(h http.Handler) http.Handler {
 		AllowCredentials: true,
 	})
 
-	return c.Handler(h)
+	return bucketCorsHandler{handler: h, defaultHandler: c.Handler(h)}
 }
 
 // setIgnoreResourcesHandler -
@@ -436,26 +437,20 @@ func setIgnoreResourcesHandler(h http.Handler) http.Handler {
 // Checks requests for not implemented Bucket resources
 func ignoreNotImplementedBucketResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable PutBucketACL, GetBucketACL, GetBucketCors,
-		// GetBucketWebsite, GetBucketAcccelerate,
-		// GetBucketRequestPayment, GetBucketLogging,
-		// GetBucketLifecycle, GetBucketReplication,
-		// GetBucketTagging, GetBucketVersioning,
+		// Enable PutBucketACL, GetBucketACL, GetBucketWebsite,
+		// GetBucketAcccelerate, GetBucketRequestPayment,
+		// GetBucketLifecycle, GetBucketTagging,
 		// DeleteBucketTagging, and DeleteBucketWebsite
 		// dummy calls specifically.
 		if name == "acl" && req.Method == http.MethodPut {
 			return false
 		}
 		if ((name == "acl" ||
-			name == "cors" ||
 			name == "website" ||
 			name == "accelerate" ||
 			name == "requestPayment" ||
-			name == "logging" ||
 			name == "lifecycle" ||
-			name == "replication" ||
-			name == "tagging" ||
-			name == "versioning") && req.Method == http.MethodGet) ||
+			name == "tagging") && req.Method == http.MethodGet) ||
 			((name == "tagging" ||
 				name == "website") && req.Method == http.MethodDelete) {
 			return false
@@ -485,14 +480,10 @@ func ignoreNotImplementedObjectResources(req *http.Request) bool {
 // List of not implemented bucket queries
 var notImplementedBucketResourceNames = map[string]bool{
 	"accelerate":     true,
-	"cors":           true,
 	"inventory":      true,
-	"logging":        true,
 	"metrics":        true,
-	"replication":    true,
 	"requestPayment": true,
 	"tagging":        true,
-	"versioning":     true,
 	"website":        true,
 }
 
@@ -31,6 +31,7 @@ import (
 	xldap "github.com/minio/minio/cmd/config/identity/ldap"
 	"github.com/minio/minio/cmd/config/identity/openid"
 	"github.com/minio/minio/cmd/config/policy/opa"
+	"github.com/minio/minio/cmd/config/replication"
 	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
@@ -156,6 +157,14 @@ var (
 	globalLifecycleSys       *LifecycleSys
 	globalBucketSSEConfigSys *BucketSSEConfigSys
 
+	globalBucketReplicationSys = NewBucketReplicationSys()
+
+	globalBucketLoggingSys = NewBucketLoggingSys()
+
+	globalBucketCorsSys = NewBucketCorsSys()
+
+	globalTierConfigSys = NewTierConfigSys()
+
 	// globalAPIThrottling controls S3 requests throttling when
 	// enabled in the config or in the shell environment.
 	globalAPIThrottling apiThrottling
@@ -217,8 +226,10 @@ var (
 	globalHealingTimeout   = newDynamicTimeout(30*time.Minute /*1*/, 30*time.Minute)           // timeout for healing related ops
 
 	globalBucketObjectLockConfig = objectlock.NewBucketObjectLockConfig()
+	globalBucketVersioningSys    = NewBucketVersioningSys()
 
 	globalBucketQuotaSys     *BucketQuotaSys
+	globalMeteringSys        *MeteringSys
 	globalBucketStorageCache bucketStorageCache
 
 	// Disk cache drives
@@ -253,6 +264,12 @@ var (
 	// Is compression enabled?
 	globalCompressConfig compress.Config
 
+	// Replication target of the buckets.
+	globalReplicationConfig replication.Config
+
+	// Replication workers, nil if no replication target is configured.
+	globalReplicationPool *replicationPool
+
 	// Some standard object extensions which we strictly dis-allow for compression.
 	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z", ".xz", ".mp4", ".mkv", ".mov"}
 
@@ -30,6 +30,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
@@ -365,6 +366,11 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 		defer globalHTTPStats.currentS3Requests.Dec(api)
 
 		statsWriter := logger.NewResponseWriter(w)
+		var body *meteringReader
+		if r.Body != nil {
+			body = &meteringReader{ReadCloser: r.Body}
+			r.Body = body
+		}
 
 		f.ServeHTTP(statsWriter, r)
 
@@ -374,6 +380,16 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 		durationSecs := time.Since(statsWriter.StartTime).Seconds()
 
 		globalHTTPStats.updateStats(api, r, statsWriter, durationSecs)
+
+		var bytesIn uint64
+		if body != nil {
+			bytesIn = body.n
+		}
+		user := ""
+		if statsWriter.StatusCode != http.StatusForbidden {
+			user = getRequestParentUser(r)
+		}
+		globalMeteringSys.Record(api, getRequestMeteringBucket(r, mux.Vars(r)["bucket"]), user, bytesIn, uint64(statsWriter.Size()))
 	}
 }
 
@@ -46,6 +46,22 @@ const (
 	XCacheLookup = "X-Cache-Lookup"
 )
 
+// Standard HTTP CORS constants
+const (
+	Origin = "Origin"
+	Vary   = "Vary"
+
+	AccessControlRequestMethod  = "Access-Control-Request-Method"
+	AccessControlRequestHeaders = "Access-Control-Request-Headers"
+
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+)
+
 // Standard S3 HTTP request constants
 const (
 	IfModifiedSince   = "If-Modified-Since"
@@ -61,6 +77,13 @@ const (
 	AmzTagCount      = "X-Amz-Tag-Count"
 	AmzTagDirective  = "X-Amz-Tagging-Directive"
 
+	// S3 object versioning
+	AmzVersionID    = "X-Amz-Version-Id"
+	AmzDeleteMarker = "X-Amz-Delete-Marker"
+
+	// S3 bucket replication
+	AmzBucketReplicationStatus = "X-Amz-Replication-Status"
+
 	// S3 extensions
 	AmzCopySourceIfModifiedSince   = "x-amz-copy-source-if-modified-since"
 	AmzCopySourceIfUnmodifiedSince = "x-amz-copy-source-if-unmodified-since"
@@ -18,6 +18,7 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -134,8 +135,9 @@ func AddAuditTarget(t Target) {
 	AuditTargets = append(AuditTargets, t)
 }
 
-// AuditLog - logs audit logs to all audit targets.
-func AuditLog(w http.ResponseWriter, r *http.Request, api string, reqClaims map[string]interface{}) {
+// AuditLog - logs audit logs to all audit targets, with the tags
+// added to the ReqInfo of ctx while the request was handled.
+func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, api string, reqClaims map[string]interface{}) {
 	// Fast exit if there is not audit target configured
 	if len(AuditTargets) == 0 {
 		return
@@ -172,6 +174,9 @@ func AuditLog(w http.ResponseWriter, r *http.Request, api string, reqClaims map[
 	entry.API.StatusCode = statusCode
 	entry.API.TimeToFirstByte = timeToFirstByte.String()
 	entry.API.TimeToResponse = timeToResponse.String()
+	if reqInfo := GetReqInfo(ctx); reqInfo != nil {
+		entry.Tags = reqInfo.GetTagsMap()
+	}
 
 	// Send audit logs only to http targets.
 	for _, t := range AuditTargets {
@@ -17,10 +17,19 @@
 package logger
 
 import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
+	"github.com/minio/minio/pkg/event/target"
+	xnet "github.com/minio/minio/pkg/net"
 )
 
 // Console logger target
@@ -33,29 +42,151 @@ type HTTP struct {
 	Enabled   bool   `json:"enabled"`
 	Endpoint  string `json:"endpoint"`
 	AuthToken string `json:"authToken"`
+	// LogKind of the entries sent by a logger target, audit targets send all entries
+	LogKind string `json:"logKind,omitempty"`
+	// APIEvents are the names of the audited apis that are sent, all if empty
+	APIEvents  []string `json:"apiEvents,omitempty"`
+	QueueDir   string   `json:"queueDir,omitempty"`
+	QueueLimit uint64   `json:"queueLimit,omitempty"`
+}
+
+// Validate the HTTP logger target
+func (h HTTP) Validate() error {
+	if !h.Enabled {
+		return nil
+	}
+	if h.Endpoint == "" {
+		return errors.New("endpoint empty")
+	}
+	switch Kind(h.LogKind) {
+	case "", Minio, Application, All:
+	default:
+		return fmt.Errorf("invalid log kind %q, expected one of %s, %s or %s", h.LogKind, Minio, Application, All)
+	}
+	if h.QueueDir != "" && !filepath.IsAbs(h.QueueDir) {
+		return errors.New("queueDir path should be absolute")
+	}
+	return nil
+}
+
+// Kafka audit target, with the client options of the Kafka notification target
+type Kafka struct {
+	target.KafkaArgs
+	// APIEvents are the names of the audited apis that are sent, all if empty
+	APIEvents []string `json:"apiEvents,omitempty"`
+}
+
+// File audit target, a local file of json lines rotated by size and age
+type File struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+	// MaxSize in bytes and MaxAge of the file before it is rotated, never if 0
+	MaxSize uint64        `json:"maxSize,omitempty"`
+	MaxAge  time.Duration `json:"maxAge,omitempty"`
+	// MaxBackups is the number of rotated files kept, all if 0
+	MaxBackups int  `json:"maxBackups,omitempty"`
+	Compress   bool `json:"compress,omitempty"`
+	// APIEvents are the names of the audited apis that are written, all if empty
+	APIEvents []string `json:"apiEvents,omitempty"`
+}
+
+// Validate the file audit target
+func (f File) Validate() error {
+	if !f.Enabled {
+		return nil
+	}
+	if f.Path == "" {
+		return errors.New("path empty")
+	}
+	if !filepath.IsAbs(f.Path) {
+		return errors.New("path should be absolute")
+	}
+	if f.MaxBackups < 0 {
+		return errors.New("max backups should not be negative")
+	}
+	return nil
 }
 
 // Config console and http logger targets
 type Config struct {
-	Console Console         `json:"console"`
-	HTTP    map[string]HTTP `json:"http"`
-	Audit   map[string]HTTP `json:"audit"`
+	Console    Console          `json:"console"`
+	HTTP       map[string]HTTP  `json:"http"`
+	Audit      map[string]HTTP  `json:"audit"`
+	AuditKafka map[string]Kafka `json:"auditKafka,omitempty"`
+	AuditFile  map[string]File  `json:"auditFile,omitempty"`
 }
 
 // HTTP endpoint logger
 const (
-	Endpoint  = "endpoint"
-	AuthToken = "auth_token"
+	Endpoint   = "endpoint"
+	AuthToken  = "auth_token"
+	LogKind    = "log_kind"
+	APIEvents  = "api_events"
+	QueueDir   = "queue_dir"
+	QueueLimit = "queue_limit"
+
+	EnvLoggerWebhookEnable     = "MINIO_LOGGER_WEBHOOK_ENABLE"
+	EnvLoggerWebhookEndpoint   = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
+	EnvLoggerWebhookAuthToken  = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
+	EnvLoggerWebhookLogKind    = "MINIO_LOGGER_WEBHOOK_LOG_KIND"
+	EnvLoggerWebhookQueueDir   = "MINIO_LOGGER_WEBHOOK_QUEUE_DIR"
+	EnvLoggerWebhookQueueLimit = "MINIO_LOGGER_WEBHOOK_QUEUE_LIMIT"
+
+	EnvAuditWebhookEnable     = "MINIO_AUDIT_WEBHOOK_ENABLE"
+	EnvAuditWebhookEndpoint   = "MINIO_AUDIT_WEBHOOK_ENDPOINT"
+	EnvAuditWebhookAuthToken  = "MINIO_AUDIT_WEBHOOK_AUTH_TOKEN"
+	EnvAuditWebhookAPIEvents  = "MINIO_AUDIT_WEBHOOK_API_EVENTS"
+	EnvAuditWebhookQueueDir   = "MINIO_AUDIT_WEBHOOK_QUEUE_DIR"
+	EnvAuditWebhookQueueLimit = "MINIO_AUDIT_WEBHOOK_QUEUE_LIMIT"
+)
+
+// Kafka and file audit targets, the other Kafka keys are the keys of the Kafka notification target
+const (
+	Path       = "path"
+	MaxSize    = "max_size"
+	MaxAge     = "max_age"
+	MaxBackups = "max_backups"
+	Compress   = "compress"
 
-	EnvLoggerWebhookEnable    = "MINIO_LOGGER_WEBHOOK_ENABLE"
-	EnvLoggerWebhookEndpoint  = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
-	EnvLoggerWebhookAuthToken = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
+	EnvAuditKafkaEnable        = "MINIO_AUDIT_KAFKA_ENABLE"
+	EnvAuditKafkaBrokers       = "MINIO_AUDIT_KAFKA_BROKERS"
+	EnvAuditKafkaTopic         = "MINIO_AUDIT_KAFKA_TOPIC"
+	EnvAuditKafkaTLS           = "MINIO_AUDIT_KAFKA_TLS"
+	EnvAuditKafkaTLSSkipVerify = "MINIO_AUDIT_KAFKA_TLS_SKIP_VERIFY"
+	EnvAuditKafkaTLSClientAuth = "MINIO_AUDIT_KAFKA_TLS_CLIENT_AUTH"
+	EnvAuditKafkaSASLEnable    = "MINIO_AUDIT_KAFKA_SASL"
+	EnvAuditKafkaSASLUsername  = "MINIO_AUDIT_KAFKA_SASL_USERNAME"
+	EnvAuditKafkaSASLPassword  = "MINIO_AUDIT_KAFKA_SASL_PASSWORD"
+	EnvAuditKafkaSASLMechanism = "MINIO_AUDIT_KAFKA_SASL_MECHANISM"
+	EnvAuditKafkaClientTLSCert = "MINIO_AUDIT_KAFKA_CLIENT_TLS_CERT"
+	EnvAuditKafkaClientTLSKey  = "MINIO_AUDIT_KAFKA_CLIENT_TLS_KEY"
+	EnvAuditKafkaVersion       = "MINIO_AUDIT_KAFKA_VERSION"
+	EnvAuditKafkaAPIEvents     = "MINIO_AUDIT_KAFKA_API_EVENTS"
 
-	EnvAuditWebhookEnable    = "MINIO_AUDIT_WEBHOOK_ENABLE"
-	EnvAuditWebhookEndpoint  = "MINIO_AUDIT_WEBHOOK_ENDPOINT"
-	EnvAuditWebhookAuthToken = "MINIO_AUDIT_WEBHOOK_AUTH_TOKEN"
+	EnvAuditFileEnable     = "MINIO_AUDIT_FILE_ENABLE"
+	EnvAuditFilePath       = "MINIO_AUDIT_FILE_PATH"
+	EnvAuditFileMaxSize    = "MINIO_AUDIT_FILE_MAX_SIZE"
+	EnvAuditFileMaxAge     = "MINIO_AUDIT_FILE_MAX_AGE"
+	EnvAuditFileMaxBackups = "MINIO_AUDIT_FILE_MAX_BACKUPS"
+	EnvAuditFileCompress   = "MINIO_AUDIT_FILE_COMPRESS"
+	EnvAuditFileAPIEvents  = "MINIO_AUDIT_FILE_API_EVENTS"
 )
 
+// defaultAPIEvents are the audited apis that change buckets and objects
+var defaultAPIEvents = []string{
+	"PutBucket",
+	"DeleteBucket",
+	"DeleteMultipleObjects",
+	"PutObject",
+	"CopyObject",
+	"DeleteObject",
+	"NewMultipartUpload",
+	"CompleteMultipartUpload",
+	"AbortMultipartUpload",
+	"PutObjectPart",
+	"CopyObjectPart",
+}
+
 // Default KVS for loggerHTTP and loggerAuditHTTP
 var (
 	DefaultKVS = config.KVS{
@@ -71,6 +202,18 @@ var (
 			Key:   AuthToken,
 			Value: "",
 		},
+		config.KV{
+			Key:   LogKind,
+			Value: string(All),
+		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
 	}
 	DefaultAuditKVS = config.KVS{
 		config.KV{
@@ -85,6 +228,106 @@ var (
 			Key:   AuthToken,
 			Value: "",
 		},
+		config.KV{
+			Key:   APIEvents,
+			Value: strings.Join(defaultAPIEvents, ","),
+		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
+	}
+	DefaultAuditKafkaKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.KafkaTopic,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaBrokers,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSASLUsername,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSASLPassword,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSASLMechanism,
+			Value: "plain",
+		},
+		config.KV{
+			Key:   target.KafkaClientTLSCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaClientTLSKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaTLSClientAuth,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.KafkaSASL,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.KafkaTLS,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.KafkaTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.KafkaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   APIEvents,
+			Value: strings.Join(defaultAPIEvents, ","),
+		},
+	}
+	DefaultAuditFileKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Path,
+			Value: "",
+		},
+		config.KV{
+			Key:   MaxSize,
+			Value: "100MiB",
+		},
+		config.KV{
+			Key:   MaxAge,
+			Value: "24h",
+		},
+		config.KV{
+			Key:   MaxBackups,
+			Value: "10",
+		},
+		config.KV{
+			Key:   Compress,
+			Value: config.EnableOn,
+		},
+		config.KV{
+			Key:   APIEvents,
+			Value: strings.Join(defaultAPIEvents, ","),
+		},
 	}
 )
 
@@ -95,8 +338,10 @@ func NewConfig() Config {
 		Console: Console{
 			Enabled: true,
 		},
-		HTTP:  make(map[string]HTTP),
-		Audit: make(map[string]HTTP),
+		HTTP:       make(map[string]HTTP),
+		Audit:      make(map[string]HTTP),
+		AuditKafka: make(map[string]Kafka),
+		AuditFile:  make(map[string]File),
 	}
 
 	// Create an example HTTP logger
@@ -138,6 +383,7 @@ func lookupLegacyConfig() (Config, error) {
 		cfg.HTTP[target] = HTTP{
 			Enabled:  true,
 			Endpoint: endpoint,
+			LogKind:  string(All),
 		}
 	}
 
@@ -162,8 +408,9 @@ func lookupLegacyConfig() (Config, error) {
 			continue
 		}
 		cfg.Audit[target] = HTTP{
-			Enabled:  true,
-			Endpoint: endpoint,
+			Enabled:   true,
+			Endpoint:  endpoint,
+			APIEvents: defaultAPIEvents,
 		}
 	}
 
@@ -222,11 +469,22 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if target != config.Default {
 			authTokenEnv = EnvLoggerWebhookAuthToken + config.Default + target
 		}
-		cfg.HTTP[target] = HTTP{
-			Enabled:   true,
-			Endpoint:  env.Get(endpointEnv, ""),
-			AuthToken: env.Get(authTokenEnv, ""),
+		queueLimit, err := parseQueueLimit(env.Get(targetEnv(EnvLoggerWebhookQueueLimit, target), ""))
+		if err != nil {
+			return cfg, err
 		}
+		l := HTTP{
+			Enabled:    true,
+			Endpoint:   env.Get(endpointEnv, ""),
+			AuthToken:  env.Get(authTokenEnv, ""),
+			LogKind:    strings.ToUpper(env.Get(targetEnv(EnvLoggerWebhookLogKind, target), string(All))),
+			QueueDir:   env.Get(targetEnv(EnvLoggerWebhookQueueDir, target), ""),
+			QueueLimit: queueLimit,
+		}
+		if err = l.Validate(); err != nil {
+			return cfg, err
+		}
+		cfg.HTTP[target] = l
 	}
 
 	for _, target := range loggerAuditTargets {
@@ -251,11 +509,22 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if target != config.Default {
 			authTokenEnv = EnvAuditWebhookAuthToken + config.Default + target
 		}
-		cfg.Audit[target] = HTTP{
-			Enabled:   true,
-			Endpoint:  env.Get(endpointEnv, ""),
-			AuthToken: env.Get(authTokenEnv, ""),
+		queueLimit, err := parseQueueLimit(env.Get(targetEnv(EnvAuditWebhookQueueLimit, target), ""))
+		if err != nil {
+			return cfg, err
+		}
+		l := HTTP{
+			Enabled:    true,
+			Endpoint:   env.Get(endpointEnv, ""),
+			AuthToken:  env.Get(authTokenEnv, ""),
+			APIEvents:  parseAPIEvents(env.Get(targetEnv(EnvAuditWebhookAPIEvents, target), DefaultAuditKVS.Get(APIEvents))),
+			QueueDir:   env.Get(targetEnv(EnvAuditWebhookQueueDir, target), ""),
+			QueueLimit: queueLimit,
+		}
+		if err = l.Validate(); err != nil {
+			return cfg, err
 		}
+		cfg.Audit[target] = l
 	}
 
 	for starget, kv := range scfg[config.LoggerWebhookSubSys] {
@@ -279,11 +548,22 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if !enabled {
 			continue
 		}
-		cfg.HTTP[starget] = HTTP{
-			Enabled:   true,
-			Endpoint:  kv.Get(Endpoint),
-			AuthToken: kv.Get(AuthToken),
+		queueLimit, err := parseQueueLimit(lookupKV(kv, DefaultKVS, QueueLimit))
+		if err != nil {
+			return cfg, err
+		}
+		l := HTTP{
+			Enabled:    true,
+			Endpoint:   kv.Get(Endpoint),
+			AuthToken:  kv.Get(AuthToken),
+			LogKind:    strings.ToUpper(lookupKV(kv, DefaultKVS, LogKind)),
+			QueueDir:   kv.Get(QueueDir),
+			QueueLimit: queueLimit,
+		}
+		if err = l.Validate(); err != nil {
+			return cfg, err
 		}
+		cfg.HTTP[starget] = l
 	}
 
 	for starget, kv := range scfg[config.AuditWebhookSubSys] {
@@ -307,12 +587,205 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if !enabled {
 			continue
 		}
-		cfg.Audit[starget] = HTTP{
-			Enabled:   true,
-			Endpoint:  kv.Get(Endpoint),
-			AuthToken: kv.Get(AuthToken),
+		queueLimit, err := parseQueueLimit(lookupKV(kv, DefaultAuditKVS, QueueLimit))
+		if err != nil {
+			return cfg, err
+		}
+		l := HTTP{
+			Enabled:    true,
+			Endpoint:   kv.Get(Endpoint),
+			AuthToken:  kv.Get(AuthToken),
+			APIEvents:  parseAPIEvents(lookupKV(kv, DefaultAuditKVS, APIEvents)),
+			QueueDir:   kv.Get(QueueDir),
+			QueueLimit: queueLimit,
+		}
+		if err = l.Validate(); err != nil {
+			return cfg, err
 		}
+		cfg.Audit[starget] = l
+	}
+
+	if cfg.AuditKafka, err = lookupAuditKafkaConfig(scfg[config.AuditKafkaSubSys]); err != nil {
+		return cfg, err
+	}
+	if cfg.AuditFile, err = lookupAuditFileConfig(scfg[config.AuditFileSubSys]); err != nil {
+		return cfg, err
 	}
 
 	return cfg, nil
 }
+
+// mergeTargets returns the configured targets, and the targets enabled
+// in the environment with the default configuration.
+func mergeTargets(cfgTargets map[string]config.KVS, enableEnv string, defaultKVS config.KVS) map[string]config.KVS {
+	targets := make(map[string]config.KVS)
+	for _, e := range env.List(enableEnv) {
+		tgt := strings.TrimPrefix(e, enableEnv+config.Default)
+		if tgt == enableEnv {
+			tgt = config.Default
+		}
+		targets[tgt] = defaultKVS
+	}
+	for tgt, kv := range cfgTargets {
+		targets[tgt] = kv
+	}
+	return targets
+}
+
+// lookupAuditKafkaConfig - lookup the Kafka audit targets, override with ENVs if set.
+func lookupAuditKafkaConfig(kvs map[string]config.KVS) (map[string]Kafka, error) {
+	targets := make(map[string]Kafka)
+	for starget, kv := range mergeTargets(kvs, EnvAuditKafkaEnable, DefaultAuditKafkaKVS) {
+		subSysTarget := config.AuditKafkaSubSys
+		if starget != config.Default {
+			subSysTarget = config.AuditKafkaSubSys + config.SubSystemSeparator + starget
+		}
+		if err := config.CheckValidKeys(subSysTarget, kv, DefaultAuditKafkaKVS); err != nil {
+			return nil, err
+		}
+		get := func(envName, key string) string {
+			return env.Get(targetEnv(envName, starget), lookupKV(kv, DefaultAuditKafkaKVS, key))
+		}
+		enabled, err := config.ParseBool(get(EnvAuditKafkaEnable, config.Enable))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		k := Kafka{
+			APIEvents: parseAPIEvents(get(EnvAuditKafkaAPIEvents, APIEvents)),
+		}
+		k.Enable = true
+		brokers := get(EnvAuditKafkaBrokers, target.KafkaBrokers)
+		if brokers == "" {
+			return nil, config.Errorf("kafka 'brokers' cannot be empty")
+		}
+		for _, b := range strings.Split(brokers, config.ValueSeparator) {
+			host, err := xnet.ParseHost(b)
+			if err != nil {
+				return nil, err
+			}
+			k.Brokers = append(k.Brokers, *host)
+		}
+		if k.Topic = get(EnvAuditKafkaTopic, target.KafkaTopic); k.Topic == "" {
+			return nil, config.Errorf("kafka 'topic' cannot be empty")
+		}
+		k.Version = get(EnvAuditKafkaVersion, target.KafkaVersion)
+
+		clientAuth, err := strconv.Atoi(get(EnvAuditKafkaTLSClientAuth, target.KafkaTLSClientAuth))
+		if err != nil {
+			return nil, err
+		}
+		k.TLS.Enable = get(EnvAuditKafkaTLS, target.KafkaTLS) == config.EnableOn
+		k.TLS.SkipVerify = get(EnvAuditKafkaTLSSkipVerify, target.KafkaTLSSkipVerify) == config.EnableOn
+		k.TLS.ClientAuth = tls.ClientAuthType(clientAuth)
+		k.TLS.ClientTLSCert = get(EnvAuditKafkaClientTLSCert, target.KafkaClientTLSCert)
+		k.TLS.ClientTLSKey = get(EnvAuditKafkaClientTLSKey, target.KafkaClientTLSKey)
+
+		k.SASL.Enable = get(EnvAuditKafkaSASLEnable, target.KafkaSASL) == config.EnableOn
+		k.SASL.User = get(EnvAuditKafkaSASLUsername, target.KafkaSASLUsername)
+		k.SASL.Password = get(EnvAuditKafkaSASLPassword, target.KafkaSASLPassword)
+		k.SASL.Mechanism = get(EnvAuditKafkaSASLMechanism, target.KafkaSASLMechanism)
+
+		if err = k.Validate(); err != nil {
+			return nil, err
+		}
+		targets[starget] = k
+	}
+	return targets, nil
+}
+
+// lookupAuditFileConfig - lookup the file audit targets, override with ENVs if set.
+func lookupAuditFileConfig(kvs map[string]config.KVS) (map[string]File, error) {
+	targets := make(map[string]File)
+	for starget, kv := range mergeTargets(kvs, EnvAuditFileEnable, DefaultAuditFileKVS) {
+		subSysTarget := config.AuditFileSubSys
+		if starget != config.Default {
+			subSysTarget = config.AuditFileSubSys + config.SubSystemSeparator + starget
+		}
+		if err := config.CheckValidKeys(subSysTarget, kv, DefaultAuditFileKVS); err != nil {
+			return nil, err
+		}
+		get := func(envName, key string) string {
+			return env.Get(targetEnv(envName, starget), lookupKV(kv, DefaultAuditFileKVS, key))
+		}
+		enabled, err := config.ParseBool(get(EnvAuditFileEnable, config.Enable))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		f := File{
+			Enabled:   true,
+			Path:      get(EnvAuditFilePath, Path),
+			APIEvents: parseAPIEvents(get(EnvAuditFileAPIEvents, APIEvents)),
+		}
+		if v := get(EnvAuditFileMaxSize, MaxSize); v != "" {
+			if f.MaxSize, err = humanize.ParseBytes(v); err != nil {
+				return nil, fmt.Errorf("invalid max size %q: %w", v, err)
+			}
+		}
+		if v := get(EnvAuditFileMaxAge, MaxAge); v != "" {
+			if f.MaxAge, err = time.ParseDuration(v); err != nil {
+				return nil, fmt.Errorf("invalid max age %q: %w", v, err)
+			}
+		}
+		if v := get(EnvAuditFileMaxBackups, MaxBackups); v != "" {
+			if f.MaxBackups, err = strconv.Atoi(v); err != nil {
+				return nil, fmt.Errorf("invalid max backups %q: %w", v, err)
+			}
+		}
+		if f.Compress, err = config.ParseBool(get(EnvAuditFileCompress, Compress)); err != nil {
+			return nil, err
+		}
+
+		if err = f.Validate(); err != nil {
+			return nil, err
+		}
+		targets[starget] = f
+	}
+	return targets, nil
+}
+
+// targetEnv returns the name of an environment variable of a target
+func targetEnv(name, target string) string {
+	if target == config.Default {
+		return name
+	}
+	return name + config.Default + target
+}
+
+// lookupKV returns the value of a key, or its default value if the
+// key is missing from a configuration saved before it existed.
+func lookupKV(kv, defaults config.KVS, key string) string {
+	if v, ok := kv.Lookup(key); ok {
+		return v
+	}
+	return defaults.Get(key)
+}
+
+// parseAPIEvents parses a comma separated list of api names
+func parseAPIEvents(s string) []string {
+	var events []string
+	for _, event := range strings.Split(s, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func parseQueueLimit(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	limit, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid queue limit %q: %w", s, err)
+	}
+	return limit, nil
+}
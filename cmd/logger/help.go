@@ -16,7 +16,10 @@
 
 package logger
 
-import "github.com/minio/minio/cmd/config"
+import (
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/event/target"
+)
 
 // Help template for logger http and audit
 var (
@@ -32,6 +35,24 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         LogKind,
+			Description: `kind of log entries to send, one of 'MINIO', 'APPLICATION' or 'ALL', defaults to 'ALL'`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for undelivered log entries e.g. '/home/logs'`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueLimit,
+			Description: `maximum limit for undelivered log entries, defaults to '100000'`,
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -52,6 +73,153 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         APIEvents,
+			Description: `comma separated list of audited API names to send, all if empty e.g. "PutObject,DeleteObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for undelivered log entries e.g. '/home/logs'`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueLimit,
+			Description: `maximum limit for undelivered log entries, defaults to '100000'`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpAuditKafka = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.KafkaBrokers,
+			Description: "comma separated list of Kafka broker addresses",
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         target.KafkaTopic,
+			Description: "Kafka topic used for audit entries",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSASLUsername,
+			Description: "username for SASL/PLAIN or SASL/SCRAM authentication",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSASLPassword,
+			Description: "password for SASL/PLAIN or SASL/SCRAM authentication",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSASLMechanism,
+			Description: "sasl authentication mechanism, one of 'plain', 'sha256' or 'sha512', default 'plain'",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.KafkaTLSClientAuth,
+			Description: "clientAuth determines the Kafka server's policy for TLS client auth",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSASL,
+			Description: "set to 'on' to enable SASL authentication",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.KafkaTLS,
+			Description: "set to 'on' to enable TLS",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.KafkaTLSSkipVerify,
+			Description: `trust server TLS without verification, defaults to "off" (verify)`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.KafkaClientTLSCert,
+			Description: "path to client certificate for mTLS auth",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.KafkaClientTLSKey,
+			Description: "path to client key for mTLS auth",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.KafkaVersion,
+			Description: "specify the version of the Kafka cluster",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         APIEvents,
+			Description: `comma separated list of audited API names to send, all if empty e.g. "PutObject,DeleteObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpAuditFile = config.HelpKVS{
+		config.HelpKV{
+			Key:         Path,
+			Description: `absolute path of the audit file e.g. "/var/log/minio/audit.log"`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         MaxSize,
+			Description: `size the audit file is rotated at, never if empty, defaults to '100MiB'`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         MaxAge,
+			Description: `age the audit file is rotated at e.g. "1h", never if empty, defaults to '24h'`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         MaxBackups,
+			Description: `number of rotated audit files kept, all if 0, defaults to '10'`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Compress,
+			Description: `set to 'on' to compress the rotated audit files with gzip, defaults to 'on'`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         APIEvents,
+			Description: `comma separated list of audited API names to write, all if empty e.g. "PutObject,DeleteObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
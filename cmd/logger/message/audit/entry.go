@@ -28,6 +28,19 @@ import (
 // Version - represents the current version of audit log structure.
 const Version = "1"
 
+// Tags added to the entry of a request by the object layer, through the
+// tags of the logger.ReqInfo of the request context.
+const (
+	// TagIpfsHash is the IPFS hash of the object after the request
+	TagIpfsHash = "ipfsHash"
+	// TagIpfsDataHash is the IPFS hash of the data of the object after the request
+	TagIpfsDataHash = "ipfsDataHash"
+	// TagIpfsBucketHash is the IPFS hash of the bucket after the request
+	TagIpfsBucketHash = "ipfsBucketHash"
+	// TagErrorCode is the S3 error code of the response to the request
+	TagErrorCode = "errorCode"
+)
+
 // Entry - audit entry logs.
 type Entry struct {
 	Version      string `json:"version"`
@@ -45,10 +58,15 @@ type Entry struct {
 	RemoteHost string                 `json:"remotehost,omitempty"`
 	RequestID  string                 `json:"requestID,omitempty"`
 	UserAgent  string                 `json:"userAgent,omitempty"`
+	ReqMethod  string                 `json:"requestMethod,omitempty"`
+	ReqURI     string                 `json:"requestURI,omitempty"`
+	ReqProto   string                 `json:"requestProto,omitempty"`
+	ReqHost    string                 `json:"requestHost,omitempty"`
 	ReqClaims  map[string]interface{} `json:"requestClaims,omitempty"`
 	ReqQuery   map[string]string      `json:"requestQuery,omitempty"`
 	ReqHeader  map[string]string      `json:"requestHeader,omitempty"`
 	RespHeader map[string]string      `json:"responseHeader,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
 }
 
 // ToEntry - constructs an audit entry object.
@@ -73,6 +91,10 @@ func ToEntry(w http.ResponseWriter, r *http.Request, reqClaims map[string]interf
 		RemoteHost:   handlers.GetSourceIP(r),
 		RequestID:    w.Header().Get(xhttp.AmzRequestID),
 		UserAgent:    r.UserAgent(),
+		ReqMethod:    r.Method,
+		ReqURI:       r.RequestURI,
+		ReqProto:     r.Proto,
+		ReqHost:      r.Host,
 		Time:         time.Now().UTC().Format(time.RFC3339Nano),
 		ReqQuery:     reqQuery,
 		ReqHeader:    reqHeader,
@@ -82,3 +104,20 @@ func ToEntry(w http.ResponseWriter, r *http.Request, reqClaims map[string]interf
 
 	return entry
 }
+
+// Matches returns whether an entry is sent to a target configured with these
+// api names, only successful calls are sent, of all apis if apiEvents is empty.
+func (e Entry) Matches(apiEvents []string) bool {
+	if e.API.StatusCode == 0 || e.API.StatusCode > 299 {
+		return false
+	}
+	if len(apiEvents) == 0 {
+		return true
+	}
+	for _, name := range apiEvents {
+		if name == e.API.Name {
+			return true
+		}
+	}
+	return false
+}
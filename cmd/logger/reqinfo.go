@@ -80,9 +80,9 @@ func (r *ReqInfo) SetTags(key string, val string) *ReqInfo {
 	defer r.Unlock()
 	// Search of tag key already exists in tags
 	var updated bool
-	for _, tag := range r.tags {
-		if tag.Key == key {
-			tag.Val = val
+	for i := range r.tags {
+		if r.tags[i].Key == key {
+			r.tags[i].Val = val
 			updated = true
 			break
 		}
@@ -104,6 +104,23 @@ func (r *ReqInfo) GetTags() []KeyVal {
 	return append([]KeyVal(nil), r.tags...)
 }
 
+// GetTagsMap - returns the user defined tags as a map, the last value of a key wins
+func (r *ReqInfo) GetTagsMap() map[string]string {
+	if r == nil {
+		return nil
+	}
+	r.RLock()
+	defer r.RUnlock()
+	if len(r.tags) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(r.tags))
+	for _, t := range r.tags {
+		m[t.Key] = t.Val
+	}
+	return m
+}
+
 // SetReqInfo sets ReqInfo in the context.
 func SetReqInfo(ctx context.Context, req *ReqInfo) context.Context {
 	if ctx == nil {
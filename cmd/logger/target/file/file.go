@@ -0,0 +1,300 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger/message/audit"
+)
+
+const (
+	// backupTimeFormat is the time a file was rotated, in the names of the backups
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressExt      = ".gz"
+)
+
+// Target implements logger.Target and appends the json format of the
+// audit entries, one per line, to a local file. The file is rotated
+// when it reaches a size or an age, and the rotated files are kept
+// with the time of their rotation in their name, compressed if set.
+// Entries are kept in an internal buffer until they are written, when
+// the buffer is full new entries are ignored and an error is returned
+// to the caller.
+type Target struct {
+	logCh chan audit.Entry
+
+	path string
+	// maxSize is the size in bytes a file is rotated at, never if 0
+	maxSize int64
+	// maxAge is the age a file is rotated at, never if 0
+	maxAge time.Duration
+	// maxBackups is the number of rotated files kept, all if 0
+	maxBackups int
+	compress   bool
+	// names of the audited apis that are written, all if empty
+	apiEvents []string
+
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// Option is a function type that accepts a pointer Target
+type Option func(*Target)
+
+// WithMaxSize rotates the file when it reaches size bytes.
+func WithMaxSize(size int64) Option {
+	return func(t *Target) {
+		t.maxSize = size
+	}
+}
+
+// WithMaxAge rotates the file when it was opened age ago.
+func WithMaxAge(age time.Duration) Option {
+	return func(t *Target) {
+		t.maxAge = age
+	}
+}
+
+// WithMaxBackups removes the oldest rotated files beyond count.
+func WithMaxBackups(count int) Option {
+	return func(t *Target) {
+		t.maxBackups = count
+	}
+}
+
+// WithCompress compresses the rotated files with gzip.
+func WithCompress(compress bool) Option {
+	return func(t *Target) {
+		t.compress = compress
+	}
+}
+
+// WithAPIEvents limits the audit entries written to these api names.
+func WithAPIEvents(apiEvents []string) Option {
+	return func(t *Target) {
+		t.apiEvents = apiEvents
+	}
+}
+
+// New initializes a new audit target which writes the entries to the file
+// at path, the entries written by a previous run are appended to.
+func New(path string, opts ...Option) (*Target, error) {
+	if !filepath.IsAbs(path) {
+		return nil, errors.New("audit file path should be absolute")
+	}
+	t := &Target{
+		logCh: make(chan audit.Entry, 10000),
+		path:  path,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0770); err != nil {
+		return nil, err
+	}
+	if err := t.open(); err != nil {
+		return nil, err
+	}
+	go func() {
+		for entry := range t.logCh {
+			if err := t.write(entry); err != nil {
+				log.Printf("unable to write audit entry to %s: %v", t.path, err)
+			}
+		}
+	}()
+	return t, nil
+}
+
+// open opens the file for appending, its age is counted from
+// its modification time if it was left by a previous run.
+func (t *Target) open() error {
+	f, err := os.OpenFile(t.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	t.file = f
+	t.size = fi.Size()
+	t.opened = time.Now()
+	if t.size > 0 {
+		t.opened = fi.ModTime()
+	}
+	return nil
+}
+
+// write appends an entry to the file, rotating it first if it is full or too old.
+func (t *Target) write(entry audit.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	full := t.maxSize > 0 && t.size > 0 && t.size+int64(len(data)) > t.maxSize
+	old := t.maxAge > 0 && time.Since(t.opened) >= t.maxAge
+	if t.file == nil || full || old {
+		if err = t.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := t.file.Write(data)
+	t.size += int64(n)
+	return err
+}
+
+// rotate renames the file to a backup and opens a new file.
+func (t *Target) rotate() error {
+	if t.file != nil {
+		if err := t.file.Close(); err != nil {
+			return err
+		}
+		t.file = nil
+		if t.size > 0 {
+			backup := t.backupName(time.Now())
+			if err := os.Rename(t.path, backup); err != nil {
+				return err
+			}
+			if t.compress {
+				if err := compressFile(backup); err != nil {
+					log.Printf("unable to compress audit file %s: %v", backup, err)
+				}
+			}
+			t.removeBackups()
+		}
+	}
+	return t.open()
+}
+
+// backupName returns the name of the file rotated at now, the time is moved
+// forward if a file was already rotated at the same time.
+func (t *Target) backupName(now time.Time) string {
+	ext := filepath.Ext(t.path)
+	for {
+		backup := strings.TrimSuffix(t.path, ext) + "-" + now.UTC().Format(backupTimeFormat) + ext
+		if _, err := os.Stat(backup); os.IsNotExist(err) {
+			if _, err = os.Stat(backup + compressExt); os.IsNotExist(err) {
+				return backup
+			}
+		}
+		now = now.Add(time.Millisecond)
+	}
+}
+
+// backups returns the rotated files, oldest first.
+func (t *Target) backups() ([]string, error) {
+	ext := filepath.Ext(t.path)
+	prefix := filepath.Base(strings.TrimSuffix(t.path, ext)) + "-"
+	files, err := ioutil.ReadDir(filepath.Dir(t.path))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), compressExt), ext)
+		if _, err := time.Parse(backupTimeFormat, ts); err != nil {
+			continue
+		}
+		names = append(names, filepath.Join(filepath.Dir(t.path), name))
+	}
+	// the names sort by rotation time, with or without compression extension
+	sort.Slice(names, func(i, j int) bool {
+		return strings.TrimSuffix(names[i], compressExt) < strings.TrimSuffix(names[j], compressExt)
+	})
+	return names, nil
+}
+
+// removeBackups removes the oldest rotated files beyond maxBackups.
+func (t *Target) removeBackups() {
+	if t.maxBackups <= 0 {
+		return
+	}
+	names, err := t.backups()
+	if err != nil {
+		log.Printf("unable to list audit files of %s: %v", t.path, err)
+		return
+	}
+	for len(names) > t.maxBackups {
+		if err = os.Remove(names[0]); err != nil {
+			log.Printf("unable to remove audit file %s: %v", names[0], err)
+		}
+		names = names[1:]
+	}
+}
+
+// compressFile replaces a file by its gzip compressed copy.
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+compressExt, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err == nil {
+		err = zw.Close()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(name + compressExt)
+		return err
+	}
+	return os.Remove(name)
+}
+
+// Send an audit entry to the file, other log entries are ignored.
+func (t *Target) Send(entry interface{}, errKind string) error {
+	e, ok := entry.(audit.Entry)
+	if !ok || !e.Matches(t.apiEvents) {
+		return nil
+	}
+
+	select {
+	case t.logCh <- e:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/logger/message/audit"
+)
+
+func testEntry(i int) audit.Entry {
+	var e audit.Entry
+	e.API.Name = "PutObject"
+	e.API.Bucket = "bucket"
+	e.API.Object = "object" + strconv.Itoa(i)
+	e.API.StatusCode = 200
+	return e
+}
+
+// readEntries returns the objects of the entries of a file, compressed or not
+func readEntries(t *testing.T, name string) []string {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(name, compressExt) {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = zr
+	}
+	var objects []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var e audit.Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, e.API.Object)
+	}
+	return objects
+}
+
+func TestTargetRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data, _ := json.Marshal(testEntry(0))
+	path := filepath.Join(dir, "audit.log")
+	// two entries per file
+	target, err := New(path, WithMaxSize(int64(2*len(data)+2)), WithMaxBackups(2), WithCompress(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 7; i++ {
+		if err = target.write(testEntry(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if objects := readEntries(t, path); len(objects) != 1 || objects[0] != "object6" {
+		t.Fatalf("unexpected entries in the audit file: %v", objects)
+	}
+	backups, err := target.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %v", backups)
+	}
+	for i, backup := range backups {
+		if !strings.HasSuffix(backup, ".log"+compressExt) {
+			t.Fatalf("backup %s is not compressed", backup)
+		}
+		objects := readEntries(t, backup)
+		expected := []string{"object" + strconv.Itoa(2+2*i), "object" + strconv.Itoa(3+2*i)}
+		if strings.Join(objects, ",") != strings.Join(expected, ",") {
+			t.Fatalf("expected entries %v in %s, got %v", expected, backup, objects)
+		}
+	}
+}
+
+func TestTargetRotateAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	target, err := New(path, WithMaxAge(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = target.write(testEntry(0)); err != nil {
+		t.Fatal(err)
+	}
+	target.opened = target.opened.Add(-time.Hour)
+	if err = target.write(testEntry(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := target.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup, got %v", backups)
+	}
+	if objects := readEntries(t, backups[0]); len(objects) != 1 || objects[0] != "object0" {
+		t.Fatalf("unexpected entries in the backup: %v", objects)
+	}
+	if objects := readEntries(t, path); len(objects) != 1 || objects[0] != "object1" {
+		t.Fatalf("unexpected entries in the audit file: %v", objects)
+	}
+}
+
+func TestTargetSendFilter(t *testing.T) {
+	target := &Target{logCh: make(chan audit.Entry, 3), apiEvents: []string{"PutObject"}}
+
+	failed := testEntry(0)
+	failed.API.StatusCode = 404
+	deleted := testEntry(1)
+	deleted.API.Name = "DeleteObject"
+	for _, entry := range []interface{}{testEntry(2), failed, deleted, "not an audit entry"} {
+		if err := target.Send(entry, "ALL"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(target.logCh) != 1 {
+		t.Fatalf("expected 1 entry to be sent, got %d", len(target.logCh))
+	}
+}
@@ -20,46 +20,44 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/queuestore"
 )
 
 const (
 	IpfsHashHeader      = "CONTENT-IPFS-HASH"
 	IpfsHashContentType = "IPFS-CONTENT-TYPE"
-	InfoEndpoint        = "http://localhost:8889/info?"
-)
 
-var (
-	supportedApiEvents = []string{
-		"PutBucket",
-		"DeleteBucket",
-		"DeleteMultipleObjects",
-		"PutObject",
-		"CopyObject",
-		"DeleteObject",
-		"NewMultipartUpload",
-		// not sure about these below
-		"CompleteMultipartUpload",
-		"AbortMultipartUpload",
-		"PutObjectPart",
-		"CopyObjectPart",
-	}
+	// entryExt is the extension of the files of the queued entries
+	entryExt = ".entry"
+
+	// minRetryBackoff is the time before an entry is sent again, doubled on every failure
+	minRetryBackoff = time.Second
+	// maxRetryBackoff is the longest time between attempts to send an entry
+	maxRetryBackoff = time.Minute
 )
 
 // Target implements logger.Target and sends the json
 // format of a log entry to the configured http endpoint.
-// An internal buffer of logs is maintained but when the
-// buffer is full, new logs are just ignored and an error
-// is returned to the caller.
+// Entries are kept in an internal buffer, or in a queue
+// directory if one is configured, until they are sent, and
+// are sent again with backoff while the endpoint is down.
+// When the buffer or queue is full, new logs are ignored
+// and an error is returned to the caller.
 type Target struct {
-	// Channel of log entries
-	logCh chan interface{}
+	// Channel of log entries, unless they are queued in store
+	logCh chan queuedEntry
+	// Persistent queue of log entries
+	store *queuestore.QueueStore
+	// Signals the sender that entries were queued in store
+	queuedCh chan struct{}
 
 	// HTTP(s) endpoint
 	endpoint string
@@ -68,72 +66,131 @@ type Target struct {
 	// User-Agent to be set on each log to `endpoint`
 	userAgent string
 	logKind   string
-	client    http.Client
+	// names of the audited apis that are sent, all if empty
+	apiEvents  []string
+	queueDir   string
+	queueLimit uint64
+	client     http.Client
 }
 
-// FLEEK ADDED LOGGING TYPES ****
-
-type ipfsInfoResponse struct {
-	Bucket string `json:"bucket,omitempty"`
-	Object string `json:"object,omitempty"`
-	Hash   string `json:"hash,omitempty"`
-}
-
-type hashResponseHelper struct {
-	Hash            string
-	IpfsContentType string
-}
-
-type logFormattedEntry struct {
-	Entry           interface{} `json:"entry,omitempty"`
-	IpfsHash        string      `json:"hash,omitempty"`
-	IpfsContentType string      `json:"contentType,omitempty"`
+// queuedEntry is a log entry waiting to be sent. Audit entries are
+// kept typed so the ipfs hashes in their tags can be sent as headers.
+type queuedEntry struct {
+	Audit *audit.Entry    `json:"audit,omitempty"`
+	Log   json.RawMessage `json:"log,omitempty"`
 }
 
 func (h *Target) startHTTPLogger() {
 	// Create a routine which sends json logs received
-	// from an internal channel.
+	// from an internal channel, or queued in the store.
 	go func() {
-		for entry := range h.logCh {
-			logJSON, err := json.Marshal(&entry)
-			if err != nil {
-				continue
+		if h.store == nil {
+			for entry := range h.logCh {
+				h.deliver(entry)
 			}
+			return
+		}
+		for {
+			// entries left by a previous run are sent first
+			h.sendQueued()
+			<-h.queuedCh
+		}
+	}()
+}
 
-			req, err := http.NewRequest(http.MethodPost, h.endpoint, bytes.NewReader(logJSON))
-			if err != nil {
-				continue
-			}
-			req.Header.Set(xhttp.ContentType, "application/json")
+// sendQueued sends the entries of the store, oldest first
+func (h *Target) sendQueued() {
+	keys, err := h.store.Keys()
+	if err != nil {
+		log.Println("unable to list queued log entries: " + err.Error())
+		return
+	}
+	for _, key := range keys {
+		var entry queuedEntry
+		if err := h.store.Get(key, &entry); err != nil {
+			log.Println("unable to read queued log entry: " + err.Error())
+			continue
+		}
+		h.deliver(entry)
+		if err := h.store.Del(key); err != nil {
+			log.Println("unable to remove queued log entry: " + err.Error())
+		}
+	}
+}
 
-			// Set user-agent to indicate MinIO release
-			// version to the configured log endpoint
-			req.Header.Set("User-Agent", h.userAgent)
+// deliver sends an entry, it retries with backoff until the endpoint accepted or rejected it
+func (h *Target) deliver(entry queuedEntry) {
+	wait := minRetryBackoff
+	for {
+		err := h.send(entry)
+		if err == nil {
+			return
+		}
+		if wait == minRetryBackoff {
+			// only the first failure is logged, until the endpoint is back
+			log.Printf("unable to send log entry to %s, retrying: %v", h.endpoint, err)
+		}
+		time.Sleep(wait)
+		if wait *= 2; wait > maxRetryBackoff {
+			wait = maxRetryBackoff
+		}
+	}
+}
 
-			if h.authToken != "" {
-				req.Header.Set("Authorization", h.authToken)
-			}
+// send posts an entry to the endpoint, it returns an error if the entry should be sent again.
+func (h *Target) send(entry queuedEntry) error {
+	logJSON := []byte(entry.Log)
+	if entry.Audit != nil {
+		var err error
+		if logJSON, err = json.Marshal(entry.Audit); err != nil {
+			log.Println("unable to marshal log entry: " + err.Error())
+			return nil
+		}
+	}
 
-			// add ipfshash to headers
-			infoRes, err := h.getHashFromEntry(entry)
-			if err == nil {
-				req.Header.Set(IpfsHashHeader, infoRes.Hash)
-				req.Header.Set(IpfsHashContentType, infoRes.IpfsContentType)
-				if  err := logEntry(entry, infoRes.Hash, infoRes.IpfsContentType); err != nil {
-					log.Println("unable to log entry for fleek event: " + string(logJSON))
-				}
-			}
+	req, err := http.NewRequest(http.MethodPost, h.endpoint, bytes.NewReader(logJSON))
+	if err != nil {
+		log.Println("unable to build log request: " + err.Error())
+		return nil
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
 
-			resp, err := h.client.Do(req)
-			if err != nil {
-				h.client.CloseIdleConnections()
-				continue
-			}
+	// Set user-agent to indicate MinIO release
+	// version to the configured log endpoint
+	req.Header.Set("User-Agent", h.userAgent)
 
-			// Drain any response.
-			xhttp.DrainBody(resp.Body)
+	if h.authToken != "" {
+		req.Header.Set("Authorization", h.authToken)
+	}
+
+	if entry.Audit != nil {
+		// add the ipfs hash of the object, or of the bucket, to the headers
+		if hash := entry.Audit.Tags[audit.TagIpfsHash]; hash != "" {
+			req.Header.Set(IpfsHashHeader, hash)
+			req.Header.Set(IpfsHashContentType, "Object")
+		} else if hash := entry.Audit.Tags[audit.TagIpfsBucketHash]; hash != "" {
+			req.Header.Set(IpfsHashHeader, hash)
+			req.Header.Set(IpfsHashContentType, "Bucket")
 		}
-	}()
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		h.client.CloseIdleConnections()
+		return err
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("sending log entry failed with %v", resp.Status)
+	case resp.StatusCode >= http.StatusBadRequest:
+		// sending the same entry again would be rejected as well
+		log.Printf("log entry rejected by %s with %v", h.endpoint, resp.Status)
+	}
+	return nil
 }
 
 // Option is a function type that accepts a pointer Target
@@ -176,12 +233,27 @@ func WithTransport(transport *http.Transport) Option {
 	}
 }
 
+// WithAPIEvents limits the audit entries sent to the target to
+// these api names, all successful api calls are sent if empty.
+func WithAPIEvents(apiEvents []string) Option {
+	return func(t *Target) {
+		t.apiEvents = apiEvents
+	}
+}
+
+// WithQueueDir queues the entries in a directory until they are sent,
+// at most limit entries, or a default limit if it is 0.
+func WithQueueDir(queueDir string, limit uint64) Option {
+	return func(t *Target) {
+		t.queueDir = queueDir
+		t.queueLimit = limit
+	}
+}
+
 // New initializes a new logger target which
 // sends log over http to the specified endpoint
-func New(opts ...Option) *Target {
-	h := &Target{
-		logCh: make(chan interface{}, 10000),
-	}
+func New(opts ...Option) (*Target, error) {
+	h := &Target{}
 
 	// Loop through each option
 	for _, opt := range opts {
@@ -190,23 +262,47 @@ func New(opts ...Option) *Target {
 		opt(h)
 	}
 
+	if h.queueDir != "" {
+		h.store = queuestore.New(h.queueDir, entryExt, h.queueLimit)
+		if err := h.store.Open(); err != nil {
+			return nil, err
+		}
+		h.queuedCh = make(chan struct{}, 1)
+	} else {
+		h.logCh = make(chan queuedEntry, 10000)
+	}
+
 	h.startHTTPLogger()
-	return h
+	return h, nil
 }
 
 // Send log message 'e' to http target.
 func (h *Target) Send(entry interface{}, errKind string) error {
-	/*	if h.logKind != errKind && h.logKind != "ALL" {
+	if h.logKind != errKind && h.logKind != "ALL" {
 		return nil
-	}*/
-	var ok bool
-	var entryStruct *audit.Entry
-	if entryStruct, ok = checkEntry(entry); !ok {
+	}
+	e, ok, err := h.checkEntry(entry)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	if h.store != nil {
+		if _, err := h.store.Put(e); err != nil {
+			return err
+		}
+		select {
+		case h.queuedCh <- struct{}{}:
+		default:
+			// the sender was already signaled
+		}
 		return nil
 	}
 
 	select {
-	case h.logCh <- entryStruct:
+	case h.logCh <- e:
 	default:
 		// log channel is full, do not wait and return
 		// an error immediately to the caller
@@ -216,114 +312,26 @@ func (h *Target) Send(entry interface{}, errKind string) error {
 	return nil
 }
 
-
-/****** NOTE: @dougmolina ADDED LOGIC TO SUPPORT FLEEK EVENTS *****/
-// TODO: maybe make both audit Entry a non pointer to keep it cohesive across all funcs
-func (h *Target) getHashFromEntry(entry interface{}) (*hashResponseHelper, error) {
-	var ok bool
-	var entryStruct *audit.Entry
-	if entryStruct, ok = entry.(*audit.Entry); !ok {
-		logCastMsg := "system Error trying to cast audit log entry"
-		log.Println(logCastMsg)
-		return nil, errors.New(logCastMsg)
-	}
-
-	// build endpoint URL
-	bucketName := entryStruct.API.Bucket
-	var endpoint string
-	endpoint = InfoEndpoint + "bucket=" + bucketName
-
-	if entryStruct.API.Object != "" {
-		endpoint = endpoint + "&object=" + entryStruct.API.Object
-	}
-
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
-	if err != nil {
-		log.Println("Error building request for objectInfo :" + err.Error())
-		return nil, err
-	}
-
-	resp, err := h.client.Do(req)
-	if err != nil {
-		log.Println("Error fetching response for objectInfo :" + err.Error())
-		h.client.CloseIdleConnections()
-		return nil, err
-	}
-
-	bytes, err := ioutil.ReadAll(resp.Body)
-
-	if err != nil {
-		log.Println("Error parsing body response for objectInfo :" + err.Error())
-		return nil, err
-	}
-
-	var infoResponse ipfsInfoResponse
-	if err := json.Unmarshal(bytes, &infoResponse); err != nil {
-		log.Println("Error parsing json body response for objectInfo :" + err.Error())
-		return nil, err
-	}
-	// Drain any response.
-	defer xhttp.DrainBody(resp.Body)
-
-	var ipfsContentType string
-	if infoResponse.Object == "" {
-		ipfsContentType = "Bucket"
-	} else {
-		ipfsContentType = "Object"
-	}
-
-	return &hashResponseHelper{
-		Hash:            infoResponse.Hash,
-		IpfsContentType: ipfsContentType,
-	}, nil
-}
-
-func contains(arr []string, str string) bool {
-	for _, a := range arr {
-		if a == str {
-			return true
+// checkEntry returns whether an entry should be sent, audit entries are only
+// sent for successful calls of the configured apis, other entries are always sent.
+func (h *Target) checkEntry(entry interface{}) (queuedEntry, bool, error) {
+	entryStruct, ok := entry.(audit.Entry)
+	if !ok {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return queuedEntry{}, false, err
 		}
-	}
-	return false
-}
-
-// checks for Events that we want to send
-func checkEntry(entry interface{}) (*audit.Entry, bool) {
-	var ok bool
-	var entryStruct audit.Entry
-	if entryStruct, ok = entry.(audit.Entry); !ok {
-		log.Println("system Error trying to cast audit log entry")
-		return nil, false
+		return queuedEntry{Log: data}, true, nil
 	}
 
 	if entryStruct.API.StatusCode == 0 {
 		log.Println("system Error unable to read status code to determine logging payload")
-		return nil, false
+		return queuedEntry{}, false, nil
 	}
 
-	if entryStruct.API.StatusCode > 299 {
-		return nil, false
+	if !entryStruct.Matches(h.apiEvents) {
+		return queuedEntry{}, false, nil
 	}
 
-	if contains(supportedApiEvents, entryStruct.API.Name) {
-		return &entryStruct, true
-	}
-
-	return nil, false
+	return queuedEntry{Audit: &entryStruct}, true, nil
 }
-
-func logEntry(entry interface{}, hash string, contentType string) error {
-	logEntry := logFormattedEntry{
-		Entry:           entry,
-		IpfsHash:        hash,
-		IpfsContentType: contentType,
-	}
-	logJSON, err := json.Marshal(&logEntry)
-	if err != nil {
-		return errors.New("unable to marshal log entry")
-	}
-
-	log.Println("FLEEK CRUD: " + string(logJSON))
-
-	return nil
-}
\ No newline at end of file
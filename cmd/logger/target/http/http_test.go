@@ -0,0 +1,166 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/queuestore"
+)
+
+type testCollector struct {
+	sync.Mutex
+	down     bool
+	failures int
+	entries  []audit.Entry
+	hashes   []string
+}
+
+func (c *testCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.Lock()
+	defer c.Unlock()
+	if c.down {
+		c.failures++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var entry audit.Entry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.entries = append(c.entries, entry)
+	c.hashes = append(c.hashes, r.Header.Get(IpfsHashHeader)+" "+r.Header.Get(IpfsHashContentType))
+}
+
+func (c *testCollector) setDown(down bool) {
+	c.Lock()
+	c.down = down
+	c.Unlock()
+}
+
+func (c *testCollector) failed() bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.failures > 0
+}
+
+func (c *testCollector) received() ([]audit.Entry, []string) {
+	c.Lock()
+	defer c.Unlock()
+	return append([]audit.Entry(nil), c.entries...), append([]string(nil), c.hashes...)
+}
+
+func newTestEntry(name, bucket, object string, statusCode int) audit.Entry {
+	entry := audit.Entry{}
+	entry.API.Name = name
+	entry.API.Bucket = bucket
+	entry.API.Object = object
+	entry.API.StatusCode = statusCode
+	entry.Tags = map[string]string{audit.TagIpfsBucketHash: "hash-of-" + bucket}
+	if object != "" {
+		entry.Tags[audit.TagIpfsHash] = "hash-of-" + object
+	}
+	return entry
+}
+
+func TestTargetQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "http-target")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	collector := &testCollector{down: true}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+	target, err := New(WithEndpoint(server.URL),
+		WithLogKind("all"),
+		WithAPIEvents([]string{"PutObject", "PutBucket"}),
+		WithQueueDir(dir, 2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []audit.Entry{
+		newTestEntry("PutObject", "bucket", "a b", http.StatusOK),
+		newTestEntry("GetObject", "bucket", "a b", http.StatusOK),
+		newTestEntry("PutObject", "bucket", "c", http.StatusForbidden),
+		newTestEntry("PutBucket", "bucket", "", http.StatusOK),
+	} {
+		if err := target.Send(entry, "ALL"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first entry is being retried, so both entries are still queued
+	if err := target.Send(newTestEntry("PutObject", "bucket", "e", http.StatusOK), "ALL"); err != queuestore.ErrLimitExceeded {
+		t.Fatalf("expected %v, but got %v", queuestore.ErrLimitExceeded, err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !collector.failed() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the collector to be called")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	collector.setDown(false)
+	for {
+		entries, hashes := collector.received()
+		if len(entries) == 2 {
+			if entries[0].API.Object != "a b" || entries[1].API.Name != "PutBucket" {
+				t.Fatalf("unexpected entries %+v", entries)
+			}
+			if hashes[0] != "hash-of-a b Object" || hashes[1] != "hash-of-bucket Bucket" {
+				t.Fatalf("unexpected hashes %v", hashes)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 entries to be sent, but got %+v", entries)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	for time.Now().Before(deadline) {
+		if keys, err := target.store.Keys(); err == nil && len(keys) == 0 {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("expected the sent entries to be removed from the queue")
+}
+
+func TestTargetLogKind(t *testing.T) {
+	target, err := New(WithEndpoint("http://localhost:0"), WithLogKind("application"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := target.Send(map[string]string{"message": "minio"}, "MINIO"); err != nil {
+		t.Fatal(err)
+	}
+	if len(target.logCh) != 0 {
+		t.Fatal("expected entries of other kinds to be ignored")
+	}
+}
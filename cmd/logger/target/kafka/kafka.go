@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	sarama "github.com/Shopify/sarama"
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/event/target"
+)
+
+const (
+	// minRetryBackoff is the time before an entry is sent again, doubled on every failure
+	minRetryBackoff = time.Second
+	// maxRetryBackoff is the longest time between attempts to send an entry
+	maxRetryBackoff = time.Minute
+)
+
+// Target implements logger.Target and publishes the json format of the
+// audit entries to a Kafka topic, with the bucket and object of the
+// request as message key. Entries are kept in an internal buffer until
+// they are published, and are published again with backoff while the
+// brokers are down. When the buffer is full, new entries are ignored and
+// an error is returned to the caller.
+type Target struct {
+	logCh chan audit.Entry
+
+	args     target.KafkaArgs
+	config   *sarama.Config
+	producer sarama.SyncProducer
+	// names of the audited apis that are sent, all if empty
+	apiEvents []string
+}
+
+// New initializes a new audit target which publishes the entries to Kafka,
+// with the client options of the Kafka notification target. The brokers do
+// not need to be up, the target connects when it sends the first entry.
+func New(args target.KafkaArgs, apiEvents []string) (*Target, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+	config, err := target.NewKafkaConfig(args)
+	if err != nil {
+		return nil, err
+	}
+	t := &Target{
+		logCh:     make(chan audit.Entry, 10000),
+		args:      args,
+		config:    config,
+		apiEvents: apiEvents,
+	}
+	go func() {
+		for entry := range t.logCh {
+			t.deliver(entry)
+		}
+	}()
+	return t, nil
+}
+
+// deliver publishes an entry, it retries with backoff until the brokers accepted it
+func (t *Target) deliver(entry audit.Entry) {
+	wait := minRetryBackoff
+	for {
+		err := t.send(entry)
+		if err == nil {
+			return
+		}
+		if wait == minRetryBackoff {
+			// only the first failure is logged, until the brokers are back
+			log.Printf("unable to publish audit entry to kafka topic %s, retrying: %v", t.args.Topic, err)
+		}
+		time.Sleep(wait)
+		if wait *= 2; wait > maxRetryBackoff {
+			wait = maxRetryBackoff
+		}
+	}
+}
+
+// send publishes an entry, it returns an error if the entry should be published again.
+func (t *Target) send(entry audit.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Println("unable to marshal audit entry: " + err.Error())
+		return nil
+	}
+
+	if t.producer == nil {
+		brokers := make([]string, 0, len(t.args.Brokers))
+		for _, broker := range t.args.Brokers {
+			brokers = append(brokers, broker.String())
+		}
+		if t.producer, err = sarama.NewSyncProducer(brokers, t.config); err != nil {
+			return err
+		}
+	}
+
+	key := entry.API.Bucket
+	if entry.API.Object != "" {
+		key += "/" + entry.API.Object
+	}
+	msg := sarama.ProducerMessage{
+		Topic: t.args.Topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(data),
+	}
+	if _, _, err = t.producer.SendMessage(&msg); err != nil {
+		// a new producer is created by the next attempt
+		t.producer.Close()
+		t.producer = nil
+		return err
+	}
+	return nil
+}
+
+// Send an audit entry to the Kafka topic, other log entries are ignored.
+func (t *Target) Send(entry interface{}, errKind string) error {
+	e, ok := entry.(audit.Entry)
+	if !ok || !e.Matches(t.apiEvents) {
+		return nil
+	}
+
+	select {
+	case t.logCh <- e:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+	return nil
+}
@@ -0,0 +1,534 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// meteringPrefix is where every server saves its hourly rollups, as
+	// metering/<hour>/<node>.json in the meta bucket.
+	meteringPrefix        = "metering"
+	meteringHourFormat    = "2006-01-02T15"
+	meteringFlushInterval = 5 * time.Minute
+	// meteringMaxRange is the longest time range of a metering query
+	meteringMaxRange = 31 * 24 * time.Hour
+
+	// bucketOwnerConfigFile records the parent user that created a bucket
+	bucketOwnerConfigFile = "owner.json"
+)
+
+var errMeteringInvalidRange = errors.New("metering time range must end after it starts, and be at most 31 days")
+
+// bucketOwner is the content of the owner config file of a bucket
+type bucketOwner struct {
+	ParentUser string `json:"parentUser"`
+}
+
+// MeteringSys counts the usage of the buckets and parent users by hour. Every server counts
+// the requests it handles, and saves the counters of the current hour every few minutes,
+// so the usage of an hour is the sum of the rollups of all servers.
+type MeteringSys struct {
+	sync.Mutex
+	node    string
+	enabled bool
+	// flushMu serializes the flushes, so an older rollup never overwrites a newer one
+	flushMu sync.Mutex
+	hours   map[time.Time]*madmin.MeteringRollup
+	// merged are the hours with the rollup saved before a restart merged into hours
+	merged map[time.Time]bool
+	// owners caches the owners of the buckets, "" for buckets without owner
+	owners map[string]string
+}
+
+// NewMeteringSys - creates a new metering system.
+func NewMeteringSys() *MeteringSys {
+	node := "local"
+	if globalIsDistXL {
+		node = GetLocalPeer(globalEndpoints)
+	}
+	return &MeteringSys{
+		node:   node,
+		hours:  make(map[time.Time]*madmin.MeteringRollup),
+		merged: make(map[time.Time]bool),
+		owners: make(map[string]string),
+	}
+}
+
+// meteringNodes returns the names of the servers that save rollups
+func meteringNodes() []string {
+	if !globalIsDistXL {
+		return []string{"local"}
+	}
+	return append(GetRemotePeers(globalEndpoints), GetLocalPeer(globalEndpoints))
+}
+
+// meteringRollupFile returns the name of the rollup of a server in the meta bucket
+func meteringRollupFile(hour time.Time, node string) string {
+	node = strings.NewReplacer(":", "_", "/", "_").Replace(node)
+	return path.Join(meteringPrefix, hour.UTC().Format(meteringHourFormat), node+".json")
+}
+
+// meteringAPIClass returns the class of an api, as named by collectAPIStats
+func meteringAPIClass(api string) string {
+	switch {
+	case api == "listenbucketnotification":
+		return madmin.MeteringClassOther
+	case strings.HasPrefix(api, "list"):
+		return madmin.MeteringClassList
+	case strings.HasPrefix(api, "get"), strings.HasPrefix(api, "head"), api == "selectobjectcontent":
+		return madmin.MeteringClassRead
+	case strings.HasPrefix(api, "put"), strings.HasPrefix(api, "copy"), strings.HasPrefix(api, "post"),
+		api == "newmultipartupload", api == "completemutipartupload":
+		return madmin.MeteringClassWrite
+	case strings.HasPrefix(api, "delete"), api == "abortmultipartupload":
+		return madmin.MeteringClassDelete
+	}
+	return madmin.MeteringClassOther
+}
+
+// Record counts a request to bucket by user in the current hour, user is the
+// parent user of the request, or empty for anonymous requests.
+func (sys *MeteringSys) Record(api, bucket, user string, bytesIn, bytesOut uint64) {
+	if sys == nil || (bucket == "" && user == "") {
+		return
+	}
+	class := meteringAPIClass(api)
+
+	sys.Lock()
+	defer sys.Unlock()
+	if !sys.enabled {
+		// the counters would never be saved
+		return
+	}
+	// the hour is taken with the lock held, so a flush never drops the counters of
+	// a past hour after they are saved
+	hour := UTCNow().Truncate(time.Hour)
+	rollup, ok := sys.hours[hour]
+	if !ok {
+		rollup = &madmin.MeteringRollup{
+			Time:    hour,
+			Buckets: make(map[string]madmin.MeteringCounters),
+			Users:   make(map[string]madmin.MeteringCounters),
+		}
+		sys.hours[hour] = rollup
+	}
+	count := func(m map[string]madmin.MeteringCounters, key string) {
+		c := m[key]
+		c.BytesIn += bytesIn
+		c.BytesOut += bytesOut
+		if c.Requests == nil {
+			c.Requests = make(map[string]uint64)
+		}
+		c.Requests[class]++
+		m[key] = c
+	}
+	if bucket != "" {
+		count(rollup.Buckets, bucket)
+	}
+	if user != "" {
+		count(rollup.Users, user)
+	}
+}
+
+// SetBucketOwner records the parent user that created a bucket, the bytes stored
+// of a user are the size of its buckets. Anonymous buckets have no owner.
+func (sys *MeteringSys) SetBucketOwner(ctx context.Context, objAPI ObjectLayer, bucket, user string) {
+	if sys == nil || user == "" {
+		return
+	}
+	sys.Lock()
+	enabled := sys.enabled
+	sys.Unlock()
+	if !enabled {
+		return
+	}
+	data, err := json.Marshal(bucketOwner{ParentUser: user})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	// the owner is kept by the bucket name the data usage is reported by
+	bucket = namespacedBucket(ctx, objAPI, bucket)
+	if err = saveConfig(ctx, objAPI, path.Join(bucketConfigPrefix, bucket, bucketOwnerConfigFile), data); err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	sys.Lock()
+	sys.owners[bucket] = user
+	sys.Unlock()
+}
+
+// RemoveBucketOwner removes the owner of a deleted bucket
+func (sys *MeteringSys) RemoveBucketOwner(ctx context.Context, objAPI ObjectLayer, bucket string) {
+	if sys == nil {
+		return
+	}
+	bucket = namespacedBucket(ctx, objAPI, bucket)
+	sys.Lock()
+	delete(sys.owners, bucket)
+	sys.Unlock()
+	err := deleteConfig(ctx, objAPI, path.Join(bucketConfigPrefix, bucket, bucketOwnerConfigFile))
+	if err != nil && err != errConfigNotFound && !isErrBucketNotFound(err) {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// bucketOwner returns the owner of a bucket, from the cache or the owner config
+func (sys *MeteringSys) bucketOwner(ctx context.Context, objAPI ObjectLayer, bucket string) string {
+	sys.Lock()
+	owner, ok := sys.owners[bucket]
+	sys.Unlock()
+	if ok {
+		return owner
+	}
+	data, err := readConfig(ctx, objAPI, path.Join(bucketConfigPrefix, bucket, bucketOwnerConfigFile))
+	if err == nil {
+		var o bucketOwner
+		if err = json.Unmarshal(data, &o); err == nil {
+			owner = o.ParentUser
+		}
+	}
+	if err != nil && err != errConfigNotFound {
+		// the owner is read again on the next flush
+		return ""
+	}
+	sys.Lock()
+	sys.owners[bucket] = owner
+	sys.Unlock()
+	return owner
+}
+
+// Init starts saving the rollups of this server, requests are not counted before.
+// The rollups counted since the last flush are saved by Stop when the server stops.
+func (sys *MeteringSys) Init(ctx context.Context, objAPI ObjectLayer) {
+	sys.Lock()
+	sys.enabled = true
+	sys.Unlock()
+	go func() {
+		ticker := time.NewTicker(meteringFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				logger.LogIf(ctx, sys.Flush(ctx, objAPI))
+			}
+		}
+	}()
+}
+
+// Stop saves the rollups counted until the server stopped serving requests,
+// while the object layer is still available.
+func (sys *MeteringSys) Stop(objAPI ObjectLayer) {
+	if sys == nil {
+		return
+	}
+	sys.Lock()
+	enabled := sys.enabled
+	sys.Unlock()
+	if !enabled {
+		return
+	}
+	logger.LogIf(context.Background(), sys.Flush(context.Background(), objAPI))
+}
+
+// Flush saves the rollups of this server, with the bytes stored as last computed by the
+// data usage crawler. The rollups of past hours are dropped once they are saved. A rollup
+// saved before a restart is merged into the counters of its hour before it is overwritten.
+func (sys *MeteringSys) Flush(ctx context.Context, objAPI ObjectLayer) error {
+	if sys == nil {
+		return nil
+	}
+	sys.flushMu.Lock()
+	defer sys.flushMu.Unlock()
+
+	hour := UTCNow().Truncate(time.Hour)
+	sys.Lock()
+	if _, ok := sys.hours[hour]; !ok {
+		// the current hour is saved without requests, for the bytes stored
+		sys.hours[hour] = &madmin.MeteringRollup{Time: hour}
+	}
+	sys.Unlock()
+	if err := sys.mergeSavedRollups(ctx, objAPI); err != nil {
+		return err
+	}
+	sys.Lock()
+	rollups := make([]madmin.MeteringRollup, 0, len(sys.hours))
+	for _, rollup := range sys.hours {
+		rollups = append(rollups, copyMeteringRollup(*rollup))
+	}
+	sys.Unlock()
+
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		logger.LogIf(ctx, err)
+	}
+	owners := make(map[string]string, len(dataUsageInfo.BucketsSizes))
+	for bucket := range dataUsageInfo.BucketsSizes {
+		owners[bucket] = sys.bucketOwner(ctx, objAPI, bucket)
+	}
+
+	for _, rollup := range rollups {
+		if rollup.Buckets == nil {
+			rollup.Buckets = make(map[string]madmin.MeteringCounters)
+		}
+		if rollup.Users == nil {
+			rollup.Users = make(map[string]madmin.MeteringCounters)
+		}
+		for bucket, size := range dataUsageInfo.BucketsSizes {
+			c := rollup.Buckets[bucket]
+			c.BytesStored = size
+			rollup.Buckets[bucket] = c
+		}
+		for bucket, size := range dataUsageInfo.BucketsSizes {
+			if owner := owners[bucket]; owner != "" {
+				c := rollup.Users[owner]
+				c.BytesStored += size
+				rollup.Users[owner] = c
+			}
+		}
+		data, err := json.Marshal(rollup)
+		if err != nil {
+			return err
+		}
+		if err = saveConfig(ctx, objAPI, meteringRollupFile(rollup.Time, sys.node), data); err != nil {
+			return err
+		}
+		if rollup.Time.Before(hour) {
+			sys.Lock()
+			delete(sys.hours, rollup.Time)
+			delete(sys.merged, rollup.Time)
+			sys.Unlock()
+		}
+	}
+	return nil
+}
+
+// mergeSavedRollups adds the rollups this server saved for its hours, before it was restarted,
+// to the counters of the hours. Every hour is merged once, the rollups saved later include
+// the merged counters.
+func (sys *MeteringSys) mergeSavedRollups(ctx context.Context, objAPI ObjectLayer) error {
+	sys.Lock()
+	var hours []time.Time
+	for hour := range sys.hours {
+		if !sys.merged[hour] {
+			hours = append(hours, hour)
+		}
+	}
+	sys.Unlock()
+
+	for _, hour := range hours {
+		var saved madmin.MeteringRollup
+		data, err := readConfig(ctx, objAPI, meteringRollupFile(hour, sys.node))
+		switch {
+		case err == nil:
+			if err = json.Unmarshal(data, &saved); err != nil {
+				return err
+			}
+		case err != errConfigNotFound && !isErrBucketNotFound(err):
+			return err
+		}
+		sys.Lock()
+		rollup := sys.hours[hour]
+		for name, c := range saved.Buckets {
+			if rollup.Buckets == nil {
+				rollup.Buckets = make(map[string]madmin.MeteringCounters)
+			}
+			rollup.Buckets[name] = mergeMeteringCounters(rollup.Buckets[name], c)
+		}
+		for name, c := range saved.Users {
+			if rollup.Users == nil {
+				rollup.Users = make(map[string]madmin.MeteringCounters)
+			}
+			rollup.Users[name] = mergeMeteringCounters(rollup.Users[name], c)
+		}
+		sys.merged[hour] = true
+		sys.Unlock()
+	}
+	return nil
+}
+
+// copyMeteringRollup returns a deep copy of a rollup
+func copyMeteringRollup(rollup madmin.MeteringRollup) madmin.MeteringRollup {
+	copyCounters := func(m map[string]madmin.MeteringCounters) map[string]madmin.MeteringCounters {
+		if m == nil {
+			return nil
+		}
+		c := make(map[string]madmin.MeteringCounters, len(m))
+		for k, v := range m {
+			requests := make(map[string]uint64, len(v.Requests))
+			for class, n := range v.Requests {
+				requests[class] = n
+			}
+			v.Requests = requests
+			c[k] = v
+		}
+		return c
+	}
+	rollup.Buckets = copyCounters(rollup.Buckets)
+	rollup.Users = copyCounters(rollup.Users)
+	return rollup
+}
+
+// mergeMeteringCounters adds the counters of a server to the counters of an hour, the bytes
+// stored are read from the same data usage by every server, so they are not summed up.
+func mergeMeteringCounters(dst, src madmin.MeteringCounters) madmin.MeteringCounters {
+	if src.BytesStored > dst.BytesStored {
+		dst.BytesStored = src.BytesStored
+	}
+	dst.BytesIn += src.BytesIn
+	dst.BytesOut += src.BytesOut
+	for class, n := range src.Requests {
+		if dst.Requests == nil {
+			dst.Requests = make(map[string]uint64)
+		}
+		dst.Requests[class] += n
+	}
+	return dst
+}
+
+// getMeteringInfo returns the usage of the hours that start between start and end,
+// limited to a bucket and a user if they are not empty.
+func getMeteringInfo(ctx context.Context, objAPI ObjectLayer, start, end time.Time, bucket, user string) (madmin.MeteringInfo, error) {
+	start, end = start.UTC(), end.UTC()
+	if !end.After(start) || end.Sub(start) > meteringMaxRange {
+		return madmin.MeteringInfo{}, errMeteringInvalidRange
+	}
+	// the counters of this server since the last flush are included
+	if err := globalMeteringSys.Flush(ctx, objAPI); err != nil {
+		logger.LogIf(ctx, err)
+	}
+
+	info := madmin.MeteringInfo{
+		Start: start,
+		End:   end,
+		Hours: []madmin.MeteringRollup{},
+	}
+	nodes := meteringNodes()
+	hour := start.Truncate(time.Hour)
+	if hour.Before(start) {
+		hour = hour.Add(time.Hour)
+	}
+	for ; hour.Before(end); hour = hour.Add(time.Hour) {
+		total := madmin.MeteringRollup{Time: hour}
+		found := false
+		for _, node := range nodes {
+			data, err := readConfig(ctx, objAPI, meteringRollupFile(hour, node))
+			if err == errConfigNotFound || isErrBucketNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return info, err
+			}
+			var rollup madmin.MeteringRollup
+			if err = json.Unmarshal(data, &rollup); err != nil {
+				return info, err
+			}
+			found = true
+			for name, c := range rollup.Buckets {
+				if bucket != "" && name != bucket {
+					continue
+				}
+				if total.Buckets == nil {
+					total.Buckets = make(map[string]madmin.MeteringCounters)
+				}
+				total.Buckets[name] = mergeMeteringCounters(total.Buckets[name], c)
+			}
+			for name, c := range rollup.Users {
+				if user != "" && name != user {
+					continue
+				}
+				if total.Users == nil {
+					total.Users = make(map[string]madmin.MeteringCounters)
+				}
+				total.Users[name] = mergeMeteringCounters(total.Users[name], c)
+			}
+		}
+		if found {
+			info.Hours = append(info.Hours, total)
+		}
+	}
+	sort.Slice(info.Hours, func(i, j int) bool {
+		return info.Hours[i].Time.Before(info.Hours[j].Time)
+	})
+	return info, nil
+}
+
+// getRequestParentUser returns the user a request is made by, the parent user of service
+// accounts and temporary credentials, or "" for anonymous and unknown credentials. The
+// signature is not verified, the handlers reject requests with an invalid signature.
+func getRequestParentUser(r *http.Request) string {
+	var (
+		cred  auth.Credentials
+		s3Err APIErrorCode
+	)
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypePresigned, authTypeStreamingSigned:
+		cred, _, s3Err = getReqAccessKeyV4(r, "", serviceS3)
+	case authTypeSignedV2, authTypePresignedV2:
+		cred, _, s3Err = getReqAccessKeyV2(r)
+	default:
+		return ""
+	}
+	if s3Err != ErrNone {
+		return ""
+	}
+	if cred.ParentUser != "" {
+		return cred.ParentUser
+	}
+	return cred.AccessKey
+}
+
+// getRequestMeteringBucket returns the name of the bucket of a request as the data usage reports it,
+// so the requests and the bytes stored of a bucket are counted together. Gateways with a bucket
+// namespace per account report the namespaced buckets.
+func getRequestMeteringBucket(r *http.Request, bucket string) string {
+	objAPI := newObjectLayerWithoutSafeModeFn()
+	if _, ok := objAPI.(GatewayBucketNamespacer); !ok || bucket == "" {
+		return bucket
+	}
+	ctx := context.WithValue(r.Context(), "Authorization", getReqAccessCred(r, globalServerRegion))
+	return namespacedBucket(ctx, objAPI, bucket)
+}
+
+// meteringReader counts the bytes read from a request body
+type meteringReader struct {
+	io.ReadCloser
+	n uint64
+}
+
+func (r *meteringReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += uint64(n)
+	return n, err
+}
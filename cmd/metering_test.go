@@ -0,0 +1,217 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestMeteringAPIClass(t *testing.T) {
+	testCases := []struct {
+		api   string
+		class string
+	}{
+		{"getobject", madmin.MeteringClassRead},
+		{"headobject", madmin.MeteringClassRead},
+		{"selectobjectcontent", madmin.MeteringClassRead},
+		{"putobject", madmin.MeteringClassWrite},
+		{"copyobjectpart", madmin.MeteringClassWrite},
+		{"postpolicybucket", madmin.MeteringClassWrite},
+		{"newmultipartupload", madmin.MeteringClassWrite},
+		{"completemutipartupload", madmin.MeteringClassWrite},
+		{"listobjectsv2", madmin.MeteringClassList},
+		{"listbuckets", madmin.MeteringClassList},
+		{"deletemultipleobjects", madmin.MeteringClassDelete},
+		{"abortmultipartupload", madmin.MeteringClassDelete},
+		{"listenbucketnotification", madmin.MeteringClassOther},
+		{"restoreobject", madmin.MeteringClassOther},
+	}
+	for _, testCase := range testCases {
+		if class := meteringAPIClass(testCase.api); class != testCase.class {
+			t.Errorf("%s: expected class %s, got %s", testCase.api, testCase.class, class)
+		}
+	}
+}
+
+func TestMergeMeteringCounters(t *testing.T) {
+	a := madmin.MeteringCounters{BytesStored: 10, BytesIn: 1, BytesOut: 2, Requests: map[string]uint64{"read": 1}}
+	b := madmin.MeteringCounters{BytesStored: 10, BytesIn: 3, BytesOut: 4, Requests: map[string]uint64{"read": 2, "write": 1}}
+
+	c := mergeMeteringCounters(mergeMeteringCounters(madmin.MeteringCounters{}, a), b)
+	if c.BytesStored != 10 || c.BytesIn != 4 || c.BytesOut != 6 {
+		t.Fatalf("unexpected counters %+v", c)
+	}
+	if c.Requests["read"] != 3 || c.Requests["write"] != 1 {
+		t.Fatalf("unexpected requests %v", c.Requests)
+	}
+	if a.Requests["read"] != 1 {
+		t.Fatal("merge modified its source")
+	}
+}
+
+func TestMeteringFlush(t *testing.T) {
+	objAPI, disk, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(disk)
+
+	sys := NewMeteringSys()
+	sys.enabled = true
+	defer func(old *MeteringSys) { globalMeteringSys = old }(globalMeteringSys)
+	globalMeteringSys = sys
+
+	if err = objAPI.MakeBucketWithLocation(GlobalContext, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	sys.SetBucketOwner(GlobalContext, objAPI, "bucket", "alice")
+
+	gui := make(chan DataUsageInfo, 1)
+	gui <- DataUsageInfo{LastUpdate: UTCNow(), BucketsSizes: map[string]uint64{"bucket": 100}}
+	close(gui)
+	storeDataUsageInBackend(GlobalContext, objAPI, gui)
+
+	sys.Record("putobject", "bucket", "alice", 100, 0)
+	sys.Record("getobject", "bucket", "", 0, 100)
+	sys.Record("listbuckets", "", "alice", 0, 10)
+
+	// drop the cached owner, it is read from the bucket configuration
+	sys.owners = make(map[string]string)
+
+	end := UTCNow().Add(time.Hour)
+	info, err := getMeteringInfo(GlobalContext, objAPI, end.Add(-2*time.Hour), end, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Hours) != 1 {
+		t.Fatalf("expected one hour, got %d", len(info.Hours))
+	}
+	hour := info.Hours[0]
+	if !hour.Time.Equal(UTCNow().Truncate(time.Hour)) {
+		t.Errorf("unexpected hour %s", hour.Time)
+	}
+	bucket := hour.Buckets["bucket"]
+	if bucket.BytesStored != 100 || bucket.BytesIn != 100 || bucket.BytesOut != 100 {
+		t.Errorf("unexpected bucket counters %+v", bucket)
+	}
+	if bucket.Requests[madmin.MeteringClassRead] != 1 || bucket.Requests[madmin.MeteringClassWrite] != 1 {
+		t.Errorf("unexpected bucket requests %v", bucket.Requests)
+	}
+	user := hour.Users["alice"]
+	if user.BytesStored != 100 || user.BytesIn != 100 || user.BytesOut != 10 {
+		t.Errorf("unexpected user counters %+v", user)
+	}
+	if user.Requests[madmin.MeteringClassList] != 1 || user.Requests[madmin.MeteringClassWrite] != 1 {
+		t.Errorf("unexpected user requests %v", user.Requests)
+	}
+
+	// filters
+	info, err = getMeteringInfo(GlobalContext, objAPI, end.Add(-2*time.Hour), end, "other", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Hours) != 1 || len(info.Hours[0].Buckets) != 0 || len(info.Hours[0].Users) != 1 {
+		t.Errorf("unexpected filtered info %+v", info)
+	}
+
+	if _, err = getMeteringInfo(GlobalContext, objAPI, end, end.Add(-time.Hour), "", ""); err != errMeteringInvalidRange {
+		t.Errorf("expected errMeteringInvalidRange, got %v", err)
+	}
+	if _, err = getMeteringInfo(GlobalContext, objAPI, end.Add(-32*24*time.Hour), end, "", ""); err != errMeteringInvalidRange {
+		t.Errorf("expected errMeteringInvalidRange, got %v", err)
+	}
+
+	// a restarted server adds its new requests to the rollup it saved before
+	restarted := NewMeteringSys()
+	restarted.enabled = true
+	globalMeteringSys = restarted
+	restarted.Record("getobject", "bucket", "", 0, 100)
+	info, err = getMeteringInfo(GlobalContext, objAPI, end.Add(-2*time.Hour), end, "bucket", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Hours) != 1 {
+		t.Fatalf("expected one hour, got %d", len(info.Hours))
+	}
+	bucket = info.Hours[0].Buckets["bucket"]
+	if bucket.BytesOut != 200 || bucket.Requests[madmin.MeteringClassRead] != 2 || bucket.Requests[madmin.MeteringClassWrite] != 1 {
+		t.Errorf("expected the counters before the restart to be kept, got %+v", bucket)
+	}
+}
+
+func TestMeteringStop(t *testing.T) {
+	objAPI, disk, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(disk)
+	defer func(old *MeteringSys) { globalMeteringSys = old }(globalMeteringSys)
+
+	ctx, cancel := context.WithCancel(GlobalContext)
+	sys := NewMeteringSys()
+	sys.Init(ctx, objAPI)
+	sys.Record("getobject", "bucket", "", 0, 100)
+	cancel()
+	sys.Stop(objAPI)
+
+	// the counters are saved by the stopped server
+	globalMeteringSys = NewMeteringSys()
+	end := UTCNow().Add(time.Hour)
+	info, err := getMeteringInfo(GlobalContext, objAPI, end.Add(-2*time.Hour), end, "bucket", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Hours) != 1 || info.Hours[0].Buckets["bucket"].BytesOut != 100 {
+		t.Fatalf("expected the counters to be saved on stop, got %+v", info)
+	}
+}
+
+// namespacedObjectLayer is a gateway with a bucket namespace per account
+type namespacedObjectLayer struct {
+	ObjectLayer
+}
+
+func (namespacedObjectLayer) NamespacedBucket(ctx context.Context, bucket string) string {
+	return "namespace:" + bucket
+}
+
+func (namespacedObjectLayer) ListNamespacedBuckets(ctx context.Context) ([]BucketInfo, error) {
+	return nil, nil
+}
+
+func TestGetRequestMeteringBucket(t *testing.T) {
+	defer func(objAPI ObjectLayer) { globalObjectAPI = objAPI }(globalObjectAPI)
+	r := httptest.NewRequest("GET", "/bucket/object", nil)
+
+	globalObjectAPI = nil
+	if bucket := getRequestMeteringBucket(r, "bucket"); bucket != "bucket" {
+		t.Errorf("expected bucket, got %s", bucket)
+	}
+	globalObjectAPI = namespacedObjectLayer{}
+	if bucket := getRequestMeteringBucket(r, "bucket"); bucket != "namespace:bucket" {
+		t.Errorf("expected the namespaced bucket, got %s", bucket)
+	}
+	if bucket := getRequestMeteringBucket(r, ""); bucket != "" {
+		t.Errorf("expected no bucket, got %s", bucket)
+	}
+}
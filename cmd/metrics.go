@@ -19,6 +19,7 @@ package cmd
 import (
 	"net/http"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -51,6 +52,17 @@ var (
 	)
 )
 
+// usageMetricsInterval is how long the data usage reported in metrics is cached.
+const usageMetricsInterval = 30 * time.Second
+
+// usageMetricsCache caches the data usage between scrapes, loading it reads the
+// crawler results from the backend, and gateways compute it for all buckets.
+var usageMetricsCache struct {
+	sync.Mutex
+	dataUsageInfo DataUsageInfo
+	lastUpdate    time.Time
+}
+
 func init() {
 	prometheus.MustRegister(httpRequestsDuration)
 	prometheus.MustRegister(newMinioCollector())
@@ -88,6 +100,78 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 	httpMetricsPrometheus(ch)
 	gatewayMetricsPrometheus(ch)
 	healingMetricsPrometheus(ch)
+	usageMetricsPrometheus(ch)
+}
+
+// loadUsageMetrics returns the data usage to report, loaded at most once every usageMetricsInterval
+func loadUsageMetrics(objLayer ObjectLayer) (DataUsageInfo, error) {
+	usageMetricsCache.Lock()
+	defer usageMetricsCache.Unlock()
+	if time.Since(usageMetricsCache.lastUpdate) < usageMetricsInterval {
+		return usageMetricsCache.dataUsageInfo, nil
+	}
+	dataUsageInfo, err := loadDataUsageFromBackend(GlobalContext, objLayer)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+	usageMetricsCache.dataUsageInfo = dataUsageInfo
+	usageMetricsCache.lastUpdate = time.Now()
+	return dataUsageInfo, nil
+}
+
+// collects data usage metrics for MinIO instance in Prometheus specific format
+// and sends to given channel
+func usageMetricsPrometheus(ch chan<- prometheus.Metric) {
+	objLayer := newObjectLayerWithoutSafeModeFn()
+	// Service not initialized yet
+	if objLayer == nil {
+		return
+	}
+
+	dataUsageInfo, err := loadUsageMetrics(objLayer)
+	// Data usage has not been calculated yet
+	if err != nil || dataUsageInfo.LastUpdate.IsZero() {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("usage", "objects", "count"),
+			"Total number of objects",
+			nil, nil),
+		prometheus.GaugeValue,
+		float64(dataUsageInfo.ObjectsCount),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("usage", "objects", "size"),
+			"Total size of all objects",
+			nil, nil),
+		prometheus.GaugeValue,
+		float64(dataUsageInfo.ObjectsTotalSize),
+	)
+	for interval, count := range dataUsageInfo.ObjectsSizesHistogram {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("usage", "objects", "histogram"),
+				"Total number of objects in each size interval",
+				[]string{"object_size"}, nil),
+			prometheus.GaugeValue,
+			float64(count),
+			interval,
+		)
+	}
+	for bucket, size := range dataUsageInfo.BucketsSizes {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("bucket", "usage", "size"),
+				"Total size of all objects in the bucket",
+				[]string{"bucket"}, nil),
+			prometheus.GaugeValue,
+			float64(size),
+			bucket,
+		)
+	}
 }
 
 // collects healing specific metrics for MinIO instance in Prometheus specific format
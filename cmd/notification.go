@@ -34,10 +34,14 @@ import (
 	"github.com/minio/minio-go/v6/pkg/set"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
@@ -243,6 +247,19 @@ func (sys *NotificationSys) LoadGroup(group string) []NotificationPeerErr {
 	return ng.Wait()
 }
 
+// LoadTierConfig - reloads the remote tiers across all peers
+func (sys *NotificationSys) LoadTierConfig() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(GlobalContext, func() error { return client.LoadTierConfig() }, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // DeleteServiceAccount - deletes a specific service account across all peers
 func (sys *NotificationSys) DeleteServiceAccount(accessKey string) []NotificationPeerErr {
 	ng := WithNPeers(len(sys.peerClients))
@@ -568,6 +585,10 @@ func (sys *NotificationSys) DeleteBucket(ctx context.Context, bucketName string)
 	globalNotificationSys.RemoveNotification(bucketName)
 	globalBucketObjectLockConfig.Remove(bucketName)
 	globalBucketQuotaSys.Remove(bucketName)
+	globalBucketVersioningSys.Remove(bucketName)
+	globalBucketReplicationSys.Remove(bucketName)
+	globalBucketLoggingSys.Remove(bucketName)
+	globalBucketCorsSys.Remove(bucketName)
 	globalPolicySys.Remove(bucketName)
 	globalLifecycleSys.Remove(bucketName)
 
@@ -672,6 +693,111 @@ func (sys *NotificationSys) RemoveBucketLifecycle(ctx context.Context, bucketNam
 	}()
 }
 
+// SetBucketReplicationConfig - calls SetBucketReplicationConfig on all peers.
+func (sys *NotificationSys) SetBucketReplicationConfig(ctx context.Context, bucketName string,
+	replicationConfig *replication.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketReplicationConfig(bucketName, replicationConfig)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketReplicationConfig - calls RemoveBucketReplicationConfig on all peers.
+func (sys *NotificationSys) RemoveBucketReplicationConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketReplicationConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketLoggingConfig - calls SetBucketLoggingConfig on all peers.
+func (sys *NotificationSys) SetBucketLoggingConfig(ctx context.Context, bucketName string,
+	loggingConfig *logging.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketLoggingConfig(bucketName, loggingConfig)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketLoggingConfig - calls RemoveBucketLoggingConfig on all peers.
+func (sys *NotificationSys) RemoveBucketLoggingConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketLoggingConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketCorsConfig - calls SetBucketCorsConfig on all peers.
+func (sys *NotificationSys) SetBucketCorsConfig(ctx context.Context, bucketName string,
+	corsConfig *cors.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketCorsConfig(bucketName, corsConfig)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketCorsConfig - calls RemoveBucketCorsConfig on all peers.
+func (sys *NotificationSys) RemoveBucketCorsConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketCorsConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
 // SetBucketSSEConfig - calls SetBucketSSEConfig on all peers.
 func (sys *NotificationSys) SetBucketSSEConfig(ctx context.Context, bucketName string,
 	encConfig *bucketsse.BucketSSEConfig) {
@@ -944,6 +1070,26 @@ func (sys *NotificationSys) PutBucketQuotaConfig(ctx context.Context, bucketName
 	}
 }
 
+// PutBucketVersioningConfig - put bucket versioning configuration to all peers.
+func (sys *NotificationSys) PutBucketVersioningConfig(ctx context.Context, bucketName string, v versioning.Versioning) {
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			return sys.peerClients[index].PutBucketVersioningConfig(bucketName, v)
+		}, index)
+	}
+	for i, err := range g.Wait() {
+		if err != nil {
+			logger.GetReqInfo(ctx).AppendTags("remotePeer", sys.peerClients[i].host.String())
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
 // NetOBDInfo - Net OBD information
 func (sys *NotificationSys) NetOBDInfo(ctx context.Context) madmin.ServerNetOBDInfo {
 	var sortedGlobalEndpoints []string
@@ -1296,8 +1442,14 @@ type eventArgs struct {
 	Object       ObjectInfo
 	ReqParams    map[string]string
 	RespElements map[string]string
-	Host         string
-	UserAgent    string
+	// ExtraElements are added to the response elements of the event.
+	ExtraElements map[string]string
+	Host          string
+	UserAgent     string
+
+	// fromGateway is set for events sent by object layers
+	// implementing GatewayEventSender.
+	fromGateway bool
 }
 
 // ToEvent - converts to notification event.
@@ -1316,6 +1468,9 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 	if args.RespElements["content-length"] != "" {
 		respElements["content-length"] = args.RespElements["content-length"]
 	}
+	for k, v := range args.ExtraElements {
+		respElements[k] = v
+	}
 	keyName := args.Object.Name
 	if escape {
 		keyName = url.QueryEscape(args.Object.Name)
@@ -1381,6 +1536,12 @@ func sendEvent(args eventArgs) {
 		return
 	}
 
+	// Gateways which send their own object events are not notified twice.
+	if !args.fromGateway && isObjectChangeEvent(args.EventName) &&
+		gatewaySendsObjectEvents(newObjectLayerFn()) {
+		return
+	}
+
 	if globalHTTPListen.HasSubscribers() {
 		globalHTTPListen.Publish(args.ToEvent(false))
 	}
@@ -86,6 +86,9 @@ func deleteBucketMetadata(ctx context.Context, bucket string, objAPI ObjectLayer
 
 	// Delete notification config, if present - ignore any errors.
 	removeNotificationConfig(ctx, objAPI, bucket)
+
+	// Delete the owner of the bucket, if present.
+	globalMeteringSys.RemoveBucketOwner(ctx, objAPI, bucket)
 }
 
 // Depending on the disk type network or local, initialize storage API.
@@ -419,6 +422,132 @@ func listObjects(ctx context.Context, obj ObjectLayer, bucket, prefix, marker, d
 	return result, nil
 }
 
+// removeDeleteMarkers - removes the objects whose current version is a
+// delete marker from a listing, they are only listed with their versions.
+func removeDeleteMarkers(loi ListObjectsInfo) ListObjectsInfo {
+	objInfos := loi.Objects[:0]
+	for _, objInfo := range loi.Objects {
+		if objInfo.DeleteMarker {
+			continue
+		}
+		objInfos = append(objInfos, objInfo)
+	}
+	loi.Objects = objInfos
+	return loi
+}
+
+// listObjectVersions - lists the versions of the objects listed by listFn,
+// the versions of an object are returned by versionsFn. The versions of
+// keyMarker after versionIDMarker are listed first, the other objects after
+// keyMarker are listed in the order of their names.
+func listObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int,
+	listFn func(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsInfo, error),
+	versionsFn func(ctx context.Context, bucket, object string) ([]ObjectInfo, error)) (result ListObjectVersionsInfo, err error) {
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	// With max keys of zero we have reached eof, return right here.
+	if maxKeys == 0 {
+		return result, nil
+	}
+
+	var count int
+	truncate := func() {
+		result.IsTruncated = true
+		if n := len(result.Objects); n > 0 && (len(result.Prefixes) == 0 || result.Objects[n-1].Name > result.Prefixes[len(result.Prefixes)-1]) {
+			result.NextMarker = result.Objects[n-1].Name
+			result.NextVersionIDMarker = result.Objects[n-1].VersionID
+			if result.NextVersionIDMarker == "" {
+				result.NextVersionIDMarker = nullVersionID
+			}
+		} else {
+			result.NextMarker = result.Prefixes[len(result.Prefixes)-1]
+		}
+	}
+	// addVersions adds versions to the result, it returns false once maxKeys are listed.
+	addVersions := func(versions []ObjectInfo) bool {
+		for _, version := range versions {
+			if count == maxKeys {
+				truncate()
+				return false
+			}
+			result.Objects = append(result.Objects, version)
+			count++
+		}
+		return true
+	}
+	getVersions := func(objInfo ObjectInfo) ([]ObjectInfo, error) {
+		versions, err := versionsFn(ctx, bucket, objInfo.Name)
+		if err != nil {
+			// The object might have got deleted in the interim period of
+			// listing, directory objects do not keep versions.
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				if HasSuffix(objInfo.Name, SlashSeparator) {
+					objInfo.IsLatest = true
+					return []ObjectInfo{objInfo}, nil
+				}
+				return nil, nil
+			}
+			return nil, err
+		}
+		return versions, nil
+	}
+
+	// List the remaining versions of keyMarker first.
+	if keyMarker != "" && versionIDMarker != "" {
+		versions, err := getVersions(ObjectInfo{Bucket: bucket, Name: keyMarker})
+		if err != nil {
+			return result, err
+		}
+		for i, version := range versions {
+			if version.VersionID == versionIDMarker || (version.VersionID == "" && versionIDMarker == nullVersionID) {
+				if !addVersions(versions[i+1:]) {
+					return result, nil
+				}
+				break
+			}
+		}
+	}
+
+	marker := keyMarker
+	for {
+		loi, err := listFn(ctx, bucket, prefix, marker, delimiter, maxObjectList)
+		if err != nil {
+			return result, err
+		}
+
+		// Merge the objects and the prefixes, both are sorted by name.
+		objInfos, prefixes := loi.Objects, loi.Prefixes
+		for len(objInfos) > 0 || len(prefixes) > 0 {
+			if len(prefixes) > 0 && (len(objInfos) == 0 || prefixes[0] < objInfos[0].Name) {
+				if count == maxKeys {
+					truncate()
+					return result, nil
+				}
+				result.Prefixes = append(result.Prefixes, prefixes[0])
+				prefixes = prefixes[1:]
+				count++
+				continue
+			}
+			versions, err := getVersions(objInfos[0])
+			if err != nil {
+				return result, err
+			}
+			objInfos = objInfos[1:]
+			if !addVersions(versions) {
+				return result, nil
+			}
+		}
+
+		if !loi.IsTruncated || loi.NextMarker == "" {
+			return result, nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
 // Fetch the histogram interval corresponding
 // to the passed object size.
 func objSizeToHistoInterval(usize uint64) string {
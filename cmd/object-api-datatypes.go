@@ -95,6 +95,17 @@ var ObjectsHistogramIntervals = []objectHistogramInterval{
 	{"GREATER_THAN_512_MB", 1024 * 1024 * 512, math.MaxInt64},
 }
 
+// ObjectsHistogramIntervalName returns the name of the interval
+// in ObjectsHistogramIntervals that contains the given size.
+func ObjectsHistogramIntervalName(size int64) string {
+	for _, interval := range ObjectsHistogramIntervals {
+		if size >= interval.start && size <= interval.end {
+			return interval.name
+		}
+	}
+	return ""
+}
+
 // DataUsageInfo represents data usage stats of the underlying Object API
 type DataUsageInfo struct {
 	// LastUpdate is the timestamp of when the data usage info was last updated.
@@ -168,6 +179,15 @@ type ObjectInfo struct {
 	// User-Defined object tags
 	UserTags string
 
+	// VersionID of the object version, empty for the null version.
+	VersionID string
+
+	// IsLatest indicates if this is the current version of the object.
+	IsLatest bool
+
+	// DeleteMarker indicates if the version is a delete marker.
+	DeleteMarker bool
+
 	// List of individual parts, maximum size of upto 10,000
 	Parts []ObjectPartInfo `json:"-"`
 
@@ -302,6 +322,26 @@ type ListObjectsInfo struct {
 	Prefixes []string
 }
 
+// ListObjectVersionsInfo - container for list object versions.
+type ListObjectVersionsInfo struct {
+	// Indicates whether the returned list is truncated, the versions
+	// after NextMarker and NextVersionIDMarker are listed by the next
+	// request.
+	IsTruncated bool
+
+	// When response is truncated, the key and the version ID of the
+	// last listed version.
+	NextMarker          string
+	NextVersionIDMarker string
+
+	// List of the versions of the objects, newest first for an object,
+	// including the delete markers.
+	Objects []ObjectInfo
+
+	// List of prefixes for this request.
+	Prefixes []string
+}
+
 // ListObjectsV2Info - container for list objects version 2.
 type ListObjectsV2Info struct {
 	// Indicates whether the returned list objects response is truncated. A
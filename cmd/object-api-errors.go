@@ -80,6 +80,27 @@ func toObjectErr(err error, params ...string) error {
 				UploadID: params[2],
 			}
 		}
+	case errFileVersionNotFound:
+		switch len(params) {
+		case 2:
+			err = VersionNotFound{
+				Bucket: params[0],
+				Object: params[1],
+			}
+		case 3:
+			err = VersionNotFound{
+				Bucket:    params[0],
+				Object:    params[1],
+				VersionID: params[2],
+			}
+		}
+	case errFileIsDeleteMarker:
+		if len(params) >= 2 {
+			err = MethodNotAllowed{
+				Bucket: params[0],
+				Object: params[1],
+			}
+		}
 	case errFileNameTooLong:
 		if len(params) >= 2 {
 			err = ObjectNameInvalid{
@@ -189,6 +210,24 @@ func (e ObjectNotFound) Error() string {
 	return "Object not found: " + e.Bucket + "#" + e.Object
 }
 
+// VersionNotFound version of an object does not exist.
+type VersionNotFound struct {
+	Bucket    string
+	Object    string
+	VersionID string
+}
+
+func (e VersionNotFound) Error() string {
+	return "Version not found: " + e.Bucket + "#" + e.Object + " (" + e.VersionID + ")"
+}
+
+// MethodNotAllowed the version of an object is a delete marker.
+type MethodNotAllowed GenericError
+
+func (e MethodNotAllowed) Error() string {
+	return "Method not allowed: " + e.Bucket + "#" + e.Object
+}
+
 // ObjectAlreadyExists object already exists.
 type ObjectAlreadyExists GenericError
 
@@ -272,6 +311,20 @@ func (e BucketSSEConfigNotFound) Error() string {
 	return "No bucket encryption found for bucket: " + e.Bucket
 }
 
+// BucketReplicationConfigNotFound - no bucket replication config found.
+type BucketReplicationConfigNotFound GenericError
+
+func (e BucketReplicationConfigNotFound) Error() string {
+	return "No bucket replication config found for bucket: " + e.Bucket
+}
+
+// BucketCorsConfigNotFound - no bucket CORS config found.
+type BucketCorsConfigNotFound GenericError
+
+func (e BucketCorsConfigNotFound) Error() string {
+	return "No bucket CORS config found for bucket: " + e.Bucket
+}
+
 // BucketQuotaConfigNotFound - no bucket quota config found.
 type BucketQuotaConfigNotFound GenericError
 
@@ -463,6 +516,12 @@ func isErrObjectNotFound(err error) bool {
 	return errors.As(err, &objNotFound)
 }
 
+// isErrVersionNotFound - Check if error type is VersionNotFound.
+func isErrVersionNotFound(err error) bool {
+	var versionNotFound VersionNotFound
+	return errors.As(err, &versionNotFound)
+}
+
 // PreConditionFailed - Check if copy precondition failed
 type PreConditionFailed struct{}
 
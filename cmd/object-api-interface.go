@@ -33,6 +33,10 @@ import (
 // CheckCopyPreconditionFn returns true if copy precondition check failed.
 type CheckCopyPreconditionFn func(o ObjectInfo, encETag string) bool
 
+// CheckPutPreconditionFn returns true if the object replaced by a put,
+// empty if there is none, fails the precondition of the put.
+type CheckPutPreconditionFn func(o ObjectInfo) bool
+
 // GetObjectInfoFn is the signature of GetObjectInfo function.
 type GetObjectInfoFn func(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 
@@ -42,6 +46,11 @@ type ObjectOptions struct {
 	UserDefined          map[string]string
 	PartNumber           int
 	CheckCopyPrecondFn   CheckCopyPreconditionFn
+	CheckPutPrecondFn    CheckPutPreconditionFn
+	VersionID            string // version of the object, "null" for the null version
+	Versioned            bool   // a write creates a new version of the object
+	VersionSuspended     bool   // a write replaces the null version of the object
+	NoLock               bool   // GetObjectInfo does not lock the object, the caller holds its lock
 }
 
 // LockType represents required locking for ObjectLayer operations
@@ -103,7 +112,7 @@ type ObjectLayer interface {
 	HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error)
 	HealBucket(ctx context.Context, bucket string, dryRun, remove bool) (madmin.HealResultItem, error)
 	HealObject(ctx context.Context, bucket, object string, opts madmin.HealOpts) (madmin.HealResultItem, error)
-	HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn healObjectFn) error
+	HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn HealObjectFn) error
 
 	ListBucketsHeal(ctx context.Context) (buckets []BucketInfo, err error)
 
@@ -120,6 +129,12 @@ type ObjectLayer interface {
 	// Compression support check.
 	IsCompressionSupported() bool
 
+	// Data usage support check.
+	IsDataUsageSupported() bool
+
+	// Versioning support check.
+	IsVersioningSupported() bool
+
 	// Lifecycle operations
 	SetBucketLifecycle(context.Context, string, *lifecycle.Lifecycle) error
 	GetBucketLifecycle(context.Context, string) (*lifecycle.Lifecycle, error)
@@ -136,6 +151,10 @@ type ObjectLayer interface {
 	// Check Readiness
 	IsReady(ctx context.Context) bool
 
+	// Versioning operations
+	DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error)
+	ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error)
+
 	// ObjectTagging operations
 	PutObjectTag(context.Context, string, string, string) error
 	GetObjectTag(context.Context, string, string) (tagging.Tagging, error)
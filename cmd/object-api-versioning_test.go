@@ -0,0 +1,145 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+// Wrapper for calling object versioning tests for both XL multiple disks and single node setup.
+func TestObjectVersions(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectVersions)
+}
+
+// Unit test for the versions of the objects of versioned buckets.
+func testObjectVersions(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "dir/object"
+
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: Unable to create bucket: %v", instanceType, err)
+	}
+
+	putObject := func(content string, opts ObjectOptions) ObjectInfo {
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(content)), int64(len(content)), "", ""), opts)
+		if err != nil {
+			t.Fatalf("%s: Unable to put object: %v", instanceType, err)
+		}
+		return objInfo
+	}
+	getObject := func(versionID string) string {
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatalf("%s: Unable to get version %q: %v", instanceType, versionID, err)
+		}
+		defer gr.Close()
+		data, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatalf("%s: Unable to read version %q: %v", instanceType, versionID, err)
+		}
+		return string(data)
+	}
+	listVersions := func() []ObjectInfo {
+		result, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+		if err != nil {
+			t.Fatalf("%s: Unable to list versions: %v", instanceType, err)
+		}
+		return result.Objects
+	}
+
+	versioned := ObjectOptions{Versioned: true}
+	v1 := putObject("version 1", versioned)
+	v2 := putObject("version 2", versioned)
+	if v1.VersionID == "" || v2.VersionID == "" || v1.VersionID == v2.VersionID {
+		t.Fatalf("%s: Expected distinct version IDs, got %q and %q", instanceType, v1.VersionID, v2.VersionID)
+	}
+
+	if content := getObject(""); content != "version 2" {
+		t.Errorf("%s: Expected the latest version, got %q", instanceType, content)
+	}
+	if content := getObject(v1.VersionID); content != "version 1" {
+		t.Errorf("%s: Expected the first version, got %q", instanceType, content)
+	}
+
+	// Deleting the object adds a delete marker.
+	marker, err := obj.DeleteObjectVersion(ctx, bucket, object, versioned)
+	if err != nil {
+		t.Fatalf("%s: Unable to delete object: %v", instanceType, err)
+	}
+	if !marker.DeleteMarker || marker.VersionID == "" {
+		t.Fatalf("%s: Expected a delete marker, got %#v", instanceType, marker)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Errorf("%s: Expected ObjectNotFound, got %v", instanceType, err)
+	}
+	loi, err := obj.ListObjects(ctx, bucket, "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: Unable to list objects: %v", instanceType, err)
+	}
+	if len(loi.Objects) != 0 {
+		t.Errorf("%s: Expected no objects, got %d", instanceType, len(loi.Objects))
+	}
+
+	versions := listVersions()
+	expected := []string{marker.VersionID, v2.VersionID, v1.VersionID}
+	if len(versions) != len(expected) {
+		t.Fatalf("%s: Expected %d versions, got %d", instanceType, len(expected), len(versions))
+	}
+	for i, version := range versions {
+		if version.Name != object || version.VersionID != expected[i] || version.IsLatest != (i == 0) {
+			t.Errorf("%s: Unexpected version %d: %#v", instanceType, i, version)
+		}
+	}
+	if !versions[0].DeleteMarker {
+		t.Errorf("%s: Expected the latest version to be a delete marker", instanceType)
+	}
+
+	// Removing the delete marker restores the previous version.
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, ObjectOptions{VersionID: marker.VersionID}); err != nil {
+		t.Fatalf("%s: Unable to delete the delete marker: %v", instanceType, err)
+	}
+	if content := getObject(""); content != "version 2" {
+		t.Errorf("%s: Expected the second version, got %q", instanceType, content)
+	}
+
+	// Removing the current version makes the previous one current.
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, ObjectOptions{VersionID: v2.VersionID}); err != nil {
+		t.Fatalf("%s: Unable to delete the second version: %v", instanceType, err)
+	}
+	if content := getObject(""); content != "version 1" {
+		t.Errorf("%s: Expected the first version, got %q", instanceType, content)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: v2.VersionID}); !isErrVersionNotFound(err) {
+		t.Errorf("%s: Expected VersionNotFound, got %v", instanceType, err)
+	}
+
+	// With versioning suspended the null version is replaced.
+	suspended := ObjectOptions{VersionSuspended: true}
+	putObject("null 1", suspended)
+	if nullVersion := putObject("null 2", suspended); nullVersion.VersionID != "" {
+		t.Errorf("%s: Expected the null version, got %q", instanceType, nullVersion.VersionID)
+	}
+	if content := getObject(nullVersionID); content != "null 2" {
+		t.Errorf("%s: Expected the null version, got %q", instanceType, content)
+	}
+	if versions = listVersions(); len(versions) != 2 {
+		t.Errorf("%s: Expected 2 versions, got %d", instanceType, len(versions))
+	}
+}
@@ -254,28 +254,45 @@ func isETagEqual(left, right string) bool {
 
 // deleteObject is a convenient wrapper to delete an object, this
 // is a common function to be called from object handlers and
-// web handlers.
-func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer, bucket, object string, r *http.Request) (err error) {
-	deleteObject := obj.DeleteObject
-	if cache != nil {
-		deleteObject = cache.DeleteObject
+// web handlers. On object layers keeping versions the version in
+// opts is deleted, or a delete marker is added on versioned buckets.
+func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer, bucket, object string, opts ObjectOptions, r *http.Request) (objInfo ObjectInfo, err error) {
+	objInfo = ObjectInfo{Bucket: bucket, Name: object}
+	if obj.IsVersioningSupported() && (opts.VersionID != "" || opts.Versioned || opts.VersionSuspended) {
+		// The disk cache only runs with the gateways, which do not keep versions.
+		if objInfo, err = obj.DeleteObjectVersion(ctx, bucket, object, opts); err != nil {
+			return objInfo, err
+		}
+	} else {
+		deleteObject := obj.DeleteObject
+		if cache != nil {
+			deleteObject = cache.DeleteObject
+		}
+		// Proceed to delete the object.
+		if err = deleteObject(ctx, bucket, object); err != nil {
+			return objInfo, err
+		}
 	}
-	// Proceed to delete the object.
-	if err = deleteObject(ctx, bucket, object); err != nil {
-		return err
+
+	eventName := event.ObjectRemovedDelete
+	if objInfo.DeleteMarker && opts.VersionID == "" {
+		eventName = event.ObjectRemovedDeleteMarkerCreated
 	}
 
 	// Notify object deleted event.
 	sendEvent(eventArgs{
-		EventName:  event.ObjectRemovedDelete,
+		EventName:  eventName,
 		BucketName: bucket,
-		Object: ObjectInfo{
-			Name: object,
-		},
-		ReqParams: extractReqParams(r),
-		UserAgent: r.UserAgent(),
-		Host:      handlers.GetSourceIP(r),
+		Object:     objInfo,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       handlers.GetSourceIP(r),
 	})
 
-	return nil
+	// Deletes of versions are not replicated.
+	if opts.VersionID == "" {
+		scheduleReplicationDelete(ctx, bucket, object)
+	}
+
+	return objInfo, nil
 }
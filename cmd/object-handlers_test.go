@@ -1763,7 +1763,7 @@ func testAPICopyObjectPartHandler(obj ObjectLayer, instanceType, bucketName stri
 		{
 			bucketName:         bucketName,
 			uploadID:           uploadID,
-			copySourceHeader:   url.QueryEscape(SlashSeparator+bucketName+SlashSeparator+objectName) + "?versionId=17",
+			copySourceHeader:   url.QueryEscape(SlashSeparator+bucketName+SlashSeparator+objectName) + "?versionId=cf8b5bbc-6dc9-4c6c-9d1b-6f0e3a7c2d14",
 			accessKey:          credentials.AccessKey,
 			secretKey:          credentials.SecretKey,
 			expectedRespStatus: http.StatusNotFound,
@@ -1783,7 +1783,7 @@ func testAPICopyObjectPartHandler(obj ObjectLayer, instanceType, bucketName stri
 			bucketName:          bucketName,
 			uploadID:            uploadID,
 			copySourceHeader:    url.QueryEscape(SlashSeparator + bucketName + SlashSeparator + objectName),
-			copySourceVersionID: "17",
+			copySourceVersionID: "cf8b5bbc-6dc9-4c6c-9d1b-6f0e3a7c2d14",
 			accessKey:           credentials.AccessKey,
 			secretKey:           credentials.SecretKey,
 			expectedRespStatus:  http.StatusNotFound,
@@ -2156,7 +2156,7 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 		{
 			bucketName:         bucketName,
 			newObjectName:      "newObject1",
-			copySourceHeader:   url.QueryEscape(SlashSeparator+bucketName+SlashSeparator+objectName) + "?versionId=17",
+			copySourceHeader:   url.QueryEscape(SlashSeparator+bucketName+SlashSeparator+objectName) + "?versionId=cf8b5bbc-6dc9-4c6c-9d1b-6f0e3a7c2d14",
 			accessKey:          credentials.AccessKey,
 			secretKey:          credentials.SecretKey,
 			expectedRespStatus: http.StatusNotFound,
@@ -2176,7 +2176,7 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 			bucketName:          bucketName,
 			newObjectName:       "newObject1",
 			copySourceHeader:    url.QueryEscape(SlashSeparator + bucketName + SlashSeparator + objectName),
-			copySourceVersionID: "17",
+			copySourceVersionID: "cf8b5bbc-6dc9-4c6c-9d1b-6f0e3a7c2d14",
 			accessKey:           credentials.AccessKey,
 			secretKey:           credentials.SecretKey,
 			expectedRespStatus:  http.StatusNotFound,
@@ -34,10 +34,14 @@ import (
 	"github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/cmd/rest"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
@@ -605,6 +609,99 @@ func (client *peerRESTClient) SetBucketLifecycle(bucket string, bucketLifecycle
 	return nil
 }
 
+// RemoveBucketReplicationConfig - Remove bucket replication configuration on the peer node
+func (client *peerRESTClient) RemoveBucketReplicationConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketReplicationRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketReplicationConfig - Set bucket replication configuration on the peer node
+func (client *peerRESTClient) SetBucketReplicationConfig(bucket string, replicationConfig *replication.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(replicationConfig)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketReplicationSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketLoggingConfig - Remove bucket logging configuration on the peer node
+func (client *peerRESTClient) RemoveBucketLoggingConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketLoggingRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketLoggingConfig - Set bucket logging configuration on the peer node
+func (client *peerRESTClient) SetBucketLoggingConfig(bucket string, loggingConfig *logging.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(loggingConfig)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketLoggingSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketCorsConfig - Remove bucket CORS configuration on the peer node
+func (client *peerRESTClient) RemoveBucketCorsConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketCorsRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketCorsConfig - Set bucket CORS configuration on the peer node
+func (client *peerRESTClient) SetBucketCorsConfig(bucket string, corsConfig *cors.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(corsConfig)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketCorsSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // RemoveBucketSSEConfig - Remove bucket encryption configuration on the peer node
 func (client *peerRESTClient) RemoveBucketSSEConfig(bucket string) error {
 	values := make(url.Values)
@@ -693,6 +790,25 @@ func (client *peerRESTClient) PutBucketQuotaConfig(bucket string, q madmin.Bucke
 	return nil
 }
 
+// PutBucketVersioningConfig - PUT bucket versioning configuration.
+func (client *peerRESTClient) PutBucketVersioningConfig(bucket string, v versioning.Versioning) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(&v)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodPutBucketVersioningConfig, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // DeletePolicy - delete a specific canned policy.
 func (client *peerRESTClient) DeletePolicy(policyName string) (err error) {
 	values := make(url.Values)
@@ -800,6 +916,16 @@ func (client *peerRESTClient) LoadGroup(group string) error {
 	return nil
 }
 
+// LoadTierConfig - reload the remote tiers on the peer node.
+func (client *peerRESTClient) LoadTierConfig() error {
+	respBody, err := client.call(peerRESTMethodLoadTierConfig, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // ServerUpdate - sends server update message to remote peers.
 func (client *peerRESTClient) ServerUpdate(updateURL, sha256Hex string, latestReleaseTime time.Time) error {
 	values := make(url.Values)
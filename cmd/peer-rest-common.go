@@ -65,6 +65,14 @@ const (
 	peerRESTMethodBucketObjectLockConfigRemove = "/removebucketobjectlockconfig"
 	peerRESTMethodPutBucketQuotaConfig         = "/putbucketquotaconfig"
 	peerRESTMethodBucketQuotaConfigRemove      = "/removebucketquotaconfig"
+	peerRESTMethodPutBucketVersioningConfig    = "/putbucketversioningconfig"
+	peerRESTMethodBucketReplicationSet         = "/setbucketreplication"
+	peerRESTMethodBucketReplicationRemove      = "/removebucketreplication"
+	peerRESTMethodBucketLoggingSet             = "/setbucketlogging"
+	peerRESTMethodBucketLoggingRemove          = "/removebucketlogging"
+	peerRESTMethodBucketCorsSet                = "/setbucketcors"
+	peerRESTMethodBucketCorsRemove             = "/removebucketcors"
+	peerRESTMethodLoadTierConfig               = "/loadtierconfig"
 )
 
 const (
@@ -158,6 +158,24 @@ func newAllSubsystems() {
 
 	// Create new bucket quota subsystem
 	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Create new bucket versioning subsystem
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// Create new bucket replication subsystem
+	globalBucketReplicationSys = NewBucketReplicationSys()
+
+	// Create new bucket logging subsystem
+	globalBucketLoggingSys = NewBucketLoggingSys()
+
+	// Create new bucket CORS subsystem
+	globalBucketCorsSys = NewBucketCorsSys()
+
+	// Create new metering subsystem
+	globalMeteringSys = NewMeteringSys()
+
+	// Create new remote tier subsystem
+	globalTierConfigSys = NewTierConfigSys()
 }
 
 func initSafeMode() (err error) {
@@ -310,6 +328,31 @@ func initAllSubsystems(newObject ObjectLayer) (err error) {
 		return fmt.Errorf("Unable to initialize bucket quota system: %w", err)
 	}
 
+	// Initialize bucket versioning system.
+	if err = globalBucketVersioningSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket versioning system: %w", err)
+	}
+
+	// Initialize bucket replication system.
+	if err = globalBucketReplicationSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket replication system: %w", err)
+	}
+
+	// Initialize bucket logging system.
+	if err = globalBucketLoggingSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket logging system: %w", err)
+	}
+
+	// Initialize bucket CORS system.
+	if err = globalBucketCorsSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket CORS system: %w", err)
+	}
+
+	// Initialize remote tiers.
+	if err = globalTierConfigSys.Init(newObject); err != nil {
+		return fmt.Errorf("Unable to initialize remote tiers: %w", err)
+	}
+
 	// Populate existing buckets to the etcd backend
 	if globalDNSConfig != nil {
 		initFederatorBackend(buckets, newObject)
@@ -479,8 +522,17 @@ func serverMain(ctx *cli.Context) {
 
 	go startBackgroundOps(GlobalContext, newObject)
 
+	// Every server saves the usage of the requests it handles
+	globalMeteringSys.Init(GlobalContext, newObject)
+
 	logger.FatalIf(initSafeMode(), "Unable to initialize server switching into safe-mode")
 
+	// Every server replicates the objects it writes
+	logger.LogIf(GlobalContext, initBucketReplication(GlobalContext, newObject))
+
+	// Every server writes the access logs of the requests it serves
+	startBucketLogging(GlobalContext, newObject)
+
 	if globalCacheConfig.Enabled {
 		// initialize the new disk cache objects.
 		var cacheAPI CacheObjectLayer
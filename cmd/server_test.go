@@ -116,6 +116,7 @@ func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.TestObjectMultipartListError(c)
 	suite.TestObjectValidMD5(c)
 	suite.TestObjectMultipart(c)
+	suite.TestBucketVersioning(c)
 	suite.TearDownSuite(c)
 }
 
@@ -580,7 +581,7 @@ func (s *TestSuiteCommon) TestDeleteMultipleObjects(c *check) {
 	c.Assert(err, nil)
 	for i := 0; i < 10; i++ {
 		// All the objects should be under deleted list (including non-existent object)
-		c.Assert(deleteResp.DeletedObjects[i], delObjReq.Objects[i])
+		c.Assert(deleteResp.DeletedObjects[i].ObjectName, delObjReq.Objects[i].ObjectName)
 	}
 	c.Assert(len(deleteResp.Errors), 0)
 
@@ -600,7 +601,7 @@ func (s *TestSuiteCommon) TestDeleteMultipleObjects(c *check) {
 	err = xml.Unmarshal(delRespBytes, &deleteResp)
 	c.Assert(err, nil)
 	for i := 0; i < 10; i++ {
-		c.Assert(deleteResp.DeletedObjects[i], delObjReq.Objects[i])
+		c.Assert(deleteResp.DeletedObjects[i].ObjectName, delObjReq.Objects[i].ObjectName)
 	}
 	c.Assert(len(deleteResp.Errors), 0)
 }
@@ -2736,3 +2737,68 @@ func (s *TestSuiteCommon) TestObjectMultipart(c *check) {
 	etag := getCompleteMultipartMD5(parts)
 	c.Assert(canonicalizeETag(response.Header.Get(xhttp.ETag)), etag)
 }
+
+// TestBucketVersioning - enables versioning on a bucket through the whole
+// server handler chain and validates the versions kept for an object.
+func (s *TestSuiteCommon) TestBucketVersioning(c *check) {
+	bucketName := getRandomBucketName()
+	client := http.Client{Transport: s.transport}
+
+	request, err := newTestSignedRequest("PUT", getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err := client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	versioningURL := makeTestTargetURL(s.endPoint, bucketName, "", url.Values{"versioning": []string{""}})
+	versioningConfig := []byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`)
+	request, err = newTestSignedRequest("PUT", versioningURL,
+		int64(len(versioningConfig)), bytes.NewReader(versioningConfig), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest("GET", versioningURL, 0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	body, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(strings.Contains(string(body), "<Status>Enabled</Status>"), true)
+
+	// Every write of the object keeps a new version.
+	objectName := "versioned-object"
+	versionIDs := make(map[string]bool)
+	for _, data := range []string{"hello one", "hello two"} {
+		request, err = newTestSignedRequest("PUT", getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(len(data)), bytes.NewReader([]byte(data)), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		response, err = client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		versionID := response.Header.Get(xhttp.AmzVersionID)
+		c.Assert(versionID != "" && versionID != nullVersionID, true)
+		versionIDs[versionID] = true
+	}
+	c.Assert(len(versionIDs), 2)
+
+	request, err = newTestSignedRequest("GET", makeTestTargetURL(s.endPoint, bucketName, "", url.Values{"versions": []string{""}}),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	var listResp struct {
+		Versions []struct {
+			VersionID string `xml:"VersionId"`
+		} `xml:"Version"`
+	}
+	c.Assert(xml.NewDecoder(response.Body).Decode(&listResp), nil)
+	c.Assert(len(listResp.Versions), 2)
+	for _, version := range listResp.Versions {
+		c.Assert(versionIDs[version.VersionID], true)
+	}
+}
@@ -60,6 +60,10 @@ func handleSignals() {
 		cancelGlobalContext()
 
 		if objAPI := newObjectLayerWithoutSafeModeFn(); objAPI != nil {
+			// No requests are served anymore, write their access logs
+			// and metering counters.
+			stopBucketLogging(objAPI)
+			globalMeteringSys.Stop(objAPI)
 			oerr = objAPI.Shutdown(context.Background())
 			logger.LogIf(context.Background(), oerr)
 		}
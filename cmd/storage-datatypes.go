@@ -63,6 +63,12 @@ type FileInfo struct {
 	Parts []ObjectPartInfo
 
 	Quorum int
+
+	// Version ID of the current version of the object.
+	VersionID string
+
+	// Set if the current version of the object is a delete marker.
+	DeleteMarker bool
 }
 
 // ToObjectInfo converts FileInfo into objectInfo.
@@ -83,6 +89,8 @@ func (entry FileInfo) ToObjectInfo() ObjectInfo {
 			Size:            entry.Size,
 			ContentType:     entry.Metadata["content-type"],
 			ContentEncoding: entry.Metadata["content-encoding"],
+			VersionID:       entry.VersionID,
+			DeleteMarker:    entry.DeleteMarker,
 		}
 
 		// Extract etag from metadata.
@@ -91,6 +99,9 @@ func (entry FileInfo) ToObjectInfo() ObjectInfo {
 		// All the parts per object.
 		objInfo.Parts = entry.Parts
 
+		// The data of a transitioned object is on its tier.
+		setTransitionedObjectInfo(&objInfo, entry.Metadata)
+
 		// etag/md5Sum has already been extracted. We need to
 		// remove to avoid it from appearing as part of
 		// response headers. e.g, X-Minio-* or X-Amz-*.
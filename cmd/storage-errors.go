@@ -43,6 +43,12 @@ var errDiskAccessDenied = StorageErr("disk access denied")
 // errFileNotFound - cannot find the file.
 var errFileNotFound = StorageErr("file not found")
 
+// errFileVersionNotFound - cannot find the version of the file.
+var errFileVersionNotFound = StorageErr("file version not found")
+
+// errFileIsDeleteMarker - the version of the file is a delete marker.
+var errFileIsDeleteMarker = StorageErr("file version is a delete marker")
+
 // errTooManyOpenFiles - too many open files.
 var errTooManyOpenFiles = StorageErr("too many open files")
 
@@ -425,6 +425,9 @@ func readMetadata(buf []byte, volume, entry string) FileInfo {
 		Metadata: m.Meta,
 		Parts:    m.Parts,
 		Quorum:   m.Erasure.DataBlocks,
+
+		VersionID:    m.VersionID,
+		DeleteMarker: m.DeleteMarker,
 	}
 }
 
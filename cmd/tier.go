@@ -0,0 +1,223 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const tierConfigFile = "tier-config.json"
+
+var (
+	errTierInvalidConfig = errors.New("invalid tier configuration")
+	errTierInvalidName   = errors.New("tier name must be set and must not be a storage class of the server")
+	errTierExists        = errors.New("tier already exists")
+	errTierNotFound      = errors.New("tier not found")
+	errTierInUse         = errors.New("tier is used by a bucket lifecycle")
+)
+
+// TierConfigSys - the remote tiers the lifecycle rules transition objects to.
+type TierConfigSys struct {
+	sync.RWMutex
+	tiers    map[string]madmin.TierConfig
+	backends map[string]warmBackend
+}
+
+// NewTierConfigSys returns initialized TierConfigSys
+func NewTierConfigSys() *TierConfigSys {
+	return &TierConfigSys{
+		tiers:    make(map[string]madmin.TierConfig),
+		backends: make(map[string]warmBackend),
+	}
+}
+
+// Init - loads the tiers from the config, remote tiers are not supported
+// in gateway mode.
+func (sys *TierConfigSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if globalIsGateway {
+		return nil
+	}
+
+	return sys.Load(GlobalContext, objAPI)
+}
+
+// Load - reloads the tiers from the config.
+func (sys *TierConfigSys) Load(ctx context.Context, objAPI ObjectLayer) error {
+	tiers, err := readTierConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	backends := make(map[string]warmBackend, len(tiers))
+	for name, cfg := range tiers {
+		backend, err := newWarmBackend(cfg)
+		if err != nil {
+			return err
+		}
+		backends[name] = backend
+	}
+
+	sys.Lock()
+	sys.tiers = tiers
+	sys.backends = backends
+	sys.Unlock()
+	return nil
+}
+
+// Add - adds a tier after checking that its bucket is reachable and
+// saves the tiers in the config.
+func (sys *TierConfigSys) Add(ctx context.Context, objAPI ObjectLayer, cfg madmin.TierConfig) error {
+	if cfg.Name == "" || cfg.Name == storageclass.STANDARD || cfg.Name == storageclass.RRS {
+		return fmt.Errorf("%w: %v", errTierInvalidConfig, errTierInvalidName)
+	}
+
+	backend, err := newWarmBackend(cfg)
+	if err == nil {
+		err = backend.Check(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", errTierInvalidConfig, err)
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.tiers[cfg.Name]; ok {
+		return errTierExists
+	}
+
+	tiers := make(map[string]madmin.TierConfig, len(sys.tiers)+1)
+	for name, tier := range sys.tiers {
+		tiers[name] = tier
+	}
+	tiers[cfg.Name] = cfg
+	if err = saveTierConfig(ctx, objAPI, tiers); err != nil {
+		return err
+	}
+
+	sys.tiers = tiers
+	sys.backends[cfg.Name] = backend
+	return nil
+}
+
+// Remove - removes a tier which is not used by a bucket lifecycle and
+// saves the tiers in the config.
+func (sys *TierConfigSys) Remove(ctx context.Context, objAPI ObjectLayer, name string) error {
+	if globalLifecycleSys.IsTierUsed(name) {
+		return errTierInUse
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.tiers[name]; !ok {
+		return errTierNotFound
+	}
+
+	tiers := make(map[string]madmin.TierConfig, len(sys.tiers))
+	for n, tier := range sys.tiers {
+		if n != name {
+			tiers[n] = tier
+		}
+	}
+	if err := saveTierConfig(ctx, objAPI, tiers); err != nil {
+		return err
+	}
+
+	sys.tiers = tiers
+	delete(sys.backends, name)
+	return nil
+}
+
+// List - returns the tiers sorted by name, without their secret keys.
+func (sys *TierConfigSys) List() []madmin.TierConfig {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	tiers := make([]madmin.TierConfig, 0, len(sys.tiers))
+	for _, tier := range sys.tiers {
+		tier.SecretKey = ""
+		tiers = append(tiers, tier)
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].Name < tiers[j].Name
+	})
+	return tiers
+}
+
+// Get - returns the remote storage of the tier.
+func (sys *TierConfigSys) Get(name string) (backend warmBackend, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+	backend, ok = sys.backends[name]
+	return
+}
+
+func readTierConfig(ctx context.Context, objAPI ObjectLayer) (map[string]madmin.TierConfig, error) {
+	tiers := make(map[string]madmin.TierConfig)
+	configFile := path.Join(minioConfigPrefix, tierConfigFile)
+	data, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return tiers, nil
+		}
+		return nil, err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = json.Unmarshal(data, &tiers); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+func saveTierConfig(ctx context.Context, objAPI ObjectLayer, tiers map[string]madmin.TierConfig) error {
+	data, err := json.Marshal(tiers)
+	if err != nil {
+		return err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	configFile := path.Join(minioConfigPrefix, tierConfigFile)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
@@ -0,0 +1,104 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-pipeline-go/pipeline"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	warmBackendAzureBlockSize      = 25 * humanize.MiByte
+	warmBackendAzureMaxBuffers     = 4
+	warmBackendAzureRetryAttempts  = 5
+	warmBackendAzureDefaultAddress = "https://%s.blob.core.windows.net"
+)
+
+// warmBackendAzure keeps the transitioned objects in a container of an
+// Azure storage account, with the blob client used by the Azure gateway.
+type warmBackendAzure struct {
+	container azblob.ContainerURL
+	prefix    string
+}
+
+func newWarmBackendAzure(cfg madmin.TierConfig) (*warmBackendAzure, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf(warmBackendAzureDefaultAddress, cfg.AccessKey)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := azblob.NewSharedKeyCredential(cfg.AccessKey, cfg.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Transport: NewGatewayHTTPTransport()}
+	p := azblob.NewPipeline(credential, azblob.PipelineOptions{
+		HTTPSender: pipeline.FactoryFunc(func(next pipeline.Policy, po *pipeline.PolicyOptions) pipeline.PolicyFunc {
+			return func(ctx context.Context, request pipeline.Request) (pipeline.Response, error) {
+				resp, err := httpClient.Do(request.WithContext(ctx))
+				return pipeline.NewHTTPResponse(resp), err
+			}
+		}),
+	})
+	return &warmBackendAzure{
+		container: azblob.NewServiceURL(*u, p).NewContainerURL(cfg.Bucket),
+		prefix:    cfg.Prefix,
+	}, nil
+}
+
+func (az *warmBackendAzure) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	blobURL := az.container.NewBlockBlobURL(az.prefix + object)
+	_, err := azblob.UploadStreamToBlockBlob(ctx, r, blobURL, azblob.UploadStreamToBlockBlobOptions{
+		BufferSize: warmBackendAzureBlockSize,
+		MaxBuffers: warmBackendAzureMaxBuffers,
+	})
+	return err
+}
+
+func (az *warmBackendAzure) Get(ctx context.Context, object string, offset, length int64) (io.ReadCloser, error) {
+	blobURL := az.container.NewBlobURL(az.prefix + object)
+	blob, err := blobURL.Download(ctx, offset, length, azblob.BlobAccessConditions{}, false)
+	if err != nil {
+		return nil, err
+	}
+	return blob.Body(azblob.RetryReaderOptions{MaxRetryRequests: warmBackendAzureRetryAttempts}), nil
+}
+
+func (az *warmBackendAzure) Remove(ctx context.Context, object string) error {
+	blobURL := az.container.NewBlobURL(az.prefix + object)
+	_, err := blobURL.Delete(ctx, azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{})
+	return err
+}
+
+func (az *warmBackendAzure) Check(ctx context.Context) error {
+	_, err := az.container.GetProperties(ctx, azblob.LeaseAccessConditions{})
+	if stErr, ok := err.(azblob.StorageError); ok && stErr.ServiceCode() == azblob.ServiceCodeContainerNotFound {
+		return errTierBucketMissing
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// warmBackendS3 keeps the transitioned objects in a bucket of an S3
+// compatible storage.
+type warmBackendS3 struct {
+	client *miniogo.Client
+	bucket string
+	prefix string
+}
+
+func newWarmBackendS3(cfg madmin.TierConfig) (*warmBackendS3, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3.amazonaws.com"
+	}
+	host, secure, err := ParseGatewayEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	client, err := miniogo.NewWithOptions(host, &miniogo.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       secure,
+		Region:       cfg.Region,
+		BucketLookup: miniogo.BucketLookupAuto,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.SetCustomTransport(NewGatewayHTTPTransport())
+	return &warmBackendS3{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: cfg.Prefix,
+	}, nil
+}
+
+func (s3 *warmBackendS3) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	_, err := s3.client.PutObjectWithContext(ctx, s3.bucket, s3.prefix+object, r, length, miniogo.PutObjectOptions{})
+	return err
+}
+
+func (s3 *warmBackendS3) Get(ctx context.Context, object string, offset, length int64) (io.ReadCloser, error) {
+	opts := miniogo.GetObjectOptions{}
+	if err := opts.SetRange(offset, offset+length-1); err != nil {
+		return nil, err
+	}
+	return s3.client.GetObjectWithContext(ctx, s3.bucket, s3.prefix+object, opts)
+}
+
+func (s3 *warmBackendS3) Remove(ctx context.Context, object string) error {
+	return s3.client.RemoveObject(s3.bucket, s3.prefix+object)
+}
+
+func (s3 *warmBackendS3) Check(ctx context.Context) error {
+	ok, err := s3.client.BucketExistsWithContext(ctx, s3.bucket)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errTierBucketMissing
+	}
+	return nil
+}
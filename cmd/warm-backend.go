@@ -0,0 +1,62 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// warmBackend is the remote storage of a tier, it keeps the data of the
+// objects transitioned to the tier under the bucket and prefix of the tier.
+type warmBackend interface {
+	// Put uploads length bytes read from r as the object.
+	Put(ctx context.Context, object string, r io.Reader, length int64) error
+	// Get returns a reader of length bytes of the object starting at offset.
+	Get(ctx context.Context, object string, offset, length int64) (io.ReadCloser, error)
+	// Remove removes the object.
+	Remove(ctx context.Context, object string) error
+	// Check verifies that the bucket of the tier is reachable with its credentials.
+	Check(ctx context.Context) error
+}
+
+var (
+	errTierInvalidType   = errors.New("tier type must be s3 or azure")
+	errTierMissingBucket = errors.New("tier bucket must be set")
+	errTierMissingCreds  = errors.New("tier access and secret keys must be set")
+	errTierBucketMissing = errors.New("tier bucket does not exist")
+)
+
+// newWarmBackend returns the remote storage of the tier.
+func newWarmBackend(cfg madmin.TierConfig) (warmBackend, error) {
+	if cfg.Bucket == "" {
+		return nil, errTierMissingBucket
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errTierMissingCreds
+	}
+	switch cfg.Type {
+	case madmin.TierS3:
+		return newWarmBackendS3(cfg)
+	case madmin.TierAzure:
+		return newWarmBackendAzure(cfg)
+	}
+	return nil, errTierInvalidType
+}
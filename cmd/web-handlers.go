@@ -599,6 +599,19 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 		deleteObjects = web.CacheAPI().DeleteObjects
 	}
 
+	// Objects of versioned buckets get a delete marker each.
+	var versionOpts ObjectOptions
+	setVersioningOpts(args.BucketName, &versionOpts)
+	if objectAPI.IsVersioningSupported() && (versionOpts.Versioned || versionOpts.VersionSuspended) {
+		deleteObjects = func(ctx context.Context, bucket string, objects []string) ([]error, error) {
+			errs := make([]error, len(objects))
+			for idx, object := range objects {
+				_, errs[idx] = objectAPI.DeleteObjectVersion(ctx, bucket, object, versionOpts)
+			}
+			return errs, nil
+		}
+	}
+
 	claims, owner, authErr := webRequestAuthenticate(r)
 	if authErr != nil {
 		if authErr == errNoAuthToken {
@@ -723,7 +736,7 @@ next:
 				return toJSONError(ctx, errAccessDenied)
 			}
 			if apiErr == ErrNone {
-				if err = deleteObject(ctx, objectAPI, web.CacheAPI(), args.BucketName, objectName, r); err != nil {
+				if _, err = deleteObject(ctx, objectAPI, web.CacheAPI(), args.BucketName, objectName, versionOpts, r); err != nil {
 					break next
 				}
 			}
@@ -948,7 +961,7 @@ func (web *webAPIHandlers) CreateURLToken(r *http.Request, args *WebGenericArgs,
 func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "WebUpload")
 
-	defer logger.AuditLog(w, r, "WebUpload", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "WebUpload", mustGetClaimsFromToken(r))
 
 	objectAPI := web.ObjectAPI()
 	if objectAPI == nil {
@@ -1043,6 +1056,11 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := enforceBucketQuota(ctx, bucket, size); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
 	// Extract incoming metadata if any.
 	metadata, err := extractMetadata(ctx, r)
 	if err != nil {
@@ -1171,7 +1189,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "WebDownload")
 
-	defer logger.AuditLog(w, r, "WebDownload", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "WebDownload", mustGetClaimsFromToken(r))
 
 	objectAPI := web.ObjectAPI()
 	if objectAPI == nil {
@@ -1364,7 +1382,7 @@ func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 	host := handlers.GetSourceIP(r)
 
 	ctx := newContext(r, w, "WebDownloadZip")
-	defer logger.AuditLog(w, r, "WebDownloadZip", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "WebDownloadZip", mustGetClaimsFromToken(r))
 
 	objectAPI := web.ObjectAPI()
 	if objectAPI == nil {
@@ -676,6 +676,16 @@ func (s *xlSets) IsCompressionSupported() bool {
 	return s.getHashedSet("").IsCompressionSupported()
 }
 
+// IsDataUsageSupported returns whether data usage crawling is implemented for this layer.
+func (s *xlSets) IsDataUsageSupported() bool {
+	return true
+}
+
+// IsVersioningSupported returns whether bucket versioning is implemented for this layer.
+func (s *xlSets) IsVersioningSupported() bool {
+	return s.getHashedSet("").IsVersioningSupported()
+}
+
 // DeleteBucket - deletes a bucket on all sets simultaneously,
 // even if one of the sets fail to delete buckets, we proceed to
 // undo a successful operation.
@@ -760,6 +770,11 @@ func (s *xlSets) DeleteObject(ctx context.Context, bucket string, object string)
 	return s.getHashedSet(object).DeleteObject(ctx, bucket, object)
 }
 
+// DeleteObjectVersion - deletes a version of an object from the hashedSet based on the object name.
+func (s *xlSets) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	return s.getHashedSet(object).DeleteObjectVersion(ctx, bucket, object, opts)
+}
+
 // DeleteObjects - bulk delete of objects
 // Bulk delete is only possible within one set. For that purpose
 // objects are group by set first, and then bulk delete is invoked
@@ -822,7 +837,12 @@ func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucke
 		return srcSet.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
 	}
 
-	putOpts := ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined}
+	putOpts := ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+	}
 	return destSet.putObject(ctx, destBucket, destObject, srcInfo.PutObjReader, putOpts)
 }
 
@@ -1066,6 +1086,8 @@ func (s *xlSets) listObjectsNonSlash(ctx context.Context, bucket, prefix, marker
 				Size:            result.Size,
 				ContentType:     result.Metadata["content-type"],
 				ContentEncoding: result.Metadata["content-encoding"],
+				VersionID:       result.VersionID,
+				DeleteMarker:    result.DeleteMarker,
 			}
 
 			// Extract etag from metadata.
@@ -1210,7 +1232,18 @@ func (s *xlSets) listObjects(ctx context.Context, bucket, prefix, marker, delimi
 // walked and merged at this layer. Resulting value through the merge process sends
 // the data in lexically sorted order.
 func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, err error) {
-	return s.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	loi, err = s.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	return removeDeleteMarkers(loi), err
+}
+
+// ListObjectVersions - lists the versions of the objects across all sets.
+func (s *xlSets) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	return listObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys, s.listObjects, s.getObjectVersions)
+}
+
+// getObjectVersions - returns the versions of an object from the hashedSet based on the object name.
+func (s *xlSets) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	return s.getHashedSet(object).getObjectVersions(ctx, bucket, object)
 }
 
 func (s *xlSets) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
@@ -1732,7 +1765,7 @@ func (s *xlSets) Walk(ctx context.Context, bucket, prefix string, results chan<-
 				return
 			}
 
-			if quorumCount >= s.drivesPerSet/2 {
+			if quorumCount >= s.drivesPerSet/2 && !entry.DeleteMarker {
 				results <- entry.ToObjectInfo() // Read quorum exists proceed
 			}
 			// skip entries which do not have quorum
@@ -1744,7 +1777,7 @@ func (s *xlSets) Walk(ctx context.Context, bucket, prefix string, results chan<-
 
 // HealObjects - Heal all objects recursively at a specified prefix, any
 // dangling objects deleted as well automatically.
-func (s *xlSets) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObject healObjectFn) error {
+func (s *xlSets) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObject HealObjectFn) error {
 	endWalkCh := make(chan struct{})
 	defer close(endWalkCh)
 
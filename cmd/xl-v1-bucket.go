@@ -340,3 +340,14 @@ func (xl xlObjects) IsEncryptionSupported() bool {
 func (xl xlObjects) IsCompressionSupported() bool {
 	return true
 }
+
+// IsDataUsageSupported returns whether data usage crawling is implemented for this layer.
+// Crawling is done by xlSets for individual xlObjects.
+func (xl xlObjects) IsDataUsageSupported() bool {
+	return false
+}
+
+// IsVersioningSupported returns whether bucket versioning is implemented for this layer.
+func (xl xlObjects) IsVersioningSupported() bool {
+	return true
+}
@@ -18,7 +18,6 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
@@ -169,42 +168,49 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 			continue
 		}
 
-		switch scanMode {
-		case madmin.HealDeepScan:
-			erasureInfo := partsMetadata[i].Erasure
-			erasure, err := NewErasure(ctx, erasureInfo.DataBlocks, erasureInfo.ParityBlocks, erasureInfo.BlockSize)
-			if err != nil {
-				dataErrs[i] = err
+		// The parts of every version of the object are checked.
+	versionsLoop:
+		for _, version := range partsMetadata[i].versions() {
+			if version.DeleteMarker {
 				continue
 			}
-
-			// disk has a valid xl.json but may not have all the
-			// parts. This is considered an outdated disk, since
-			// it needs healing too.
-			for _, part := range partsMetadata[i].Parts {
-				checksumInfo := erasureInfo.GetChecksumInfo(part.Number)
-				partPath := pathJoin(object, fmt.Sprintf("part.%d", part.Number))
-				err = onlineDisk.VerifyFile(bucket, partPath, erasure.ShardFileSize(part.Size), checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+			switch scanMode {
+			case madmin.HealDeepScan:
+				erasureInfo := version.Erasure
+				erasure, err := NewErasure(ctx, erasureInfo.DataBlocks, erasureInfo.ParityBlocks, erasureInfo.BlockSize)
 				if err != nil {
-					if !IsErr(err, []error{
-						errFileNotFound,
-						errVolumeNotFound,
-						errFileCorrupt,
-					}...) {
-						logger.GetReqInfo(ctx).AppendTags("disk", onlineDisk.String())
-						logger.LogIf(ctx, err)
-					}
 					dataErrs[i] = err
-					break
+					break versionsLoop
 				}
-			}
-		case madmin.HealNormalScan:
-			for _, part := range partsMetadata[i].Parts {
-				partPath := pathJoin(object, fmt.Sprintf("part.%d", part.Number))
-				_, err := onlineDisk.StatFile(bucket, partPath)
-				if err != nil {
-					dataErrs[i] = err
-					break
+
+				// disk has a valid xl.json but may not have all the
+				// parts. This is considered an outdated disk, since
+				// it needs healing too.
+				for _, part := range version.Parts {
+					checksumInfo := erasureInfo.GetChecksumInfo(part.Number)
+					partPath := version.partPath(object, part.Number)
+					err = onlineDisk.VerifyFile(bucket, partPath, erasure.ShardFileSize(part.Size), checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+					if err != nil {
+						if !IsErr(err, []error{
+							errFileNotFound,
+							errVolumeNotFound,
+							errFileCorrupt,
+						}...) {
+							logger.GetReqInfo(ctx).AppendTags("disk", onlineDisk.String())
+							logger.LogIf(ctx, err)
+						}
+						dataErrs[i] = err
+						break versionsLoop
+					}
+				}
+			case madmin.HealNormalScan:
+				for _, part := range version.Parts {
+					partPath := version.partPath(object, part.Number)
+					_, err := onlineDisk.StatFile(bucket, partPath)
+					if err != nil {
+						dataErrs[i] = err
+						break versionsLoop
+					}
 				}
 			}
 		}
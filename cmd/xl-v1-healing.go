@@ -354,6 +354,11 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 		return result, toObjectErr(pErr, bucket, object)
 	}
 
+	// The data of every version of the object is healed, the versions of an
+	// object which keeps versions are healed next to each other.
+	versions := latestMeta.versions()
+	versioned := latestMeta.DataDir != "" || len(latestMeta.Versions) > 0
+
 	// Clear data files of the object on outdated disks
 	for _, disk := range outDatedDisks {
 		// Before healing outdated disks, we need to remove
@@ -365,6 +370,19 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 			continue
 		}
 
+		if versioned {
+			for _, v := range versions {
+				if v.DataDir != "" {
+					_ = cleanupDir(ctx, disk, bucket, pathJoin(object, v.DataDir))
+					continue
+				}
+				for _, part := range v.Parts {
+					_ = disk.DeleteFile(bucket, v.partPath(object, part.Number))
+				}
+			}
+			continue
+		}
+
 		// List and delete the object directory,
 		files, derr := disk.ListDir(bucket, object, -1, "")
 		if derr == nil {
@@ -379,80 +397,111 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 	latestDisks = shuffleDisks(availableDisks, latestMeta.Erasure.Distribution)
 	outDatedDisks = shuffleDisks(outDatedDisks, latestMeta.Erasure.Distribution)
 	partsMetadata = shufflePartsMetadata(partsMetadata, latestMeta.Erasure.Distribution)
+
+	// The versions written to the outdated disks, with the parts
+	// and checksums of the healed data.
+	healedVersions := make([][]xlMetaObjectVersion, len(outDatedDisks))
 	for i := range outDatedDisks {
 		if outDatedDisks[i] == nil {
 			continue
 		}
-		partsMetadata[i] = newXLMetaFromXLMeta(latestMeta)
+		healedVersions[i] = make([]xlMetaObjectVersion, len(versions))
+		for k, v := range versions {
+			v.Erasure.Checksums = nil
+			v.Parts = nil
+			healedVersions[i][k] = v
+		}
 	}
 
 	// We write at temporary location and then rename to final location.
 	tmpID := mustGetUUID()
 
-	// Heal each part. erasureHealFile() will write the healed
-	// part to .minio/tmp/uuid/ which needs to be renamed later to
-	// the final location.
-	erasure, err := NewErasure(ctx, latestMeta.Erasure.DataBlocks,
-		latestMeta.Erasure.ParityBlocks, latestMeta.Erasure.BlockSize)
-	if err != nil {
-		return result, toObjectErr(err, bucket, object)
-	}
-
-	erasureInfo := latestMeta.Erasure
-	for partIndex := 0; partIndex < len(latestMeta.Parts); partIndex++ {
-		partSize := latestMeta.Parts[partIndex].Size
-		partActualSize := latestMeta.Parts[partIndex].ActualSize
-		partNumber := latestMeta.Parts[partIndex].Number
-		tillOffset := erasure.ShardFileTillOffset(0, partSize, partSize)
-		readers := make([]io.ReaderAt, len(latestDisks))
-		checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
-		for i, disk := range latestDisks {
-			if disk == OfflineDisk {
-				continue
-			}
-			checksumInfo := partsMetadata[i].Erasure.GetChecksumInfo(partNumber)
-			partPath := pathJoin(object, fmt.Sprintf("part.%d", partNumber))
-			readers[i] = newBitrotReader(disk, bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
+	// Heal each part of each version. erasureHealFile() will write
+	// the healed part to .minio/tmp/uuid/ which needs to be renamed
+	// later to the final location.
+	for k, version := range versions {
+		if version.DeleteMarker {
+			continue
 		}
-		writers := make([]io.Writer, len(outDatedDisks))
-		for i, disk := range outDatedDisks {
-			if disk == OfflineDisk {
-				continue
+		erasure, err := NewErasure(ctx, version.Erasure.DataBlocks,
+			version.Erasure.ParityBlocks, version.Erasure.BlockSize)
+		if err != nil {
+			return result, toObjectErr(err, bucket, object)
+		}
+
+		erasureInfo := version.Erasure
+		for partIndex := 0; partIndex < len(version.Parts); partIndex++ {
+			partSize := version.Parts[partIndex].Size
+			partActualSize := version.Parts[partIndex].ActualSize
+			partNumber := version.Parts[partIndex].Number
+			tillOffset := erasure.ShardFileTillOffset(0, partSize, partSize)
+			readers := make([]io.ReaderAt, len(latestDisks))
+			checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
+			for i, disk := range latestDisks {
+				if disk == OfflineDisk {
+					continue
+				}
+				// The checksum of the part is kept in the version of the disk.
+				var checksumInfo ChecksumInfo
+				if j := partsMetadata[i].findVersion(version.VersionID); j >= 0 {
+					checksumInfo = partsMetadata[i].versions()[j].Erasure.GetChecksumInfo(partNumber)
+				}
+				partPath := version.partPath(object, partNumber)
+				readers[i] = newBitrotReader(disk, bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
 			}
-			partPath := pathJoin(tmpID, fmt.Sprintf("part.%d", partNumber))
-			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath, tillOffset, checksumAlgo, erasure.ShardSize())
-		}
-		hErr := erasure.Heal(ctx, readers, writers, partSize)
-		closeBitrotReaders(readers)
-		closeBitrotWriters(writers)
-		if hErr != nil {
-			return result, toObjectErr(hErr, bucket, object)
-		}
-		// outDatedDisks that had write errors should not be
-		// written to for remaining parts, so we nil it out.
-		for i, disk := range outDatedDisks {
-			if disk == nil {
-				continue
+			writers := make([]io.Writer, len(outDatedDisks))
+			for i, disk := range outDatedDisks {
+				if disk == OfflineDisk {
+					continue
+				}
+				partPath := version.partPath(tmpID, partNumber)
+				writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath, tillOffset, checksumAlgo, erasure.ShardSize())
 			}
-			// A non-nil stale disk which did not receive
-			// a healed part checksum had a write error.
-			if writers[i] == nil {
-				outDatedDisks[i] = nil
-				disksToHealCount--
-				continue
+			hErr := erasure.Heal(ctx, readers, writers, partSize)
+			closeBitrotReaders(readers)
+			closeBitrotWriters(writers)
+			if hErr != nil {
+				return result, toObjectErr(hErr, bucket, object)
+			}
+			// outDatedDisks that had write errors should not be
+			// written to for remaining parts, so we nil it out.
+			for i, disk := range outDatedDisks {
+				if disk == nil {
+					continue
+				}
+				// A non-nil stale disk which did not receive
+				// a healed part checksum had a write error.
+				if writers[i] == nil {
+					outDatedDisks[i] = nil
+					disksToHealCount--
+					continue
+				}
+				healed := &healedVersions[i][k]
+				healed.Parts = append(healed.Parts, ObjectPartInfo{
+					Number:     partNumber,
+					Size:       partSize,
+					ActualSize: partActualSize,
+				})
+				healed.Erasure.AddChecksumInfo(ChecksumInfo{
+					PartNumber: partNumber,
+					Algorithm:  checksumAlgo,
+					Hash:       bitrotWriterSum(writers[i]),
+				})
+			}
+
+			// If all disks are having errors, we give up.
+			if disksToHealCount == 0 {
+				return result, fmt.Errorf("all disks without up-to-date data had write errors")
 			}
-			partsMetadata[i].AddObjectPart(partNumber, "", partSize, partActualSize)
-			partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
-				PartNumber: partNumber,
-				Algorithm:  checksumAlgo,
-				Hash:       bitrotWriterSum(writers[i]),
-			})
 		}
+	}
 
-		// If all disks are having errors, we give up.
-		if disksToHealCount == 0 {
-			return result, fmt.Errorf("all disks without up-to-date data had write errors")
+	for i := range outDatedDisks {
+		if outDatedDisks[i] == nil {
+			continue
 		}
+		partsMetadata[i] = newXLMetaFromXLMeta(latestMeta)
+		partsMetadata[i].setVersions(healedVersions[i])
 	}
 
 	// Cleanup in case of xl.json writing failure
@@ -473,8 +522,12 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 		}
 
 		// Attempt a rename now from healed data to final location.
-		aErr = disk.RenameFile(minioMetaTmpBucket, retainSlash(tmpID), bucket,
-			retainSlash(object))
+		if versioned {
+			aErr = renameHealedVersions(disk, tmpID, bucket, object, versions)
+		} else {
+			aErr = disk.RenameFile(minioMetaTmpBucket, retainSlash(tmpID), bucket,
+				retainSlash(object))
+		}
 		if aErr != nil {
 			logger.LogIf(ctx, aErr)
 			return result, toObjectErr(aErr, bucket, object)
@@ -493,6 +546,32 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 	return result, nil
 }
 
+// renameHealedVersions - moves the healed versions of an object which keeps
+// versions to their final location, xl.json is moved last.
+func renameHealedVersions(disk StorageAPI, tmpID, bucket, object string, versions []xlMetaObjectVersion) error {
+	for _, v := range versions {
+		if v.DeleteMarker || len(v.Parts) == 0 {
+			continue
+		}
+		if v.DataDir != "" {
+			err := disk.RenameFile(minioMetaTmpBucket, retainSlash(pathJoin(tmpID, v.DataDir)),
+				bucket, retainSlash(pathJoin(object, v.DataDir)))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		for _, part := range v.Parts {
+			err := disk.RenameFile(minioMetaTmpBucket, v.partPath(tmpID, part.Number),
+				bucket, v.partPath(object, part.Number))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return disk.RenameFile(minioMetaTmpBucket, pathJoin(tmpID, xlMetaJSONFile), bucket, pathJoin(object, xlMetaJSONFile))
+}
+
 // healObjectDir - heals object directory specifically, this special call
 // is needed since we do not have a special backend format for directories.
 func (xl xlObjects) healObjectDir(ctx context.Context, bucket, object string, dryRun bool, remove bool) (hr madmin.HealResultItem, err error) {
@@ -300,6 +300,88 @@ func TestHealObjectXL(t *testing.T) {
 	}
 }
 
+// Tests healing of all the versions of an object.
+func TestHealObjectVersionsXL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(ctx, mustGetZoneEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	object := "object"
+	err = obj.MakeBucketWithLocation(ctx, bucket, "")
+	if err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	var versionIDs []string
+	for _, content := range []string{"version 1", "version 2"} {
+		data := []byte(content)
+		objInfo, err1 := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err1 != nil {
+			t.Fatalf("Failed to put an object - %v", err1)
+		}
+		versionIDs = append(versionIDs, objInfo.VersionID)
+	}
+
+	// Remove the object with all its versions from the first disk.
+	z := obj.(*xlZones)
+	xl := z.zones[0].sets[0]
+	firstDisk := xl.getDisks()[0]
+	if err = cleanupDir(ctx, firstDisk, bucket, object); err != nil {
+		t.Fatalf("Failed to delete the object - %v", err)
+	}
+
+	_, err = obj.HealObject(ctx, bucket, object, madmin.HealOpts{ScanMode: madmin.HealNormalScan})
+	if err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+
+	xlMeta, err := readXLMeta(ctx, firstDisk, bucket, object)
+	if err != nil {
+		t.Fatalf("Expected xl.json file to be present but read failed - %v", err)
+	}
+	versions := xlMeta.versions()
+	if len(versions) != len(versionIDs) {
+		t.Fatalf("Expected %d versions, got %d", len(versionIDs), len(versions))
+	}
+	for _, versionID := range versionIDs {
+		i := xlMeta.findVersion(versionID)
+		if i < 0 {
+			t.Fatalf("Expected version %s to be healed", versionID)
+		}
+		if len(versions[i].Parts) != 1 || len(versions[i].Erasure.Checksums) != 1 {
+			t.Fatalf("Expected the part of version %s to be recorded", versionID)
+		}
+		_, err = firstDisk.StatFile(bucket, versions[i].partPath(object, 1))
+		if err != nil {
+			t.Errorf("Expected the part of version %s to be present but stat failed - %v", versionID, err)
+		}
+	}
+
+	// The healed data must pass a deep scan.
+	result, err := obj.HealObject(ctx, bucket, object, madmin.HealOpts{ScanMode: madmin.HealDeepScan, DryRun: true})
+	if err != nil {
+		t.Fatalf("Failed to scan object - %v", err)
+	}
+	for _, drive := range result.After.Drives {
+		if drive.State != madmin.DriveStateOk {
+			t.Errorf("Expected drive %s to be ok, got %s", drive.Endpoint, drive.State)
+		}
+	}
+}
+
 // Tests healing of empty directories
 func TestHealEmptyDirectoryXL(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -160,12 +160,50 @@ type xlMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// Captures all the individual object `xl.json`.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// Version ID of the current object `xl.json`, empty for the null version.
+	VersionID string `json:"versionId,omitempty"`
+	// Set if the current object `xl.json` is a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
+	// Directory of the parts of the current object `xl.json`, the
+	// parts are kept in the object directory when it is empty.
+	DataDir string `json:"dataDir,omitempty"`
+	// Non-current versions of the object, newest first.
+	Versions []xlMetaObjectVersion `json:"versions,omitempty"`
+}
+
+// xlMetaObjectVersion - a version of an object kept in `xl.json`,
+// each version keeps its parts in its own data directory.
+type xlMetaObjectVersion struct {
+	VersionID    string            `json:"versionId,omitempty"`
+	DeleteMarker bool              `json:"deleteMarker,omitempty"`
+	DataDir      string            `json:"dataDir,omitempty"`
+	Stat         statInfo          `json:"stat"`
+	Erasure      ErasureInfo       `json:"erasure"`
+	Meta         map[string]string `json:"meta,omitempty"`
+	Parts        []ObjectPartInfo  `json:"parts,omitempty"`
+}
+
+// isVersion - returns true if this is the version with versionID, the
+// null version is matched by "null".
+func (v xlMetaObjectVersion) isVersion(versionID string) bool {
+	if versionID == nullVersionID {
+		versionID = ""
+	}
+	return v.VersionID == versionID
+}
+
+// partPath - returns the path of a part of this version.
+func (v xlMetaObjectVersion) partPath(object string, partNumber int) string {
+	return pathJoin(object, v.DataDir, fmt.Sprintf("part.%d", partNumber))
 }
 
 // XL metadata constants.
 const (
+	// XL meta version, keeps the versions of an object and their data directories.
+	xlMetaVersion = "1.0.2"
+
 	// XL meta version.
-	xlMetaVersion = "1.0.1"
+	xlMetaVersion101 = "1.0.1"
 
 	// XL meta version.
 	xlMetaVersion100 = "1.0.0"
@@ -198,6 +236,7 @@ func newXLMetaFromXLMeta(meta xlMetaV1) xlMetaV1 {
 	xlMeta := meta
 	xlMeta.Erasure.Checksums = nil
 	xlMeta.Parts = nil
+	xlMeta.Versions = nil
 	return xlMeta
 }
 
@@ -211,7 +250,7 @@ func (m xlMetaV1) IsValid() bool {
 // Verifies if the backend format metadata is sane by validating
 // the version string and format style.
 func isXLMetaFormatValid(version, format string) bool {
-	return ((version == xlMetaVersion || version == xlMetaVersion100) &&
+	return ((version == xlMetaVersion || version == xlMetaVersion101 || version == xlMetaVersion100) &&
 		format == xlMetaFormat)
 }
 
@@ -221,8 +260,65 @@ func isXLMetaErasureInfoValid(data, parity int) bool {
 	return ((data >= parity) && (data != 0) && (parity != 0))
 }
 
+// currentVersion - returns the current version of the object.
+func (m xlMetaV1) currentVersion() xlMetaObjectVersion {
+	return xlMetaObjectVersion{
+		VersionID:    m.VersionID,
+		DeleteMarker: m.DeleteMarker,
+		DataDir:      m.DataDir,
+		Stat:         m.Stat,
+		Erasure:      m.Erasure,
+		Meta:         m.Meta,
+		Parts:        m.Parts,
+	}
+}
+
+// versions - returns all the versions of the object, the current
+// version first and the non-current versions newest first.
+func (m xlMetaV1) versions() []xlMetaObjectVersion {
+	return append([]xlMetaObjectVersion{m.currentVersion()}, m.Versions...)
+}
+
+// setVersions - makes the first of versions the current version of
+// the object and keeps the others as its non-current versions.
+func (m *xlMetaV1) setVersions(versions []xlMetaObjectVersion) {
+	current := versions[0]
+	m.VersionID = current.VersionID
+	m.DeleteMarker = current.DeleteMarker
+	m.DataDir = current.DataDir
+	m.Stat = current.Stat
+	m.Erasure = current.Erasure
+	m.Meta = current.Meta
+	m.Parts = current.Parts
+	m.Versions = nil
+	if len(versions) > 1 {
+		m.Versions = versions[1:]
+	}
+}
+
+// findVersion - returns the index of the version with versionID in
+// the versions of the object, -1 if the object has no such version.
+func (m xlMetaV1) findVersion(versionID string) int {
+	for i, v := range m.versions() {
+		if v.isVersion(versionID) {
+			return i
+		}
+	}
+	return -1
+}
+
+// partPath - returns the path of a part of the current version.
+func (m xlMetaV1) partPath(object string, partNumber int) string {
+	return m.currentVersion().partPath(object, partNumber)
+}
+
 // Converts metadata to object info.
 func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
+	return m.currentVersion().ToObjectInfo(bucket, object)
+}
+
+// Converts the metadata of a version to object info.
+func (m xlMetaObjectVersion) ToObjectInfo(bucket, object string) ObjectInfo {
 	objInfo := ObjectInfo{
 		IsDir:           false,
 		Bucket:          bucket,
@@ -231,6 +327,8 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 		ModTime:         m.Stat.ModTime,
 		ContentType:     m.Meta["content-type"],
 		ContentEncoding: m.Meta["content-encoding"],
+		VersionID:       m.VersionID,
+		DeleteMarker:    m.DeleteMarker,
 	}
 	// Update expires
 	var (
@@ -259,6 +357,9 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 	// All the parts per object.
 	objInfo.Parts = m.Parts
 
+	// The data of a transitioned object is on its tier.
+	setTransitionedObjectInfo(&objInfo, m.Meta)
+
 	// Update storage class
 	if sc, ok := m.Meta[xhttp.AmzStorageClass]; ok {
 		objInfo.StorageClass = sc
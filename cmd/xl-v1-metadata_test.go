@@ -219,6 +219,7 @@ func TestIsXLMetaFormatValid(t *testing.T) {
 		{4, xlMetaVersion100, "hello", false},
 		{5, xlMetaVersion, xlMetaFormat, true},
 		{6, xlMetaVersion100, xlMetaFormat, true},
+		{7, xlMetaVersion101, xlMetaFormat, true},
 	}
 	for _, tt := range tests {
 		if got := isXLMetaFormatValid(tt.version, tt.format); got != tt.want {
@@ -179,6 +179,7 @@ func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, objec
 		meta["content-type"] = contentType
 	}
 	xlMeta.Stat.ModTime = UTCNow()
+	setMultipartUploadMetadata(meta, bucket, object, xlMeta.Stat.ModTime)
 	xlMeta.Meta = meta
 
 	uploadID := mustGetUUID()
@@ -646,16 +647,28 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 	// Save successfully calculated md5sum.
 	xlMeta.Meta["etag"] = s3MD5
+	removeMultipartUploadMetadata(xlMeta.Meta)
 
 	// Save the consolidated actual size.
 	xlMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// A new version keeps its parts in its own data directory.
+	versioned := opts.Versioned || opts.VersionSuspended
+	if versioned {
+		xlMeta.DataDir = mustGetUUID()
+		if opts.Versioned {
+			xlMeta.VersionID = mustGetUUID()
+		}
+	}
+
 	// Update all xl metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
 		partsMetadata[index].Stat = xlMeta.Stat
 		partsMetadata[index].Meta = xlMeta.Meta
 		partsMetadata[index].Parts = xlMeta.Parts
+		partsMetadata[index].VersionID = xlMeta.VersionID
+		partsMetadata[index].DataDir = xlMeta.DataDir
 	}
 
 	tempXLMetaPath := mustGetUUID()
@@ -674,7 +687,11 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(rErr, minioMetaMultipartBucket, uploadIDPath)
 	}
 
-	if xl.isObject(bucket, object) {
+	// The versions kept by the object are not replaced by a new version.
+	var replaced ObjectInfo
+	if !versioned && xl.isObject(bucket, object) {
+		replaced, _ = xl.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+
 		// Rename if an object already exists to temporary location.
 		newUniqueID := mustGetUUID()
 
@@ -703,10 +720,26 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		}
 	}
 
-	// Rename the multipart object to final location.
-	if onlineDisks, err = rename(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, bucket, object, true, writeQuorum, nil); err != nil {
+	if versioned {
+		// Move the parts into the data directory of the new version.
+		for _, part := range xlMeta.Parts {
+			partName := fmt.Sprintf("part.%d", part.Number)
+			onlineDisks, err = rename(ctx, onlineDisks, minioMetaMultipartBucket, pathJoin(uploadIDPath, partName),
+				minioMetaMultipartBucket, pathJoin(uploadIDPath, xlMeta.DataDir, partName), false, writeQuorum, nil)
+			if err != nil {
+				return oi, toObjectErr(err, bucket, object)
+			}
+		}
+		onlineDisks, err = xl.renameObjectVersion(ctx, minioMetaMultipartBucket, uploadIDPath, bucket, object,
+			onlineDisks, partsMetadata, writeQuorum, opts.VersionSuspended)
+	} else {
+		// Rename the multipart object to final location.
+		onlineDisks, err = rename(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, bucket, object, true, writeQuorum, nil)
+	}
+	if err != nil {
 		return oi, toObjectErr(err, bucket, object)
 	}
+	removeReplacedTransitionedObject(ctx, replaced)
 
 	// Check if there is any offline disk and add it to the MRF list
 	for i := 0; i < len(onlineDisks); i++ {
@@ -787,6 +820,8 @@ func (xl xlObjects) cleanupStaleMultipartUploads(ctx context.Context, cleanupInt
 // Remove the old multipart uploads on the given disk.
 func (xl xlObjects) cleanupStaleMultipartUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration) {
 	now := time.Now()
+	// The uploads aborted by a bucket lifecycle are removed as well.
+	checkLifecycle := globalLifecycleSys != nil && globalLifecycleSys.HasAbortIncompleteMultipartUpload()
 	shaDirs, err := disk.ListDir(minioMetaMultipartBucket, "", -1, "")
 	if err != nil {
 		return
@@ -802,7 +837,13 @@ func (xl xlObjects) cleanupStaleMultipartUploadsOnDisk(ctx context.Context, disk
 			if err != nil {
 				continue
 			}
-			if now.Sub(fi.ModTime) > expiry {
+			abort := now.Sub(fi.ModTime) > expiry
+			if !abort && checkLifecycle {
+				if xlMeta, err := readXLMeta(ctx, disk, minioMetaMultipartBucket, uploadIDPath); err == nil {
+					abort = globalLifecycleSys.AbortMultipartUpload(xlMeta.Meta)
+				}
+			}
+			if abort {
 				writeQuorum := getWriteQuorum(len(xl.getDisks()))
 				xl.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, writeQuorum, false)
 			}
@@ -19,7 +19,6 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"net/http"
 	"path"
@@ -121,7 +120,12 @@ func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBuc
 		return xlMeta.ToObjectInfo(srcBucket, srcObject), nil
 	}
 
-	putOpts := ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined}
+	putOpts := ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+	}
 	return xl.PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
 }
 
@@ -153,6 +157,15 @@ func (xl xlObjects) GetObjectNInfo(ctx context.Context, bucket, object string, r
 		return nil, nErr
 	}
 
+	// The data of a transitioned object is read from its tier.
+	if isTransitioned(objInfo.UserDefined) {
+		rc, err := getTransitionedObject(ctx, objInfo, off, length)
+		if err != nil {
+			return nil, toObjectErr(err, bucket, object)
+		}
+		return fn(rc, h, opts.CheckCopyPrecondFn, func() { rc.Close() })
+	}
+
 	pr, pw := io.Pipe()
 	go func() {
 		err := xl.getObject(ctx, bucket, object, off, length, pw, "", opts)
@@ -205,6 +218,9 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 	// Read metadata associated with the object from all disks.
 	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
 
+	// Pick the requested version of the object.
+	resolveXLMetaVersion(metaArr, errs, opts.VersionID)
+
 	// get Quorum for this object
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
 	if err != nil {
@@ -224,6 +240,16 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return err
 	}
 
+	if xlMeta.DeleteMarker {
+		return toObjectErr(deleteMarkerErr(opts.VersionID), bucket, object)
+	}
+
+	// The data of a transitioned object is read from its tier.
+	if isTransitioned(xlMeta.Meta) {
+		err = copyTransitionedObject(ctx, xlMeta.ToObjectInfo(bucket, object), startOffset, length, writer)
+		return toObjectErr(err, bucket, object)
+	}
+
 	// Reorder online disks based on erasure distribution order.
 	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
 
@@ -289,7 +315,7 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 				continue
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partNumber)
-			partPath := pathJoin(object, fmt.Sprintf("part.%d", partNumber))
+			partPath := xlMeta.partPath(object, partNumber)
 			readers[index] = newBitrotReader(disk, bucket, partPath, tillOffset,
 				checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
 		}
@@ -383,6 +409,9 @@ func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string, op
 	// Read metadata associated with the object from all disks.
 	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
 
+	// Pick the requested version of the object.
+	resolveXLMetaVersion(metaArr, errs, opt.VersionID)
+
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
 	if err != nil {
 		return objInfo, err
@@ -400,6 +429,10 @@ func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string, op
 		return objInfo, err
 	}
 
+	if xlMeta.DeleteMarker {
+		return objInfo, deleteMarkerErr(opt.VersionID)
+	}
+
 	return xlMeta.ToObjectInfo(bucket, object), nil
 }
 
@@ -493,6 +526,29 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		opts.UserDefined = make(map[string]string)
 	}
 
+	if opts.CheckPutPrecondFn != nil {
+		oi, err := xl.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+				return ObjectInfo{}, err
+			}
+		}
+		if opts.CheckPutPrecondFn(oi) {
+			return ObjectInfo{}, PreConditionFailed{}
+		}
+	}
+
+	// A new version keeps its parts in its own data directory, next
+	// to the data directories of the versions kept by the object.
+	versioned := opts.Versioned || opts.VersionSuspended
+	var versionID, dataDir string
+	if versioned {
+		dataDir = mustGetUUID()
+		if opts.Versioned {
+			versionID = mustGetUUID()
+		}
+	}
+
 	storageDisks := xl.getDisks()
 
 	// Get parity and data drive count based on storage class metadata
@@ -584,7 +640,7 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	}
 
 	partName := "part.1"
-	tempErasureObj := pathJoin(uniqueID, partName)
+	tempErasureObj := pathJoin(uniqueID, dataDir, partName)
 
 	writers := make([]io.Writer, len(onlineDisks))
 	for i, disk := range onlineDisks {
@@ -623,14 +679,21 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	// Save additional erasureMetadata.
 	modTime := UTCNow()
 
-	opts.UserDefined["etag"] = r.MD5CurrentHexString()
+	// The stub of a transitioned object keeps the ETag of the object.
+	if !isTransitioned(opts.UserDefined) || opts.UserDefined["etag"] == "" {
+		opts.UserDefined["etag"] = r.MD5CurrentHexString()
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
 		opts.UserDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
 	}
 
-	if xl.isObject(bucket, object) {
+	// The versions kept by the object are not replaced by a new version.
+	var replaced ObjectInfo
+	if !versioned && xl.isObject(bucket, object) {
+		replaced, _ = xl.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+
 		// Rename if an object already exists to temporary location.
 		newUniqueID := mustGetUUID()
 
@@ -652,6 +715,8 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		partsMetadata[index].Meta = opts.UserDefined
 		partsMetadata[index].Stat.Size = n
 		partsMetadata[index].Stat.ModTime = modTime
+		partsMetadata[index].VersionID = versionID
+		partsMetadata[index].DataDir = dataDir
 	}
 
 	// Write unique `xl.json` for each disk.
@@ -660,9 +725,15 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	}
 
 	// Rename the successfully written temporary object to final location.
-	if onlineDisks, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
+	if versioned {
+		onlineDisks, err = xl.renameObjectVersion(ctx, minioMetaTmpBucket, tempObj, bucket, object, onlineDisks, partsMetadata, writeQuorum, opts.VersionSuspended)
+	} else {
+		onlineDisks, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil)
+	}
+	if err != nil {
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
+	removeReplacedTransitionedObject(ctx, replaced)
 
 	// Whether a disk was initially or becomes offline
 	// during this upload, send it to the MRF list.
@@ -687,6 +758,7 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		ContentType:     xlMeta.Meta["content-type"],
 		ContentEncoding: xlMeta.Meta["content-encoding"],
 		UserDefined:     xlMeta.Meta,
+		VersionID:       xlMeta.VersionID,
 	}
 
 	return objInfo, nil
@@ -866,7 +938,10 @@ func (xl xlObjects) deleteObjects(ctx context.Context, bucket string, objects []
 		}
 	}
 
-	for i := range objects {
+	// The data of the deleted transitioned objects is removed from their tier.
+	deleted := make([]ObjectInfo, len(objects))
+
+	for i, object := range objects {
 		if errs[i] != nil {
 			continue
 		}
@@ -877,9 +952,21 @@ func (xl xlObjects) deleteObjects(ctx context.Context, bucket string, objects []
 		// storage class only needs to be honored for
 		// Read() requests alone which we already do.
 		writeQuorums[i] = getWriteQuorum(len(storageDisks))
+		if !isObjectDirs[i] {
+			deleted[i], _ = xl.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+		}
 	}
 
-	return xl.doDeleteObjects(ctx, bucket, objects, errs, writeQuorums, isObjectDirs)
+	errs, err := xl.doDeleteObjects(ctx, bucket, objects, errs, writeQuorums, isObjectDirs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range objects {
+		if errs[i] == nil {
+			removeReplacedTransitionedObject(ctx, deleted[i])
+		}
+	}
+	return errs, nil
 }
 
 // DeleteObjects deletes objects in bulk, this function will still automatically split objects list
@@ -957,22 +1044,29 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 		}
 	}
 
+	var deleted ObjectInfo
 	if isObjectDir {
 		writeQuorum = getWriteQuorum(len(storageDisks))
 	} else {
 		// Read metadata associated with the object from all disks.
 		partsMetadata, errs := readAllXLMetadata(ctx, storageDisks, bucket, object)
 		// get Quorum for this object
-		_, writeQuorum, err = objectQuorumFromMeta(ctx, xl, partsMetadata, errs)
+		var readQuorum int
+		readQuorum, writeQuorum, err = objectQuorumFromMeta(ctx, xl, partsMetadata, errs)
 		if err != nil {
 			return toObjectErr(err, bucket, object)
 		}
+		_, modTime := listOnlineDisks(storageDisks, partsMetadata, errs)
+		if xlMeta, perr := pickValidXLMeta(ctx, partsMetadata, modTime, readQuorum); perr == nil {
+			deleted = xlMeta.ToObjectInfo(bucket, object)
+		}
 	}
 
 	// Delete the object on all disks.
 	if err = xl.deleteObject(ctx, bucket, object, writeQuorum, isObjectDir); err != nil {
 		return toObjectErr(err, bucket, object)
 	}
+	removeReplacedTransitionedObject(ctx, deleted)
 
 	// Success.
 	return nil
@@ -1022,6 +1116,13 @@ func (xl xlObjects) PutObjectTag(ctx context.Context, bucket, object string, tag
 	}
 
 	for i, xlMeta := range metaArr {
+		if errs[i] != nil {
+			continue
+		}
+		// The tags of a delete marker are not kept.
+		if xlMeta.DeleteMarker {
+			return toObjectErr(errFileNotFound, bucket, object)
+		}
 		// clean xlMeta.Meta of tag key, before updating the new tags
 		delete(xlMeta.Meta, xhttp.AmzObjectTagging)
 		// Don't update for empty tags
@@ -1034,7 +1135,7 @@ func (xl xlObjects) PutObjectTag(ctx context.Context, bucket, object string, tag
 	tempObj := mustGetUUID()
 
 	// Write unique `xl.json` for each disk.
-	if disks, err = writeUniqueXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+	if disks, err = writeUniqueXLMetadata(ctx, evalDisks(disks, errs), minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
 		return toObjectErr(err, bucket, object)
 	}
 
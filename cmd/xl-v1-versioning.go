@@ -0,0 +1,323 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+// resolveXLMetaVersion - replaces the current version of each `xl.json`
+// read from the disks by the version with versionID, the disks which do
+// not keep this version are marked with errFileVersionNotFound. The
+// current version is kept if versionID is empty.
+func resolveXLMetaVersion(metaArr []xlMetaV1, errs []error, versionID string) {
+	if versionID == "" {
+		return
+	}
+	for i := range metaArr {
+		if errs[i] != nil {
+			continue
+		}
+		idx := metaArr[i].findVersion(versionID)
+		if idx == -1 {
+			metaArr[i] = xlMetaV1{}
+			errs[i] = errFileVersionNotFound
+			continue
+		}
+		metaArr[i].setVersions(metaArr[i].versions()[idx : idx+1])
+	}
+}
+
+// deleteMarkerErr - returns the error of a read of a delete marker, an
+// object whose current version is a delete marker is not found.
+func deleteMarkerErr(versionID string) error {
+	if versionID == "" {
+		return errFileNotFound
+	}
+	return errFileIsDeleteMarker
+}
+
+// renameObjectVersion - moves a new version of an object, written with its
+// `xl.json` and its data directory at srcPrefix, to its final location. The
+// versions kept by the object are added to the `xl.json` of the new version,
+// the null version is replaced when versioning is suspended.
+func (xl xlObjects) renameObjectVersion(ctx context.Context, srcBucket, srcPrefix, bucket, object string,
+	onlineDisks []StorageAPI, partsMetadata []xlMetaV1, writeQuorum int, suspended bool) ([]StorageAPI, error) {
+	// The first version creates the object.
+	if !xl.isObject(bucket, object) {
+		return rename(ctx, onlineDisks, srcBucket, srcPrefix, bucket, object, true, writeQuorum, nil)
+	}
+
+	dataDir := partsMetadata[0].DataDir
+	disks, err := rename(ctx, onlineDisks, srcBucket, pathJoin(srcPrefix, dataDir), bucket, pathJoin(object, dataDir), true, writeQuorum, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the versions kept on each disk, in the order of the new version.
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	dropped := make([][]xlMetaObjectVersion, len(disks))
+	for i := range partsMetadata {
+		if errs[i] != nil {
+			continue
+		}
+		var versions []xlMetaObjectVersion
+		for _, v := range metaArr[i].versions() {
+			if suspended && v.isVersion(nullVersionID) {
+				dropped[i] = append(dropped[i], v)
+				continue
+			}
+			versions = append(versions, v)
+		}
+		partsMetadata[i].Versions = versions
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Write unique `xl.json` for each disk and rename it atomically over the current one.
+	if disks, err = writeUniqueXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err == nil {
+		disks, err = renameXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum)
+	}
+	if err != nil {
+		// The new version is not referenced, remove its data.
+		xl.deleteObject(ctx, bucket, pathJoin(object, dataDir), writeQuorum, false)
+		return nil, err
+	}
+
+	xl.deleteVersionsData(ctx, disks, bucket, object, dropped)
+
+	// Remove the `xl.json` left at the source of the new version.
+	xl.deleteObject(ctx, srcBucket, srcPrefix, writeQuorum, false)
+
+	return disks, nil
+}
+
+// deleteVersionsData - removes the parts of the versions dropped from
+// `xl.json` on each disk, a failure only leaves unreferenced parts behind.
+func (xl xlObjects) deleteVersionsData(ctx context.Context, disks []StorageAPI, bucket, object string, dropped [][]xlMetaObjectVersion) {
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		if disks[index] == nil || len(dropped[index]) == 0 {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			for _, v := range dropped[index] {
+				if v.DataDir != "" {
+					if err := cleanupDir(ctx, disks[index], bucket, pathJoin(object, v.DataDir)); err != nil {
+						return err
+					}
+					continue
+				}
+				// The null version written before versioning was enabled
+				// keeps its parts in the object directory.
+				for _, part := range v.Parts {
+					err := disks[index].DeleteFile(bucket, v.partPath(object, part.Number))
+					if err != nil && err != errFileNotFound {
+						return err
+					}
+				}
+			}
+			return nil
+		}, index)
+	}
+
+	for _, err := range g.Wait() {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// DeleteObjectVersion - deletes a version of an object. If no version is
+// specified a delete marker becomes the current version of the object, it
+// replaces the null version when versioning is suspended.
+func (xl xlObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	objInfo = ObjectInfo{Bucket: bucket, Name: object}
+
+	// Directories and the objects of buckets without versioning do not keep versions.
+	if HasSuffix(object, SlashSeparator) || (opts.VersionID == "" && !opts.Versioned && !opts.VersionSuspended) {
+		return objInfo, xl.DeleteObject(ctx, bucket, object)
+	}
+
+	defer ObjectPathUpdated(path.Join(bucket, object))
+
+	storageDisks := xl.getDisks()
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, storageDisks, bucket, object)
+
+	// get Quorum for this object
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return objInfo, toObjectErr(reducedErr, bucket, object)
+	}
+
+	// List all online disks.
+	onlineDisks, modTime := listOnlineDisks(storageDisks, metaArr, errs)
+
+	// Pick latest valid metadata.
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Order online disks and metadata in accordance with distribution order.
+	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
+	metaArr = shufflePartsMetadata(metaArr, xlMeta.Erasure.Distribution)
+
+	// Versions removed from `xl.json` on each disk.
+	dropped := make([][]xlMetaObjectVersion, len(onlineDisks))
+
+	if opts.VersionID == "" {
+		marker := xlMetaObjectVersion{
+			DeleteMarker: true,
+			Stat:         statInfo{ModTime: UTCNow()},
+			Erasure: ErasureInfo{
+				Algorithm:    xlMeta.Erasure.Algorithm,
+				DataBlocks:   xlMeta.Erasure.DataBlocks,
+				ParityBlocks: xlMeta.Erasure.ParityBlocks,
+				BlockSize:    xlMeta.Erasure.BlockSize,
+				Distribution: xlMeta.Erasure.Distribution,
+			},
+			Meta: map[string]string{},
+		}
+		if opts.Versioned {
+			marker.VersionID = mustGetUUID()
+		}
+		for i := range metaArr {
+			if onlineDisks[i] == nil {
+				continue
+			}
+			versions := []xlMetaObjectVersion{marker}
+			for _, v := range metaArr[i].versions() {
+				if opts.VersionSuspended && v.isVersion(nullVersionID) {
+					dropped[i] = append(dropped[i], v)
+					continue
+				}
+				versions = append(versions, v)
+			}
+			metaArr[i].setVersions(versions)
+		}
+		objInfo.VersionID = marker.VersionID
+		objInfo.DeleteMarker = true
+	} else {
+		idx := xlMeta.findVersion(opts.VersionID)
+		if idx == -1 {
+			return objInfo, toObjectErr(errFileVersionNotFound, bucket, object, opts.VersionID)
+		}
+		version := xlMeta.versions()[idx]
+		objInfo.VersionID = version.VersionID
+		objInfo.DeleteMarker = version.DeleteMarker
+
+		// The object is removed with its last version.
+		if len(xlMeta.Versions) == 0 {
+			if err = xl.deleteObject(ctx, bucket, object, writeQuorum, false); err != nil {
+				return objInfo, toObjectErr(err, bucket, object)
+			}
+			return objInfo, nil
+		}
+
+		for i := range metaArr {
+			if onlineDisks[i] == nil {
+				continue
+			}
+			var versions []xlMetaObjectVersion
+			for _, v := range metaArr[i].versions() {
+				if v.isVersion(opts.VersionID) {
+					dropped[i] = append(dropped[i], v)
+					continue
+				}
+				versions = append(versions, v)
+			}
+			if len(versions) == 0 {
+				// This disk does not keep the other versions, it is left for healing.
+				onlineDisks[i] = nil
+				dropped[i] = nil
+				continue
+			}
+			metaArr[i].setVersions(versions)
+		}
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Write unique `xl.json` for each disk.
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Atomically rename `xl.json` from tmp location to destination for each disk.
+	if onlineDisks, err = renameXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	xl.deleteVersionsData(ctx, onlineDisks, bucket, object, dropped)
+
+	return objInfo, nil
+}
+
+// getObjectVersions - returns all the versions of an object, the current
+// version first and the non-current versions newest first.
+func (xl xlObjects) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+
+	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return nil, toObjectErr(err, bucket, object)
+	}
+
+	// Reduce list of UUIDs to a single common value.
+	modTime, _ := commonTime(listObjectModtimes(metaArr, errs))
+
+	// Pick latest valid metadata.
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return nil, toObjectErr(err, bucket, object)
+	}
+
+	var objInfos []ObjectInfo
+	for i, v := range xlMeta.versions() {
+		objInfo := v.ToObjectInfo(bucket, object)
+		objInfo.IsLatest = i == 0
+		objInfos = append(objInfos, objInfo)
+	}
+	return objInfos, nil
+}
+
+// ListObjectVersions - not implemented, the versions are listed by xlSets.
+func (xl xlObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return ListObjectVersionsInfo{}, NotImplemented{}
+}
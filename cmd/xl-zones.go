@@ -438,12 +438,14 @@ func (z *xlZones) GetObject(ctx context.Context, bucket, object string, startOff
 }
 
 func (z *xlZones) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
-	// Lock the object before reading.
-	objectLock := z.NewNSLock(ctx, bucket, object)
-	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
-		return ObjectInfo{}, err
+	if !opts.NoLock {
+		// Lock the object before reading.
+		objectLock := z.NewNSLock(ctx, bucket, object)
+		if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer objectLock.RUnlock()
 	}
-	defer objectLock.RUnlock()
 
 	if z.SingleZone() {
 		return z.zones[0].GetObjectInfo(ctx, bucket, object, opts)
@@ -509,6 +511,28 @@ func (z *xlZones) DeleteObject(ctx context.Context, bucket string, object string
 	return nil
 }
 
+// DeleteObjectVersion - deletes a version of an object from the zone which keeps it.
+func (z *xlZones) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	// Acquire a write lock before deleting the object.
+	objectLock := z.NewNSLock(ctx, bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if z.SingleZone() {
+		return z.zones[0].DeleteObjectVersion(ctx, bucket, object, opts)
+	}
+	for _, zone := range z.zones {
+		objInfo, err = zone.DeleteObjectVersion(ctx, bucket, object, opts)
+		if err != nil && isErrObjectNotFound(err) {
+			continue
+		}
+		return objInfo, err
+	}
+	return objInfo, ObjectNotFound{Bucket: bucket, Object: object}
+}
+
 func (z *xlZones) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
 	derrs := make([]error, len(objects))
 	for i := range derrs {
@@ -541,7 +565,8 @@ func (z *xlZones) DeleteObjects(ctx context.Context, bucket string, objects []st
 func (z *xlZones) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
 	// Check if this request is only metadata update.
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(destBucket, destObject))
-	if !cpSrcDstSame {
+	// A copy onto the same object writes a new version of it on versioned buckets.
+	if !cpSrcDstSame || !srcInfo.metadataOnly {
 		objectLock := z.NewNSLock(ctx, destBucket, destObject)
 		if err := objectLock.GetLock(globalObjectTimeout); err != nil {
 			return objInfo, err
@@ -646,6 +671,8 @@ func (z *xlZones) listObjectsNonSlash(ctx context.Context, bucket, prefix, marke
 				Size:            result.Size,
 				ContentType:     result.Metadata["content-type"],
 				ContentEncoding: result.Metadata["content-encoding"],
+				VersionID:       result.VersionID,
+				DeleteMarker:    result.DeleteMarker,
 			}
 
 			// Extract etag from metadata.
@@ -990,7 +1017,32 @@ func (z *xlZones) ListObjects(ctx context.Context, bucket, prefix, marker, delim
 		return z.zones[0].ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
 	}
 
-	return z.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	loi, err := z.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	return removeDeleteMarkers(loi), err
+}
+
+// ListObjectVersions - lists the versions of the objects across all zones.
+func (z *xlZones) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	if z.SingleZone() {
+		return z.zones[0].ListObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys)
+	}
+
+	return listObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys, z.listObjects, z.getObjectVersions)
+}
+
+// getObjectVersions - returns the versions of an object from the zone which keeps it.
+func (z *xlZones) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	for _, zone := range z.zones {
+		objInfos, err := zone.getObjectVersions(ctx, bucket, object)
+		if err != nil {
+			if isErrObjectNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		return objInfos, nil
+	}
+	return nil, ObjectNotFound{Bucket: bucket, Object: object}
 }
 
 func (z *xlZones) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (ListMultipartsInfo, error) {
@@ -1237,6 +1289,16 @@ func (z *xlZones) IsCompressionSupported() bool {
 	return true
 }
 
+// IsDataUsageSupported returns whether data usage crawling is implemented for this layer.
+func (z *xlZones) IsDataUsageSupported() bool {
+	return true
+}
+
+// IsVersioningSupported returns whether bucket versioning is implemented for this layer.
+func (z *xlZones) IsVersioningSupported() bool {
+	return true
+}
+
 // DeleteBucket - deletes a bucket on all zones simultaneously,
 // even if one of the zones fail to delete buckets, we proceed to
 // undo a successful operation.
@@ -1438,7 +1500,7 @@ func (z *xlZones) Walk(ctx context.Context, bucket, prefix string, results chan<
 				return
 			}
 
-			if quorumCount >= zoneDrivesPerSet[zoneIndex]/2 {
+			if quorumCount >= zoneDrivesPerSet[zoneIndex]/2 && !entry.DeleteMarker {
 				results <- entry.ToObjectInfo() // Read quorum exists proceed
 			}
 
@@ -1449,9 +1511,10 @@ func (z *xlZones) Walk(ctx context.Context, bucket, prefix string, results chan<
 	return nil
 }
 
-type healObjectFn func(string, string) error
+// HealObjectFn closure function heals the object.
+type HealObjectFn func(string, string) error
 
-func (z *xlZones) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObject healObjectFn) error {
+func (z *xlZones) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObject HealObjectFn) error {
 	var zonesEntryChs [][]FileInfoCh
 
 	endWalkCh := make(chan struct{})
@@ -0,0 +1,97 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+)
+
+const (
+	// Maximum 64KiB size per CORS config.
+	maxCORSConfigSize = 64 << 10
+
+	// Maximum number of rules per CORS config.
+	maxCORSRules = 100
+)
+
+var (
+	errCORSRulesMissing     = Errorf("CORS configuration must contain at least one CORSRule")
+	errTooManyCORSRules     = Errorf("CORS configuration should not have more than 100 rules")
+	errNegativeMaxAge       = Errorf("MaxAgeSeconds must not be negative")
+	errMethodsMissing       = Errorf("CORSRule must contain at least one AllowedMethod")
+	errOriginsMissing       = Errorf("CORSRule must contain at least one AllowedOrigin")
+	errInvalidRuleID        = Errorf("ID must be less than 255 characters")
+	errExposeHeaderWildcard = Errorf("ExposeHeader can not contain a wildcard")
+)
+
+// Config - bucket CORS configuration specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+type Config struct {
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name `xml:"CORSConfiguration"`
+	CORSRules []Rule   `xml:"CORSRule"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(io.LimitReader(reader, maxCORSConfigSize)).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the CORS configuration
+func (c Config) Validate() error {
+	if len(c.CORSRules) == 0 {
+		return errCORSRulesMissing
+	}
+	if len(c.CORSRules) > maxCORSRules {
+		return errTooManyCORSRules
+	}
+	for _, rule := range c.CORSRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match - returns the first rule allowing a request from origin with
+// method, headers are the headers a preflight request asks to send.
+func (c Config) Match(origin, method string, headers []string) (Rule, bool) {
+	for _, rule := range c.CORSRules {
+		if rule.matchOrigin(origin) && rule.matchMethod(method) && rule.matchHeaders(headers) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// supportedMethods - methods a rule can allow.
+var supportedMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPut:    true,
+	http.MethodHead:   true,
+	http.MethodPost:   true,
+	http.MethodDelete: true,
+}
@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		expectedErr string
+	}{
+		{ // Valid config with every element
+			inputConfig: `<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><ID>app</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+		},
+		{ // Valid config with the required elements
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+		},
+		{ // No rules
+			inputConfig: `<CORSConfiguration></CORSConfiguration>`,
+			expectedErr: errCORSRulesMissing.Error(),
+		},
+		{ // Missing method
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			expectedErr: errMethodsMissing.Error(),
+		},
+		{ // Missing origin
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: errOriginsMissing.Error(),
+		},
+		{ // Unsupported method
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: "Found unsupported HTTP method in CORS config. Unsupported method is PATCH",
+		},
+		{ // Origin with two wildcards
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: `AllowedOrigin "https://*.example.*" can not have more than one wildcard`,
+		},
+		{ // Expose header with a wildcard
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><ExposeHeader>x-amz-*</ExposeHeader></CORSRule></CORSConfiguration>`,
+			expectedErr: errExposeHeaderWildcard.Error(),
+		},
+		{ // Negative max age
+			inputConfig: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><MaxAgeSeconds>-1</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+			expectedErr: errNegativeMaxAge.Error(),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			_, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("%d: Expected no error but got %v", i+1, err)
+			}
+			if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+				t.Fatalf("%d: Expected %s but got %v", i+1, tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	config, err := ParseConfig(bytes.NewReader([]byte(`<CORSConfiguration>
+	<CORSRule><ID>write</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedMethod>DELETE</AllowedMethod><AllowedHeader>Content-*</AllowedHeader><AllowedHeader>x-amz-meta-app</AllowedHeader></CORSRule>
+	<CORSRule><ID>read</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>HEAD</AllowedMethod></CORSRule>
+</CORSConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+	}{
+		{"https://app.example.com", http.MethodPut, nil, "write"},
+		{"https://app.example.com", http.MethodPut, []string{"content-type", "X-Amz-Meta-App"}, "write"},
+		{"https://app.example.com", http.MethodPut, []string{"x-amz-acl"}, ""},
+		{"https://example.com", http.MethodPut, nil, ""},
+		{"http://app.example.com", http.MethodDelete, nil, ""},
+		{"http://other.com", http.MethodGet, nil, "read"},
+		{"https://app.example.com", http.MethodHead, nil, "read"},
+		{"http://other.com", http.MethodGet, []string{"content-type"}, ""},
+		{"http://other.com", http.MethodPost, nil, ""},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.Match(tc.origin, tc.method, tc.headers)
+		if ok != (tc.expectedID != "") || rule.ID != tc.expectedID {
+			t.Errorf("Test %d: expected rule %q but got %q (%v)", i+1, tc.expectedID, rule.ID, ok)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"strings"
+
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// Rule - a set of origins and methods allowed to access the bucket.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the rule element
+func (r Rule) Validate() error {
+	if len(r.ID) > 255 {
+		return errInvalidRuleID
+	}
+	if len(r.AllowedMethods) == 0 {
+		return errMethodsMissing
+	}
+	for _, method := range r.AllowedMethods {
+		if !supportedMethods[method] {
+			return Errorf("Found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return errOriginsMissing
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return Errorf("AllowedOrigin \"%s\" can not have more than one wildcard", origin)
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return Errorf("AllowedHeader \"%s\" can not have more than one wildcard", header)
+		}
+	}
+	for _, header := range r.ExposeHeaders {
+		if strings.Contains(header, "*") {
+			return errExposeHeaderWildcard
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return errNegativeMaxAge
+	}
+	return nil
+}
+
+// AllowsAnyOrigin - returns true if the rule allows every origin.
+func (r Rule) AllowsAnyOrigin() bool {
+	for _, origin := range r.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchOrigin(origin string) bool {
+	for _, allowed := range r.AllowedOrigins {
+		if wildcard.MatchSimple(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchMethod(method string) bool {
+	for _, allowed := range r.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHeaders - every header must be allowed by the rule,
+// header names are compared case insensitively.
+func (r Rule) matchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		allowed := false
+		for _, pattern := range r.AllowedHeaders {
+			if wildcard.MatchSimple(strings.ToLower(pattern), header) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
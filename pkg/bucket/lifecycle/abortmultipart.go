@@ -0,0 +1,64 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+)
+
+var (
+	errAbortMultipartInvalidDays = Errorf("DaysAfterInitiation must be positive integer when used with AbortIncompleteMultipartUpload")
+	errAbortMultipartWithTags    = Errorf("AbortIncompleteMultipartUpload cannot be specified with Tags")
+)
+
+// AbortIncompleteMultipartUpload - the number of days after which the
+// incomplete multipart uploads are aborted.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation int      `xml:"DaysAfterInitiation"`
+}
+
+// UnmarshalXML parses the number of days after initiation and validates
+// if greater than zero
+func (a *AbortIncompleteMultipartUpload) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	type abortWrapper AbortIncompleteMultipartUpload
+	var aw abortWrapper
+	if err := d.DecodeElement(&aw, &startElement); err != nil {
+		return err
+	}
+	if aw.DaysAfterInitiation <= 0 {
+		return errAbortMultipartInvalidDays
+	}
+	*a = AbortIncompleteMultipartUpload(aw)
+	return nil
+}
+
+// MarshalXML is extended to leave out empty
+// <AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload> tags
+func (a AbortIncompleteMultipartUpload) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.IsNull() {
+		return nil
+	}
+	type abortWrapper AbortIncompleteMultipartUpload
+	aw := abortWrapper(a)
+	return e.EncodeElement(&aw, start)
+}
+
+// IsNull returns true if the number of days after initiation is not set
+func (a AbortIncompleteMultipartUpload) IsNull() bool {
+	return a.DaysAfterInitiation == 0
+}
@@ -0,0 +1,84 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+// TestAbortIncompleteMultipartUpload checks if the number of days after
+// initiation is parsed and marshaled back correctly
+func TestAbortIncompleteMultipartUpload(t *testing.T) {
+	testCases := []struct {
+		inputXML     string
+		expectedDays int
+		expectedErr  error
+	}{
+		{ // Valid number of days
+			inputXML:     `<AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>`,
+			expectedDays: 7,
+			expectedErr:  nil,
+		},
+		{ // Zero days
+			inputXML:    `<AbortIncompleteMultipartUpload><DaysAfterInitiation>0</DaysAfterInitiation></AbortIncompleteMultipartUpload>`,
+			expectedErr: errAbortMultipartInvalidDays,
+		},
+		{ // Negative days
+			inputXML:    `<AbortIncompleteMultipartUpload><DaysAfterInitiation>-1</DaysAfterInitiation></AbortIncompleteMultipartUpload>`,
+			expectedErr: errAbortMultipartInvalidDays,
+		},
+		{ // Missing days
+			inputXML:    `<AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload>`,
+			expectedErr: errAbortMultipartInvalidDays,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			var abort AbortIncompleteMultipartUpload
+			err := xml.Unmarshal([]byte(tc.inputXML), &abort)
+			if err != tc.expectedErr {
+				t.Fatalf("%d: Expected %v but got %v", i+1, tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if abort.DaysAfterInitiation != tc.expectedDays {
+				t.Fatalf("%d: Expected %d days but got %d", i+1, tc.expectedDays, abort.DaysAfterInitiation)
+			}
+			out, err := xml.Marshal(abort)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tc.inputXML {
+				t.Fatalf("%d: Expected %s but got %s", i+1, tc.inputXML, out)
+			}
+		})
+	}
+
+	// An empty AbortIncompleteMultipartUpload is left out of a rule
+	out, err := xml.Marshal(Rule{Status: Enabled, Expiration: Expiration{Days: ExpirationDays(3)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rule Rule
+	if err = xml.Unmarshal(out, &rule); err != nil {
+		t.Fatalf("Expected the rule %s to be parsed, got %v", out, err)
+	}
+}
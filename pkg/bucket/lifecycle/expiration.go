@@ -103,6 +103,16 @@ type Expiration struct {
 	Date    ExpirationDate `xml:"Date,omitempty"`
 }
 
+// MarshalXML is extended to leave out empty <Expiration></Expiration> tags
+func (e Expiration) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if e.IsNull() {
+		return nil
+	}
+	type expirationWrapper Expiration
+	ew := expirationWrapper(e)
+	return enc.EncodeElement(&ew, start)
+}
+
 // Validate - validates the "Expiration" element
 func (e Expiration) Validate() error {
 	// Neither expiration days or date is specified
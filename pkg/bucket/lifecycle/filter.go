@@ -18,6 +18,7 @@ package lifecycle
 
 import (
 	"encoding/xml"
+	"net/url"
 
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 )
@@ -91,3 +92,27 @@ func (f Filter) Validate() error {
 func (f Filter) isEmpty() bool {
 	return f.And.isEmpty() && f.Prefix == "" && f.Tag == tagging.Tag{}
 }
+
+// TestTags tests if the object tags, as url encoded key value pairs, match
+// all the tags of the filter, a filter without tags matches every object.
+func (f Filter) TestTags(userTags string) bool {
+	var tags []tagging.Tag
+	switch {
+	case !f.Tag.IsEmpty():
+		tags = []tagging.Tag{f.Tag}
+	case len(f.And.Tags) != 0:
+		tags = f.And.Tags
+	default:
+		return true
+	}
+	values, err := url.ParseQuery(userTags)
+	if err != nil {
+		return false
+	}
+	for _, tag := range tags {
+		if _, ok := values[tag.Key]; !ok || values.Get(tag.Key) != tag.Value {
+			return false
+		}
+	}
+	return true
+}
@@ -29,8 +29,7 @@ var (
 	errLifecycleOverlappingPrefix = Errorf("Lifecycle configuration has rules with overlapping prefix")
 )
 
-// Action represents a delete action or other transition
-// actions that will be implemented later.
+// Action represents a delete action or a transition action.
 type Action int
 
 const (
@@ -38,6 +37,8 @@ const (
 	NoneAction Action = iota
 	// DeleteAction means the object needs to be removed after evaluting lifecycle rules
 	DeleteAction
+	// TransitionAction means the object needs to be moved to a remote tier after evaluting lifecycle rules
+	TransitionAction
 )
 
 // Lifecycle - Configuration for bucket lifecycle.
@@ -106,28 +107,52 @@ func (lc Lifecycle) FilterRuleActions(objName, objTags string) (Expiration, Tran
 		if rule.Status == Disabled {
 			continue
 		}
-		tags := rule.Tags()
-		if strings.HasPrefix(objName, rule.Prefix()) {
-			if tags != "" {
-				if strings.Contains(objTags, tags) {
-					return rule.Expiration, Transition{}
-				}
-			} else {
-				return rule.Expiration, Transition{}
-			}
+		if strings.HasPrefix(objName, rule.Prefix()) && rule.Filter.TestTags(objTags) {
+			return rule.Expiration, rule.Transition
 		}
 	}
 	return Expiration{}, Transition{}
 }
 
+// HasAbortIncompleteMultipartUpload returns true if an enabled rule aborts
+// the incomplete multipart uploads.
+func (lc Lifecycle) HasAbortIncompleteMultipartUpload() bool {
+	for _, rule := range lc.Rules {
+		if rule.Status == Enabled && !rule.AbortIncompleteMultipartUpload.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeAbortMultipartUpload returns true if the incomplete multipart upload
+// of the object initiated at the given time needs to be aborted after
+// evaluating all rules.
+func (lc Lifecycle) ComputeAbortMultipartUpload(objName string, initiated time.Time) bool {
+	if objName == "" || initiated.IsZero() {
+		return false
+	}
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled || rule.AbortIncompleteMultipartUpload.IsNull() {
+			continue
+		}
+		if strings.HasPrefix(objName, rule.Prefix()) {
+			days := time.Duration(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation) * 24 * time.Hour
+			return time.Now().After(initiated.Add(days))
+		}
+	}
+	return false
+}
+
 // ComputeAction returns the action to perform by evaluating all lifecycle rules
-// against the object name and its modification time.
+// against the object name and its modification time, an object due for
+// expiration is not transitioned.
 func (lc Lifecycle) ComputeAction(objName, objTags string, modTime time.Time) Action {
 	var action = NoneAction
 	if modTime.IsZero() {
 		return action
 	}
-	exp, _ := lc.FilterRuleActions(objName, objTags)
+	exp, tr := lc.FilterRuleActions(objName, objTags)
 	if !exp.IsDateNull() {
 		if time.Now().After(exp.Date.Time) {
 			action = DeleteAction
@@ -138,5 +163,18 @@ func (lc Lifecycle) ComputeAction(objName, objTags string, modTime time.Time) Ac
 			action = DeleteAction
 		}
 	}
+	if action == DeleteAction {
+		return action
+	}
+	if !tr.IsDateNull() {
+		if time.Now().After(tr.Date.Time) {
+			action = TransitionAction
+		}
+	}
+	if !tr.IsDaysNull() {
+		if time.Now().After(modTime.Add(time.Duration(tr.Days) * 24 * time.Hour)) {
+			action = TransitionAction
+		}
+	}
 	return action
 }
@@ -128,6 +128,11 @@ func TestMarshalLifecycleConfig(t *testing.T) {
 				Filter:     Filter{Prefix: "prefix-1"},
 				Expiration: Expiration{Date: ExpirationDate(midnightTS)},
 			},
+			{
+				Status:     "Enabled",
+				Filter:     Filter{Prefix: "prefix-2"},
+				Transition: Transition{Days: TransitionDays(3), StorageClass: "WARM"},
+			},
 		},
 	}
 	b, err := xml.MarshalIndent(&lc, "", "\t")
@@ -260,6 +265,73 @@ func TestComputeActions(t *testing.T) {
 			objectModTime:  time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
 			expectedAction: NoneAction,
 		},
+		// Too early to transition (test Days)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Days>5</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-4 * 24 * time.Hour), // Created 4 days ago
+			expectedAction: NoneAction,
+		},
+		// Should transition (test Days)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Days>5</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: TransitionAction,
+		},
+		// Should transition (test Date)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Date>` + time.Now().Truncate(24*time.Hour).UTC().Add(-24*time.Hour).Format(time.RFC3339) + `</Date><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
+			expectedAction: TransitionAction,
+		},
+		// Should remove rather than transition
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>10</Days></Expiration><Transition><Days>5</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-11 * 24 * time.Hour), // Created 11 days ago
+			expectedAction: DeleteAction,
+		},
+		// Should remove (Tag filter matches)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Tag><Key>tag1</Key><Value>value1</Value></Tag></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectTags:     "tag2=value2&tag1=value1",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: DeleteAction,
+		},
+		// Should not remove (Tag value is only a prefix of the filter value)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Tag><Key>tag1</Key><Value>value1</Value></Tag></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectTags:     "tag1=value10",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: NoneAction,
+		},
+		// Should not remove (only one of the And tags matches)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Prefix>foodir/</Prefix><Tag><Key>tag1</Key><Value>value1</Value></Tag><Tag><Key>tag2</Key><Value>value2</Value></Tag></And></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectTags:     "tag1=value1",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: NoneAction,
+		},
+		// Should remove (all of the And tags match in any order)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Prefix>foodir/</Prefix><Tag><Key>tag1</Key><Value>value1</Value></Tag><Tag><Key>tag2</Key><Value>value2</Value></Tag></And></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectTags:     "tag2=value2&tag3=value3&tag1=value1",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: DeleteAction,
+		},
+		// Abort incomplete multipart upload rule doesn't remove objects
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: NoneAction,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -275,3 +347,66 @@ func TestComputeActions(t *testing.T) {
 
 	}
 }
+
+func TestComputeAbortMultipartUpload(t *testing.T) {
+	testCases := []struct {
+		inputConfig   string
+		objectName    string
+		initiated     time.Time
+		expectedAbort bool
+	}{
+		// Empty object name (unexpected case) should never abort
+		{
+			inputConfig:   `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			initiated:     time.Now().UTC().Add(-10 * 24 * time.Hour), // Initiated 10 days ago
+			expectedAbort: false,
+		},
+		// Disabled should never abort
+		{
+			inputConfig:   `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Disabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:    "foodir/fooobject",
+			initiated:     time.Now().UTC().Add(-10 * 24 * time.Hour), // Initiated 10 days ago
+			expectedAbort: false,
+		},
+		// Prefix not matched
+		{
+			inputConfig:   `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:    "foxdir/fooobject",
+			initiated:     time.Now().UTC().Add(-10 * 24 * time.Hour), // Initiated 10 days ago
+			expectedAbort: false,
+		},
+		// Too early to abort
+		{
+			inputConfig:   `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:    "foodir/fooobject",
+			initiated:     time.Now().UTC().Add(-4 * 24 * time.Hour), // Initiated 4 days ago
+			expectedAbort: false,
+		},
+		// Should abort
+		{
+			inputConfig:   `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:    "foodir/fooobject",
+			initiated:     time.Now().UTC().Add(-6 * 24 * time.Hour), // Initiated 6 days ago
+			expectedAbort: true,
+		},
+		// Expiration rule doesn't abort uploads
+		{
+			inputConfig:   `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:    "foodir/fooobject",
+			initiated:     time.Now().UTC().Add(-6 * 24 * time.Hour), // Initiated 6 days ago
+			expectedAbort: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != nil {
+				t.Fatalf("%d: Got unexpected error: %v", i+1, err)
+			}
+			if abort := lc.ComputeAbortMultipartUpload(tc.objectName, tc.initiated); abort != tc.expectedAbort {
+				t.Fatalf("%d: Expected abort: `%v`, got: `%v`", i+1, tc.expectedAbort, abort)
+			}
+		})
+	}
+}
@@ -38,16 +38,17 @@ type Rule struct {
 	Filter     Filter     `xml:"Filter,omitempty"`
 	Expiration Expiration `xml:"Expiration,omitempty"`
 	Transition Transition `xml:"Transition,omitempty"`
-	// FIXME: add a type to catch unsupported AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
-	NoncurrentVersionExpiration NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
-	NoncurrentVersionTransition NoncurrentVersionTransition `xml:"NoncurrentVersionTransition,omitempty"`
+
+	AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	NoncurrentVersionExpiration    NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransition    NoncurrentVersionTransition    `xml:"NoncurrentVersionTransition,omitempty"`
 }
 
 var (
 	errInvalidRuleID           = Errorf("ID must be less than 255 characters")
 	errEmptyRuleStatus         = Errorf("Status should not be empty")
 	errInvalidRuleStatus       = Errorf("Status must be set to either Enabled or Disabled")
-	errMissingExpirationAction = Errorf("No expiration action found")
+	errMissingExpirationAction = Errorf("No expiration, transition or abort incomplete multipart upload action found")
 )
 
 // validateID - checks if ID is valid or not.
@@ -74,9 +75,27 @@ func (r Rule) validateStatus() error {
 }
 
 func (r Rule) validateAction() error {
-	if r.Expiration == (Expiration{}) {
+	if r.Expiration == (Expiration{}) && r.Transition == (Transition{}) && r.AbortIncompleteMultipartUpload.IsNull() {
 		return errMissingExpirationAction
 	}
+	// Multipart uploads have no tags.
+	if !r.AbortIncompleteMultipartUpload.IsNull() && r.Tags() != "" {
+		return errAbortMultipartWithTags
+	}
+	if r.Transition == (Transition{}) {
+		return nil
+	}
+	if err := r.Transition.Validate(); err != nil {
+		return err
+	}
+	// Objects which expire before they are transitioned are never transitioned.
+	exp, tr := r.Expiration, r.Transition
+	if !exp.IsDaysNull() && !tr.IsDaysNull() && int(tr.Days) >= int(exp.Days) {
+		return errTransitionAfterExpiration
+	}
+	if !exp.IsDateNull() && !tr.IsDateNull() && !tr.Date.Before(exp.Date.Time) {
+		return errTransitionAfterExpiration
+	}
 	return nil
 }
 
@@ -25,8 +25,8 @@ import (
 // TestUnsupportedRules checks if Rule xml with unsuported tags return
 // appropriate errors on parsing
 func TestUnsupportedRules(t *testing.T) {
-	// NoncurrentVersionTransition, NoncurrentVersionExpiration
-	// and Transition tags aren't supported
+	// NoncurrentVersionTransition and NoncurrentVersionExpiration
+	// tags aren't supported
 	unsupportedTestCases := []struct {
 		inputXML    string
 		expectedErr error
@@ -44,12 +44,6 @@ func TestUnsupportedRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errNoncurrentVersionExpirationUnsupported,
 		},
-		{ // Rule with unsupported Transition action
-			inputXML: ` <Rule>
-	                     <Transition></Transition>
-	                    </Rule>`,
-			expectedErr: errTransitionUnsupported,
-		},
 	}
 
 	for i, tc := range unsupportedTestCases {
@@ -94,6 +88,51 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule with transition without storage class
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition><Days>30</Days></Transition>
+	                    </Rule>`,
+			expectedErr: errTransitionNoStorageClass,
+		},
+		{ // Rule with transition without days or date
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition><StorageClass>WARM</StorageClass></Transition>
+	                    </Rule>`,
+			expectedErr: errTransitionInvalid,
+		},
+		{ // Rule with transition after expiration
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Expiration><Days>30</Days></Expiration>
+                              <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+	                    </Rule>`,
+			expectedErr: errTransitionAfterExpiration,
+		},
+		{ // Rule with transition before expiration
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Expiration><Days>60</Days></Expiration>
+                              <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with abort incomplete multipart upload only
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with abort incomplete multipart upload and a tag filter
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Filter><Tag><Key>key</Key><Value>value</Value></Tag></Filter>
+                              <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+	                    </Rule>`,
+			expectedErr: errAbortMultipartWithTags,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
@@ -18,25 +18,85 @@ package lifecycle
 
 import (
 	"encoding/xml"
+	"time"
 )
 
-// Transition - transition actions for a rule in lifecycle configuration.
-type Transition struct {
-	XMLName      xml.Name `xml:"Transition"`
-	Days         int      `xml:"Days,omitempty"`
-	Date         string   `xml:"Date,omitempty"`
-	StorageClass string   `xml:"StorageClass"`
+var (
+	errTransitionInvalidDays     = Errorf("Days must be positive integer when used with Transition")
+	errTransitionInvalid         = Errorf("Exactly one of Days or Date should be present inside Transition")
+	errTransitionNoStorageClass  = Errorf("StorageClass must be set to the name of a remote tier inside Transition")
+	errTransitionAfterExpiration = Errorf("Transition must happen before Expiration")
+)
+
+// TransitionDays is a type alias to unmarshal Days in Transition
+type TransitionDays int
+
+// UnmarshalXML parses number of days from Transition and validates if
+// greater than zero
+func (tDays *TransitionDays) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var numDays int
+	err := d.DecodeElement(&numDays, &startElement)
+	if err != nil {
+		return err
+	}
+	if numDays <= 0 {
+		return errTransitionInvalidDays
+	}
+	*tDays = TransitionDays(numDays)
+	return nil
 }
 
-var errTransitionUnsupported = Errorf("Specifying <Transition></Transition> tag is not supported")
+// MarshalXML encodes number of days to transition if it is non-zero and
+// encodes empty string otherwise
+func (tDays *TransitionDays) MarshalXML(e *xml.Encoder, startElement xml.StartElement) error {
+	if *tDays == TransitionDays(0) {
+		return nil
+	}
+	return e.EncodeElement(int(*tDays), startElement)
+}
 
-// UnmarshalXML is extended to indicate lack of support for Transition
-// xml tag in object lifecycle configuration
-func (t Transition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return errTransitionUnsupported
+// Transition - transition actions for a rule in lifecycle configuration,
+// the StorageClass is the name of the remote tier the objects are moved to.
+type Transition struct {
+	XMLName      xml.Name       `xml:"Transition"`
+	Days         TransitionDays `xml:"Days,omitempty"`
+	Date         ExpirationDate `xml:"Date,omitempty"`
+	StorageClass string         `xml:"StorageClass,omitempty"`
 }
 
-// MarshalXML is extended to leave out <Transition></Transition> tags
+// MarshalXML is extended to leave out empty <Transition></Transition> tags
 func (t Transition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.IsNull() && t.StorageClass == "" {
+		return nil
+	}
+	type transitionWrapper Transition
+	tw := transitionWrapper(t)
+	return e.EncodeElement(&tw, start)
+}
+
+// Validate - validates the "Transition" element
+func (t Transition) Validate() error {
+	// Exactly one of transition days or date is specified
+	if t.IsDaysNull() == t.IsDateNull() {
+		return errTransitionInvalid
+	}
+	if t.StorageClass == "" {
+		return errTransitionNoStorageClass
+	}
 	return nil
 }
+
+// IsDaysNull returns true if days field is null
+func (t Transition) IsDaysNull() bool {
+	return t.Days == TransitionDays(0)
+}
+
+// IsDateNull returns true if date field is null
+func (t Transition) IsDateNull() bool {
+	return t.Date == ExpirationDate{time.Time{}}
+}
+
+// IsNull returns true if both date and days fields are null
+func (t Transition) IsNull() bool {
+	return t.IsDaysNull() && t.IsDateNull()
+}
@@ -0,0 +1,83 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Maximum 128KiB size per logging config.
+const maxLoggingConfigSize = 128 << 10
+
+var (
+	errTargetBucketMissing     = Errorf("TargetBucket must be specified when logging is enabled")
+	errTargetGrantsUnsupported = Errorf("Specifying <TargetGrants></TargetGrants> is not supported")
+)
+
+// Config - bucket logging status specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLogging.html,
+// the logging of a bucket is disabled by an empty status.
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// LoggingEnabled - the bucket and the prefix of the objects
+// the access log records are written to.
+type LoggingEnabled struct {
+	TargetBucket string       `xml:"TargetBucket"`
+	TargetPrefix string       `xml:"TargetPrefix"`
+	TargetGrants *targetGrant `xml:"TargetGrants,omitempty"`
+}
+
+// targetGrant - catches the unsupported TargetGrants element.
+type targetGrant struct {
+	Grants []byte `xml:",innerxml"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(io.LimitReader(reader, maxLoggingConfigSize)).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the logging configuration
+func (c Config) Validate() error {
+	if c.LoggingEnabled == nil {
+		return nil
+	}
+	if c.LoggingEnabled.TargetBucket == "" {
+		return errTargetBucketMissing
+	}
+	if c.LoggingEnabled.TargetGrants != nil {
+		return errTargetGrantsUnsupported
+	}
+	return nil
+}
+
+// Enabled - returns true if the logging of the bucket is enabled.
+func (c Config) Enabled() bool {
+	return c.LoggingEnabled != nil
+}
@@ -0,0 +1,84 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig     string
+		expectedErr     error
+		expectedEnabled bool
+	}{
+		{ // Logging enabled with a prefix
+			inputConfig:     `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>bucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr:     nil,
+			expectedEnabled: true,
+		},
+		{ // Logging enabled without a prefix
+			inputConfig:     `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr:     nil,
+			expectedEnabled: true,
+		},
+		{ // Logging disabled
+			inputConfig:     `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/" />`,
+			expectedErr:     nil,
+			expectedEnabled: false,
+		},
+		{ // Missing target bucket
+			inputConfig: `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>bucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr: errTargetBucketMissing,
+		},
+		{ // Target grants
+			inputConfig: `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetGrants><Grant><Grantee><URI>http://acs.amazonaws.com/groups/global/AllUsers</URI></Grantee><Permission>READ</Permission></Grant></TargetGrants></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr: errTargetGrantsUnsupported,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			config, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != tc.expectedErr {
+				t.Fatalf("%d: Expected %v but got %v", i+1, tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if config.Enabled() != tc.expectedEnabled {
+				t.Fatalf("%d: Expected enabled %v but got %v", i+1, tc.expectedEnabled, config.Enabled())
+			}
+			// The parsed config is marshaled back to an equivalent config.
+			data, err := xml.Marshal(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := ParseConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("%d: Unable to parse %s: %v", i+1, data, err)
+			}
+			if parsed.Enabled() != config.Enabled() ||
+				(config.Enabled() && *parsed.LoggingEnabled != *config.LoggingEnabled) {
+				t.Fatalf("%d: Expected %v but got %v", i+1, config, parsed)
+			}
+		})
+	}
+}
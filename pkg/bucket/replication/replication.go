@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Status of the replication of an object, kept in its metadata.
+const (
+	StatusPending   = "PENDING"
+	StatusCompleted = "COMPLETED"
+	StatusFailed    = "FAILED"
+)
+
+// Maximum 128KiB size per replication config.
+const maxReplicationConfigSize = 128 << 10
+
+var (
+	errReplicationTooManyRules = Errorf("Replication configuration allows a maximum of 1000 rules")
+	errReplicationNoRule       = Errorf("Replication configuration should have at least one rule")
+	errReplicationDuplicateID  = Errorf("Replication configuration has rules with the same ID")
+)
+
+// Config - replication configuration specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketReplication.html
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	Role    string   `xml:"Role,omitempty"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(io.LimitReader(reader, maxReplicationConfigSize)).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the replication configuration
+func (c Config) Validate() error {
+	// Replication config can't have more than 1000 rules
+	if len(c.Rules) > 1000 {
+		return errReplicationTooManyRules
+	}
+	// Replication config should have at least one rule
+	if len(c.Rules) == 0 {
+		return errReplicationNoRule
+	}
+	ids := make(map[string]struct{}, len(c.Rules))
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if r.ID == "" {
+			continue
+		}
+		if _, ok := ids[r.ID]; ok {
+			return errReplicationDuplicateID
+		}
+		ids[r.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Match - returns the enabled rule of the highest priority which the
+// object name and tags, in the format tag1=value1&tag2=value2, match.
+func (c Config) Match(objName, objTags string) (rule Rule, ok bool) {
+	if objName == "" {
+		return rule, false
+	}
+	for _, r := range c.Rules {
+		if r.Status != Enabled || !r.matches(objName, objTags) {
+			continue
+		}
+		if !ok || r.Priority > rule.Priority {
+			rule, ok = r, true
+		}
+	}
+	return rule, ok
+}
+
+// MatchDelete - returns the rule replicating the deletes of the object,
+// the rules with Tag filters do not replicate deletes.
+func (c Config) MatchDelete(objName string) (rule Rule, ok bool) {
+	rule, ok = c.Match(objName, "")
+	if !ok || !rule.ReplicateDeletes() {
+		return rule, false
+	}
+	return rule, true
+}
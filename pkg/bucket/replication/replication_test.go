@@ -0,0 +1,138 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		expectedErr error
+	}{
+		{ // Rule with a prefix filter
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Rule with the legacy prefix and delete marker replication
+			inputConfig: `<ReplicationConfiguration><Rule><ID>rule1</ID><Status>Enabled</Status><Prefix>logs/</Prefix><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Rule with an And filter
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><And><Prefix>logs/</Prefix><Tag><Key>key1</Key><Value>value1</Value></Tag><Tag><Key>key2</Key><Value>value2</Value></Tag></And></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // No rules
+			inputConfig: `<ReplicationConfiguration></ReplicationConfiguration>`,
+			expectedErr: errReplicationNoRule,
+		},
+		{ // Invalid status
+			inputConfig: `<ReplicationConfiguration><Rule><Status>On</Status><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidRuleStatus,
+		},
+		{ // Destination bucket is not an ARN
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidDestinationARN,
+		},
+		{ // Filter with both a prefix and a tag
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix><Tag><Key>key1</Key><Value>value1</Value></Tag></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidFilter,
+		},
+		{ // Prefix with a filter
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Prefix>logs/</Prefix><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errPrefixAndFilter,
+		},
+		{ // Delete marker replication with a tag filter
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>key1</Key><Value>value1</Value></Tag></Filter><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errDeleteMarkerReplicationTag,
+		},
+		{ // Duplicate tag keys
+			inputConfig: `<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><And><Tag><Key>key1</Key><Value>value1</Value></Tag><Tag><Key>key1</Key><Value>value2</Value></Tag></And></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errDuplicateTagKey,
+		},
+		{ // Duplicate rule IDs
+			inputConfig: `<ReplicationConfiguration><Rule><ID>rule1</ID><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule><Rule><ID>rule1</ID><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errReplicationDuplicateID,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			_, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != tc.expectedErr {
+				t.Fatalf("Expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestMarshalConfig(t *testing.T) {
+	input := `<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule></ReplicationConfiguration>`
+	config, err := ParseConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("Expected %s, got %s", input, string(data))
+	}
+}
+
+func TestMatch(t *testing.T) {
+	input := `<ReplicationConfiguration>
+<Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><Destination><Bucket>arn:aws:s3:::logs</Bucket></Destination></Rule>
+<Rule><ID>tagged</ID><Status>Enabled</Status><Priority>2</Priority><Filter><And><Prefix>logs/</Prefix><Tag><Key>class</Key><Value>audit</Value></Tag></And></Filter><Destination><Bucket>arn:aws:s3:::audit</Bucket></Destination></Rule>
+<Rule><ID>disabled</ID><Status>Disabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::tmp</Bucket></Destination></Rule>
+</ReplicationConfiguration>`
+	config, err := ParseConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		objName        string
+		objTags        string
+		expectedRuleID string
+		deleteRuleID   string
+	}{
+		{"logs/1.log", "", "logs", "logs"},
+		{"logs/1.log", "class=audit", "tagged", "logs"},
+		{"logs/1.log", "class=audit&owner=admin", "tagged", "logs"},
+		{"logs/1.log", "class=other", "logs", "logs"},
+		{"tmp/1.log", "", "", ""},
+		{"data/1.log", "class=audit", "", ""},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.Match(tc.objName, tc.objTags)
+		if ok != (tc.expectedRuleID != "") || rule.ID != tc.expectedRuleID {
+			t.Errorf("Test %d: Expected rule %q, got %q", i+1, tc.expectedRuleID, rule.ID)
+		}
+		rule, ok = config.MatchDelete(tc.objName)
+		if ok != (tc.deleteRuleID != "") || (ok && rule.ID != tc.deleteRuleID) {
+			t.Errorf("Test %d: Expected delete rule %q, got %q", i+1, tc.deleteRuleID, rule.ID)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio/pkg/bucket/object/tagging"
+)
+
+// Status represents Enabled/Disabled status
+type Status string
+
+// Supported status types
+const (
+	Enabled  Status = "Enabled"
+	Disabled Status = "Disabled"
+)
+
+// DestinationARNPrefix - prefix of the ARN of a destination bucket.
+const DestinationARNPrefix = "arn:aws:s3:::"
+
+var (
+	errInvalidRuleID              = Errorf("ID must be less than 255 characters")
+	errEmptyRuleStatus            = Errorf("Status should not be empty")
+	errInvalidRuleStatus          = Errorf("Status must be set to either Enabled or Disabled")
+	errInvalidPriority            = Errorf("Priority must be a positive number")
+	errInvalidFilter              = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errPrefixAndFilter            = Errorf("Prefix cannot be specified together with a Filter")
+	errDuplicateTagKey            = Errorf("Duplicate Tag Keys are not allowed")
+	errInvalidDestinationARN      = Errorf("Destination bucket must be specified as %s<bucket>", DestinationARNPrefix)
+	errInvalidDeleteMarkerStatus  = Errorf("DeleteMarkerReplication Status must be set to either Enabled or Disabled")
+	errDeleteMarkerReplicationTag = Errorf("Delete marker replication is not supported for rules with Tag filters")
+)
+
+// And - a tag to combine a prefix and multiple tags for a replication rule.
+type And struct {
+	XMLName xml.Name      `xml:"And"`
+	Prefix  string        `xml:"Prefix,omitempty"`
+	Tags    []tagging.Tag `xml:"Tag,omitempty"`
+}
+
+// isEmpty returns true if Tags field is null
+func (a And) isEmpty() bool {
+	return len(a.Tags) == 0 && a.Prefix == ""
+}
+
+// Validate - validates the And field
+func (a And) Validate() error {
+	keys := make(map[string]struct{}, len(a.Tags))
+	for _, t := range a.Tags {
+		if _, has := keys[t.Key]; has {
+			return errDuplicateTagKey
+		}
+		keys[t.Key] = struct{}{}
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter - a filter for a replication configuration Rule.
+type Filter struct {
+	XMLName xml.Name    `xml:"Filter"`
+	Prefix  string      `xml:"Prefix,omitempty"`
+	And     And         `xml:"And,omitempty"`
+	Tag     tagging.Tag `xml:"Tag,omitempty"`
+}
+
+// MarshalXML - produces the xml representation of the Filter struct
+// only one of Prefix, And and Tag should be present in the output.
+func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch {
+	case !f.And.isEmpty():
+		if err := e.EncodeElement(f.And, xml.StartElement{Name: xml.Name{Local: "And"}}); err != nil {
+			return err
+		}
+	case !f.Tag.IsEmpty():
+		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
+			return err
+		}
+	default:
+		// Always print Prefix field when both And & Tag are empty
+		if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// Validate - validates the filter element
+func (f Filter) Validate() error {
+	// A Filter must have exactly one of Prefix, Tag, or And specified.
+	var n int
+	if f.Prefix != "" {
+		n++
+	}
+	if !f.Tag.IsEmpty() {
+		n++
+		if err := f.Tag.Validate(); err != nil {
+			return err
+		}
+	}
+	if !f.And.isEmpty() {
+		n++
+		if err := f.And.Validate(); err != nil {
+			return err
+		}
+	}
+	if n > 1 {
+		return errInvalidFilter
+	}
+	return nil
+}
+
+// isEmpty - returns true if Filter tag is empty
+func (f Filter) isEmpty() bool {
+	return f.And.isEmpty() && f.Prefix == "" && f.Tag.IsEmpty()
+}
+
+// DeleteMarkerReplication - whether deletes of objects are replicated.
+type DeleteMarkerReplication struct {
+	Status Status `xml:"Status"`
+}
+
+// Destination - the bucket the objects are replicated to, the remote
+// endpoint holding it is configured on the server.
+type Destination struct {
+	XMLName      xml.Name `xml:"Destination"`
+	Bucket       string   `xml:"Bucket"`
+	StorageClass string   `xml:"StorageClass,omitempty"`
+}
+
+// BucketName - returns the name of the destination bucket.
+func (d Destination) BucketName() string {
+	return strings.TrimPrefix(d.Bucket, DestinationARNPrefix)
+}
+
+// Validate - validates the destination element
+func (d Destination) Validate() error {
+	if !strings.HasPrefix(d.Bucket, DestinationARNPrefix) || d.BucketName() == "" {
+		return errInvalidDestinationARN
+	}
+	return nil
+}
+
+// Rule - a rule for replication configuration.
+type Rule struct {
+	XMLName                 xml.Name                 `xml:"Rule"`
+	ID                      string                   `xml:"ID,omitempty"`
+	Status                  Status                   `xml:"Status"`
+	Priority                int                      `xml:"Priority,omitempty"`
+	Prefix                  string                   `xml:"Prefix,omitempty"`
+	Filter                  *Filter                  `xml:"Filter,omitempty"`
+	DeleteMarkerReplication *DeleteMarkerReplication `xml:"DeleteMarkerReplication,omitempty"`
+	Destination             Destination              `xml:"Destination"`
+}
+
+// validateStatus - checks if status is valid or not.
+func (r Rule) validateStatus() error {
+	// Status can't be empty
+	if len(r.Status) == 0 {
+		return errEmptyRuleStatus
+	}
+
+	// Status must be one of Enabled or Disabled
+	if r.Status != Enabled && r.Status != Disabled {
+		return errInvalidRuleStatus
+	}
+	return nil
+}
+
+// Validate - validates the rule element
+func (r Rule) Validate() error {
+	// cannot be longer than 255 characters
+	if len(r.ID) > 255 {
+		return errInvalidRuleID
+	}
+	if err := r.validateStatus(); err != nil {
+		return err
+	}
+	if r.Priority < 0 {
+		return errInvalidPriority
+	}
+	if r.Filter != nil {
+		if r.Prefix != "" {
+			return errPrefixAndFilter
+		}
+		if err := r.Filter.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.DeleteMarkerReplication != nil {
+		switch r.DeleteMarkerReplication.Status {
+		case Enabled:
+			if len(r.Tags()) != 0 {
+				return errDeleteMarkerReplicationTag
+			}
+		case Disabled:
+		default:
+			return errInvalidDeleteMarkerStatus
+		}
+	}
+	return r.Destination.Validate()
+}
+
+// GetPrefix - a rule can have its prefix under <Rule></Rule>, under
+// <Filter></Filter> or under <Filter><And></And></Filter>. This method
+// returns the prefix from the location where it is available.
+func (r Rule) GetPrefix() string {
+	if r.Prefix != "" {
+		return r.Prefix
+	}
+	if r.Filter == nil {
+		return ""
+	}
+	if r.Filter.Prefix != "" {
+		return r.Filter.Prefix
+	}
+	return r.Filter.And.Prefix
+}
+
+// Tags - returns the tags of the filter of the rule, which can be
+// under <Filter></Filter> or under <Filter><And></And></Filter>.
+func (r Rule) Tags() []tagging.Tag {
+	if r.Filter == nil {
+		return nil
+	}
+	if !r.Filter.Tag.IsEmpty() {
+		return []tagging.Tag{r.Filter.Tag}
+	}
+	return r.Filter.And.Tags
+}
+
+// ReplicateDeletes - returns true if the deletes of objects matching the
+// rule are replicated.
+func (r Rule) ReplicateDeletes() bool {
+	return r.DeleteMarkerReplication != nil && r.DeleteMarkerReplication.Status == Enabled
+}
+
+// matches - returns true if the object name and tags, in the format
+// tag1=value1&tag2=value2, match the filter of the rule.
+func (r Rule) matches(objName, objTags string) bool {
+	if !strings.HasPrefix(objName, r.GetPrefix()) {
+		return false
+	}
+	tags := r.Tags()
+	if len(tags) == 0 {
+		return true
+	}
+	values, err := url.ParseQuery(objTags)
+	if err != nil {
+		return false
+	}
+	for _, tag := range tags {
+		if vs, ok := values[tag.Key]; !ok || vs[0] != tag.Value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// State - versioning state of a bucket.
+type State string
+
+const (
+	// Enabled - every write of an object creates a new version of the object.
+	Enabled State = "Enabled"
+
+	// Suspended - writes of an object replace its null version, the
+	// versions created while versioning was enabled are kept.
+	Suspended State = "Suspended"
+)
+
+// Maximum 1KiB size per versioning config.
+const maxVersioningConfigSize = 1 << 10
+
+// Errors returned while parsing a versioning configuration.
+var (
+	errInvalidStatus       = errors.New("only 'Enabled' or 'Suspended' values are allowed to Status element")
+	errMFADeleteNotAllowed = errors.New("MFADelete is not supported")
+)
+
+// Versioning - versioning configuration specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketVersioning.html
+type Versioning struct {
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name `xml:"VersioningConfiguration"`
+	Status    State    `xml:"Status,omitempty"`
+	MFADelete string   `xml:"MfaDelete,omitempty"`
+}
+
+// Validate - validates the versioning configuration
+func (v Versioning) Validate() error {
+	switch v.Status {
+	case Enabled, Suspended:
+	default:
+		return errInvalidStatus
+	}
+	if v.MFADelete != "" && v.MFADelete != "Disabled" {
+		return errMFADeleteNotAllowed
+	}
+	return nil
+}
+
+// Enabled - returns true if a new version is created on every write.
+func (v Versioning) Enabled() bool {
+	return v.Status == Enabled
+}
+
+// Suspended - returns true if writes replace the null version.
+func (v Versioning) Suspended() bool {
+	return v.Status == Suspended
+}
+
+// ParseConfig parses a versioning configuration from xml
+func ParseConfig(reader io.Reader) (*Versioning, error) {
+	var v Versioning
+	if err := xml.NewDecoder(io.LimitReader(reader, maxVersioningConfigSize)).Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
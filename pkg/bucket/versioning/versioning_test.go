@@ -0,0 +1,79 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		enabled   bool
+		suspended bool
+		expectErr bool
+	}{
+		{
+			input:   `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`,
+			enabled: true,
+		},
+		{
+			input:     `<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`,
+			suspended: true,
+		},
+		{
+			input:   `<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Disabled</MfaDelete></VersioningConfiguration>`,
+			enabled: true,
+		},
+		// MFA delete is not supported.
+		{
+			input:     `<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Enabled</MfaDelete></VersioningConfiguration>`,
+			expectErr: true,
+		},
+		// Status is required.
+		{
+			input:     `<VersioningConfiguration></VersioningConfiguration>`,
+			expectErr: true,
+		},
+		{
+			input:     `<VersioningConfiguration><Status>Disabled</Status></VersioningConfiguration>`,
+			expectErr: true,
+		},
+		{
+			input:     `<VersioningConfiguration><Status>Enabled</Status>`,
+			expectErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		v, err := ParseConfig(strings.NewReader(testCase.input))
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if v.Enabled() != testCase.enabled || v.Suspended() != testCase.suspended {
+			t.Errorf("Test %d: unexpected status %s", i+1, v.Status)
+		}
+	}
+}
@@ -41,6 +41,8 @@ const (
 	ObjectCreatedPutLegalHold
 	ObjectRemovedAll
 	ObjectRemovedDelete
+	ObjectRemovedDeleteMarkerCreated
+	LifecycleTransition
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -51,7 +53,7 @@ func (name Name) Expand() []Name {
 	case ObjectCreatedAll:
 		return []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut, ObjectCreatedPutRetention, ObjectCreatedPutLegalHold}
 	case ObjectRemovedAll:
-		return []Name{ObjectRemovedDelete}
+		return []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}
 	default:
 		return []Name{name}
 	}
@@ -88,6 +90,10 @@ func (name Name) String() string {
 		return "s3:ObjectRemoved:*"
 	case ObjectRemovedDelete:
 		return "s3:ObjectRemoved:Delete"
+	case ObjectRemovedDeleteMarkerCreated:
+		return "s3:ObjectRemoved:DeleteMarkerCreated"
+	case LifecycleTransition:
+		return "s3:LifecycleTransition"
 	}
 
 	return ""
@@ -166,6 +172,10 @@ func ParseName(s string) (Name, error) {
 		return ObjectRemovedAll, nil
 	case "s3:ObjectRemoved:Delete":
 		return ObjectRemovedDelete, nil
+	case "s3:ObjectRemoved:DeleteMarkerCreated":
+		return ObjectRemovedDeleteMarkerCreated, nil
+	case "s3:LifecycleTransition":
+		return LifecycleTransition, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
@@ -30,8 +30,9 @@ func TestNameExpand(t *testing.T) {
 	}{
 		{ObjectAccessedAll, []Name{ObjectAccessedGet, ObjectAccessedHead, ObjectAccessedGetRetention, ObjectAccessedGetLegalHold}},
 		{ObjectCreatedAll, []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut, ObjectCreatedPutRetention, ObjectCreatedPutLegalHold}},
-		{ObjectRemovedAll, []Name{ObjectRemovedDelete}},
+		{ObjectRemovedAll, []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}},
 		{ObjectAccessedHead, []Name{ObjectAccessedHead}},
+		{LifecycleTransition, []Name{LifecycleTransition}},
 	}
 
 	for i, testCase := range testCases {
@@ -60,6 +61,8 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedPut, "s3:ObjectCreated:Put"},
 		{ObjectRemovedAll, "s3:ObjectRemoved:*"},
 		{ObjectRemovedDelete, "s3:ObjectRemoved:Delete"},
+		{ObjectRemovedDeleteMarkerCreated, "s3:ObjectRemoved:DeleteMarkerCreated"},
+		{LifecycleTransition, "s3:LifecycleTransition"},
 		{ObjectCreatedPutRetention, "s3:ObjectCreated:PutRetention"},
 		{ObjectCreatedPutLegalHold, "s3:ObjectCreated:PutLegalHold"},
 		{ObjectAccessedGetRetention, "s3:ObjectAccessed:GetRetention"},
@@ -205,6 +208,8 @@ func TestParseName(t *testing.T) {
 	}{
 		{"s3:ObjectAccessed:*", ObjectAccessedAll, false},
 		{"s3:ObjectRemoved:Delete", ObjectRemovedDelete, false},
+		{"s3:ObjectRemoved:DeleteMarkerCreated", ObjectRemovedDeleteMarkerCreated, false},
+		{"s3:LifecycleTransition", LifecycleTransition, false},
 		{"", blankName, true},
 	}
 
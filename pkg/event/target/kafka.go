@@ -247,21 +247,15 @@ func (k KafkaArgs) pingBrokers() bool {
 	return false
 }
 
-// NewKafkaTarget - creates new Kafka target with auth credentials.
-func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*KafkaTarget, error) {
+// NewKafkaConfig - returns the sarama client configuration of the version, SASL
+// and TLS options of args, for Kafka producers.
+func NewKafkaConfig(args KafkaArgs) (*sarama.Config, error) {
 	config := sarama.NewConfig()
 
-	target := &KafkaTarget{
-		id:         event.TargetID{ID: id, Name: "kafka"},
-		args:       args,
-		loggerOnce: loggerOnce,
-	}
-
 	if args.Version != "" {
 		kafkaVersion, err := sarama.ParseKafkaVersion(args.Version)
 		if err != nil {
-			target.loggerOnce(context.Background(), err, target.ID())
-			return target, err
+			return nil, err
 		}
 		config.Version = kafkaVersion
 	}
@@ -281,10 +275,8 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 	config.Net.SASL.Enable = args.SASL.Enable
 
 	tlsConfig, err := saramatls.NewConfig(args.TLS.ClientTLSCert, args.TLS.ClientTLSKey)
-
 	if err != nil {
-		target.loggerOnce(context.Background(), err, target.ID())
-		return target, err
+		return nil, err
 	}
 
 	config.Net.TLS.Enable = args.TLS.Enable
@@ -297,6 +289,22 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 	config.Producer.Retry.Max = 10
 	config.Producer.Return.Successes = true
 
+	return config, nil
+}
+
+// NewKafkaTarget - creates new Kafka target with auth credentials.
+func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*KafkaTarget, error) {
+	target := &KafkaTarget{
+		id:         event.TargetID{ID: id, Name: "kafka"},
+		args:       args,
+		loggerOnce: loggerOnce,
+	}
+
+	config, err := NewKafkaConfig(args)
+	if err != nil {
+		target.loggerOnce(context.Background(), err, target.ID())
+		return target, err
+	}
 	target.config = config
 
 	brokers := []string{}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/queuestore"
 )
 
 const retryInterval = 3 * time.Second
@@ -33,7 +34,7 @@ const retryInterval = 3 * time.Second
 var errNotConnected = errors.New("not connected to target server/service")
 
 // errLimitExceeded error is sent when the maximum limit is reached.
-var errLimitExceeded = errors.New("the maximum store limit reached")
+var errLimitExceeded = queuestore.ErrLimitExceeded
 
 // Store - To persist the events.
 type Store interface {
@@ -53,11 +54,11 @@ func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx conte
 		defer retryTicker.Stop()
 		defer close(eventKeyCh)
 		for {
-			names, err := store.List()
+			keys, err := store.List()
 			if err == nil {
-				for _, name := range names {
+				for _, key := range keys {
 					select {
-					case eventKeyCh <- strings.TrimSuffix(name, eventExt):
+					case eventKeyCh <- key:
 						// Get next key.
 					case <-doneCh:
 						return
@@ -65,7 +66,7 @@ func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx conte
 				}
 			}
 
-			if len(names) < 2 {
+			if len(keys) < 2 {
 				select {
 				case <-retryTicker.C:
 					if err != nil {
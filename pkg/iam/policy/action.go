@@ -130,6 +130,30 @@ const (
 	// GetBucketEncryptionAction - GetBucketEncryption REST API action
 	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
 
+	// PutBucketVersioningAction - PutBucketVersioning REST API action
+	PutBucketVersioningAction = "s3:PutBucketVersioning"
+
+	// GetBucketVersioningAction - GetBucketVersioning REST API action
+	GetBucketVersioningAction = "s3:GetBucketVersioning"
+
+	// PutBucketReplicationAction - PutBucketReplication REST API action
+	PutBucketReplicationAction = "s3:PutReplicationConfiguration"
+
+	// GetBucketReplicationAction - GetBucketReplication REST API action
+	GetBucketReplicationAction = "s3:GetReplicationConfiguration"
+
+	// PutBucketLoggingAction - PutBucketLogging REST API action
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+
+	// GetBucketLoggingAction - GetBucketLogging REST API action
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
+	// PutBucketCorsAction - PutBucketCors and DeleteBucketCors REST API action
+	PutBucketCorsAction = "s3:PutBucketCORS"
+
+	// GetBucketCorsAction - GetBucketCors REST API action
+	GetBucketCorsAction = "s3:GetBucketCORS"
+
 	// AllActions - all API actions
 	AllActions = "s3:*"
 )
@@ -170,6 +194,14 @@ var supportedActions = map[Action]struct{}{
 	DeleteObjectTaggingAction:              {},
 	PutBucketEncryptionAction:              {},
 	GetBucketEncryptionAction:              {},
+	PutBucketVersioningAction:              {},
+	GetBucketVersioningAction:              {},
+	PutBucketReplicationAction:             {},
+	GetBucketReplicationAction:             {},
+	PutBucketLoggingAction:                 {},
+	GetBucketLoggingAction:                 {},
+	PutBucketCorsAction:                    {},
+	GetBucketCorsAction:                    {},
 }
 
 // List of all supported object actions.
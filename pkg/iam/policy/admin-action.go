@@ -109,6 +109,13 @@ const (
 	// GetBucketQuotaAdminAction - allow getting bucket quota
 	GetBucketQuotaAdminAction = "admin:GetBucketQuota"
 
+	// Remote tier Actions
+
+	// SetTierAdminAction - allow adding and removing remote tiers
+	SetTierAdminAction = "admin:SetTier"
+	// ListTierAdminAction - allow listing remote tiers
+	ListTierAdminAction = "admin:ListTier"
+
 	// AllAdminActions - provides all admin permissions
 	AllAdminActions = "admin:*"
 )
@@ -146,6 +153,8 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	SetBucketQuotaAdminAction:      {},
 	GetBucketQuotaAdminAction:      {},
 	ListUserPoliciesAdminAction:    {},
+	SetTierAdminAction:             {},
+	ListTierAdminAction:            {},
 }
 
 func parseAdminAction(s string) (AdminAction, error) {
@@ -196,4 +205,6 @@ var adminActionConditionKeyMap = map[Action]condition.KeySet{
 	ListUserPoliciesAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	SetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	GetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetTierAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListTierAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
 }
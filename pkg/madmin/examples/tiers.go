@@ -0,0 +1,62 @@
+// +build ignore
+
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func main() {
+	// Note: YOUR-ACCESSKEYID, YOUR-SECRETACCESSKEY, my-tier-accesskey,
+	// my-tier-secretkey and my-tier-bucketname are dummy values, please
+	// replace them with original values.
+
+	// API requests are secure (HTTPS) if secure=true and insecure (HTTPS) otherwise.
+	// New returns an MinIO Admin client object.
+	madmClnt, err := madmin.New("your-minio.example.com:9000", "YOUR-ACCESSKEYID", "YOUR-SECRETACCESSKEY", true)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Objects transitioned with <StorageClass>WARM</StorageClass> are moved to my-tier-bucketname.
+	err = madmClnt.AddTier(context.Background(), madmin.TierConfig{
+		Name:      "WARM",
+		Type:      madmin.TierS3,
+		Endpoint:  "https://s3.amazonaws.com",
+		AccessKey: "my-tier-accesskey",
+		SecretKey: "my-tier-secretkey",
+		Bucket:    "my-tier-bucketname",
+		Prefix:    "transitioned/",
+	})
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	tiers, err := madmClnt.ListTiers(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	for _, tier := range tiers {
+		log.Println(tier.Name, tier.Type, tier.Endpoint, tier.Bucket, tier.Prefix)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// API classes of the metered requests
+const (
+	MeteringClassRead   = "read"   // GetObject, HeadObject, SelectObjectContent and other Get* APIs
+	MeteringClassWrite  = "write"  // PutObject, CopyObject, multipart uploads and other Put* APIs
+	MeteringClassList   = "list"   // ListBuckets, ListObjects, ListMultipartUploads, ListObjectParts
+	MeteringClassDelete = "delete" // DeleteObject, DeleteBucket, AbortMultipartUpload and other Delete* APIs
+	MeteringClassOther  = "other"  // all other APIs
+)
+
+// MeteringCounters are the usage of a bucket or user during an hour
+type MeteringCounters struct {
+	// BytesStored is the size of the bucket, or of the buckets created
+	// by the user, as last computed by the data usage crawler
+	BytesStored uint64 `json:"bytesStored"`
+	BytesIn     uint64 `json:"bytesIn"`
+	BytesOut    uint64 `json:"bytesOut"`
+	// Requests is the number of requests by API class
+	Requests map[string]uint64 `json:"requests,omitempty"`
+}
+
+// MeteringRollup is the usage of the buckets and parent users during an hour
+type MeteringRollup struct {
+	Time    time.Time                   `json:"time"`
+	Buckets map[string]MeteringCounters `json:"buckets,omitempty"`
+	Users   map[string]MeteringCounters `json:"users,omitempty"`
+}
+
+// MeteringInfo is the hourly usage during a time range
+type MeteringInfo struct {
+	Start time.Time        `json:"start"`
+	End   time.Time        `json:"end"`
+	Hours []MeteringRollup `json:"hours"`
+}
+
+// MeteringOpts filters the metering info
+type MeteringOpts struct {
+	// Start and End of the time range, the hours that start in the range are returned
+	Start time.Time
+	End   time.Time
+	// Bucket limits the usage to a bucket, User to a parent user, all if empty
+	Bucket string
+	User   string
+}
+
+// MeteringInfo returns the hourly usage of the buckets and parent users during a time range
+func (adm *AdminClient) MeteringInfo(ctx context.Context, opts MeteringOpts) (MeteringInfo, error) {
+	queryValues := url.Values{}
+	queryValues.Set("start", opts.Start.UTC().Format(time.RFC3339))
+	queryValues.Set("end", opts.End.UTC().Format(time.RFC3339))
+	if opts.Bucket != "" {
+		queryValues.Set("bucket", opts.Bucket)
+	}
+	if opts.User != "" {
+		queryValues.Set("user", opts.User)
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/meteringinfo",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return MeteringInfo{}, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return MeteringInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return MeteringInfo{}, err
+	}
+
+	var meteringInfo MeteringInfo
+	if err = json.Unmarshal(respBytes, &meteringInfo); err != nil {
+		return MeteringInfo{}, err
+	}
+	return meteringInfo, nil
+}
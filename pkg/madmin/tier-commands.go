@@ -0,0 +1,139 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// TierType - the kind of remote storage of a tier.
+type TierType string
+
+// Supported tier types
+const (
+	TierS3    TierType = "s3"
+	TierAzure TierType = "azure"
+)
+
+// TierConfig carries the configuration of a remote tier, the objects
+// transitioned by the lifecycle rules whose StorageClass is the name
+// of the tier are moved to its bucket.
+type TierConfig struct {
+	Name string   `json:"name"`
+	Type TierType `json:"type"`
+	// Endpoint of the remote storage, the Azure endpoint defaults to
+	// https://<AccessKey>.blob.core.windows.net
+	Endpoint string `json:"endpoint,omitempty"`
+	// AccessKey and SecretKey of the remote storage, the account name
+	// and key for Azure
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey,omitempty"`
+	// Bucket, or Azure container, and Prefix of the transitioned objects
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+// AddTier - adds a remote tier.
+func (adm *AdminClient) AddTier(ctx context.Context, cfg TierConfig) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/add-tier",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/add-tier to add a tier.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListTiers - lists the remote tiers, their secret keys are not returned.
+func (adm *AdminClient) ListTiers(ctx context.Context) ([]TierConfig, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/list-tiers",
+	}
+
+	// Execute GET on /minio/admin/v3/list-tiers
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var tiers []TierConfig
+	if err = json.Unmarshal(data, &tiers); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// RemoveTier - removes a remote tier.
+func (adm *AdminClient) RemoveTier(ctx context.Context, name string) error {
+	queryValues := url.Values{}
+	queryValues.Set("name", name)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/remove-tier",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v3/remove-tier to remove a tier.
+	resp, err := adm.executeMethod(ctx, http.MethodDelete, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package queuestore persists queued entries in a directory until they are
+// processed, it is used by the targets of events, audit logs and replication.
+package queuestore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio/pkg/sys"
+)
+
+// DefaultLimit is the maximum number of entries of a store created without a
+// limit, unless the maximum open file limit is lower.
+const DefaultLimit = 100000
+
+// ErrLimitExceeded is returned when an entry is put in a full store.
+var ErrLimitExceeded = errors.New("the maximum store limit reached")
+
+// QueueStore persists JSON encoded entries in a directory, one file per
+// entry named after its key and the extension of the store. Entries are
+// listed in the order they were put, so their keys start with the time they
+// were put, modification times may be equal.
+type QueueStore struct {
+	sync.RWMutex
+	currentEntries uint64
+	entryLimit     uint64
+	directory      string
+	ext            string
+	lastQueued     int64
+}
+
+// New returns a store of the entries in directory with the file extension
+// ext, which holds at most limit entries.
+func New(directory, ext string, limit uint64) *QueueStore {
+	if limit == 0 {
+		limit = DefaultLimit
+		_, maxRLimit, err := sys.GetMaxOpenFileLimit()
+		if err == nil && maxRLimit < limit {
+			// Limit the maximum number of entries
+			// to maximum open file limit
+			limit = maxRLimit
+		}
+	}
+	return &QueueStore{
+		directory:  directory,
+		ext:        ext,
+		entryLimit: limit,
+	}
+}
+
+// Open creates the directory if not present, and counts the entries left by
+// a previous run.
+func (store *QueueStore) Open() error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := os.MkdirAll(store.directory, os.FileMode(0770)); err != nil {
+		return err
+	}
+	names, err := store.list()
+	if err != nil {
+		return err
+	}
+	store.currentEntries = uint64(len(names))
+	return nil
+}
+
+// Full returns whether the store holds the maximum number of entries.
+func (store *QueueStore) Full() bool {
+	store.RLock()
+	defer store.RUnlock()
+	return store.currentEntries >= store.entryLimit
+}
+
+// Put adds an entry to the store and returns its key.
+func (store *QueueStore) Put(v interface{}) (string, error) {
+	store.Lock()
+	defer store.Unlock()
+	if store.currentEntries >= store.entryLimit {
+		return "", ErrLimitExceeded
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	queued := time.Now().UnixNano()
+	if queued <= store.lastQueued {
+		queued = store.lastQueued + 1
+	}
+	store.lastQueued = queued
+	key := fmt.Sprintf("%016x-%s", queued, uuid.New().String())
+	if err := ioutil.WriteFile(filepath.Join(store.directory, key+store.ext), data, os.FileMode(0660)); err != nil {
+		return "", err
+	}
+	store.currentEntries++
+	return key, nil
+}
+
+// Get reads the entry of key into v, an entry that cannot be read is removed.
+func (store *QueueStore) Get(key string, v interface{}) (err error) {
+	store.RLock()
+	defer func() {
+		store.RUnlock()
+		if err != nil {
+			store.Del(key)
+		}
+	}()
+
+	data, err := ioutil.ReadFile(filepath.Join(store.directory, key+store.ext))
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return os.ErrNotExist
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Del removes the entry of key from the store.
+func (store *QueueStore) Del(key string) error {
+	store.Lock()
+	defer store.Unlock()
+	if err := os.Remove(filepath.Join(store.directory, key+store.ext)); err != nil {
+		return err
+	}
+	if store.currentEntries > 0 {
+		store.currentEntries--
+	}
+	return nil
+}
+
+// Keys returns the keys of the entries, oldest first.
+func (store *QueueStore) Keys() ([]string, error) {
+	store.RLock()
+	defer store.RUnlock()
+	names, err := store.list()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, strings.TrimSuffix(name, store.ext))
+	}
+	return keys, nil
+}
+
+// list lock less, the names are sorted by the time the entries were put.
+func (store *QueueStore) list() ([]string, error) {
+	files, err := ioutil.ReadDir(store.directory)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), store.ext) {
+			names = append(names, file.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,105 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queuestore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type testEntry struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+func TestQueueStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-queuestore-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := New(dir, ".entry", 2)
+	if err = store.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []testEntry{{"a", 1}, {"b", 2}}
+	var keys []string
+	for _, e := range entries {
+		key, err := store.Put(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	if !store.Full() {
+		t.Fatal("expected the store to be full")
+	}
+	if _, err = store.Put(entries[0]); err != ErrLimitExceeded {
+		t.Fatalf("expected %v, got %v", ErrLimitExceeded, err)
+	}
+
+	// The entries are listed in the order they were put, also after a restart.
+	store = New(dir, ".entry", 2)
+	if err = store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	queued, err := store.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(queued, keys) {
+		t.Fatalf("expected keys %v, got %v", keys, queued)
+	}
+	for i, key := range keys {
+		var e testEntry
+		if err = store.Get(key, &e); err != nil {
+			t.Fatal(err)
+		}
+		if e != entries[i] {
+			t.Fatalf("expected entry %v, got %v", entries[i], e)
+		}
+	}
+
+	if err = store.Del(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Get(keys[0], &testEntry{}); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+	if _, err = store.Put(entries[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	// An entry that cannot be read is removed, files of other stores are ignored.
+	if err = ioutil.WriteFile(filepath.Join(dir, keys[1]+".entry"), []byte("{"), 0660); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "other.event"), []byte("{}"), 0660); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Get(keys[1], &testEntry{}); err == nil {
+		t.Fatal("expected an error reading an invalid entry")
+	}
+	if queued, err = store.Keys(); err != nil || len(queued) != 1 {
+		t.Fatalf("expected one entry, got %v, %v", queued, err)
+	}
+}
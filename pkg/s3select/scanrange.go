@@ -0,0 +1,203 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// ScanRange - represents elements inside <ScanRange/> in request XML.
+//
+// Only records that start within the range are processed, the last of
+// them is read beyond the end of the range until it is complete. If only
+// End is set, the range is the last End bytes of the object.
+type ScanRange struct {
+	Start *int64 `xml:"Start"`
+	End   *int64 `xml:"End"`
+}
+
+// validate - checks the scan range, which is only supported for
+// uncompressed CSV and JSON lines input.
+func (sr *ScanRange) validate(input *InputSerialization) error {
+	switch {
+	case sr.Start == nil && sr.End == nil:
+		return errInvalidRequestParameter(errors.New("ScanRange must have a Start or an End"))
+	case (sr.Start != nil && *sr.Start < 0) || (sr.End != nil && *sr.End < 0):
+		return errInvalidRequestParameter(errors.New("ScanRange Start and End must not be negative"))
+	case sr.Start != nil && sr.End != nil && *sr.Start > *sr.End:
+		return errInvalidRequestParameter(errors.New("ScanRange Start must not be after End"))
+	case input.CompressionType != noneType:
+		return errInvalidRequestParameter(errors.New("ScanRange is only supported for uncompressed input"))
+	}
+
+	switch input.format {
+	case csvFormat:
+		if input.CSVArgs.AllowQuotedRecordDelimiter {
+			return errInvalidRequestParameter(errors.New("ScanRange is not supported with AllowQuotedRecordDelimiter"))
+		}
+	case jsonFormat:
+		if !strings.EqualFold(input.JSONArgs.ContentType, "lines") {
+			return errInvalidRequestParameter(errors.New("ScanRange is only supported for JSON of type LINES"))
+		}
+	default:
+		return errInvalidRequestParameter(errors.New("ScanRange is only supported for CSV and JSON input"))
+	}
+	return nil
+}
+
+// SetObjectSize - sets the size of the queried object, which is
+// required to resolve a scan range that only has an End.
+func (s3Select *S3Select) SetObjectSize(size int64) {
+	s3Select.objectSize = size
+	s3Select.objectSizeSet = true
+}
+
+// openInput - returns the CSV or JSON input, limited to the
+// records that start within the scan range if one is set.
+func (s3Select *S3Select) openInput(getReader func(offset, length int64) (io.ReadCloser, error)) (io.ReadCloser, error) {
+	sr := s3Select.ScanRange
+	if sr == nil {
+		return getReader(0, -1)
+	}
+
+	start, end := int64(0), int64(-1)
+	switch {
+	case sr.Start == nil:
+		if !s3Select.objectSizeSet {
+			return nil, errInvalidRequestParameter(errors.New("ScanRange without Start requires the object size"))
+		}
+		if start = s3Select.objectSize - *sr.End; start < 0 {
+			start = 0
+		}
+	case sr.End == nil:
+		start = *sr.Start
+	default:
+		start, end = *sr.Start, *sr.End
+	}
+
+	delim := []byte("\n")
+	if s3Select.Input.format == csvFormat && s3Select.Input.CSVArgs.RecordDelimiter != "" {
+		delim = []byte(s3Select.Input.CSVArgs.RecordDelimiter)
+	}
+
+	// The CSV header is always read from the first record.
+	var header []byte
+	if s3Select.Input.format == csvFormat && start > 0 &&
+		!strings.EqualFold(s3Select.Input.CSVArgs.FileHeaderInfo, "none") {
+		hr, err := newScanRangeReader(getReader, 0, 0, delim)
+		if err != nil {
+			return nil, err
+		}
+		header, err = ioutil.ReadAll(hr)
+		hr.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := newScanRangeReader(getReader, start, end, delim)
+	if err != nil {
+		return nil, err
+	}
+	r.buf = header
+	return r, nil
+}
+
+// scanRangeReader - reads the records of an object that start
+// between start and end, end is -1 for the end of the object.
+type scanRangeReader struct {
+	rc    io.ReadCloser
+	r     *bufio.Reader
+	delim []byte
+	pos   int64 // offset of the next byte of r in the object
+	end   int64
+	buf   []byte // unread bytes of the current record
+	err   error
+}
+
+func newScanRangeReader(getReader func(offset, length int64) (io.ReadCloser, error), start, end int64, delim []byte) (*scanRangeReader, error) {
+	// Reading starts before the range, so a delimiter right
+	// before it is found and the first record is not skipped.
+	offset := start - int64(len(delim))
+	if offset < 0 {
+		offset = 0
+	}
+	rc, err := getReader(offset, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &scanRangeReader{
+		rc:    rc,
+		r:     bufio.NewReader(rc),
+		delim: delim,
+		pos:   offset,
+		end:   end,
+	}
+	for r.pos < start && r.err == nil {
+		var rec []byte
+		rec, r.err = r.readRecord()
+		r.pos += int64(len(rec))
+	}
+	return r, nil
+}
+
+// readRecord - reads the next record including its delimiter, the
+// last record of the object is returned together with io.EOF.
+func (r *scanRangeReader) readRecord() ([]byte, error) {
+	last := r.delim[len(r.delim)-1]
+	var rec []byte
+	for {
+		b, err := r.r.ReadSlice(last)
+		rec = append(rec, b...)
+		switch {
+		case err == bufio.ErrBufferFull:
+		case err != nil:
+			return rec, err
+		case bytes.HasSuffix(rec, r.delim):
+			return rec, nil
+		}
+	}
+}
+
+// Read - reads the records within the range.
+func (r *scanRangeReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.end >= 0 && r.pos > r.end {
+			return 0, io.EOF
+		}
+		r.buf, r.err = r.readRecord()
+		r.pos += int64(len(r.buf))
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close - closes the underlying reader.
+func (r *scanRangeReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,187 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v6"
+)
+
+func TestScanRange(t *testing.T) {
+	csvInput := "id,text\n1,a\n2,b\n3,c\n"
+	jsonInput := "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"
+
+	csvRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT id FROM S3Object</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>%s</CompressionType>
+        <CSV>
+            <FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <JSON>
+        </JSON>
+    </OutputSerialization>
+    <ScanRange>%s</ScanRange>
+</SelectObjectContentRequest>`
+	jsonRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT id FROM S3Object</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <JSON>
+            <Type>%s</Type>
+        </JSON>
+    </InputSerialization>
+    <OutputSerialization>
+        <JSON>
+        </JSON>
+    </OutputSerialization>
+    <ScanRange>%s</ScanRange>
+</SelectObjectContentRequest>`
+
+	var testTable = []struct {
+		name       string
+		input      string
+		requestXML string
+		wantErr    bool
+		wantResult string
+	}{
+		{
+			name:       "csv-record",
+			input:      csvInput,
+			requestXML: fmt.Sprintf(csvRequest, "NONE", "<Start>12</Start><End>15</End>"),
+			wantResult: `{"id":"2"}`,
+		},
+		{
+			name:       "csv-record-start",
+			input:      csvInput,
+			requestXML: fmt.Sprintf(csvRequest, "NONE", "<Start>9</Start><End>12</End>"),
+			wantResult: `{"id":"2"}`,
+		},
+		{
+			name:       "csv-single-byte",
+			input:      csvInput,
+			requestXML: fmt.Sprintf(csvRequest, "NONE", "<Start>8</Start><End>8</End>"),
+			wantResult: `{"id":"1"}`,
+		},
+		{
+			name:       "csv-start",
+			input:      csvInput,
+			requestXML: fmt.Sprintf(csvRequest, "NONE", "<Start>11</Start>"),
+			wantResult: "{\"id\":\"2\"}\n{\"id\":\"3\"}",
+		},
+		{
+			name:       "csv-suffix",
+			input:      csvInput,
+			requestXML: fmt.Sprintf(csvRequest, "NONE", "<End>5</End>"),
+			wantResult: `{"id":"3"}`,
+		},
+		{
+			name:       "csv-whole",
+			input:      csvInput,
+			requestXML: fmt.Sprintf(csvRequest, "NONE", "<Start>0</Start><End>100</End>"),
+			wantResult: "{\"id\":\"1\"}\n{\"id\":\"2\"}\n{\"id\":\"3\"}",
+		},
+		{
+			name:       "json-lines",
+			input:      jsonInput,
+			requestXML: fmt.Sprintf(jsonRequest, "LINES", "<Start>1</Start><End>9</End>"),
+			wantResult: `{"id":2}`,
+		},
+		{
+			name:       "empty",
+			requestXML: fmt.Sprintf(csvRequest, "NONE", ""),
+			wantErr:    true,
+		},
+		{
+			name:       "start-after-end",
+			requestXML: fmt.Sprintf(csvRequest, "NONE", "<Start>10</Start><End>9</End>"),
+			wantErr:    true,
+		},
+		{
+			name:       "compressed",
+			requestXML: fmt.Sprintf(csvRequest, "GZIP", "<Start>0</Start>"),
+			wantErr:    true,
+		},
+		{
+			name:       "json-document",
+			requestXML: fmt.Sprintf(jsonRequest, "DOCUMENT", "<Start>0</Start>"),
+			wantErr:    true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			s3Select, err := NewS3Select(strings.NewReader(testCase.requestXML))
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			s3Select.SetObjectSize(int64(len(testCase.input)))
+
+			if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+				if offset < 0 {
+					offset += int64(len(testCase.input))
+				}
+				data := testCase.input[offset:]
+				if length >= 0 && length < int64(len(data)) {
+					data = data[:length]
+				}
+				return ioutil.NopCloser(strings.NewReader(data)), nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			w := &testResponseWriter{}
+			s3Select.Evaluate(w)
+			s3Select.Close()
+			resp := http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+				ContentLength: int64(len(w.response)),
+			}
+			res, err := minio.NewSelectResults(&resp, "testbucket")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotS := strings.TrimSpace(string(got)); gotS != testCase.wantResult {
+				t.Errorf("received response does not match with expected reply\ngot: %s\nwant:%s", gotS, testCase.wantResult)
+			}
+		})
+	}
+}
@@ -211,10 +211,13 @@ type S3Select struct {
 	Input          InputSerialization  `xml:"InputSerialization"`
 	Output         OutputSerialization `xml:"OutputSerialization"`
 	Progress       RequestProgress     `xml:"RequestProgress"`
+	ScanRange      *ScanRange          `xml:"ScanRange"`
 
 	statement      *sql.SelectStatement
 	progressReader *progressReader
 	recordReader   recordReader
+	objectSize     int64
+	objectSizeSet  bool
 }
 
 var (
@@ -255,6 +258,12 @@ func (s3Select *S3Select) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 		return errMissingRequiredParameter(fmt.Errorf("OutputSerialization must be provided"))
 	}
 
+	if parsedS3Select.ScanRange != nil {
+		if err := parsedS3Select.ScanRange.validate(&parsedS3Select.Input); err != nil {
+			return err
+		}
+	}
+
 	statement, err := sql.ParseSelectStatement(parsedS3Select.Expression)
 	if err != nil {
 		return err
@@ -290,7 +299,7 @@ func (s3Select *S3Select) getProgress() (bytesScanned, bytesProcessed int64) {
 func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadCloser, error)) error {
 	switch s3Select.Input.format {
 	case csvFormat:
-		rc, err := getReader(0, -1)
+		rc, err := s3Select.openInput(getReader)
 		if err != nil {
 			return err
 		}
@@ -312,7 +321,7 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 		}
 		return nil
 	case jsonFormat:
-		rc, err := getReader(0, -1)
+		rc, err := s3Select.openInput(getReader)
 		if err != nil {
 			return err
 		}